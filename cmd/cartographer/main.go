@@ -16,12 +16,17 @@ package main
 
 import (
 	"flag"
+	"time"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/mirrors"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
 	"github.com/vmware-tanzu/cartographer/pkg/root"
 )
 
@@ -29,12 +34,62 @@ var devMode bool
 var port int
 var certDir string
 var verbosity string
+var maxMappedRequestsPerEvent int
+var mappedRequestsSpilloverDelay time.Duration
+var allowedStampedKinds string
+var deniedStampedKinds string
+var forbiddenApplyMaxRetries int64
+var forbiddenApplyRetryBackoff time.Duration
+var statusFlushWindow time.Duration
+var policyObjects string
+var watchRetryBackoff time.Duration
+var watchRetryMaxBackoff time.Duration
+var namespace string
+var allowOutputOverrides bool
+var transientErrorBackoff time.Duration
+var runnableMaxOutputBytes int
+var runnableMaxTotalOutputBytes int
+var runnableNamespaceFairQueue bool
+var warnImagePathSchema bool
+var warnRunTemplateStatus bool
+var checkStampedObjectPermissions bool
+var runnableTrackedObjectDebounce time.Duration
+var pauseConfigMap string
+var runnableMaxFailedAttempts int64
+var maxTrackedKinds int
+var metricsBindAddress string
+var registryMirrors string
 
 func init() {
 	flag.IntVar(&port, "Port", 9443, "Webhook server Port")
 	flag.StringVar(&certDir, "cert-dir", "", "Webhook server tls dir")
 	flag.BoolVar(&devMode, "dev", false, "Human readable logs")
 	flag.StringVar(&verbosity, "log-level", "info", "Log levels")
+	flag.IntVar(&maxMappedRequestsPerEvent, "max-mapped-requests-per-event", 0, "Maximum reconcile requests a single watched event enqueues at once, the rest are delayed (0 is unlimited)")
+	flag.DurationVar(&mappedRequestsSpilloverDelay, "mapped-requests-spillover-delay", 5*time.Second, "Delay between batches of reconcile requests beyond max-mapped-requests-per-event")
+	flag.StringVar(&allowedStampedKinds, "allowed-stamped-kinds", "", "Comma separated Kind.group list of the only kinds templates may stamp, e.g. Deployment.apps,ConfigMap (empty allows all kinds)")
+	flag.StringVar(&deniedStampedKinds, "denied-stamped-kinds", "", "Comma separated Kind.group list of kinds templates may never stamp, e.g. ClusterRole.rbac.authorization.k8s.io,Secret")
+	flag.Int64Var(&forbiddenApplyMaxRetries, "forbidden-apply-max-retries", 0, "Times to requeue an owner whose stamped object is rejected as Forbidden, in case RBAC has not yet propagated (0 disables)")
+	flag.DurationVar(&forbiddenApplyRetryBackoff, "forbidden-apply-retry-backoff", 2*time.Second, "Delay before the first requeue of a Forbidden stamped object, doubled on every retry")
+	flag.DurationVar(&statusFlushWindow, "status-flush-window", 0, "Window within which status updates of the same workload, deliverable or runnable are coalesced into one write (0 writes every update immediately)")
+	flag.StringVar(&policyObjects, "policy-objects", "", "Comma separated Kind.version.group/[namespace/]name list of admission policy objects whose changes reconcile every workload and deliverable, e.g. ValidatingWebhookConfiguration.v1.admissionregistration.k8s.io/my-webhook")
+	flag.DurationVar(&watchRetryBackoff, "watch-retry-backoff", time.Second, "Delay before retrying to watch a stamped kind whose watch could not be established, e.g. because its CRD is not installed, doubled on every failure")
+	flag.DurationVar(&watchRetryMaxBackoff, "watch-retry-max-backoff", 5*time.Minute, "Maximum delay between retries to watch a stamped kind")
+	flag.StringVar(&namespace, "namespace", "", "Only reconcile the workloads, deliverables and runnables of this namespace (empty reconciles every namespace)")
+	flag.BoolVar(&allowOutputOverrides, "allow-output-overrides", false, "Honor carto.run/override-output.<resource>.<output> annotations that replace the outputs of a workload's resources, for debugging")
+	flag.DurationVar(&transientErrorBackoff, "transient-error-backoff", 2*time.Second, "Delay before requeueing a runnable that could not be read because the API server timed out, was unavailable or throttled the request (0 leaves it to the rate limiter)")
+	flag.IntVar(&runnableMaxOutputBytes, "runnable-max-output-bytes", 64*1024, "Maximum size of the json of each output recorded in a runnable's status, larger outputs are truncated (0 is unlimited)")
+	flag.IntVar(&runnableMaxTotalOutputBytes, "runnable-max-total-output-bytes", 512*1024, "Maximum size of the json of all outputs recorded in a runnable's status together, outputs beyond it are truncated (0 is unlimited)")
+	flag.BoolVar(&runnableNamespaceFairQueue, "runnable-namespace-fair-queue", false, "Dequeue runnables round-robin by namespace, so a namespace with many queued runnables does not hold back the runnables of other namespaces")
+	flag.BoolVar(&warnImagePathSchema, "warn-image-path-schema", false, "Warn on admission of image templates whose imagePath reads a field the schema of the stamped kind does not declare")
+	flag.BoolVar(&warnRunTemplateStatus, "warn-run-template-status", false, "Warn on admission of run templates whose output paths read the status of a stamped kind that has no status subresource")
+	flag.BoolVar(&checkStampedObjectPermissions, "check-stamped-object-permissions", false, "Review whether the service account of a workload may create and patch each stamped object before applying it, reporting the missing permission rather than failing the apply as Forbidden (one extra api call per verb and resource)")
+	flag.DurationVar(&runnableTrackedObjectDebounce, "runnable-tracked-object-debounce", 0, "Window within which the events of an object stamped for a runnable are coalesced into one reconcile of the runnable, run at the end of the window (0 reconciles on every event)")
+	flag.StringVar(&pauseConfigMap, "pause-config-map", "", "Namespace/name of a config map whose paused key, set to \"true\", stops every workload, deliverable and runnable from being reconciled, for cluster maintenance (empty disables)")
+	flag.Int64Var(&runnableMaxFailedAttempts, "runnable-max-failed-attempts", 0, "Times in a row a runnable may fail with the same class of retried error before it is reported ReconcileFailedPermanently and no longer requeued until its spec changes (0 retries forever)")
+	flag.IntVar(&maxTrackedKinds, "max-tracked-kinds", 0, "Maximum kinds of stamped objects each of the workload, deliverable and runnable controllers watches at once, the least recently used kind's informer being stopped to watch another (0 is unlimited and shares the manager's informers)")
+	flag.StringVar(&metricsBindAddress, "metrics-bind-address", "0", "Address the prometheus metrics are served on, e.g. :8080 (0 disables)")
+	flag.StringVar(&registryMirrors, "registry-mirrors", "", "Comma separated from=to list of image prefixes to rewrite the images read from image templates with before they are passed downstream, e.g. docker.io/library=mirror.example.com/dockerhub (the longest matching prefix wins, tags and digests are kept)")
 	flag.Parse()
 }
 
@@ -44,10 +99,52 @@ func main() {
 		panic(err)
 	}
 
+	parsedPolicyObjects, err := registrar.ParsePolicyObjects(policyObjects)
+	if err != nil {
+		panic(err)
+	}
+
+	pause, err := controller.ParsePauseConfigMap(pauseConfigMap)
+	if err != nil {
+		panic(err)
+	}
+
+	parsedRegistryMirrors, err := mirrors.Parse(registryMirrors)
+	if err != nil {
+		panic(err)
+	}
+
 	cmd := root.Command{
-		Port:    port,
-		CertDir: certDir,
-		Logger:  zap.New(zap.UseDevMode(devMode), loggerOpt),
+		Port:                         port,
+		CertDir:                      certDir,
+		Logger:                       zap.New(zap.UseDevMode(devMode), loggerOpt),
+		MaxMappedRequestsPerEvent:    maxMappedRequestsPerEvent,
+		MappedRequestsSpilloverDelay: mappedRequestsSpilloverDelay,
+		StampedKindPolicy: kindpolicy.Policy{
+			Allowed: kindpolicy.ParseGroupKinds(allowedStampedKinds),
+			Denied:  kindpolicy.ParseGroupKinds(deniedStampedKinds),
+		},
+		ForbiddenApplyMaxRetries:      forbiddenApplyMaxRetries,
+		ForbiddenApplyRetryBackoff:    forbiddenApplyRetryBackoff,
+		StatusFlushWindow:             statusFlushWindow,
+		PolicyObjects:                 parsedPolicyObjects,
+		WatchRetryBackoff:             watchRetryBackoff,
+		WatchRetryMaxBackoff:          watchRetryMaxBackoff,
+		Namespace:                     namespace,
+		AllowOutputOverrides:          allowOutputOverrides,
+		TransientErrorBackoff:         transientErrorBackoff,
+		RunnableMaxOutputBytes:        runnableMaxOutputBytes,
+		RunnableMaxTotalOutputBytes:   runnableMaxTotalOutputBytes,
+		RunnableNamespaceFairQueue:    runnableNamespaceFairQueue,
+		WarnImagePathSchema:           warnImagePathSchema,
+		WarnRunTemplateStatus:         warnRunTemplateStatus,
+		CheckStampedObjectPermissions: checkStampedObjectPermissions,
+		RunnableTrackedObjectDebounce: runnableTrackedObjectDebounce,
+		RunnableMaxFailedAttempts:     runnableMaxFailedAttempts,
+		MaxTrackedKinds:               maxTrackedKinds,
+		MetricsBindAddress:            metricsBindAddress,
+		RegistryMirrors:               parsedRegistryMirrors,
+		Pause:                         pause,
 	}
 
 	if err = cmd.Execute(ctrl.SetupSignalHandler()); err != nil {
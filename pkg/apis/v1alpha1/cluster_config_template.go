@@ -37,7 +37,29 @@ type ClusterConfigTemplate struct {
 
 type ConfigTemplateSpec struct {
 	TemplateSpec `json:",inline"`
-	ConfigPath   string `json:"configPath"`
+	// ConfigPath is the path of the config in the stamped object. Required
+	// unless outputFrom is set.
+	ConfigPath string `json:"configPath,omitempty"`
+	// OutputFrom reads the config from a ConfigMap or Secret named by the
+	// stamped object, in place of the configPath
+	OutputFrom *OutputFrom `json:"outputFrom,omitempty"`
+	// OutputSubresource evaluates the output paths against the named
+	// subresource of the stamped object rather than the object itself
+	// +kubebuilder:validation:Enum=status;scale
+	OutputSubresource string `json:"outputSubresource,omitempty"`
+	// OutputRequired fails the resource when a value read at an output path
+	// is empty or only whitespace, rather than passing it on to the resources
+	// that consume it. Defaults to false.
+	OutputRequired *bool `json:"outputRequired,omitempty"`
+	// OutputLanguage is the language the output paths are written in:
+	// jsonpath, or a ytt (starlark) expression over data.values. Defaults
+	// to jsonpath.
+	// +kubebuilder:validation:Enum=jsonpath;ytt
+	OutputLanguage string `json:"outputLanguage,omitempty"`
+	// ReadinessGates are conditions the stamped object must report before
+	// the outputs are read. Until they all pass, the resources consuming the
+	// outputs are not updated.
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty"`
 }
 
 type ConfigTemplateStatus struct {
@@ -46,10 +68,20 @@ type ConfigTemplateStatus struct {
 var _ webhook.Validator = &ClusterConfigTemplate{}
 
 func (c *ClusterConfigTemplate) ValidateCreate() error {
-	return c.Spec.TemplateSpec.validate()
+	return c.validate()
 }
 
 func (c *ClusterConfigTemplate) ValidateUpdate(_ runtime.Object) error {
+	return c.validate()
+}
+
+func (c *ClusterConfigTemplate) validate() error {
+	if err := validateOutputPathOrFrom("configPath", c.Spec.ConfigPath, c.Spec.OutputFrom); err != nil {
+		return err
+	}
+	if err := validateReadinessGates(c.Spec.ReadinessGates); err != nil {
+		return err
+	}
 	return c.Spec.TemplateSpec.validate()
 }
 
@@ -37,6 +37,9 @@ var _ = Describe("ClusterConfigTemplate", func() {
 					Name:      "some-template",
 					Namespace: "default",
 				},
+				Spec: v1alpha1.ConfigTemplateSpec{
+					ConfigPath: ".data",
+				},
 			}
 		})
 
@@ -90,6 +93,58 @@ var _ = Describe("ClusterConfigTemplate", func() {
 			})
 		})
 
+		Describe("outputFrom", func() {
+			BeforeEach(func() {
+				raw, err := json.Marshal(&ArbitraryObject{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "some-kind",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-name",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				template.Spec.Template = &runtime.RawExtension{Raw: raw}
+				template.Spec.ConfigPath = ""
+				template.Spec.OutputFrom = &v1alpha1.OutputFrom{
+					Kind:         v1alpha1.OutputFromConfigMap,
+					NameFromPath: ".status.resultsConfigMap",
+					Key:          "result",
+				}
+			})
+
+			It("succeeds when outputFrom is well formed", func() {
+				Expect(template.ValidateCreate()).To(Succeed())
+				Expect(template.ValidateUpdate(nil)).To(Succeed())
+			})
+
+			It("returns an error when configPath is set as well", func() {
+				template.Spec.ConfigPath = ".data"
+				Expect(template.ValidateCreate()).To(MatchError("exactly one of configPath and outputFrom must be set"))
+			})
+
+			It("returns an error when neither configPath nor outputFrom is set", func() {
+				template.Spec.OutputFrom = nil
+				Expect(template.ValidateUpdate(nil)).To(MatchError("exactly one of configPath and outputFrom must be set"))
+			})
+
+			It("returns an error when nameFromPath is missing", func() {
+				template.Spec.OutputFrom.NameFromPath = ""
+				Expect(template.ValidateCreate()).To(MatchError("outputFrom.nameFromPath must be set"))
+			})
+
+			It("returns an error when key is missing", func() {
+				template.Spec.OutputFrom.Key = ""
+				Expect(template.ValidateCreate()).To(MatchError("outputFrom.key must be set"))
+			})
+
+			It("returns an error when the kind is neither ConfigMap nor Secret", func() {
+				template.Spec.OutputFrom.Kind = "Pod"
+				Expect(template.ValidateCreate()).To(MatchError("outputFrom.kind must be one of [ConfigMap, Secret]"))
+			})
+		})
+
 		Describe("#Update", func() {
 			Context("template is well formed", func() {
 				BeforeEach(func() {
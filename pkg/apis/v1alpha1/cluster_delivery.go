@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -70,6 +71,10 @@ type ClusterDeliveryResource struct {
 	Sources     []ResourceReference              `json:"sources,omitempty"`
 	Deployment  *DeploymentReference             `json:"deployment,omitempty"`
 	Configs     []ResourceReference              `json:"configs,omitempty"`
+	// TargetNamespace is interpolated against the deliverable, e.g. "$(deliverable.metadata.labels.env)$-apps",
+	// and is the namespace the resource's object is stamped into. An object stamped outside of the
+	// deliverable's namespace is not owned by the deliverable.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
 }
 
 type DeploymentReference struct {
@@ -122,7 +127,69 @@ func (c *ClusterDelivery) validateNewState() error {
 		return err
 	}
 
-	return c.validateDeploymentTemplateDidNotReceiveConfig()
+	if err := c.validateDeploymentTemplateDidNotReceiveConfig(); err != nil {
+		return err
+	}
+
+	return c.ValidateOutputReferences()
+}
+
+// ValidateOutputReferences checks that every source, config and deployment a resource consumes
+// is provided by a resource of the delivery whose template kind produces that output. Both
+// ClusterSourceTemplates and ClusterDeploymentTemplates produce a source, no delivery template
+// produces a config.
+func (c *ClusterDelivery) ValidateOutputReferences() error {
+	sourceKinds := []string{"ClusterSourceTemplate", "ClusterDeploymentTemplate"}
+
+	for _, resource := range c.Spec.Resources {
+		if err := c.validateResourceRefs(resource.Sources, sourceKinds); err != nil {
+			return fmt.Errorf("invalid sources for resource [%s]: %w", resource.Name, err)
+		}
+
+		if err := c.validateResourceRefs(resource.Configs, nil); err != nil {
+			return fmt.Errorf("invalid configs for resource [%s]: %w", resource.Name, err)
+		}
+
+		if resource.Deployment != nil {
+			deployment := []ResourceReference{{Name: "deployment", Resource: resource.Deployment.Resource}}
+			if err := c.validateResourceRefs(deployment, sourceKinds); err != nil {
+				return fmt.Errorf("invalid deployment for resource [%s]: %w", resource.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterDelivery) validateResourceRefs(references []ResourceReference, targetKinds []string) error {
+	for _, ref := range references {
+		referencedResource := c.getResourceByName(ref.Resource)
+		if referencedResource == nil {
+			return fmt.Errorf("[%s] is provided by unknown resource [%s]", ref.Name, ref.Resource)
+		}
+		if len(targetKinds) == 0 {
+			return fmt.Errorf("resource [%s] cannot provide [%s], no delivery template produces it", referencedResource.Name, ref.Name)
+		}
+
+		kindMatches := false
+		for _, kind := range targetKinds {
+			kindMatches = kindMatches || referencedResource.TemplateRef.Kind == kind
+		}
+		if !kindMatches {
+			return fmt.Errorf("resource [%s] providing [%s] must reference a %s", referencedResource.Name, ref.Name, strings.Join(targetKinds, " or "))
+		}
+	}
+	return nil
+}
+
+func (c *ClusterDelivery) getResourceByName(name string) *ClusterDeliveryResource {
+	for i := range c.Spec.Resources {
+		if c.Spec.Resources[i].Name == name {
+			return &c.Spec.Resources[i]
+		}
+	}
+
+	return nil
 }
 
 func (c *ClusterDelivery) validateDeploymentPassedToProperReceivers() error {
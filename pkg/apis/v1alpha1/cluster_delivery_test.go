@@ -75,6 +75,57 @@ var _ = Describe("Delivery Validation", func() {
 			})
 		})
 
+		Context("Delivery resources consuming outputs", func() {
+			BeforeEach(func() {
+				delivery.Spec.Resources = append(delivery.Spec.Resources,
+					v1alpha1.ClusterDeliveryResource{
+						Name: "deployer",
+						TemplateRef: v1alpha1.DeliveryClusterTemplateReference{
+							Kind: "ClusterDeploymentTemplate",
+							Name: "deployment-template",
+						},
+						Deployment: &v1alpha1.DeploymentReference{Resource: "source-provider"},
+					},
+					v1alpha1.ClusterDeliveryResource{
+						Name: "promoter",
+						TemplateRef: v1alpha1.DeliveryClusterTemplateReference{
+							Kind: "ClusterTemplate",
+							Name: "template",
+						},
+						Sources: []v1alpha1.ResourceReference{
+							{Name: "deployed", Resource: "deployer"},
+							{Name: "source", Resource: "other-source-provider"},
+						},
+					},
+				)
+			})
+
+			It("does not return an error when each output is produced by the referenced resource", func() {
+				Expect(delivery.ValidateOutputReferences()).To(Succeed())
+				Expect(delivery.ValidateCreate()).To(Succeed())
+			})
+
+			It("returns an error when a source is provided by an unknown resource", func() {
+				delivery.Spec.Resources[3].Sources[1].Resource = "missing"
+				Expect(delivery.ValidateCreate()).To(MatchError("invalid sources for resource [promoter]: [source] is provided by unknown resource [missing]"))
+			})
+
+			It("returns an error when a source is provided by a resource whose template produces no source", func() {
+				delivery.Spec.Resources[3].Sources[0].Resource = "promoter"
+				Expect(delivery.ValidateCreate()).To(MatchError("invalid sources for resource [promoter]: resource [promoter] providing [deployed] must reference a ClusterSourceTemplate or ClusterDeploymentTemplate"))
+			})
+
+			It("returns an error when the deployment is provided by a resource whose template produces no source", func() {
+				delivery.Spec.Resources[2].Deployment.Resource = "promoter"
+				Expect(delivery.ValidateCreate()).To(MatchError("invalid deployment for resource [deployer]: resource [promoter] providing [deployment] must reference a ClusterSourceTemplate or ClusterDeploymentTemplate"))
+			})
+
+			It("returns an error when a config is consumed, as no delivery template produces one", func() {
+				delivery.Spec.Resources[3].Configs = []v1alpha1.ResourceReference{{Name: "config", Resource: "source-provider"}}
+				Expect(delivery.ValidateCreate()).To(MatchError("invalid configs for resource [promoter]: resource [source-provider] cannot provide [config], no delivery template produces it"))
+			})
+		})
+
 		Context("Delivery with malformed params", func() {
 			Context("Top level params are malformed", func() {
 				Context("param does not specify a value or default", func() {
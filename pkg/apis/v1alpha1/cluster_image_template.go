@@ -19,6 +19,8 @@
 package v1alpha1
 
 import (
+	"errors"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -36,19 +38,82 @@ type ClusterImageTemplate struct {
 }
 type ImageTemplateSpec struct {
 	TemplateSpec `json:",inline"`
-	ImagePath    string `json:"imagePath"`
+	// ImagePath is the path of the image in the stamped object. Defaults
+	// to .status.image unless outputFrom is set.
+	ImagePath string `json:"imagePath,omitempty"`
+	// OutputFrom reads the image from a ConfigMap or Secret named by the
+	// stamped object, in place of the imagePath
+	OutputFrom *OutputFrom `json:"outputFrom,omitempty"`
+	// ImageSort sorts the values the imagePath matches and picks one of
+	// them, for objects that list several images. Only supported with the
+	// jsonpath output language.
+	ImageSort *OutputSort `json:"imageSort,omitempty"`
+	// OutputSubresource evaluates the output paths against the named
+	// subresource of the stamped object rather than the object itself
+	// +kubebuilder:validation:Enum=status;scale
+	OutputSubresource string `json:"outputSubresource,omitempty"`
+	// OutputRequired fails the resource when a value read at an output path
+	// is empty or only whitespace, rather than passing it on to the resources
+	// that consume it. Defaults to true.
+	OutputRequired *bool `json:"outputRequired,omitempty"`
+	// OutputLanguage is the language the output paths are written in:
+	// jsonpath, or a ytt (starlark) expression over data.values. Defaults
+	// to jsonpath.
+	// +kubebuilder:validation:Enum=jsonpath;ytt
+	OutputLanguage string `json:"outputLanguage,omitempty"`
+	// ReadinessGates are conditions the stamped object must report before
+	// the outputs are read. Until they all pass, the resources consuming the
+	// outputs are not updated.
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty"`
+	// RequireDigest fails the resource when the image read is not pinned
+	// to a digest, e.g. only tagged, rather than passing a mutable
+	// reference on to the resources that consume it.
+	RequireDigest bool `json:"requireDigest,omitempty"`
 }
 
 type ImageTemplateStatus struct {
 }
 
+const DefaultImagePath = ".status.image"
+
+var _ webhook.Defaulter = &ClusterImageTemplate{}
+
+// Default fills in the path at which image builders conventionally expose the image, when the
+// template reads the image neither from a path nor from outputFrom.
+func (c *ClusterImageTemplate) Default() {
+	if c.Spec.OutputLanguage != "" && c.Spec.OutputLanguage != OutputLanguageJsonPath {
+		return
+	}
+	if c.Spec.ImagePath == "" && c.Spec.OutputFrom == nil {
+		c.Spec.ImagePath = DefaultImagePath
+	}
+}
+
 var _ webhook.Validator = &ClusterImageTemplate{}
 
 func (c *ClusterImageTemplate) ValidateCreate() error {
-	return c.Spec.TemplateSpec.validate()
+	return c.validate()
 }
 
 func (c *ClusterImageTemplate) ValidateUpdate(_ runtime.Object) error {
+	return c.validate()
+}
+
+func (c *ClusterImageTemplate) validate() error {
+	if err := validateOutputPathOrFrom("imagePath", c.Spec.ImagePath, c.Spec.OutputFrom); err != nil {
+		return err
+	}
+	if c.Spec.ImageSort != nil {
+		if c.Spec.OutputFrom != nil {
+			return errors.New("imageSort cannot be set with outputFrom")
+		}
+		if err := c.Spec.ImageSort.validate("imageSort", c.Spec.OutputLanguage); err != nil {
+			return err
+		}
+	}
+	if err := validateReadinessGates(c.Spec.ReadinessGates); err != nil {
+		return err
+	}
 	return c.Spec.TemplateSpec.validate()
 }
 
@@ -37,6 +37,9 @@ var _ = Describe("ClusterImageTemplate", func() {
 					Name:      "some-template",
 					Namespace: "default",
 				},
+				Spec: v1alpha1.ImageTemplateSpec{
+					ImagePath: ".status.image",
+				},
 			}
 		})
 
@@ -90,6 +93,119 @@ var _ = Describe("ClusterImageTemplate", func() {
 			})
 		})
 
+		Describe("imageSort", func() {
+			BeforeEach(func() {
+				raw, err := json.Marshal(&ArbitraryObject{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "some-kind",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-name",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				template.Spec.Template = &runtime.RawExtension{Raw: raw}
+				template.Spec.ImagePath = ".status.images"
+				template.Spec.ImageSort = &v1alpha1.OutputSort{SortBy: ".platform", Pick: v1alpha1.OutputPickLast}
+			})
+
+			It("succeeds when the sort is well formed", func() {
+				Expect(template.ValidateCreate()).To(Succeed())
+				Expect(template.ValidateUpdate(nil)).To(Succeed())
+			})
+
+			It("returns an error when sortBy is missing", func() {
+				template.Spec.ImageSort.SortBy = ""
+				Expect(template.ValidateCreate()).To(MatchError("imageSort.sortBy must be set"))
+			})
+
+			It("returns an error when the output language is ytt", func() {
+				template.Spec.OutputLanguage = v1alpha1.OutputLanguageYtt
+				Expect(template.ValidateUpdate(nil)).To(MatchError("imageSort is only supported with the jsonpath output language"))
+			})
+		})
+
+		Describe("readinessGates", func() {
+			BeforeEach(func() {
+				raw, err := json.Marshal(&ArbitraryObject{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "some-kind",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-name",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				template.Spec.Template = &runtime.RawExtension{Raw: raw}
+				template.Spec.ImagePath = ".status.latestImage"
+				template.Spec.ReadinessGates = []v1alpha1.ReadinessGate{{ConditionType: "SBOMScanned"}}
+			})
+
+			It("succeeds when every gate names a condition type", func() {
+				Expect(template.ValidateCreate()).To(Succeed())
+				Expect(template.ValidateUpdate(nil)).To(Succeed())
+			})
+
+			It("returns an error when a gate has no condition type", func() {
+				template.Spec.ReadinessGates = append(template.Spec.ReadinessGates, v1alpha1.ReadinessGate{Status: metav1.ConditionFalse})
+				Expect(template.ValidateCreate()).To(MatchError("readinessGates[1].conditionType must be set"))
+			})
+		})
+
+		Describe("outputFrom", func() {
+			BeforeEach(func() {
+				raw, err := json.Marshal(&ArbitraryObject{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "some-kind",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-name",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				template.Spec.Template = &runtime.RawExtension{Raw: raw}
+				template.Spec.ImagePath = ""
+				template.Spec.OutputFrom = &v1alpha1.OutputFrom{
+					Kind:         v1alpha1.OutputFromConfigMap,
+					NameFromPath: ".status.resultsConfigMap",
+					Key:          "result",
+				}
+			})
+
+			It("succeeds when outputFrom is well formed", func() {
+				Expect(template.ValidateCreate()).To(Succeed())
+				Expect(template.ValidateUpdate(nil)).To(Succeed())
+			})
+
+			It("returns an error when imagePath is set as well", func() {
+				template.Spec.ImagePath = ".status.image"
+				Expect(template.ValidateCreate()).To(MatchError("exactly one of imagePath and outputFrom must be set"))
+			})
+
+			It("returns an error when neither imagePath nor outputFrom is set", func() {
+				template.Spec.OutputFrom = nil
+				Expect(template.ValidateUpdate(nil)).To(MatchError("exactly one of imagePath and outputFrom must be set"))
+			})
+
+			It("returns an error when nameFromPath is missing", func() {
+				template.Spec.OutputFrom.NameFromPath = ""
+				Expect(template.ValidateCreate()).To(MatchError("outputFrom.nameFromPath must be set"))
+			})
+
+			It("returns an error when key is missing", func() {
+				template.Spec.OutputFrom.Key = ""
+				Expect(template.ValidateCreate()).To(MatchError("outputFrom.key must be set"))
+			})
+
+			It("returns an error when the kind is neither ConfigMap nor Secret", func() {
+				template.Spec.OutputFrom.Kind = "Pod"
+				Expect(template.ValidateCreate()).To(MatchError("outputFrom.kind must be one of [ConfigMap, Secret]"))
+			})
+		})
+
 		Describe("#Update", func() {
 			Context("template is well formed", func() {
 				BeforeEach(func() {
@@ -149,4 +265,37 @@ var _ = Describe("ClusterImageTemplate", func() {
 			})
 		})
 	})
+
+	Describe("Webhook Defaulting", func() {
+		var template *v1alpha1.ClusterImageTemplate
+
+		BeforeEach(func() {
+			template = &v1alpha1.ClusterImageTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-template"},
+			}
+		})
+
+		It("defaults an omitted imagePath", func() {
+			template.Default()
+			Expect(template.Spec.ImagePath).To(Equal(".status.image"))
+		})
+
+		It("keeps an imagePath that is set", func() {
+			template.Spec.ImagePath = ".status.latestImage"
+			template.Default()
+			Expect(template.Spec.ImagePath).To(Equal(".status.latestImage"))
+		})
+
+		It("does not default the imagePath when the image is read from outputFrom", func() {
+			template.Spec.OutputFrom = &v1alpha1.OutputFrom{Kind: v1alpha1.OutputFromConfigMap, NameFromPath: ".status.results", Key: "image"}
+			template.Default()
+			Expect(template.Spec.ImagePath).To(BeEmpty())
+		})
+
+		It("does not default the imagePath of a ytt output language", func() {
+			template.Spec.OutputLanguage = v1alpha1.OutputLanguageYtt
+			template.Default()
+			Expect(template.Spec.ImagePath).To(BeEmpty())
+		})
+	})
 })
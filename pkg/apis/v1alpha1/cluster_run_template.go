@@ -19,6 +19,7 @@
 package v1alpha1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -36,6 +37,65 @@ type ClusterRunTemplateSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Template runtime.RawExtension `json:"template"`
 	Outputs  map[string]string    `json:"outputs,omitempty"`
+	// OutputTransforms reshapes outputs before they are stored, keyed by the
+	// name of an output in Outputs. Each transform is interpolated with
+	// $(<jsonpath>)$ tags against `value`, the value extracted for that output,
+	// and `outputs`, every value extracted from the same object.
+	OutputTransforms map[string]string `json:"outputTransforms,omitempty"`
+	// ReferencedOutputs are read from an object that the stamped object refers
+	// to by name, such as a TaskRun created by a PipelineRun, rather than from
+	// the stamped object itself. They are keyed by output name, like Outputs.
+	ReferencedOutputs map[string]ReferencedOutput `json:"referencedOutputs,omitempty"`
+	// Inputs declares the inputs the template expects from a Runnable.
+	Inputs []RunTemplateInput `json:"inputs,omitempty"`
+	// ResyncPeriod is how often every object of the stamped kind is
+	// reconciled again even if no change to it was observed. Kinds whose
+	// status changes are not always delivered as watch events can set a
+	// short period to catch up; the tightest period set by any template
+	// stamping the kind is used. Defaults to the controller's sync period.
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+	// RequeueAfterPath is the path in the stamped object to a hint of when the
+	// controller of the object suggests it is looked at again, such as
+	// .status.nextPollTime: a duration like "30s", an RFC 3339 timestamp or a
+	// number of seconds. A Runnable is reconciled again at that time, on top
+	// of the watch and resync of the stamped kind.
+	RequeueAfterPath string `json:"requeueAfterPath,omitempty"`
+	// ObjectMeta holds labels and annotations added to every object stamped
+	// from the template. Labels and annotations already set on the stamped
+	// object, including those Cartographer uses to track it, take precedence.
+	ObjectMeta *RunTemplateObjectMeta `json:"objectMeta,omitempty"`
+	// SensitiveOutputs are the names of outputs whose values are redacted
+	// when a Runnable reports its evaluated output paths for debugging.
+	SensitiveOutputs []string `json:"sensitiveOutputs,omitempty"`
+	// RecordOutputSources makes a Runnable report in its status, for each of
+	// its outputs, the stamped object the output was read from.
+	RecordOutputSources bool `json:"recordOutputSources,omitempty"`
+}
+
+type RunTemplateObjectMeta struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ReferencedOutput struct {
+	// NamePath is the path in the stamped object to the name of the
+	// referenced object, which is read from the stamped object's namespace.
+	NamePath string `json:"namePath"`
+	// Resource is the apiVersion and kind of the referenced object.
+	Resource ResourceType `json:"resource"`
+	// Path is the path in the referenced object to the output.
+	Path string `json:"path"`
+}
+
+type RunTemplateInput struct {
+	Name string `json:"name"`
+	// Default is used when a Runnable does not provide the input. An input
+	// without a default is required.
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+	// Schema is an OpenAPI v3 schema, as in a CustomResourceDefinition, the
+	// value of the input must match, whether provided by the Runnable or
+	// defaulted.
+	Schema *apiextensionsv1.JSON `json:"schema,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -37,20 +37,73 @@ type ClusterSourceTemplate struct {
 
 type SourceTemplateSpec struct {
 	TemplateSpec `json:",inline"`
-	URLPath      string `json:"urlPath"`
-	RevisionPath string `json:"revisionPath"`
+	// URLPath is the path of the source url in the stamped object.
+	// Defaults to .status.artifact.url
+	URLPath string `json:"urlPath,omitempty"`
+	// RevisionPath is the path of the source revision in the stamped object.
+	// Defaults to .status.artifact.revision
+	RevisionPath string `json:"revisionPath,omitempty"`
+	// DigestPath is where the stamped object exposes a digest of the source
+	// content, which consumers can pin to. Resources consuming the source
+	// see no digest when it is not set
+	DigestPath string `json:"digestPath,omitempty"`
+	// OutputSubresource evaluates the output paths against the named
+	// subresource of the stamped object rather than the object itself
+	// +kubebuilder:validation:Enum=status;scale
+	OutputSubresource string `json:"outputSubresource,omitempty"`
+	// OutputRequired fails the resource when a value read at an output path
+	// is empty or only whitespace, rather than passing it on to the resources
+	// that consume it. Defaults to true.
+	OutputRequired *bool `json:"outputRequired,omitempty"`
+	// OutputLanguage is the language the output paths are written in:
+	// jsonpath, or a ytt (starlark) expression over data.values. Defaults
+	// to jsonpath.
+	// +kubebuilder:validation:Enum=jsonpath;ytt
+	OutputLanguage string `json:"outputLanguage,omitempty"`
+	// ReadinessGates are conditions the stamped object must report before
+	// the outputs are read. Until they all pass, the resources consuming the
+	// outputs are not updated.
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty"`
 }
 
 type SourceTemplateStatus struct {
 }
 
+const (
+	DefaultURLPath      = ".status.artifact.url"
+	DefaultRevisionPath = ".status.artifact.revision"
+)
+
+var _ webhook.Defaulter = &ClusterSourceTemplate{}
+
+// Default fills in the paths at which source controllers such as flux conventionally expose
+// the url and revision of the source, when the template leaves them out.
+func (c *ClusterSourceTemplate) Default() {
+	if c.Spec.OutputLanguage != "" && c.Spec.OutputLanguage != OutputLanguageJsonPath {
+		return
+	}
+	if c.Spec.URLPath == "" {
+		c.Spec.URLPath = DefaultURLPath
+	}
+	if c.Spec.RevisionPath == "" {
+		c.Spec.RevisionPath = DefaultRevisionPath
+	}
+}
+
 var _ webhook.Validator = &ClusterSourceTemplate{}
 
 func (c *ClusterSourceTemplate) ValidateCreate() error {
-	return c.Spec.TemplateSpec.validate()
+	return c.validate()
 }
 
 func (c *ClusterSourceTemplate) ValidateUpdate(_ runtime.Object) error {
+	return c.validate()
+}
+
+func (c *ClusterSourceTemplate) validate() error {
+	if err := validateReadinessGates(c.Spec.ReadinessGates); err != nil {
+		return err
+	}
 	return c.Spec.TemplateSpec.validate()
 }
 
@@ -149,4 +149,34 @@ var _ = Describe("ClusterSourceTemplate", func() {
 			})
 		})
 	})
+
+	Describe("Webhook Defaulting", func() {
+		var template *v1alpha1.ClusterSourceTemplate
+
+		BeforeEach(func() {
+			template = &v1alpha1.ClusterSourceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-template"},
+			}
+		})
+
+		It("defaults omitted url and revision paths", func() {
+			template.Default()
+			Expect(template.Spec.URLPath).To(Equal(".status.artifact.url"))
+			Expect(template.Spec.RevisionPath).To(Equal(".status.artifact.revision"))
+		})
+
+		It("keeps the paths that are set", func() {
+			template.Spec.URLPath = ".status.url"
+			template.Default()
+			Expect(template.Spec.URLPath).To(Equal(".status.url"))
+			Expect(template.Spec.RevisionPath).To(Equal(".status.artifact.revision"))
+		})
+
+		It("does not default the paths of a ytt output language", func() {
+			template.Spec.OutputLanguage = v1alpha1.OutputLanguageYtt
+			template.Default()
+			Expect(template.Spec.URLPath).To(BeEmpty())
+			Expect(template.Spec.RevisionPath).To(BeEmpty())
+		})
+	})
 })
@@ -20,9 +20,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"unicode"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,6 +40,21 @@ const (
 	NotFoundTemplatesReadyReason = "TemplatesNotFound"
 )
 
+// SupplyChainPauseAnnotation set to "true" on a ClusterSupplyChain stops the workloads it
+// selects from being realized, leaving their stamped objects as they are, until it is removed.
+const SupplyChainPauseAnnotation = "carto.run/pause"
+
+// SourceRevisionAnnotation is set on each object stamped for a supply chain resource to the
+// revision of the source it was produced from, when a source resource is upstream of it.
+const SourceRevisionAnnotation = "carto.run/source-revision"
+
+// DefaultFieldManager is the field manager objects are stamped with when the supply chain
+// does not set spec.fieldManager.
+const DefaultFieldManager = "cartographer"
+
+// maxFieldManagerLength is the longest field manager the api server accepts.
+const maxFieldManagerLength = 128
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
@@ -47,9 +66,29 @@ type ClusterSupplyChain struct {
 	Status            SupplyChainStatus `json:"status,omitempty"`
 }
 
+func (c *ClusterSupplyChain) Paused() bool {
+	return c.Annotations[SupplyChainPauseAnnotation] == "true"
+}
+
+// GetFieldManager returns the field manager the supply chain stamps objects with.
+func (c *ClusterSupplyChain) GetFieldManager() string {
+	if c.Spec.FieldManager == "" {
+		return DefaultFieldManager
+	}
+	return c.Spec.FieldManager
+}
+
 func (c *ClusterSupplyChain) validateNewState() error {
 	names := make(map[string]bool)
 
+	if errs := c.ValidateSelector(); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	if err := c.validateFieldManager(); err != nil {
+		return err
+	}
+
 	if err := c.validateParams(); err != nil {
 		return err
 	}
@@ -65,6 +104,12 @@ func (c *ClusterSupplyChain) validateNewState() error {
 		names[resource.Name] = true
 	}
 
+	return c.ValidateOutputReferences()
+}
+
+// ValidateOutputReferences checks that every source, image and config a resource consumes is
+// provided by a resource of the supply chain whose template kind produces that output.
+func (c *ClusterSupplyChain) ValidateOutputReferences() error {
 	for _, resource := range c.Spec.Resources {
 		if err := c.validateResourceRefs(resource.Sources, "ClusterSourceTemplate"); err != nil {
 			return fmt.Errorf(
@@ -94,6 +139,38 @@ func (c *ClusterSupplyChain) validateNewState() error {
 	return nil
 }
 
+// ValidateSelector checks that the selector is not empty, as an empty selector matches no
+// workload, that every label in it is a valid label key and value, and that an owner selector
+// selects a kind.
+func (c *ClusterSupplyChain) ValidateSelector() field.ErrorList {
+	selectorPath := field.NewPath("spec", "selector")
+
+	if len(c.Spec.Selector) == 0 {
+		return field.ErrorList{field.Required(selectorPath, "an empty selector matches no workload")}
+	}
+
+	errs := metav1validation.ValidateLabels(c.Spec.Selector, selectorPath)
+	if c.Spec.OwnerSelector != nil && c.Spec.OwnerSelector.OwnerKind == "" {
+		errs = append(errs, field.Required(field.NewPath("spec", "ownerSelector", "ownerKind"), "an owner selector must select a kind"))
+	}
+	return errs
+}
+
+// validateFieldManager checks the field manager is one the api server accepts: no longer
+// than 128 characters, all of them printable.
+func (c *ClusterSupplyChain) validateFieldManager() error {
+	fieldManager := c.Spec.FieldManager
+	if len(fieldManager) > maxFieldManagerLength {
+		return fmt.Errorf("invalid field manager [%s]: must be no more than %d characters", fieldManager, maxFieldManagerLength)
+	}
+	for _, r := range fieldManager {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("invalid field manager [%q]: must only contain printable characters", fieldManager)
+		}
+	}
+	return nil
+}
+
 func (c *ClusterSupplyChain) validateParams() error {
 	for _, param := range c.Spec.Params {
 		err := param.validateDelegatableParams()
@@ -162,6 +239,14 @@ func (c *ClusterSupplyChain) GetSelector() map[string]string {
 	return c.Spec.Selector
 }
 
+func (c *ClusterSupplyChain) GetOwnerSelector() *OwnerSelector {
+	return c.Spec.OwnerSelector
+}
+
+func (c *ClusterSupplyChain) GetCanaryWeight() int {
+	return c.Spec.CanaryWeight
+}
+
 func GetSelectorsFromObject(o client.Object) []string {
 	var res []string
 	res = []string{}
@@ -177,12 +262,77 @@ func GetSelectorsFromObject(o client.Object) []string {
 }
 
 type SupplyChainSpec struct {
-	Resources         []SupplyChainResource `json:"resources"`
-	Selector          map[string]string     `json:"selector"`
-	Params            []DelegatableParam    `json:"params,omitempty"`
-	ServiceAccountRef ServiceAccountRef     `json:"serviceAccountRef,omitempty"`
+	Resources []SupplyChainResource `json:"resources"`
+	Selector  map[string]string     `json:"selector"`
+	// OwnerSelector narrows the selector to workloads owned by an object of
+	// the given kind, such as an App that creates its workloads. A workload
+	// with several owner references matches when any of them does
+	OwnerSelector     *OwnerSelector     `json:"ownerSelector,omitempty"`
+	Params            []DelegatableParam `json:"params,omitempty"`
+	ServiceAccountRef ServiceAccountRef  `json:"serviceAccountRef,omitempty"`
+	// RealizeStrategy decides what happens when a resource fails to be
+	// realized. failFast, the default, stops at the failing resource.
+	// collectAll carries on realizing the resources that do not consume the
+	// output of a failed resource and reports the failure of each.
+	// +kubebuilder:validation:Enum=failFast;collectAll
+	RealizeStrategy RealizeStrategy `json:"realizeStrategy,omitempty"`
+	// FieldManager is the field manager the objects stamped for the
+	// supply chain's workloads are created and patched with, so that
+	// several cartographer instances own their fields apart. Defaults to
+	// cartographer
+	// +kubebuilder:validation:MaxLength=128
+	FieldManager string `json:"fieldManager,omitempty"`
+	// CanaryWeight is the percentage of the workloads this supply chain is
+	// selected for among those it ties with equally specific supply chains.
+	// A workload always lands on the same supply chain of a tie, as long as
+	// the weights do not change. The supply chain of a tie without a weight
+	// takes the remaining workloads
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	CanaryWeight int `json:"canaryWeight,omitempty"`
+}
+
+// OwnerSelector matches the owner references of a workload
+type OwnerSelector struct {
+	// OwnerKind is the kind of the owner
+	OwnerKind string `json:"ownerKind"`
+	// OwnerAPIGroup is the api group of the owner, of any group when empty
+	OwnerAPIGroup string `json:"ownerAPIGroup,omitempty"`
 }
 
+// Matches verifies whether any of the owner references is of the selected kind and group.
+func (s *OwnerSelector) Matches(ownerReferences []metav1.OwnerReference) bool {
+	for _, ref := range ownerReferences {
+		if ref.Kind != s.OwnerKind {
+			continue
+		}
+		if s.OwnerAPIGroup == "" {
+			return true
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err == nil && gv.Group == s.OwnerAPIGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// Size is the number of criteria of the selector, weighed alongside the labels of a
+// supply chain selector.
+func (s *OwnerSelector) Size() int {
+	if s.OwnerAPIGroup == "" {
+		return 1
+	}
+	return 2
+}
+
+type RealizeStrategy string
+
+const (
+	RealizeStrategyFailFast   RealizeStrategy = "failFast"
+	RealizeStrategyCollectAll RealizeStrategy = "collectAll"
+)
+
 type SupplyChainResource struct {
 	Name        string                   `json:"name"`
 	TemplateRef ClusterTemplateReference `json:"templateRef"`
@@ -190,6 +340,33 @@ type SupplyChainResource struct {
 	Sources     []ResourceReference      `json:"sources,omitempty"`
 	Images      []ResourceReference      `json:"images,omitempty"`
 	Configs     []ResourceReference      `json:"configs,omitempty"`
+	// ValidateStampedObject checks the stamped object against the schema of
+	// its CustomResourceDefinition before it is submitted
+	ValidateStampedObject bool `json:"validateStampedObject,omitempty"`
+	// Condition includes the resource only for workloads whose value at the
+	// jsonpath Key equals Value. Resources consuming the output of a skipped
+	// resource are skipped as well
+	Condition *Condition `json:"condition,omitempty"`
+	// NameTemplate names the stamped object in place of the name or
+	// generateName of the template. It may reference
+	// $(workload.metadata.name)$, $(resource.name)$ and $(inputHash)$,
+	// a short hash of the resource's params and inputs. The rendered name
+	// is sanitized into a valid DNS-1123 subdomain
+	NameTemplate string `json:"nameTemplate,omitempty"`
+	// WaitForObservedGeneration reads the outputs of the stamped object only
+	// once its status.observedGeneration has reached its metadata.generation,
+	// so outputs its controller has not yet updated are not passed on
+	WaitForObservedGeneration bool `json:"waitForObservedGeneration,omitempty"`
+	// RecreateOnImmutableChange deletes and creates the stamped object again
+	// when the api server rejects a change to one of its immutable fields,
+	// such as the template of a Job, instead of failing the patch on every
+	// reconcile
+	RecreateOnImmutableChange bool `json:"recreateOnImmutableChange,omitempty"`
+	// Optional skips the resource, rather than failing the workload, when
+	// its template does not exist. Resources consuming the output of the
+	// skipped resource are skipped as well. The missing template of an
+	// optional resource does not keep the supply chain from being ready
+	Optional bool `json:"optional,omitempty"`
 }
 
 var ValidSupplyChainTemplates = []client.Object{
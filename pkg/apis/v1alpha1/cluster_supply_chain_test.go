@@ -178,6 +178,56 @@ var _ = Describe("ClusterSupplyChain", func() {
 			})
 		})
 
+		Context("Supply chain without a selector", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Selector = nil
+			})
+
+			It("on create, returns an error", func() {
+				Expect(supplyChain.ValidateCreate()).To(MatchError(
+					"spec.selector: Required value: an empty selector matches no workload",
+				))
+			})
+
+			It("on update, returns an error", func() {
+				Expect(supplyChain.ValidateUpdate(oldSupplyChain)).To(MatchError(
+					"spec.selector: Required value: an empty selector matches no workload",
+				))
+			})
+		})
+
+		Context("Supply chain with an owner selector", func() {
+			It("creates without error", func() {
+				supplyChain.Spec.OwnerSelector = &v1alpha1.OwnerSelector{OwnerKind: "App", OwnerAPIGroup: "apps.example.com"}
+				Expect(supplyChain.ValidateCreate()).NotTo(HaveOccurred())
+			})
+
+			It("rejects an owner selector without a kind", func() {
+				supplyChain.Spec.OwnerSelector = &v1alpha1.OwnerSelector{OwnerAPIGroup: "apps.example.com"}
+				Expect(supplyChain.ValidateCreate()).To(MatchError(
+					"spec.ownerSelector.ownerKind: Required value: an owner selector must select a kind",
+				))
+			})
+		})
+
+		Context("Supply chain with a field manager", func() {
+			It("creates without error", func() {
+				supplyChain.Spec.FieldManager = "cartographer-staging"
+				Expect(supplyChain.ValidateCreate()).NotTo(HaveOccurred())
+			})
+
+			It("rejects a field manager longer than 128 characters", func() {
+				supplyChain.Spec.FieldManager = strings.Repeat("a", 129)
+				Expect(supplyChain.ValidateCreate()).To(MatchError(ContainSubstring("must be no more than 128 characters")))
+				Expect(supplyChain.ValidateUpdate(oldSupplyChain)).To(MatchError(ContainSubstring("must be no more than 128 characters")))
+			})
+
+			It("rejects a field manager with characters that are not printable", func() {
+				supplyChain.Spec.FieldManager = "cartographer\n"
+				Expect(supplyChain.ValidateCreate()).To(MatchError(`invalid field manager ["cartographer\n"]: must only contain printable characters`))
+			})
+		})
+
 		Context("Supply chain with a resource reference that does not exist", func() {
 			BeforeEach(func() {
 				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
@@ -437,6 +487,17 @@ var _ = Describe("ClusterSupplyChain", func() {
 		})
 	})
 
+	Describe("GetFieldManager", func() {
+		It("defaults to cartographer", func() {
+			Expect((&v1alpha1.ClusterSupplyChain{}).GetFieldManager()).To(Equal(v1alpha1.DefaultFieldManager))
+		})
+
+		It("returns the field manager of the spec", func() {
+			supplyChain := &v1alpha1.ClusterSupplyChain{Spec: v1alpha1.SupplyChainSpec{FieldManager: "cartographer-staging"}}
+			Expect(supplyChain.GetFieldManager()).To(Equal("cartographer-staging"))
+		})
+	})
+
 	Describe("GetSelectorsFromObject", func() {
 		var expectedSelectors, actualSelectors []string
 		Context("when object is a supply chain", func() {
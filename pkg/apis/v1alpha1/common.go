@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,6 +41,27 @@ type DelegatableParam struct {
 	DefaultValue *apiextensionsv1.JSON `json:"default,omitempty"`
 }
 
+// ParamLayer is where the value of a param was set: the template's default, the supply chain or
+// delivery (Blueprint), the resource of the blueprint, or the workload or deliverable (Owner).
+type ParamLayer string
+
+const (
+	ParamLayerTemplate  ParamLayer = "Template"
+	ParamLayerBlueprint ParamLayer = "Blueprint"
+	ParamLayerResource  ParamLayer = "Resource"
+	ParamLayerOwner     ParamLayer = "Owner"
+)
+
+// ParamResolution reports a param that more than one layer set to different values.
+type ParamResolution struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	// Layer set the value the resource was stamped with.
+	Layer ParamLayer `json:"layer"`
+	// Overridden are the layers whose different value was not used.
+	Overridden []ParamLayer `json:"overridden"`
+}
+
 func (p *DelegatableParam) validateDelegatableParams() error {
 	if p.bothValuesSet() || p.neitherValueSet() {
 		return fmt.Errorf("param [%s] is invalid: must set exactly one of value and default", p.Name)
@@ -54,6 +77,118 @@ func (p *DelegatableParam) neitherValueSet() bool {
 	return p.DefaultValue == nil && p.Value == nil
 }
 
+// ControllerPaused has a negative polarity, it is reported, as True, on the workloads,
+// deliverables and runnables left alone while cartographer is paused for maintenance.
+const (
+	ControllerPaused                = "ControllerPaused"
+	ConfigMapControllerPausedReason = "PausedByConfigMap"
+)
+
+const (
+	OutputPickFirst = "first"
+	OutputPickLast  = "last"
+)
+
+// OutputSort picks one value out of the list an output path matches, so
+// that the output does not depend on the position of the value in the list
+type OutputSort struct {
+	// SortBy is a jsonpath read from each value the output path matches,
+	// the values are sorted by what it reads
+	SortBy string `json:"sortBy"`
+	// Pick is the value of the sorted list to output: first or last.
+	// Defaults to first.
+	// +kubebuilder:validation:Enum=first;last
+	Pick string `json:"pick,omitempty"`
+	// ValuePath is a jsonpath read from the picked value to produce the
+	// output. Defaults to the picked value itself.
+	ValuePath string `json:"valuePath,omitempty"`
+}
+
+func (s *OutputSort) validate(field string, outputLanguage string) error {
+	if s.SortBy == "" {
+		return fmt.Errorf("%s.sortBy must be set", field)
+	}
+	if outputLanguage != "" && outputLanguage != OutputLanguageJsonPath {
+		return fmt.Errorf("%s is only supported with the %s output language", field, OutputLanguageJsonPath)
+	}
+	return nil
+}
+
+// ReadinessGate is a condition the stamped object must report before the
+// outputs of the template are read, holding them back from the resources
+// that consume them until then
+type ReadinessGate struct {
+	// ConditionType is the type of the condition in the status of the
+	// stamped object
+	ConditionType string `json:"conditionType"`
+	// Status the condition must have: True, False or Unknown. Defaults to
+	// True.
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status,omitempty"`
+}
+
+// WantStatus is the status the condition must have for the gate to pass.
+func (g ReadinessGate) WantStatus() metav1.ConditionStatus {
+	if g.Status == "" {
+		return metav1.ConditionTrue
+	}
+	return g.Status
+}
+
+func validateReadinessGates(gates []ReadinessGate) error {
+	for i, gate := range gates {
+		if gate.ConditionType == "" {
+			return fmt.Errorf("readinessGates[%d].conditionType must be set", i)
+		}
+	}
+	return nil
+}
+
+const (
+	OutputFromConfigMap = "ConfigMap"
+	OutputFromSecret    = "Secret"
+)
+
+// OutputFrom reads an output from a ConfigMap or Secret that the controller
+// of the stamped object writes its results to, in place of an output path
+type OutputFrom struct {
+	// Kind of the object holding the output: ConfigMap or Secret. Outputs
+	// read from a Secret are sensitive and are redacted where reported.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+	// NameFromPath is a jsonpath read from the stamped object, or from its
+	// outputSubresource, to the name of the ConfigMap or Secret in the
+	// namespace of the stamped object
+	NameFromPath string `json:"nameFromPath"`
+	// Key is the key of the ConfigMap or Secret data holding the output
+	Key string `json:"key"`
+}
+
+func (o *OutputFrom) validate(field string) error {
+	if o.NameFromPath == "" {
+		return fmt.Errorf("%s.nameFromPath must be set", field)
+	}
+	if o.Key == "" {
+		return fmt.Errorf("%s.key must be set", field)
+	}
+	if o.Kind != OutputFromConfigMap && o.Kind != OutputFromSecret {
+		return fmt.Errorf("%s.kind must be one of [%s, %s]", field, OutputFromConfigMap, OutputFromSecret)
+	}
+	return nil
+}
+
+// validateOutputPathOrFrom checks that an output is read either at its path or from the
+// object named by outputFrom
+func validateOutputPathOrFrom(pathField string, path string, outputFrom *OutputFrom) error {
+	if (path == "") == (outputFrom == nil) {
+		return fmt.Errorf("exactly one of %s and outputFrom must be set", pathField)
+	}
+	if outputFrom != nil {
+		return outputFrom.validate("outputFrom")
+	}
+	return nil
+}
+
 type ResourceReference struct {
 	Name     string `json:"name"`
 	Resource string `json:"resource"`
@@ -82,6 +217,9 @@ type ObjectReference struct {
 	Namespace  string `json:"namespace,omitempty"`
 	Name       string `json:"name,omitempty"`
 	APIVersion string `json:"apiVersion,omitempty"`
+	// UID pins the reference to one instance of the object, so that an
+	// object recreated under the same name is not mistaken for it.
+	UID types.UID `json:"uid,omitempty"`
 }
 
 type ServiceAccountRef struct {
@@ -34,12 +34,26 @@ const (
 const (
 	CompleteResourcesSubmittedReason                       = "ResourceSubmissionComplete"
 	TemplateObjectRetrievalFailureResourcesSubmittedReason = "TemplateObjectRetrievalFailure"
+	TemplateNameNotFoundResourcesSubmittedReason           = "TemplateNameNotFound"
 	MissingValueAtPathResourcesSubmittedReason             = "MissingValueAtPath"
+	WaitingOnUpstreamResourcesSubmittedReason              = "WaitingOnUpstream"
 	TemplateStampFailureResourcesSubmittedReason           = "TemplateStampFailure"
 	TemplateRejectedByAPIServerResourcesSubmittedReason    = "TemplateRejectedByAPIServer"
 	UnknownErrorResourcesSubmittedReason                   = "UnknownError"
 	DeploymentConditionNotMetResourcesSubmittedReason      = "ConditionNotMet"
 	DeploymentFailedConditionMetResourcesSubmittedReason   = "FailedConditionMet"
+	StampedObjectSchemaInvalidResourcesSubmittedReason     = "StampedObjectSchemaInvalid"
+	StampedKindNotAllowedResourcesSubmittedReason          = "StampedKindNotAllowed"
+	OutputSubresourceErrorResourcesSubmittedReason         = "OutputSubresourceError"
+	OutputEmptyResourcesSubmittedReason                    = "OutputEmpty"
+	AmbiguousOutputPathResourcesSubmittedReason            = "AmbiguousOutputPath"
+	WaitingForControllerResourcesSubmittedReason           = "WaitingForController"
+	MissingOwnerReferenceResourcesSubmittedReason          = "MissingOwnerReference"
+	WorkloadNotFoundResourcesSubmittedReason               = "WorkloadNotFound"
+	InsufficientPermissionsResourcesSubmittedReason        = "InsufficientPermissions"
+	GatesNotSatisfiedResourcesSubmittedReason              = "GatesNotSatisfied"
+	StampedObjectConflictResourcesSubmittedReason          = "StampedObjectConflict"
+	ImageNotPinnedResourcesSubmittedReason                 = "ImageNotPinned"
 )
 
 // +kubebuilder:object:root=true
@@ -53,15 +67,31 @@ type Deliverable struct {
 }
 
 type DeliverableSpec struct {
-	Params             []Param `json:"params,omitempty"`
-	Source             *Source `json:"source,omitempty"`
-	ServiceAccountName string  `json:"serviceAccountName,omitempty"`
+	Params             []Param            `json:"params,omitempty"`
+	Source             *DeliverableSource `json:"source,omitempty"`
+	ServiceAccountName string             `json:"serviceAccountName,omitempty"`
+}
+
+type DeliverableSource struct {
+	Source `json:",inline"`
+	// WorkloadRef links the deliverable to a workload in its namespace. The
+	// workload, status included, is available to the delivery's templates
+	// as $(workload.*)$, and the deliverable is reconciled whenever the
+	// workload changes.
+	WorkloadRef *WorkloadReference `json:"workloadRef,omitempty"`
+}
+
+type WorkloadReference struct {
+	Name string `json:"name"`
 }
 
 type DeliverableStatus struct {
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	DeliveryRef        ObjectReference    `json:"deliveryRef,omitempty"`
+	// ForbiddenRetries counts the consecutive reconciles in which a stamped
+	// object was rejected as Forbidden and the deliverable was requeued.
+	ForbiddenRetries int64 `json:"forbiddenRetries,omitempty"`
 }
 
 // +kubebuilder:object:root=true
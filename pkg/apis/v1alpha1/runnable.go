@@ -21,24 +21,59 @@ package v1alpha1
 import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
 	RunnableReady    = "Ready"
 	RunTemplateReady = "RunTemplateReady"
+	// RunnableStampedObjectMissing has a negative polarity, it is only reported,
+	// as True, when the object last stamped for the runnable no longer exists and
+	// could not be stamped again.
+	RunnableStampedObjectMissing = "StampedObjectMissing"
+	// RunnableOutputTruncated has a negative polarity, it is only reported, as True,
+	// when outputs were truncated to fit the size limits of the controller.
+	RunnableOutputTruncated = "OutputTruncated"
+	// RunnableRequeueHintInvalid has a negative polarity, it is only reported, as True,
+	// when the value at the requeueAfterPath of the run template cannot be read as a hint.
+	RunnableRequeueHintInvalid = "RequeueHintInvalid"
+	// RunnableReconcileFailedPermanently has a negative polarity, it is only reported, as
+	// True, when the runnable failed with the same class of error as many times as the
+	// controller allows and is no longer requeued. Its reason is the class of the error.
+	RunnableReconcileFailedPermanently = "ReconcileFailedPermanently"
 )
 
 const (
 	ReadyRunTemplateReason                            = "Ready"
 	NotFoundRunTemplateReason                         = "RunTemplateNotFound"
 	StampedObjectRejectedByAPIServerRunTemplateReason = "StampedObjectRejectedByAPIServer"
+	StampedObjectConflictRunTemplateReason            = "StampedObjectConflict"
 	OutputPathNotSatisfiedRunTemplateReason           = "OutputPathNotSatisfied"
 	TemplateStampFailureRunTemplateReason             = "TemplateStampFailure"
 	FailedToListCreatedObjectsReason                  = "FailedToListCreatedObjects"
 	UnknownErrorReason                                = "UnknownError"
 	ClientBuilderErrorResourcesSubmittedReason        = "ClientBuilderError"
+	InputsImmutableRunTemplateReason                  = "InputsImmutable"
+	FailedToCancelPreviousRunRunTemplateReason        = "FailedToCancelPreviousRun"
+	StampedKindNotAllowedRunTemplateReason            = "StampedKindNotAllowed"
+	MissingRequiredInputRunTemplateReason             = "MissingRequiredInput"
+	InputInvalidRunTemplateReason                     = "InputInvalid"
+	OutputTransformErrorRunTemplateReason             = "OutputTransformError"
+	AwaitingOutputsRunTemplateReason                  = "AwaitingOutputs"
+	StampedObjectDeletedStampedObjectMissingReason    = "StampedObjectDeleted"
+	SizeLimitExceededOutputTruncatedReason            = "OutputSizeLimitExceeded"
+	MalformedRequeueHintInvalidReason                 = "MalformedRequeueHint"
 )
 
+// RunnableDebugOutputsAnnotation, set to "true", makes the reconciler report in the
+// runnable's status.debug what each output path of its run template evaluates to.
+const RunnableDebugOutputsAnnotation = "carto.run/debug-outputs"
+
+// RunnableRefreshOutputsAnnotation, set to a nonce, makes the reconciler read the stamped
+// object from the api server and extract its outputs again, without stamping. Each nonce
+// is honored once, the last honored nonce is reported in status.outputsRefreshedNonce.
+const RunnableRefreshOutputsAnnotation = "carto.run/refresh-outputs"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -53,6 +88,57 @@ type RunnableStatus struct {
 	ObservedGeneration int64                           `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition              `json:"conditions,omitempty"`
 	Outputs            map[string]apiextensionsv1.JSON `json:"outputs,omitempty"`
+	InputsHash         string                          `json:"inputsHash,omitempty"`
+	// ForbiddenRetries counts the consecutive reconciles in which the stamped
+	// object was rejected as Forbidden and the runnable was requeued.
+	ForbiddenRetries int64 `json:"forbiddenRetries,omitempty"`
+	// StampedRef refers to the object most recently stamped for the runnable.
+	StampedRef *ObjectReference `json:"stampedRef,omitempty"`
+	// StampedAt is when the object referred to by StampedRef was first seen stamped,
+	// the start of the runnable's output grace period.
+	StampedAt *metav1.Time `json:"stampedAt,omitempty"`
+	// Debug is only reported while the runnable has the carto.run/debug-outputs annotation.
+	Debug *RunnableDebug `json:"debug,omitempty"`
+	// OutputsRefreshedNonce is the last value of the carto.run/refresh-outputs
+	// annotation for which the outputs were extracted from a live read of the stamped object.
+	OutputsRefreshedNonce string `json:"outputsRefreshedNonce,omitempty"`
+	// OutputsTemplateGeneration is the generation of the run template whose declared outputs
+	// Outputs were produced by.
+	OutputsTemplateGeneration int64 `json:"outputsTemplateGeneration,omitempty"`
+	// FailedAttempts counts, by class of error, the consecutive reconciles of the current
+	// generation that failed with an error the controller retries.
+	FailedAttempts map[string]int64 `json:"failedAttempts,omitempty"`
+	// OutputSources are keyed by output name and only reported when the run template
+	// sets recordOutputSources.
+	OutputSources map[string]OutputSource `json:"outputSources,omitempty"`
+	// OutputsStaleSince is when the run template stopped being found, while Outputs are
+	// the last known outputs kept because of spec.keepOutputsOnTemplateLoss.
+	OutputsStaleSince *metav1.Time `json:"outputsStaleSince,omitempty"`
+}
+
+// OutputSource is the stamped object an output was read from.
+type OutputSource struct {
+	ObjectRef ObjectReference `json:"objectRef"`
+	// ResourceVersion is the version of the object its current value was first read from.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// ExtractedAt is when the output's current value was first read from the object.
+	ExtractedAt metav1.Time `json:"extractedAt"`
+}
+
+type RunnableDebug struct {
+	// Outputs are the output paths of the run template evaluated against the object most
+	// recently stamped for the runnable, ordered by output name.
+	Outputs []OutputPathEvaluation `json:"outputs,omitempty"`
+}
+
+type OutputPathEvaluation struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Value is the JSON the path evaluated to, truncated to 256 characters. The value of a
+	// sensitive output is redacted.
+	Value string `json:"value,omitempty"`
+	// Error says why the path could not be evaluated.
+	Error string `json:"error,omitempty"`
 }
 
 type RunnableSpec struct {
@@ -61,6 +147,19 @@ type RunnableSpec struct {
 	Selector           *ResourceSelector               `json:"selector,omitempty"`
 	Inputs             map[string]apiextensionsv1.JSON `json:"inputs,omitempty"`
 	ServiceAccountName string                          `json:"serviceAccountName,omitempty"`
+	// KeepOutputsOnTemplateLoss keeps the last known outputs, marked stale with
+	// status.outputsStaleSince, while the run template cannot be found. By default
+	// the outputs are cleared.
+	KeepOutputsOnTemplateLoss bool `json:"keepOutputsOnTemplateLoss,omitempty"`
+	ImmutableInputs           bool `json:"immutableInputs,omitempty"`
+	// CancelPreviousRuns deletes stamped objects from earlier runs that are known
+	// to still be running once a run for newer inputs has been stamped, so that
+	// their outputs cannot land after the newer run's.
+	CancelPreviousRuns bool `json:"cancelPreviousRuns,omitempty"`
+	// OutputGracePeriod is how long after an object is stamped that outputs missing
+	// from it are reported as AwaitingOutputs, with the RunTemplateReady condition
+	// Unknown, rather than as OutputPathNotSatisfied.
+	OutputGracePeriod *metav1.Duration `json:"outputGracePeriod,omitempty"`
 }
 
 type ResourceSelector struct {
@@ -76,7 +175,26 @@ type ResourceType struct {
 type TemplateReference struct {
 	Kind string `json:"kind,omitempty"`
 	// +kubebuilder:validation:MinLength=1
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
+	// NameExpression is interpolated against the runnable, e.g. "$(runnable.spec.inputs.flavor)$-builder",
+	// and takes precedence over Name when set
+	// +kubebuilder:validation:MinLength=1
+	NameExpression string `json:"nameExpression,omitempty"`
+}
+
+func (r *Runnable) Default() {
+	if r.Spec.RunTemplateRef.Kind == "" {
+		r.Spec.RunTemplateRef.Kind = "ClusterRunTemplate"
+	}
+}
+
+// StampedUID is the UID of the object most recently stamped for the runnable, empty until
+// an object has been stamped.
+func (r *Runnable) StampedUID() types.UID {
+	if r.Status.StampedRef == nil {
+		return ""
+	}
+	return r.Status.StampedRef.UID
 }
 
 // +kubebuilder:object:root=true
@@ -53,12 +53,20 @@ var _ = Describe("Runnable", func() {
 			templateReferenceType = reflect.TypeOf(templateReference)
 		})
 
-		It("requires a name", func() {
+		It("has an optional name, as a name expression may be used instead", func() {
 			nameField, found := templateReferenceType.FieldByName("Name")
 			Expect(found).To(BeTrue())
 			jsonValue := nameField.Tag.Get("json")
 			Expect(jsonValue).To(ContainSubstring("name"))
-			Expect(jsonValue).NotTo(ContainSubstring("omitempty"))
+			Expect(jsonValue).To(ContainSubstring("omitempty"))
+		})
+
+		It("has an optional name expression", func() {
+			nameExpressionField, found := templateReferenceType.FieldByName("NameExpression")
+			Expect(found).To(BeTrue())
+			jsonValue := nameExpressionField.Tag.Get("json")
+			Expect(jsonValue).To(ContainSubstring("nameExpression"))
+			Expect(jsonValue).To(ContainSubstring("omitempty"))
 		})
 
 		It("requires a kind", func() {
@@ -69,4 +77,33 @@ var _ = Describe("Runnable", func() {
 			Expect(jsonValue).To(ContainSubstring("omitempty"))
 		})
 	})
+
+	Describe("Default", func() {
+		var runnable *v1alpha1.Runnable
+
+		BeforeEach(func() {
+			runnable = &v1alpha1.Runnable{
+				Spec: v1alpha1.RunnableSpec{
+					RunTemplateRef: v1alpha1.TemplateReference{
+						Name: "my-run-template",
+					},
+				},
+			}
+		})
+
+		Context("runTemplateRef has no kind", func() {
+			It("sets the kind to ClusterRunTemplate", func() {
+				runnable.Default()
+				Expect(runnable.Spec.RunTemplateRef.Kind).To(Equal("ClusterRunTemplate"))
+			})
+		})
+
+		Context("runTemplateRef has a kind", func() {
+			It("leaves the kind untouched", func() {
+				runnable.Spec.RunTemplateRef.Kind = "SomethingElse"
+				runnable.Default()
+				Expect(runnable.Spec.RunTemplateRef.Kind).To(Equal("SomethingElse"))
+			})
+		})
+	})
 })
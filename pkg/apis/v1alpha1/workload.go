@@ -27,6 +27,8 @@ const (
 	WorkloadReady             = "Ready"
 	WorkloadSupplyChainReady  = "SupplyChainReady"
 	WorkloadResourceSubmitted = "ResourcesSubmitted"
+	WorkloadOutputOverridden  = "OutputOverridden"
+	WorkloadOutputPathWarning = "OutputPathWarning"
 )
 
 const (
@@ -34,10 +36,30 @@ const (
 	WorkloadLabelsMissingSupplyChainReason               = "WorkloadLabelsMissing"
 	NotFoundSupplyChainReadyReason                       = "SupplyChainNotFound"
 	MultipleMatchesSupplyChainReadyReason                = "MultipleSupplyChainMatches"
+	PinnedSupplyChainInvalidSupplyChainReadyReason       = "PinnedSupplyChainInvalid"
+	TerminatingSupplyChainReadyReason                    = "SupplyChainTerminating"
+	PausedSupplyChainReadyReason                         = "SupplyChainPaused"
 	ServiceAccountSecretErrorResourcesSubmittedReason    = "ServiceAccountSecretError"
 	ResourceRealizerBuilderErrorResourcesSubmittedReason = "ResourceRealizerBuilderError"
+	AnnotationOutputOverriddenReason                     = "OverrideOutputAnnotation"
+	OutputPathNotInStatusOutputPathWarningReason         = "OutputPathNotInStatus"
 )
 
+// WorkloadSupplyChainAnnotation pins a Workload to the named ClusterSupplyChain, which
+// is selected even when other supply chains match the Workload equally well. The Workload
+// must still satisfy the pinned supply chain's selector.
+const WorkloadSupplyChainAnnotation = "carto.run/supply-chain"
+
+// WorkloadDebugParamsAnnotation, set to "true", makes the reconciler report in the workload's
+// status.debug which layer won each param that several layers set to different values.
+const WorkloadDebugParamsAnnotation = "carto.run/debug-params"
+
+// WorkloadOverrideOutputAnnotationPrefix prefixes annotations of the form
+// carto.run/override-output.<resource>.<output> whose value replaces the named output of the
+// resource. It is a debugging aid and is only honored when cartographer runs with
+// --allow-output-overrides.
+const WorkloadOverrideOutputAnnotationPrefix = "carto.run/override-output."
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -80,8 +102,66 @@ type WorkloadStatus struct {
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	SupplyChainRef     ObjectReference    `json:"supplyChainRef,omitempty"`
+	// Summary is a compact view of the state of every resource in the
+	// supply chain, suitable for dashboards that do not want to read each
+	// stamped object.
+	Summary *WorkloadSummary `json:"summary,omitempty"`
+	// RealizationOrder lists the supply chain resources in the order they
+	// are realized, each after the resources whose outputs it consumes.
+	RealizationOrder []string `json:"realizationOrder,omitempty"`
+	// ForbiddenRetries counts the consecutive reconciles in which a stamped
+	// object was rejected as Forbidden and the workload was requeued.
+	ForbiddenRetries int64 `json:"forbiddenRetries,omitempty"`
+	// Debug is only reported while the workload has the carto.run/debug-params annotation.
+	Debug *WorkloadDebug `json:"debug,omitempty"`
+}
+
+type WorkloadDebug struct {
+	// Params lists, for each stamped resource in realization order, the params that more than
+	// one layer set to different values and the layer whose value was used.
+	Params []ParamResolution `json:"params,omitempty"`
+}
+
+type WorkloadSummary struct {
+	// Ready mirrors the status of the workload's Ready condition.
+	Ready metav1.ConditionStatus `json:"ready"`
+	// Resources lists the supply chain resources in the order they appear
+	// in the supply chain.
+	Resources []ResourceSummary `json:"resources,omitempty"`
 }
 
+type ResourceSummary struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Stamping;Healthy;OutputAvailable;Failed;Skipped
+	Phase ResourcePhase `json:"phase"`
+	// Condition describes why the resource failed when the supply chain is
+	// realized with the collectAll strategy.
+	Condition *metav1.Condition `json:"condition,omitempty"`
+	// OutputHash is a hash of the output last read from the resource. The
+	// resources consuming the output are stamped again when it changes.
+	OutputHash string `json:"outputHash,omitempty"`
+}
+
+type ResourcePhase string
+
+const (
+	// ResourcePhaseStamping means the resource has not been stamped yet,
+	// usually because it is waiting on an upstream resource.
+	ResourcePhaseStamping ResourcePhase = "Stamping"
+	// ResourcePhaseHealthy means the stamped object was applied but its
+	// output is not available yet.
+	ResourcePhaseHealthy ResourcePhase = "Healthy"
+	// ResourcePhaseOutputAvailable means the stamped object was applied and
+	// its output was read.
+	ResourcePhaseOutputAvailable ResourcePhase = "OutputAvailable"
+	// ResourcePhaseFailed means the resource could not be stamped or applied.
+	ResourcePhaseFailed ResourcePhase = "Failed"
+	// ResourcePhaseSkipped means the resource's condition is not met by the
+	// workload, the resource is optional and its template does not exist, or
+	// it consumes the output of a skipped resource.
+	ResourcePhaseSkipped ResourcePhase = "Skipped"
+)
+
 // +kubebuilder:object:root=true
 
 type WorkloadList struct {
@@ -414,6 +414,42 @@ func (in *ClusterRunTemplateSpec) DeepCopyInto(out *ClusterRunTemplateSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.OutputTransforms != nil {
+		in, out := &in.OutputTransforms, &out.OutputTransforms
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReferencedOutputs != nil {
+		in, out := &in.ReferencedOutputs, &out.ReferencedOutputs
+		*out = make(map[string]ReferencedOutput, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]RunTemplateInput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ObjectMeta != nil {
+		in, out := &in.ObjectMeta, &out.ObjectMeta
+		*out = new(RunTemplateObjectMeta)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SensitiveOutputs != nil {
+		in, out := &in.SensitiveOutputs, &out.SensitiveOutputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRunTemplateSpec.
@@ -637,6 +673,21 @@ func (in *Condition) DeepCopy() *Condition {
 func (in *ConfigTemplateSpec) DeepCopyInto(out *ConfigTemplateSpec) {
 	*out = *in
 	in.TemplateSpec.DeepCopyInto(&out.TemplateSpec)
+	if in.OutputFrom != nil {
+		in, out := &in.OutputFrom, &out.OutputFrom
+		*out = new(OutputFrom)
+		**out = **in
+	}
+	if in.OutputRequired != nil {
+		in, out := &in.OutputRequired, &out.OutputRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ReadinessGate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigTemplateSpec.
@@ -748,6 +799,27 @@ func (in *DeliverableList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeliverableSource) DeepCopyInto(out *DeliverableSource) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.WorkloadRef != nil {
+		in, out := &in.WorkloadRef, &out.WorkloadRef
+		*out = new(WorkloadReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeliverableSource.
+func (in *DeliverableSource) DeepCopy() *DeliverableSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DeliverableSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeliverableSpec) DeepCopyInto(out *DeliverableSpec) {
 	*out = *in
@@ -760,7 +832,7 @@ func (in *DeliverableSpec) DeepCopyInto(out *DeliverableSpec) {
 	}
 	if in.Source != nil {
 		in, out := &in.Source, &out.Source
-		*out = new(Source)
+		*out = new(DeliverableSource)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -913,6 +985,26 @@ func (in *GitSource) DeepCopy() *GitSource {
 func (in *ImageTemplateSpec) DeepCopyInto(out *ImageTemplateSpec) {
 	*out = *in
 	in.TemplateSpec.DeepCopyInto(&out.TemplateSpec)
+	if in.OutputFrom != nil {
+		in, out := &in.OutputFrom, &out.OutputFrom
+		*out = new(OutputFrom)
+		**out = **in
+	}
+	if in.ImageSort != nil {
+		in, out := &in.ImageSort, &out.ImageSort
+		*out = new(OutputSort)
+		**out = **in
+	}
+	if in.OutputRequired != nil {
+		in, out := &in.OutputRequired, &out.OutputRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ReadinessGate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTemplateSpec.
@@ -1006,6 +1098,83 @@ func (in *ObservedMatch) DeepCopy() *ObservedMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputFrom) DeepCopyInto(out *OutputFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputFrom.
+func (in *OutputFrom) DeepCopy() *OutputFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputPathEvaluation) DeepCopyInto(out *OutputPathEvaluation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputPathEvaluation.
+func (in *OutputPathEvaluation) DeepCopy() *OutputPathEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputPathEvaluation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputSort) DeepCopyInto(out *OutputSort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSort.
+func (in *OutputSort) DeepCopy() *OutputSort {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputSort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputSource) DeepCopyInto(out *OutputSource) {
+	*out = *in
+	out.ObjectRef = in.ObjectRef
+	in.ExtractedAt.DeepCopyInto(&out.ExtractedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSource.
+func (in *OutputSource) DeepCopy() *OutputSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerSelector) DeepCopyInto(out *OwnerSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerSelector.
+func (in *OwnerSelector) DeepCopy() *OwnerSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Param) DeepCopyInto(out *Param) {
 	*out = *in
@@ -1022,6 +1191,57 @@ func (in *Param) DeepCopy() *Param {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamResolution) DeepCopyInto(out *ParamResolution) {
+	*out = *in
+	if in.Overridden != nil {
+		in, out := &in.Overridden, &out.Overridden
+		*out = make([]ParamLayer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamResolution.
+func (in *ParamResolution) DeepCopy() *ParamResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGate) DeepCopyInto(out *ReadinessGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGate.
+func (in *ReadinessGate) DeepCopy() *ReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferencedOutput) DeepCopyInto(out *ReferencedOutput) {
+	*out = *in
+	out.Resource = in.Resource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferencedOutput.
+func (in *ReferencedOutput) DeepCopy() *ReferencedOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferencedOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -1060,6 +1280,26 @@ func (in *ResourceSelector) DeepCopy() *ResourceSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSummary) DeepCopyInto(out *ResourceSummary) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(v1.Condition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSummary.
+func (in *ResourceSummary) DeepCopy() *ResourceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceType) DeepCopyInto(out *ResourceType) {
 	*out = *in
@@ -1075,6 +1315,60 @@ func (in *ResourceType) DeepCopy() *ResourceType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunTemplateInput) DeepCopyInto(out *RunTemplateInput) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunTemplateInput.
+func (in *RunTemplateInput) DeepCopy() *RunTemplateInput {
+	if in == nil {
+		return nil
+	}
+	out := new(RunTemplateInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunTemplateObjectMeta) DeepCopyInto(out *RunTemplateObjectMeta) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunTemplateObjectMeta.
+func (in *RunTemplateObjectMeta) DeepCopy() *RunTemplateObjectMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(RunTemplateObjectMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Runnable) DeepCopyInto(out *Runnable) {
 	*out = *in
@@ -1102,6 +1396,26 @@ func (in *Runnable) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnableDebug) DeepCopyInto(out *RunnableDebug) {
+	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputPathEvaluation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnableDebug.
+func (in *RunnableDebug) DeepCopy() *RunnableDebug {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnableDebug)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnableList) DeepCopyInto(out *RunnableList) {
 	*out = *in
@@ -1150,6 +1464,11 @@ func (in *RunnableSpec) DeepCopyInto(out *RunnableSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.OutputGracePeriod != nil {
+		in, out := &in.OutputGracePeriod, &out.OutputGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnableSpec.
@@ -1179,6 +1498,38 @@ func (in *RunnableStatus) DeepCopyInto(out *RunnableStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.StampedRef != nil {
+		in, out := &in.StampedRef, &out.StampedRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.StampedAt != nil {
+		in, out := &in.StampedAt, &out.StampedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(RunnableDebug)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedAttempts != nil {
+		in, out := &in.FailedAttempts, &out.FailedAttempts
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OutputSources != nil {
+		in, out := &in.OutputSources, &out.OutputSources
+		*out = make(map[string]OutputSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.OutputsStaleSince != nil {
+		in, out := &in.OutputsStaleSince, &out.OutputsStaleSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnableStatus.
@@ -1225,6 +1576,16 @@ func (in *Source) DeepCopy() *Source {
 func (in *SourceTemplateSpec) DeepCopyInto(out *SourceTemplateSpec) {
 	*out = *in
 	in.TemplateSpec.DeepCopyInto(&out.TemplateSpec)
+	if in.OutputRequired != nil {
+		in, out := &in.OutputRequired, &out.OutputRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ReadinessGate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceTemplateSpec.
@@ -1278,6 +1639,11 @@ func (in *SupplyChainResource) DeepCopyInto(out *SupplyChainResource) {
 		*out = make([]ResourceReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(Condition)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupplyChainResource.
@@ -1307,6 +1673,11 @@ func (in *SupplyChainSpec) DeepCopyInto(out *SupplyChainSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.OwnerSelector != nil {
+		in, out := &in.OwnerSelector, &out.OwnerSelector
+		*out = new(OwnerSelector)
+		**out = **in
+	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]DelegatableParam, len(*in))
@@ -1492,6 +1863,28 @@ func (in *WorkloadBuild) DeepCopy() *WorkloadBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadDebug) DeepCopyInto(out *WorkloadDebug) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]ParamResolution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadDebug.
+func (in *WorkloadDebug) DeepCopy() *WorkloadDebug {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadDebug)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadList) DeepCopyInto(out *WorkloadList) {
 	*out = *in
@@ -1524,6 +1917,21 @@ func (in *WorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadServiceClaim) DeepCopyInto(out *WorkloadServiceClaim) {
 	*out = *in
@@ -1622,6 +2030,21 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 		}
 	}
 	out.SupplyChainRef = in.SupplyChainRef
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(WorkloadSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealizationOrder != nil {
+		in, out := &in.RealizationOrder, &out.RealizationOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(WorkloadDebug)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
@@ -1633,3 +2056,25 @@ func (in *WorkloadStatus) DeepCopy() *WorkloadStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSummary) DeepCopyInto(out *WorkloadSummary) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSummary.
+func (in *WorkloadSummary) DeepCopy() *WorkloadSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSummary)
+	in.DeepCopyInto(out)
+	return out
+}
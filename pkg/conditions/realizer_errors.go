@@ -0,0 +1,285 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditions
+
+import (
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	deliverablerealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
+	runnablerealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
+	workloadrealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+// FromRealizeError maps an error returned by the workload, deliverable or runnable realizer
+// to the condition its reconciler reports. handled is false when the reconciler should treat
+// the error as unhandled and requeue.
+// Errors that did not come from a realizer yield an empty condition and handled false; the
+// caller reports its own unknown error condition for those.
+func FromRealizeError(err error) (condition metav1.Condition, handled bool) {
+	switch typedErr := err.(type) {
+
+	// -- Runnable realizer errors
+	case runnablerealizer.GetRunTemplateError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.NotFoundRunTemplateReason, typedErr), false
+	case runnablerealizer.ResolveSelectorError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.TemplateStampFailureRunTemplateReason, typedErr), true
+	case runnablerealizer.MissingRequiredInputError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.MissingRequiredInputRunTemplateReason, typedErr), true
+	case runnablerealizer.InvalidInputError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.InputInvalidRunTemplateReason, typedErr), true
+	case runnablerealizer.StampError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.TemplateStampFailureRunTemplateReason, typedErr), true
+	case runnablerealizer.ApplyStampedObjectError:
+		if repository.IsStampedObjectConflict(typedErr.Err) {
+			return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.StampedObjectConflictRunTemplateReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.StampedObjectRejectedByAPIServerRunTemplateReason, typedErr),
+			kerrors.IsForbidden(typedErr.Err)
+	case runnablerealizer.StampedKindNotAllowedError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.StampedKindNotAllowedRunTemplateReason, typedErr), true
+	case runnablerealizer.ListCreatedObjectsError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.FailedToListCreatedObjectsReason, typedErr), false
+	case runnablerealizer.CancelPreviousRunError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.FailedToCancelPreviousRunRunTemplateReason, typedErr), false
+	case runnablerealizer.OutputTransformError:
+		return falseCondition(v1alpha1.RunTemplateReady, v1alpha1.OutputTransformErrorRunTemplateReason, typedErr), true
+	case runnablerealizer.RetrieveOutputError:
+		return OutputPathNotSatisfiedCondition(typedErr.StampedObject, typedErr.Error()), true
+
+	// -- Workload realizer errors
+	case workloadrealizer.GetClusterTemplateError:
+		if repository.IsTemplateNotFound(typedErr.Err) {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.TemplateNameNotFoundResourcesSubmittedReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason, typedErr), false
+	case workloadrealizer.StampError:
+		if _, ok := typedErr.Err.(templates.MissingOwnerReferenceError); ok {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.MissingOwnerReferenceResourcesSubmittedReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.TemplateStampFailureResourcesSubmittedReason, typedErr), true
+	case workloadrealizer.ApplyStampedObjectError:
+		if repository.IsStampedObjectConflict(typedErr.Err) {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.StampedObjectConflictResourcesSubmittedReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason, typedErr),
+			kerrors.IsForbidden(typedErr.Err)
+	case workloadrealizer.StampedObjectSchemaInvalidError:
+		return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.StampedObjectSchemaInvalidResourcesSubmittedReason, typedErr), true
+	case workloadrealizer.StampedKindNotAllowedError:
+		return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.StampedKindNotAllowedResourcesSubmittedReason, typedErr), true
+	case workloadrealizer.InsufficientPermissionsError:
+		return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.InsufficientPermissionsResourcesSubmittedReason, typedErr), true
+	case workloadrealizer.UpstreamOutputNotAvailableError:
+		return metav1.Condition{
+			Type:    v1alpha1.WorkloadResourceSubmitted,
+			Status:  metav1.ConditionUnknown,
+			Reason:  v1alpha1.WaitingOnUpstreamResourcesSubmittedReason,
+			Message: fmt.Sprintf("Resource [%s] waiting on output from upstream resource [%s]", typedErr.Resource.Name, typedErr.UpstreamResource),
+		}, true
+	case workloadrealizer.StampedObjectNotObservedError:
+		// the stamped object is watched, so its controller observing the generation reconciles the workload again
+		return metav1.Condition{
+			Type:    v1alpha1.WorkloadResourceSubmitted,
+			Status:  metav1.ConditionUnknown,
+			Reason:  v1alpha1.WaitingForControllerResourcesSubmittedReason,
+			Message: typedErr.Error(),
+		}, true
+	case workloadrealizer.RetrieveOutputError:
+		if subresourceErr, ok := typedErr.Err.(templates.SubresourceError); ok {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.OutputSubresourceErrorResourcesSubmittedReason, typedErr),
+				isSubresourceNotAvailable(subresourceErr)
+		}
+		if _, ok := typedErr.Err.(templates.OutputEmptyError); ok {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.OutputEmptyResourcesSubmittedReason, typedErr), true
+		}
+		if _, ok := typedErr.Err.(eval.AmbiguousJsonPathError); ok {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.AmbiguousOutputPathResourcesSubmittedReason, typedErr), true
+		}
+		if gatesErr, ok := typedErr.Err.(templates.ReadinessGatesNotSatisfiedError); ok {
+			return GatesNotSatisfiedCondition(v1alpha1.WorkloadResourceSubmitted, typedErr.Resource.Name, gatesErr), true
+		}
+		if _, ok := typedErr.Err.(templates.ImageNotPinnedError); ok {
+			return falseCondition(v1alpha1.WorkloadResourceSubmitted, v1alpha1.ImageNotPinnedResourcesSubmittedReason, typedErr), true
+		}
+		return MissingValueAtPathCondition(v1alpha1.WorkloadResourceSubmitted, typedErr.StampedObject, typedErr.JsonPathExpression()), true
+	case workloadrealizer.ResourceErrors:
+		return fromResourceErrors(typedErr)
+
+	// -- Deliverable realizer errors
+	case deliverablerealizer.GetDeliveryClusterTemplateError:
+		if repository.IsTemplateNotFound(typedErr.Err) {
+			return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.TemplateNameNotFoundResourcesSubmittedReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason, typedErr), false
+	case deliverablerealizer.StampError:
+		if _, ok := typedErr.Err.(templates.MissingOwnerReferenceError); ok {
+			return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.MissingOwnerReferenceResourcesSubmittedReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.TemplateStampFailureResourcesSubmittedReason, typedErr), true
+	case deliverablerealizer.ApplyStampedObjectError:
+		if repository.IsStampedObjectConflict(typedErr.Err) {
+			return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.StampedObjectConflictResourcesSubmittedReason, typedErr), true
+		}
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason, typedErr),
+			kerrors.IsForbidden(typedErr.Err)
+	case deliverablerealizer.StampedKindNotAllowedError:
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.StampedKindNotAllowedResourcesSubmittedReason, typedErr), true
+	case deliverablerealizer.RetrieveOutputError:
+		if subresourceErr, ok := typedErr.Err.(templates.SubresourceError); ok {
+			return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.OutputSubresourceErrorResourcesSubmittedReason, typedErr),
+				isSubresourceNotAvailable(subresourceErr)
+		}
+		return deliverableRetrieveOutputCondition(typedErr), true
+	}
+
+	return metav1.Condition{}, false
+}
+
+// fromResourceErrors reports the condition of the first failed resource, with a message
+// naming every failure. When every resource that errored is only waiting on its stamped
+// object to produce an output, the first resource waiting on one of them is reported
+// instead. The errors are handled only if each of them is.
+func fromResourceErrors(err workloadrealizer.ResourceErrors) (metav1.Condition, bool) {
+	var condition metav1.Condition
+	handled := true
+	pending := true
+	for i, resourceError := range err.Errors {
+		resourceCondition, resourceHandled := FromRealizeError(resourceError.Err)
+		if i == 0 {
+			condition = resourceCondition
+		}
+		handled = handled && resourceHandled
+		pending = pending && workloadrealizer.OutputPending(resourceError.Err)
+	}
+
+	if pending && len(err.Waiting) > 0 {
+		waitingCondition, _ := FromRealizeError(err.Waiting[0])
+		return waitingCondition, handled
+	}
+
+	if condition.Type == "" {
+		return condition, false
+	}
+	if len(err.Errors) > 1 {
+		condition.Message = err.Error()
+	}
+	return condition, handled
+}
+
+func deliverableRetrieveOutputCondition(err deliverablerealizer.RetrieveOutputError) metav1.Condition {
+	switch err.Err.(type) {
+	case templates.ObservedGenerationError:
+		return metav1.Condition{
+			Type:    v1alpha1.DeliverableResourcesSubmitted,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
+			Message: fmt.Sprintf("Resource [%s] cannot satisfy observedCompletion without observedGeneration in object status", err.ResourceName()),
+		}
+	case templates.DeploymentFailedConditionMetError:
+		return metav1.Condition{
+			Type:    v1alpha1.DeliverableResourcesSubmitted,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.DeploymentFailedConditionMetResourcesSubmittedReason,
+			Message: fmt.Sprintf("Resource [%s] failed condition met: %s", err.ResourceName(), err.Err.Error()),
+		}
+	case templates.DeploymentConditionError:
+		return metav1.Condition{
+			Type:    v1alpha1.DeliverableResourcesSubmitted,
+			Status:  metav1.ConditionUnknown,
+			Reason:  v1alpha1.DeploymentConditionNotMetResourcesSubmittedReason,
+			Message: fmt.Sprintf("Resource [%s] condition not met: %s", err.ResourceName(), err.Err.Error()),
+		}
+	case templates.OutputEmptyError:
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.OutputEmptyResourcesSubmittedReason, err)
+	case templates.ReadinessGatesNotSatisfiedError:
+		return GatesNotSatisfiedCondition(v1alpha1.DeliverableResourcesSubmitted, err.ResourceName(), err.Err)
+	case eval.AmbiguousJsonPathError:
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.AmbiguousOutputPathResourcesSubmittedReason, err)
+	case templates.JsonPathError:
+		return MissingValueAtPathCondition(v1alpha1.DeliverableResourcesSubmitted, err.StampedObject, err.JsonPathExpression())
+	default:
+		return falseCondition(v1alpha1.DeliverableResourcesSubmitted, v1alpha1.UnknownErrorResourcesSubmittedReason, err)
+	}
+}
+
+// isSubresourceNotAvailable tells a template naming a subresource its kind does not have,
+// which requeueing cannot fix, from a failure to read the subresource.
+func isSubresourceNotAvailable(err templates.SubresourceError) bool {
+	_, ok := err.Err.(repository.SubresourceNotAvailableError)
+	return ok
+}
+
+func falseCondition(conditionType string, reason string, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: err.Error(),
+	}
+}
+
+func MissingValueAtPathCondition(conditionType string, obj *unstructured.Unstructured, expression string) metav1.Condition {
+	var namespaceMsg string
+	if obj.GetNamespace() != "" {
+		namespaceMsg = fmt.Sprintf(" in namespace [%s]", obj.GetNamespace())
+	}
+	return metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionUnknown,
+		Reason: v1alpha1.MissingValueAtPathResourcesSubmittedReason,
+		Message: fmt.Sprintf("Waiting to read value [%s] from resource [%s/%s]%s",
+			expression, utils.GetFullyQualifiedType(obj), obj.GetName(), namespaceMsg),
+	}
+}
+
+// GatesNotSatisfiedCondition is Unknown rather than False: the stamped object is watched, so
+// its conditions changing to pass the gates reconciles the owner again.
+func GatesNotSatisfiedCondition(conditionType string, resourceName string, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionUnknown,
+		Reason:  v1alpha1.GatesNotSatisfiedResourcesSubmittedReason,
+		Message: fmt.Sprintf("Resource [%s] waiting on %s", resourceName, err.Error()),
+	}
+}
+
+func OutputPathNotSatisfiedCondition(obj *unstructured.Unstructured, errMsg string) metav1.Condition {
+	var namespaceMsg string
+	if obj.GetNamespace() != "" {
+		namespaceMsg = fmt.Sprintf(" in namespace [%s]", obj.GetNamespace())
+	}
+
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+
+	return metav1.Condition{
+		Type:   v1alpha1.RunTemplateReady,
+		Status: metav1.ConditionFalse,
+		Reason: v1alpha1.OutputPathNotSatisfiedRunTemplateReason,
+		Message: fmt.Sprintf("Waiting to read value from resource [%s/%s]%s: %s",
+			utils.GetFullyQualifiedType(obj), name, namespaceMsg, errMsg),
+	}
+}
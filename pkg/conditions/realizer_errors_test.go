@@ -0,0 +1,757 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditions_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/conditions"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	deliverablerealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
+	runnablerealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
+	workloadrealizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+var _ = Describe("FromRealizeError", func() {
+	var (
+		stampedObject *unstructured.Unstructured
+		forbiddenErr  error
+	)
+
+	BeforeEach(func() {
+		stampedObject = &unstructured.Unstructured{}
+		stampedObject.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   "thing.io",
+			Version: "alphabeta1",
+			Kind:    "Widget",
+		})
+		stampedObject.SetName("my-widget")
+		stampedObject.SetNamespace("my-ns")
+
+		forbiddenErr = kerrors.NewForbidden(schema.GroupResource{Group: "thing.io", Resource: "widgets"}, "my-widget", errors.New("no"))
+	})
+
+	Describe("runnable realizer errors", func() {
+		var runnable *v1alpha1.Runnable
+
+		BeforeEach(func() {
+			runnable = &v1alpha1.Runnable{}
+			runnable.Name = "my-runnable"
+			runnable.Namespace = "my-ns"
+		})
+
+		It("reports a GetRunTemplateError as a missing run template and unhandled", func() {
+			err := runnablerealizer.GetRunTemplateError{Err: errors.New("not found"), Runnable: runnable}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.RunTemplateReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.NotFoundRunTemplateReason,
+				Message: err.Error(),
+			}))
+		})
+
+		It("reports a ResolveSelectorError as a stamp failure and handled", func() {
+			err := runnablerealizer.ResolveSelectorError{
+				Err:      errors.New("no match"),
+				Selector: &v1alpha1.ResourceSelector{MatchingLabels: map[string]string{"a": "b"}},
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.RunTemplateReady))
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateStampFailureRunTemplateReason))
+			Expect(condition.Message).To(Equal(err.Error()))
+		})
+
+		It("reports a StampError as a stamp failure and handled", func() {
+			err := runnablerealizer.StampError{Err: errors.New("bad template"), Runnable: runnable}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateStampFailureRunTemplateReason))
+		})
+
+		It("reports an ApplyStampedObjectError as rejected and unhandled", func() {
+			err := runnablerealizer.ApplyStampedObjectError{Err: errors.New("conflict"), StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Reason).To(Equal(v1alpha1.StampedObjectRejectedByAPIServerRunTemplateReason))
+		})
+
+		It("treats a forbidden ApplyStampedObjectError as handled", func() {
+			err := runnablerealizer.ApplyStampedObjectError{Err: forbiddenErr, StampedObject: stampedObject}
+
+			_, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+		})
+
+		It("reports an ApplyStampedObjectError for a foreign object as a handled conflict", func() {
+			err := runnablerealizer.ApplyStampedObjectError{Err: repository.StampedObjectConflictError{Object: stampedObject, Owner: "Workload [other]"}, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.StampedObjectConflictRunTemplateReason))
+		})
+
+		It("reports a ListCreatedObjectsError as unhandled", func() {
+			err := runnablerealizer.ListCreatedObjectsError{Err: errors.New("list failed"), Namespace: "my-ns"}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Reason).To(Equal(v1alpha1.FailedToListCreatedObjectsReason))
+		})
+
+		It("reports a StampedKindNotAllowedError as not allowed and handled", func() {
+			err := runnablerealizer.StampedKindNotAllowedError{Runnable: runnable, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.RunTemplateReady))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.StampedKindNotAllowedRunTemplateReason))
+		})
+
+		It("reports a MissingRequiredInputError as a missing input and handled", func() {
+			err := runnablerealizer.MissingRequiredInputError{
+				Input:       "some-input",
+				Runnable:    runnable,
+				RunTemplate: &v1alpha1.ClusterRunTemplate{ObjectMeta: metav1.ObjectMeta{Name: "some-template"}},
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.RunTemplateReady))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.MissingRequiredInputRunTemplateReason))
+		})
+
+		It("reports an InvalidInputError as an invalid input and handled", func() {
+			err := runnablerealizer.InvalidInputError{
+				Input:       "some-input",
+				Err:         errors.New("spec.inputs[some-input]: Invalid value"),
+				Runnable:    runnable,
+				RunTemplate: &v1alpha1.ClusterRunTemplate{ObjectMeta: metav1.ObjectMeta{Name: "some-template"}},
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.RunTemplateReady))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.InputInvalidRunTemplateReason))
+			Expect(condition.Message).To(ContainSubstring("spec.inputs[some-input]"))
+		})
+
+		It("reports a CancelPreviousRunError as unhandled", func() {
+			err := runnablerealizer.CancelPreviousRunError{Err: errors.New("delete failed"), PreviousRun: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.FailedToCancelPreviousRunRunTemplateReason))
+		})
+
+		It("reports an OutputTransformError as a transform error and handled", func() {
+			err := runnablerealizer.OutputTransformError{Err: errors.New("bad transform"), Runnable: runnable, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.RunTemplateReady))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.OutputTransformErrorRunTemplateReason))
+		})
+
+		It("reports a RetrieveOutputError as an unsatisfied output path and handled", func() {
+			err := runnablerealizer.RetrieveOutputError{Err: errors.New("no status"), Runnable: runnable, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(conditions.OutputPathNotSatisfiedCondition(stampedObject, err.Error())))
+		})
+	})
+
+	Describe("workload realizer errors", func() {
+		var resource *v1alpha1.SupplyChainResource
+
+		BeforeEach(func() {
+			resource = &v1alpha1.SupplyChainResource{Name: "my-resource"}
+		})
+
+		It("reports a GetClusterTemplateError as a retrieval failure and unhandled", func() {
+			err := workloadrealizer.GetClusterTemplateError{Err: errors.New("not found")}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason,
+				Message: err.Error(),
+			}))
+		})
+
+		It("reports a GetClusterTemplateError for a template name that does not exist as TemplateNameNotFound and handled", func() {
+			err := workloadrealizer.GetClusterTemplateError{
+				Err:         repository.TemplateNotFoundError{Kind: "ClusterImageTemplate", Name: "my-tempalte"},
+				TemplateRef: v1alpha1.ClusterTemplateReference{Kind: "ClusterImageTemplate", Name: "my-tempalte"},
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.TemplateNameNotFoundResourcesSubmittedReason,
+				Message: "unable to get template [my-tempalte]: template [my-tempalte] of kind [ClusterImageTemplate] not found",
+			}))
+		})
+
+		It("reports a GetClusterTemplateError for a transient get error as a retrieval failure and unhandled", func() {
+			err := workloadrealizer.GetClusterTemplateError{
+				Err:         fmt.Errorf("failed to get template object from api server: %w", kerrors.NewServiceUnavailable("try again")),
+				TemplateRef: v1alpha1.ClusterTemplateReference{Kind: "ClusterImageTemplate", Name: "my-template"},
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason))
+		})
+
+		It("reports a StampError as a stamp failure and handled", func() {
+			err := workloadrealizer.StampError{Err: errors.New("bad template"), Resource: resource}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateStampFailureResourcesSubmittedReason))
+		})
+
+		It("reports a StampError for a template overriding the owner references as a missing owner reference and handled", func() {
+			err := workloadrealizer.StampError{Err: templates.MissingOwnerReferenceError{Owner: "Workload/my-workload"}, Resource: resource}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.WorkloadResourceSubmitted))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.MissingOwnerReferenceResourcesSubmittedReason))
+		})
+
+		It("reports an ApplyStampedObjectError as rejected and unhandled", func() {
+			err := workloadrealizer.ApplyStampedObjectError{Err: errors.New("conflict"), StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason))
+		})
+
+		It("treats a forbidden ApplyStampedObjectError as handled", func() {
+			err := workloadrealizer.ApplyStampedObjectError{Err: forbiddenErr, StampedObject: stampedObject}
+
+			_, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+		})
+
+		It("reports an ApplyStampedObjectError for a foreign object as a handled conflict", func() {
+			err := workloadrealizer.ApplyStampedObjectError{Err: repository.StampedObjectConflictError{Object: stampedObject, Owner: "Workload [other]"}, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.StampedObjectConflictResourcesSubmittedReason))
+		})
+
+		It("reports a StampedObjectSchemaInvalidError as schema invalid and handled", func() {
+			err := workloadrealizer.StampedObjectSchemaInvalidError{
+				Err:           errors.New("spec.foo: Required value"),
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.StampedObjectSchemaInvalidResourcesSubmittedReason,
+				Message: err.Error(),
+			}))
+			Expect(condition.Message).To(ContainSubstring("spec.foo: Required value"))
+		})
+
+		It("reports a StampedKindNotAllowedError as not allowed and handled", func() {
+			err := workloadrealizer.StampedKindNotAllowedError{Resource: resource, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.StampedKindNotAllowedResourcesSubmittedReason,
+				Message: err.Error(),
+			}))
+		})
+
+		It("reports an InsufficientPermissionsError as insufficient permissions and handled", func() {
+			err := workloadrealizer.InsufficientPermissionsError{
+				Err:           repository.AccessDeniedError{Verb: "create", Resource: "configmaps", Namespace: "some-namespace"},
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1alpha1.InsufficientPermissionsResourcesSubmittedReason,
+				Message: err.Error(),
+			}))
+			Expect(condition.Message).To(ContainSubstring("not permitted to [create] resource [configmaps] in namespace [some-namespace]"))
+		})
+
+		It("reports an UpstreamOutputNotAvailableError as waiting on upstream and handled", func() {
+			err := workloadrealizer.UpstreamOutputNotAvailableError{Resource: resource, UpstreamResource: "upstream-resource"}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionUnknown,
+				Reason:  v1alpha1.WaitingOnUpstreamResourcesSubmittedReason,
+				Message: "Resource [my-resource] waiting on output from upstream resource [upstream-resource]",
+			}))
+		})
+
+		It("reports a StampedObjectNotObservedError as waiting for the controller and handled", func() {
+			err := workloadrealizer.StampedObjectNotObservedError{
+				Resource:           resource,
+				StampedObject:      stampedObject,
+				Generation:         2,
+				ObservedGeneration: 1,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.WorkloadResourceSubmitted))
+			Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(condition.Reason).To(Equal(v1alpha1.WaitingForControllerResourcesSubmittedReason))
+			Expect(condition.Message).To(ContainSubstring("which its controller has not observed yet"))
+		})
+
+		It("reports a RetrieveOutputError as a missing value and handled", func() {
+			err := workloadrealizer.RetrieveOutputError{
+				Err:           templates.NewJsonPathError("spec.foo", errors.New("not there")),
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(condition.Reason).To(Equal(v1alpha1.MissingValueAtPathResourcesSubmittedReason))
+			Expect(condition.Message).To(Equal("Waiting to read value [spec.foo] from resource [widget.thing.io/my-widget] in namespace [my-ns]"))
+		})
+
+		It("reports a RetrieveOutputError for unsatisfied readiness gates as gates not satisfied and handled", func() {
+			err := workloadrealizer.RetrieveOutputError{
+				Err:           templates.ReadinessGatesNotSatisfiedError{Unsatisfied: []string{"[SBOMScanned] is [False], want [True]"}},
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition).To(Equal(metav1.Condition{
+				Type:    v1alpha1.WorkloadResourceSubmitted,
+				Status:  metav1.ConditionUnknown,
+				Reason:  v1alpha1.GatesNotSatisfiedResourcesSubmittedReason,
+				Message: "Resource [my-resource] waiting on readiness gates of the stamped object not satisfied: condition [SBOMScanned] is [False], want [True]",
+			}))
+		})
+
+		It("reports a RetrieveOutputError for an empty value as an empty output and handled", func() {
+			err := workloadrealizer.RetrieveOutputError{
+				Err:           templates.NewOutputEmptyError("status.latestImage"),
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.OutputEmptyResourcesSubmittedReason))
+			Expect(condition.Message).To(ContainSubstring("unable to retrieve outputs [status.latestImage]"))
+			Expect(condition.Message).To(ContainSubstring("value at json path 'status.latestImage' is empty"))
+		})
+
+		It("reports a RetrieveOutputError for an image without a digest as an unpinned image and handled", func() {
+			err := workloadrealizer.RetrieveOutputError{
+				Err:           templates.NewImageNotPinnedError("status.latestImage", "my-image:latest"),
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.ImageNotPinnedResourcesSubmittedReason))
+			Expect(condition.Message).To(ContainSubstring("unable to retrieve outputs [status.latestImage]"))
+			Expect(condition.Message).To(ContainSubstring("image [my-image:latest] at json path 'status.latestImage' is not pinned to a digest"))
+		})
+
+		It("reports a RetrieveOutputError for an ambiguous path as an ambiguous output path and handled", func() {
+			err := workloadrealizer.RetrieveOutputError{
+				Err:           eval.AmbiguousJsonPathError{Path: "status.conditions[*].status", Count: 2},
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.AmbiguousOutputPathResourcesSubmittedReason))
+			Expect(condition.Message).To(ContainSubstring("matches 2 values"))
+		})
+
+		Context("the output is read from a subresource", func() {
+			It("reports a kind without the subresource as an output subresource error and handled", func() {
+				err := workloadrealizer.RetrieveOutputError{
+					Err: templates.SubresourceError{
+						Err:         repository.SubresourceNotAvailableError{Subresource: "scale", Object: stampedObject},
+						Subresource: "scale",
+					},
+					Resource:      resource,
+					StampedObject: stampedObject,
+				}
+
+				condition, handled := conditions.FromRealizeError(err)
+				Expect(handled).To(BeTrue())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1alpha1.OutputSubresourceErrorResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal(err.Error()))
+			})
+
+			It("reports a failure to read the subresource as an output subresource error and unhandled", func() {
+				err := workloadrealizer.RetrieveOutputError{
+					Err:           templates.SubresourceError{Err: errors.New("connection refused"), Subresource: "scale"},
+					Resource:      resource,
+					StampedObject: stampedObject,
+				}
+
+				condition, handled := conditions.FromRealizeError(err)
+				Expect(handled).To(BeFalse())
+				Expect(condition.Reason).To(Equal(v1alpha1.OutputSubresourceErrorResourcesSubmittedReason))
+			})
+		})
+
+		Context("the errors of every failed resource are collected", func() {
+			var stampErr, applyErr error
+
+			BeforeEach(func() {
+				stampErr = workloadrealizer.StampError{Err: errors.New("bad template"), Resource: resource}
+				applyErr = workloadrealizer.ApplyStampedObjectError{Err: errors.New("conflict"), StampedObject: stampedObject}
+			})
+
+			It("reports the first failure with a message naming every failure", func() {
+				err := workloadrealizer.ResourceErrors{Errors: []workloadrealizer.ResourceError{
+					{Err: stampErr, ResourceName: "my-resource"},
+					{Err: applyErr, ResourceName: "other-resource"},
+				}}
+
+				condition, _ := conditions.FromRealizeError(err)
+				Expect(condition.Reason).To(Equal(v1alpha1.TemplateStampFailureResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal(err.Error()))
+				Expect(condition.Message).To(ContainSubstring("bad template"))
+				Expect(condition.Message).To(ContainSubstring("conflict"))
+			})
+
+			It("is handled only if every failure is handled", func() {
+				_, handled := conditions.FromRealizeError(workloadrealizer.ResourceErrors{Errors: []workloadrealizer.ResourceError{
+					{Err: stampErr, ResourceName: "my-resource"},
+				}})
+				Expect(handled).To(BeTrue())
+
+				_, handled = conditions.FromRealizeError(workloadrealizer.ResourceErrors{Errors: []workloadrealizer.ResourceError{
+					{Err: stampErr, ResourceName: "my-resource"},
+					{Err: applyErr, ResourceName: "other-resource"},
+				}})
+				Expect(handled).To(BeFalse())
+			})
+
+			Context("and resources wait on the output of a resource that has not produced it yet", func() {
+				var pendingErr error
+				var waiting []workloadrealizer.UpstreamOutputNotAvailableError
+
+				BeforeEach(func() {
+					pendingErr = workloadrealizer.RetrieveOutputError{
+						Err:           templates.NewJsonPathError("status.latestImage", errors.New("not found")),
+						Resource:      resource,
+						StampedObject: stampedObject,
+					}
+					waiting = []workloadrealizer.UpstreamOutputNotAvailableError{
+						{Resource: &v1alpha1.SupplyChainResource{Name: "downstream-resource"}, UpstreamResource: "my-resource"},
+					}
+				})
+
+				It("reports the first waiting resource with the upstream resource it waits on", func() {
+					condition, handled := conditions.FromRealizeError(workloadrealizer.ResourceErrors{
+						Errors:  []workloadrealizer.ResourceError{{Err: pendingErr, ResourceName: "my-resource"}},
+						Waiting: waiting,
+					})
+					Expect(handled).To(BeTrue())
+					Expect(condition).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionUnknown,
+						Reason:  v1alpha1.WaitingOnUpstreamResourcesSubmittedReason,
+						Message: "Resource [downstream-resource] waiting on output from upstream resource [my-resource]",
+					}))
+				})
+
+				It("reports a resource that failed ahead of the waiting ones", func() {
+					condition, _ := conditions.FromRealizeError(workloadrealizer.ResourceErrors{
+						Errors: []workloadrealizer.ResourceError{
+							{Err: pendingErr, ResourceName: "my-resource"},
+							{Err: stampErr, ResourceName: "other-resource"},
+						},
+						Waiting: waiting,
+					})
+					Expect(condition.Reason).To(Equal(v1alpha1.MissingValueAtPathResourcesSubmittedReason))
+				})
+			})
+		})
+	})
+
+	Describe("deliverable realizer errors", func() {
+		var resource *v1alpha1.ClusterDeliveryResource
+
+		BeforeEach(func() {
+			resource = &v1alpha1.ClusterDeliveryResource{Name: "my-resource"}
+		})
+
+		It("reports a GetDeliveryClusterTemplateError as a retrieval failure and unhandled", func() {
+			err := deliverablerealizer.GetDeliveryClusterTemplateError{Err: errors.New("not found")}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Type).To(Equal(v1alpha1.DeliverableResourcesSubmitted))
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason))
+		})
+
+		It("reports a GetDeliveryClusterTemplateError for a template name that does not exist as TemplateNameNotFound and handled", func() {
+			err := deliverablerealizer.GetDeliveryClusterTemplateError{
+				Err: fmt.Errorf("wrapped: %w", repository.TemplateNotFoundError{Kind: "ClusterDeploymentTemplate", Name: "my-tempalte"}),
+			}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.DeliverableResourcesSubmitted))
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateNameNotFoundResourcesSubmittedReason))
+			Expect(condition.Message).To(ContainSubstring("template [my-tempalte] of kind [ClusterDeploymentTemplate] not found"))
+		})
+
+		It("reports a StampError as a stamp failure and handled", func() {
+			err := deliverablerealizer.StampError{Err: errors.New("bad template"), Resource: resource}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateStampFailureResourcesSubmittedReason))
+		})
+
+		It("reports a StampError for a template overriding the owner references as a missing owner reference and handled", func() {
+			err := deliverablerealizer.StampError{Err: templates.MissingOwnerReferenceError{Owner: "Workload/my-workload"}, Resource: resource}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.DeliverableResourcesSubmitted))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.MissingOwnerReferenceResourcesSubmittedReason))
+		})
+
+		It("reports an ApplyStampedObjectError as rejected and unhandled", func() {
+			err := deliverablerealizer.ApplyStampedObjectError{Err: errors.New("conflict"), StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeFalse())
+			Expect(condition.Reason).To(Equal(v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason))
+		})
+
+		It("treats a forbidden ApplyStampedObjectError as handled", func() {
+			err := deliverablerealizer.ApplyStampedObjectError{Err: forbiddenErr, StampedObject: stampedObject}
+
+			_, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+		})
+
+		It("reports an ApplyStampedObjectError for a foreign object as a handled conflict", func() {
+			err := deliverablerealizer.ApplyStampedObjectError{Err: repository.StampedObjectConflictError{Object: stampedObject, Owner: "Workload [other]"}, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1alpha1.StampedObjectConflictResourcesSubmittedReason))
+		})
+
+		It("reports a StampedKindNotAllowedError as not allowed and handled", func() {
+			err := deliverablerealizer.StampedKindNotAllowedError{Resource: resource, StampedObject: stampedObject}
+
+			condition, handled := conditions.FromRealizeError(err)
+			Expect(handled).To(BeTrue())
+			Expect(condition.Type).To(Equal(v1alpha1.DeliverableResourcesSubmitted))
+			Expect(condition.Reason).To(Equal(v1alpha1.StampedKindNotAllowedResourcesSubmittedReason))
+		})
+
+		Context("RetrieveOutputError", func() {
+			var retrieveErr func(wrapped error) deliverablerealizer.RetrieveOutputError
+
+			BeforeEach(func() {
+				retrieveErr = func(wrapped error) deliverablerealizer.RetrieveOutputError {
+					return deliverablerealizer.RetrieveOutputError{Err: wrapped, Resource: resource, StampedObject: stampedObject}
+				}
+			})
+
+			It("reports a wrapped ObservedGenerationError as a stamp failure", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(templates.NewObservedGenerationError(errors.New("no gen"))))
+				Expect(handled).To(BeTrue())
+				Expect(condition.Reason).To(Equal(v1alpha1.TemplateStampFailureResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal("Resource [my-resource] cannot satisfy observedCompletion without observedGeneration in object status"))
+			})
+
+			It("reports a wrapped DeploymentFailedConditionMetError as a failed deployment", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(templates.NewDeploymentFailedConditionMetError(errors.New("boom"))))
+				Expect(handled).To(BeTrue())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1alpha1.DeploymentFailedConditionMetResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal("Resource [my-resource] failed condition met: boom"))
+			})
+
+			It("reports a wrapped DeploymentConditionError as a deployment condition not yet met", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(templates.NewDeploymentConditionError(errors.New("waiting"))))
+				Expect(handled).To(BeTrue())
+				Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+				Expect(condition.Reason).To(Equal(v1alpha1.DeploymentConditionNotMetResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal("Resource [my-resource] condition not met: waiting"))
+			})
+
+			It("reports a wrapped AmbiguousJsonPathError as an ambiguous output path", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(eval.AmbiguousJsonPathError{Path: "status.url", Count: 2}))
+				Expect(handled).To(BeTrue())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1alpha1.AmbiguousOutputPathResourcesSubmittedReason))
+			})
+
+			It("reports a wrapped JsonPathError as a missing value", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(templates.NewJsonPathError("status.foo", errors.New("not there"))))
+				Expect(handled).To(BeTrue())
+				Expect(condition).To(Equal(conditions.MissingValueAtPathCondition(v1alpha1.DeliverableResourcesSubmitted, stampedObject, "status.foo")))
+			})
+
+			It("reports a wrapped ReadinessGatesNotSatisfiedError as gates not satisfied", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(templates.ReadinessGatesNotSatisfiedError{Unsatisfied: []string{"[Healthy] is [Unknown], want [True]"}}))
+				Expect(handled).To(BeTrue())
+				Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+				Expect(condition.Reason).To(Equal(v1alpha1.GatesNotSatisfiedResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal("Resource [my-resource] waiting on readiness gates of the stamped object not satisfied: condition [Healthy] is [Unknown], want [True]"))
+			})
+
+			It("reports a wrapped OutputEmptyError as an empty output", func() {
+				err := retrieveErr(templates.NewOutputEmptyError("status.foo"))
+				condition, handled := conditions.FromRealizeError(err)
+				Expect(handled).To(BeTrue())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(v1alpha1.OutputEmptyResourcesSubmittedReason))
+				Expect(condition.Message).To(Equal(err.Error()))
+			})
+
+			It("reports any other wrapped error as an unknown error that is still handled", func() {
+				condition, handled := conditions.FromRealizeError(retrieveErr(errors.New("surprise")))
+				Expect(handled).To(BeTrue())
+				Expect(condition.Reason).To(Equal(v1alpha1.UnknownErrorResourcesSubmittedReason))
+			})
+		})
+	})
+
+	Describe("an error that did not come from a realizer", func() {
+		It("returns an empty condition and unhandled", func() {
+			condition, handled := conditions.FromRealizeError(errors.New("who knows"))
+			Expect(handled).To(BeFalse())
+			Expect(condition).To(Equal(metav1.Condition{}))
+		})
+	})
+})
+
+var _ = Describe("Realizer error condition messages", func() {
+	var obj *unstructured.Unstructured
+
+	BeforeEach(func() {
+		obj = &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   "thing.io",
+			Version: "alphabeta1",
+			Kind:    "Widget",
+		})
+		obj.SetName("my-widget")
+	})
+
+	Describe("MissingValueAtPathCondition", func() {
+		Context("stamped object has a namespace", func() {
+			It("has the correct message", func() {
+				obj.SetNamespace("my-ns")
+
+				condition := conditions.MissingValueAtPathCondition(v1alpha1.WorkloadResourceSubmitted, obj, "spec.foo")
+				Expect(condition.Message).To(Equal("Waiting to read value [spec.foo] from resource [widget.thing.io/my-widget] in namespace [my-ns]"))
+			})
+		})
+
+		Context("stamped object does not have a namespace", func() {
+			It("has the correct message", func() {
+				condition := conditions.MissingValueAtPathCondition(v1alpha1.WorkloadResourceSubmitted, obj, "spec.foo")
+				Expect(condition.Message).To(Equal("Waiting to read value [spec.foo] from resource [widget.thing.io/my-widget]"))
+			})
+		})
+	})
+
+	Describe("OutputPathNotSatisfiedCondition", func() {
+		Context("stamped object has a namespace", func() {
+			It("has the correct message", func() {
+				obj.SetNamespace("my-ns")
+
+				condition := conditions.OutputPathNotSatisfiedCondition(obj, "problem at spec.foo")
+				Expect(condition.Message).To(Equal("Waiting to read value from resource [widget.thing.io/my-widget] in namespace [my-ns]: problem at spec.foo"))
+			})
+		})
+
+		Context("stamped object does not have a namespace", func() {
+			It("has the correct message", func() {
+				condition := conditions.OutputPathNotSatisfiedCondition(obj, "problem at spec.foo")
+				Expect(condition.Message).To(Equal("Waiting to read value from resource [widget.thing.io/my-widget]: problem at spec.foo"))
+			})
+		})
+	})
+})
@@ -18,11 +18,8 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
-	"github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
-	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
 // -- Delivery conditions
@@ -81,74 +78,6 @@ func ResourcesSubmittedCondition() metav1.Condition {
 	}
 }
 
-func TemplateObjectRetrievalFailureCondition(err error) metav1.Condition {
-	return metav1.Condition{
-		Type:    v1alpha1.DeliverableResourcesSubmitted,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason,
-		Message: err.Error(),
-	}
-}
-
-func MissingValueAtPathCondition(obj *unstructured.Unstructured, expression string) metav1.Condition {
-	var namespaceMsg string
-	if obj.GetNamespace() != "" {
-		namespaceMsg = fmt.Sprintf(" in namespace [%s]", obj.GetNamespace())
-	}
-	return metav1.Condition{
-		Type:   v1alpha1.WorkloadResourceSubmitted,
-		Status: metav1.ConditionUnknown,
-		Reason: v1alpha1.MissingValueAtPathResourcesSubmittedReason,
-		Message: fmt.Sprintf("Waiting to read value [%s] from resource [%s/%s]%s",
-			expression, utils.GetFullyQualifiedType(obj), obj.GetName(), namespaceMsg),
-	}
-}
-
-func TemplateStampFailureCondition(err error) metav1.Condition {
-	return metav1.Condition{
-		Type:    v1alpha1.DeliverableResourcesSubmitted,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
-		Message: err.Error(),
-	}
-}
-
-func TemplateStampFailureByObservedGenerationCondition(err error) metav1.Condition {
-	return metav1.Condition{
-		Type:    v1alpha1.DeliverableResourcesSubmitted,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
-		Message: fmt.Sprintf("Resource [%s] cannot satisfy observedCompletion without observedGeneration in object status", err.(deliverable.RetrieveOutputError).ResourceName()),
-	}
-}
-
-func DeploymentConditionNotMetCondition(err error) metav1.Condition {
-	return metav1.Condition{
-		Type:    v1alpha1.DeliverableResourcesSubmitted,
-		Status:  metav1.ConditionUnknown,
-		Reason:  v1alpha1.DeploymentConditionNotMetResourcesSubmittedReason,
-		Message: fmt.Sprintf("Resource [%s] condition not met: %s", err.(deliverable.RetrieveOutputError).ResourceName(), err.(deliverable.RetrieveOutputError).Err.Error()),
-	}
-}
-
-func DeploymentFailedConditionMetCondition(err error) metav1.Condition {
-	return metav1.Condition{
-		Type:    v1alpha1.DeliverableResourcesSubmitted,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.DeploymentFailedConditionMetResourcesSubmittedReason,
-		Message: fmt.Sprintf("Resource [%s] failed condition met: %s", err.(deliverable.RetrieveOutputError).ResourceName(), err.(deliverable.RetrieveOutputError).Err.Error()),
-	}
-}
-
-func TemplateRejectedByAPIServerCondition(err error) metav1.Condition {
-	return metav1.Condition{
-		Type:    v1alpha1.DeliverableResourcesSubmitted,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason,
-		Message: err.Error(),
-	}
-}
-
 func UnknownResourceErrorCondition(err error) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.DeliverableResourcesSubmitted,
@@ -175,3 +104,12 @@ func ResourceRealizerBuilderErrorCondition(err error) metav1.Condition {
 		Message: err.Error(),
 	}
 }
+
+func WorkloadNotFoundCondition(name string) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.DeliverableResourcesSubmitted,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.WorkloadNotFoundResourcesSubmittedReason,
+		Message: fmt.Sprintf("workload [%s] referenced by spec.source.workloadRef not found", name),
+	}
+}
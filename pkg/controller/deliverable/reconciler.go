@@ -32,7 +32,6 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
-	"github.com/vmware-tanzu/cartographer/pkg/templates"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
@@ -43,7 +42,10 @@ type Reconciler struct {
 	ResourceRealizerBuilder realizer.ResourceRealizerBuilder
 	Realizer                realizer.Realizer
 	DynamicTracker          tracker.DynamicTracker
-	conditionManager        conditions.ConditionManager
+	ForbiddenRetry          controller.ForbiddenRetryOptions
+	// Pause leaves the deliverable alone while cartographer is paused for maintenance.
+	Pause            controller.Pause
+	conditionManager conditions.ConditionManager
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -67,6 +69,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	r.conditionManager = r.ConditionManagerBuilder(v1alpha1.DeliverableReady, deliverable.Status.Conditions)
 
+	paused, err := r.Pause.Paused(ctx)
+	if err != nil {
+		log.Error(err, "failed to read whether cartographer is paused")
+		return ctrl.Result{}, err
+	}
+	if paused {
+		r.conditionManager.AddNegative(r.Pause.PausedCondition())
+		log.Info("cartographer is paused")
+		return r.completeReconciliation(ctx, deliverable, nil)
+	}
+
 	delivery, err := r.getDeliveriesForDeliverable(ctx, deliverable)
 	if err != nil {
 		return r.completeReconciliation(ctx, deliverable, err)
@@ -100,41 +113,30 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return r.completeReconciliation(ctx, deliverable, fmt.Errorf("failed to get secret for service account [%s]: %w", deliverable.Spec.ServiceAccountName, err))
 	}
 
-	resourceRealizer, err := r.ResourceRealizerBuilder(secret, deliverable, r.Repo, delivery.Spec.Params)
+	workload, err := r.getLinkedWorkload(ctx, deliverable)
+	if err != nil {
+		return r.completeReconciliation(ctx, deliverable, err)
+	}
+
+	resourceRealizer, err := r.ResourceRealizerBuilder(secret, deliverable, workload, r.Repo, delivery.Spec.Params)
 	if err != nil {
 		r.conditionManager.AddPositive(ResourceRealizerBuilderErrorCondition(err))
 		return r.completeReconciliation(ctx, deliverable, controller.NewUnhandledError(fmt.Errorf("failed to build resource realizer: %w", err)))
 	}
 
 	stampedObjects, err := r.Realizer.Realize(ctx, resourceRealizer, delivery)
+	if ctx.Err() != nil {
+		log.Info("reconcile cancelled, requeueing without updating status", "error", ctx.Err().Error())
+		return ctrl.Result{Requeue: true}, nil
+	}
 	if err != nil {
 		log.V(logger.DEBUG).Info("failed to realize")
-		switch typedErr := err.(type) {
-		case realizer.GetDeliveryClusterTemplateError:
-			r.conditionManager.AddPositive(TemplateObjectRetrievalFailureCondition(typedErr))
-			err = controller.NewUnhandledError(err)
-		case realizer.StampError:
-			r.conditionManager.AddPositive(TemplateStampFailureCondition(typedErr))
-		case realizer.ApplyStampedObjectError:
-			r.conditionManager.AddPositive(TemplateRejectedByAPIServerCondition(typedErr))
-			if !kerrors.IsForbidden(typedErr.Err) {
-				err = controller.NewUnhandledError(err)
-			}
-		case realizer.RetrieveOutputError:
-			switch typedErr.Err.(type) {
-			case templates.ObservedGenerationError:
-				r.conditionManager.AddPositive(TemplateStampFailureByObservedGenerationCondition(typedErr))
-			case templates.DeploymentFailedConditionMetError:
-				r.conditionManager.AddPositive(DeploymentFailedConditionMetCondition(typedErr))
-			case templates.DeploymentConditionError:
-				r.conditionManager.AddPositive(DeploymentConditionNotMetCondition(typedErr))
-			case templates.JsonPathError:
-				r.conditionManager.AddPositive(MissingValueAtPathCondition(typedErr.StampedObject, typedErr.JsonPathExpression()))
-			default:
-				r.conditionManager.AddPositive(UnknownResourceErrorCondition(typedErr))
-			}
-		default:
-			r.conditionManager.AddPositive(UnknownResourceErrorCondition(typedErr))
+		condition, handled := conditions.FromRealizeError(err)
+		if condition.Type == "" {
+			condition = UnknownResourceErrorCondition(err)
+		}
+		r.conditionManager.AddPositive(condition)
+		if !handled {
 			err = controller.NewUnhandledError(err)
 		}
 	} else {
@@ -150,8 +152,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	var trackingError error
 	if len(stampedObjects) > 0 {
 		for _, stampedObject := range stampedObjects {
-			trackingError = r.DynamicTracker.Watch(log, stampedObject, &handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Deliverable{}})
-			if trackingError != nil {
+			trackingError = r.DynamicTracker.Watch(log, stampedObject, handler.EnqueueRequestsFromMapFunc(StampedObjectToDeliverableRequests), 0)
+			if pendingErr, ok := trackingError.(tracker.WatchPendingError); ok {
+				log.Info("watch on object pending",
+					"object", stampedObject, "retry after", pendingErr.RetryAfter)
+				err = pendingErr
+			} else if trackingError != nil {
 				log.Error(err, "failed to add informer for object",
 					"object", stampedObject)
 				err = controller.NewUnhandledError(trackingError)
@@ -170,6 +176,12 @@ func (r *Reconciler) completeReconciliation(ctx context.Context, deliverable *v1
 	var changed bool
 	deliverable.Status.Conditions, changed = r.conditionManager.Finalize()
 
+	forbiddenRetries, requeueAfter := r.ForbiddenRetry.Next(deliverable.Status.ForbiddenRetries, isForbiddenApplyError(err))
+	if deliverable.Status.ForbiddenRetries != forbiddenRetries {
+		deliverable.Status.ForbiddenRetries = forbiddenRetries
+		changed = true
+	}
+
 	var updateErr error
 	if changed || (deliverable.Status.ObservedGeneration != deliverable.Generation) {
 		deliverable.Status.ObservedGeneration = deliverable.Generation
@@ -188,9 +200,46 @@ func (r *Reconciler) completeReconciliation(ctx context.Context, deliverable *v1
 		log.Info("handled error reconciling deliverable", "handled error", err)
 	}
 
+	if pendingErr, ok := err.(tracker.WatchPendingError); ok {
+		log.Info("requeueing until the watch on the stamped object is established", "requeue after", pendingErr.RetryAfter)
+		return ctrl.Result{RequeueAfter: pendingErr.RetryAfter}, nil
+	}
+
+	if requeueAfter > 0 {
+		log.Info("stamped object was forbidden, requeueing in case RBAC has not yet propagated",
+			"retry", forbiddenRetries, "max retries", r.ForbiddenRetry.MaxRetries, "requeue after", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+func isForbiddenApplyError(err error) bool {
+	applyErr, ok := err.(realizer.ApplyStampedObjectError)
+	return ok && kerrors.IsForbidden(applyErr.Err)
+}
+
+// getLinkedWorkload returns the workload referenced by the deliverable's spec.source.workloadRef,
+// nil when it references none.
+func (r *Reconciler) getLinkedWorkload(ctx context.Context, deliverable *v1alpha1.Deliverable) (*v1alpha1.Workload, error) {
+	if deliverable.Spec.Source == nil || deliverable.Spec.Source.WorkloadRef == nil {
+		return nil, nil
+	}
+	name := deliverable.Spec.Source.WorkloadRef.Name
+
+	workload, err := r.Repo.GetWorkload(ctx, name, deliverable.Namespace)
+	if err != nil {
+		return nil, controller.NewUnhandledError(fmt.Errorf("failed to get workload [%s]: %w", name, err))
+	}
+
+	if workload == nil {
+		r.conditionManager.AddPositive(WorkloadNotFoundCondition(name))
+		return nil, fmt.Errorf("workload [%s] referenced by deliverable not found", name)
+	}
+
+	return workload, nil
+}
+
 func (r *Reconciler) isDeliveryReady(delivery *v1alpha1.ClusterDelivery) bool {
 	readyCondition := getDeliveryReadyCondition(delivery)
 	return readyCondition.Status == "True"
@@ -17,6 +17,7 @@ package deliverable_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
@@ -30,13 +31,18 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions/conditionsfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/deliverable"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable/deliverablefakes"
@@ -44,6 +50,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/trackerfakes"
 )
 
@@ -62,6 +69,7 @@ var _ = Describe("Reconciler", func() {
 
 		builtResourceRealizer        *deliverablefakes.FakeResourceRealizer
 		resourceRealizerSecret       *corev1.Secret
+		resourceRealizerWorkload     *v1alpha1.Workload
 		serviceAccountSecret         *corev1.Secret
 		serviceAccountName           string
 		resourceRealizerBuilderError error
@@ -95,11 +103,13 @@ var _ = Describe("Reconciler", func() {
 		repo.GetServiceAccountSecretReturns(serviceAccountSecret, nil)
 
 		resourceRealizerBuilderError = nil
-		resourceRealizerBuilder := func(secret *corev1.Secret, deliverable *v1alpha1.Deliverable, systemRepo repository.Repository, deliveryParams []v1alpha1.DelegatableParam) (realizer.ResourceRealizer, error) {
+		resourceRealizerWorkload = nil
+		resourceRealizerBuilder := func(secret *corev1.Secret, deliverable *v1alpha1.Deliverable, workload *v1alpha1.Workload, systemRepo repository.Repository, deliveryParams []v1alpha1.DelegatableParam) (realizer.ResourceRealizer, error) {
 			if resourceRealizerBuilderError != nil {
 				return nil, resourceRealizerBuilderError
 			}
 			resourceRealizerSecret = secret
+			resourceRealizerWorkload = workload
 			builtResourceRealizer = &deliverablefakes.FakeResourceRealizer{}
 			return builtResourceRealizer, nil
 		}
@@ -247,6 +257,45 @@ var _ = Describe("Reconciler", func() {
 			rlzr.RealizeReturns([]*unstructured.Unstructured{stampedObject1, stampedObject2}, nil)
 		})
 
+		Context("but cartographer is paused", func() {
+			var pauseConfigMap *corev1.ConfigMap
+
+			BeforeEach(func() {
+				pauseConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "cartographer-system", Name: "cartographer-pause"},
+					Data:       map[string]string{controller.PauseKey: "true"},
+				}
+				reconciler.Pause = controller.Pause{
+					Reader:    fake.NewClientBuilder().WithObjects(pauseConfigMap).Build(),
+					Namespace: "cartographer-system",
+					Name:      "cartographer-pause",
+				}
+			})
+
+			It("reports the controller is paused without stamping nor requeueing", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+
+				Expect(conditionManager.AddNegativeArgsForCall(0)).To(Equal(reconciler.Pause.PausedCondition()))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+
+			Context("and is resumed", func() {
+				BeforeEach(func() {
+					pauseConfigMap.Data[controller.PauseKey] = "false"
+					reconciler.Pause.Reader = fake.NewClientBuilder().WithObjects(pauseConfigMap).Build()
+				})
+
+				It("stamps the resources again", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		It("dynamically creates a resource realizer", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 
@@ -327,6 +376,80 @@ var _ = Describe("Reconciler", func() {
 			})
 		})
 
+		It("does not look up a workload when the deliverable does not link one", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.GetWorkloadCallCount()).To(Equal(0))
+			Expect(resourceRealizerWorkload).To(BeNil())
+		})
+
+		Context("the deliverable links a workload", func() {
+			BeforeEach(func() {
+				dl.Spec.Source = &v1alpha1.DeliverableSource{
+					WorkloadRef: &v1alpha1.WorkloadReference{Name: "my-workload"},
+				}
+			})
+
+			Context("and the workload exists", func() {
+				var linkedWorkload *v1alpha1.Workload
+
+				BeforeEach(func() {
+					linkedWorkload = &v1alpha1.Workload{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-workload", Namespace: "my-ns"},
+					}
+					repo.GetWorkloadReturns(linkedWorkload, nil)
+				})
+
+				It("gets the workload from the deliverable's namespace", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(repo.GetWorkloadCallCount()).To(Equal(1))
+					_, name, namespace := repo.GetWorkloadArgsForCall(0)
+					Expect(name).To(Equal("my-workload"))
+					Expect(namespace).To(Equal("my-ns"))
+				})
+
+				It("builds the resource realizer with the workload", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(resourceRealizerWorkload).To(Equal(linkedWorkload))
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("and the workload does not exist", func() {
+				BeforeEach(func() {
+					repo.GetWorkloadReturns(nil, nil)
+				})
+
+				It("calls the condition manager to report the workload was not found", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.WorkloadNotFoundCondition("my-workload")))
+				})
+
+				It("does not realize the delivery or return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and getting the workload fails", func() {
+				BeforeEach(func() {
+					repo.GetWorkloadReturns(nil, errors.New("some error"))
+				})
+
+				It("returns an unhandled error and requeues", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+
+					Expect(err).To(MatchError(ContainSubstring("failed to get workload [my-workload]: some error")))
+					Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		It("sets the DeliveryRef", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 
@@ -347,15 +470,15 @@ var _ = Describe("Reconciler", func() {
 		It("watches the stampedObjects kinds", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 			Expect(dynamicTracker.WatchCallCount()).To(Equal(2))
-			_, obj, hndl := dynamicTracker.WatchArgsForCall(0)
+			_, obj, hndl, _, _ := dynamicTracker.WatchArgsForCall(0)
 
 			Expect(obj).To(Equal(stampedObject1))
-			Expect(hndl).To(Equal(&handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Deliverable{}}))
+			Expect(enqueued(hndl, labelledForDeliverable())).To(ConsistOf(ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-deliverable", Namespace: "my-ns"}}))
 
-			_, obj, hndl = dynamicTracker.WatchArgsForCall(1)
+			_, obj, hndl, _, _ = dynamicTracker.WatchArgsForCall(1)
 
 			Expect(obj).To(Equal(stampedObject2))
-			Expect(hndl).To(Equal(&handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Deliverable{}}))
+			Expect(enqueued(hndl, labelledForDeliverable())).To(ConsistOf(ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-deliverable", Namespace: "my-ns"}}))
 		})
 
 		Context("but getting the object GVK fails", func() {
@@ -411,6 +534,33 @@ var _ = Describe("Reconciler", func() {
 			})
 		})
 
+		Context("but the reconcile is cancelled while the delivery is realized", func() {
+			var cancel context.CancelFunc
+
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(ctx)
+				rlzr.RealizeCalls(func(context.Context, realizer.ResourceRealizer, *v1alpha1.ClusterDelivery) ([]*unstructured.Unstructured, error) {
+					cancel()
+					return []*unstructured.Unstructured{stampedObject1}, context.Canceled
+				})
+			})
+
+			AfterEach(func() {
+				cancel()
+			})
+
+			It("requeues without recording a failure in the status", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{Requeue: true}))
+
+				Expect(conditionManager.AddPositiveCallCount()).To(Equal(1))
+				Expect(conditionManager.FinalizeCallCount()).To(Equal(0))
+				Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("but the realizer returns an error", func() {
 			Context("of type GetClusterTemplateError", func() {
 				var templateError error
@@ -423,7 +573,12 @@ var _ = Describe("Reconciler", func() {
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.TemplateObjectRetrievalFailureCondition(templateError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.DeliverableResourcesSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason,
+						Message: "unable to get template []: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -452,7 +607,12 @@ var _ = Describe("Reconciler", func() {
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.TemplateStampFailureCondition(stampError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.DeliverableResourcesSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
+						Message: "unable to stamp object for resource [some-name]: some error",
+					}))
 				})
 
 				It("does not return an error", func() {
@@ -483,7 +643,12 @@ var _ = Describe("Reconciler", func() {
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.TemplateRejectedByAPIServerCondition(stampedObjectError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.DeliverableResourcesSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason,
+						Message: "unable to apply object [/]: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -515,7 +680,12 @@ var _ = Describe("Reconciler", func() {
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.TemplateRejectedByAPIServerCondition(stampedObjectError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.DeliverableResourcesSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason,
+						Message: "unable to apply object [a-namespace/a-name]: fantastic error",
+					}))
 				})
 
 				It("handles the error and logs it", func() {
@@ -525,6 +695,105 @@ var _ = Describe("Reconciler", func() {
 					Expect(out).To(Say(`"level":"info"`))
 					Expect(out).To(Say(`"handled error":"unable to apply object \[a-namespace/a-name\]: fantastic error"`))
 				})
+
+				It("does not requeue", func() {
+					result, _ := reconciler.Reconcile(ctx, req)
+					Expect(result).To(Equal(ctrl.Result{}))
+					Expect(dl.Status.ForbiddenRetries).To(BeZero())
+				})
+
+				Context("and forbidden retries are enabled", func() {
+					BeforeEach(func() {
+						reconciler.ForbiddenRetry = controller.ForbiddenRetryOptions{
+							MaxRetries: 2,
+							Backoff:    time.Second,
+						}
+					})
+
+					It("requeues after the backoff and records the retry", func() {
+						result, err := reconciler.Reconcile(ctx, req)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+						Expect(dl.Status.ForbiddenRetries).To(Equal(int64(1)))
+
+						Expect(out).To(Say(`"msg":"stamped object was forbidden, requeueing in case RBAC has not yet propagated".*"retry":1,"max retries":2,"requeue after":1`))
+					})
+
+					Context("and a retry has already been recorded", func() {
+						BeforeEach(func() {
+							dl.Status.ForbiddenRetries = 1
+						})
+
+						It("doubles the backoff", func() {
+							result, err := reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(ctrl.Result{RequeueAfter: 2 * time.Second}))
+							Expect(dl.Status.ForbiddenRetries).To(Equal(int64(2)))
+						})
+					})
+
+					Context("and the retries are exhausted", func() {
+						BeforeEach(func() {
+							dl.Status.ForbiddenRetries = 2
+						})
+
+						It("treats the error as terminal", func() {
+							result, err := reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(ctrl.Result{}))
+							Expect(dl.Status.ForbiddenRetries).To(Equal(int64(2)))
+						})
+					})
+
+					Context("and the apply succeeds once RBAC has propagated", func() {
+						BeforeEach(func() {
+							rlzr.RealizeReturnsOnCall(1, nil, nil)
+						})
+
+						It("stops requeueing and resets the retries", func() {
+							result, err := reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(time.Second))
+							Expect(dl.Status.ForbiddenRetries).To(Equal(int64(1)))
+
+							result, err = reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(ctrl.Result{}))
+							Expect(dl.Status.ForbiddenRetries).To(BeZero())
+						})
+					})
+				})
+			})
+
+			Context("of type StampedKindNotAllowedError", func() {
+				var kindError realizer.StampedKindNotAllowedError
+				BeforeEach(func() {
+					stampedObject := &unstructured.Unstructured{}
+					stampedObject.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Secret"})
+					stampedObject.SetName("my-secret")
+					stampedObject.SetNamespace("my-ns")
+
+					kindError = realizer.StampedKindNotAllowedError{
+						Resource:      &v1alpha1.ClusterDeliveryResource{Name: "some-resource"},
+						StampedObject: stampedObject,
+					}
+					rlzr.RealizeReturns(nil, kindError)
+				})
+
+				It("calls the condition manager to report", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.DeliverableResourcesSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.StampedKindNotAllowedResourcesSubmittedReason,
+						Message: "stamped object [my-ns/my-secret] for resource [some-resource] is of type [secret], which cartographer is not allowed to create",
+					}))
+				})
+
+				It("does not return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				})
 			})
 
 			Context("of type RetrieveOutputError", func() {
@@ -558,7 +827,12 @@ var _ = Describe("Reconciler", func() {
 
 					It("calls the condition manager to report", func() {
 						_, _ = reconciler.Reconcile(ctx, req)
-						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.TemplateStampFailureByObservedGenerationCondition(retrieveError)))
+						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+							Type:    v1alpha1.DeliverableResourcesSubmitted,
+							Status:  metav1.ConditionFalse,
+							Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
+							Message: "Resource [some-resource] cannot satisfy observedCompletion without observedGeneration in object status",
+						}))
 					})
 
 					It("does not return an error", func() {
@@ -584,7 +858,12 @@ var _ = Describe("Reconciler", func() {
 
 					It("calls the condition manager to report", func() {
 						_, _ = reconciler.Reconcile(ctx, req)
-						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.DeploymentConditionNotMetCondition(retrieveError)))
+						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+							Type:    v1alpha1.DeliverableResourcesSubmitted,
+							Status:  metav1.ConditionUnknown,
+							Reason:  v1alpha1.DeploymentConditionNotMetResourcesSubmittedReason,
+							Message: "Resource [some-resource] condition not met: some error",
+						}))
 					})
 
 					It("does not return an error", func() {
@@ -610,7 +889,12 @@ var _ = Describe("Reconciler", func() {
 
 					It("calls the condition manager to report", func() {
 						_, _ = reconciler.Reconcile(ctx, req)
-						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.DeploymentFailedConditionMetCondition(retrieveError)))
+						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+							Type:    v1alpha1.DeliverableResourcesSubmitted,
+							Status:  metav1.ConditionFalse,
+							Reason:  v1alpha1.DeploymentFailedConditionMetResourcesSubmittedReason,
+							Message: "Resource [some-resource] failed condition met: some error",
+						}))
 					})
 
 					It("does not return an error", func() {
@@ -636,7 +920,7 @@ var _ = Describe("Reconciler", func() {
 
 					It("calls the condition manager to report", func() {
 						_, _ = reconciler.Reconcile(ctx, req)
-						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(deliverable.MissingValueAtPathCondition(stampedObject, "this.wont.find.anything")))
+						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.MissingValueAtPathCondition(v1alpha1.DeliverableResourcesSubmitted, stampedObject, "this.wont.find.anything")))
 					})
 
 					It("does not return an error", func() {
@@ -655,6 +939,48 @@ var _ = Describe("Reconciler", func() {
 					})
 				})
 
+				Context("which wraps an OutputEmptyError", func() {
+					BeforeEach(func() {
+						wrappedError = templates.NewOutputEmptyError(".status.url")
+					})
+
+					It("calls the condition manager to report", func() {
+						_, _ = reconciler.Reconcile(ctx, req)
+						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+							Type:    v1alpha1.DeliverableResourcesSubmitted,
+							Status:  metav1.ConditionFalse,
+							Reason:  v1alpha1.OutputEmptyResourcesSubmittedReason,
+							Message: retrieveError.Error(),
+						}))
+					})
+
+					It("does not return an error", func() {
+						_, err := reconciler.Reconcile(ctx, req)
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				Context("which wraps a ReadinessGatesNotSatisfiedError", func() {
+					BeforeEach(func() {
+						wrappedError = templates.ReadinessGatesNotSatisfiedError{Unsatisfied: []string{"Reconciled"}}
+					})
+
+					It("calls the condition manager to report", func() {
+						_, _ = reconciler.Reconcile(ctx, req)
+						Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+							Type:    v1alpha1.DeliverableResourcesSubmitted,
+							Status:  metav1.ConditionUnknown,
+							Reason:  v1alpha1.GatesNotSatisfiedResourcesSubmittedReason,
+							Message: "Resource [some-resource] waiting on readiness gates of the stamped object not satisfied: condition Reconciled",
+						}))
+					})
+
+					It("does not return an error", func() {
+						_, err := reconciler.Reconcile(ctx, req)
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
 				Context("which wraps any other error", func() {
 					BeforeEach(func() {
 						wrappedError = errors.New("some error")
@@ -702,6 +1028,24 @@ var _ = Describe("Reconciler", func() {
 			})
 		})
 
+		Context("but the watch on the stamped kind is pending", func() {
+			BeforeEach(func() {
+				dynamicTracker.WatchReturns(tracker.WatchPendingError{
+					GroupVersionKind: schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"},
+					RetryAfter:       4 * time.Second,
+					Err:              errors.New("no matches for kind"),
+				})
+			})
+
+			It("requeues after the tracker's backoff without an error", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{RequeueAfter: 4 * time.Second}))
+				Expect(out).To(Say(`"msg":"watch on object pending"`))
+			})
+		})
+
 		Context("but the watcher returns an error", func() {
 			BeforeEach(func() {
 				dynamicTracker.WatchReturns(errors.New("could not watch"))
@@ -893,3 +1237,30 @@ var _ = Describe("Reconciler", func() {
 		})
 	})
 })
+
+// labelledForDeliverable is an object stamped for the deliverable into another namespace
+func labelledForDeliverable() client.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace("target-ns")
+	obj.SetName("stamped")
+	obj.SetLabels(map[string]string{
+		"carto.run/deliverable-name":      "my-deliverable",
+		"carto.run/deliverable-namespace": "my-ns",
+	})
+	return obj
+}
+
+func enqueued(hndl handler.EventHandler, obj client.Object) []interface{} {
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	hndl.Create(event.CreateEvent{Object: obj}, queue)
+
+	var requests []interface{}
+	for queue.Len() > 0 {
+		request, _ := queue.Get()
+		requests = append(requests, request)
+		queue.Done(request)
+	}
+	return requests
+}
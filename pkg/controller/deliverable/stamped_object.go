@@ -0,0 +1,37 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverable
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// StampedObjectToDeliverableRequests maps an object stamped for a deliverable to that
+// deliverable, read from the labels the realizer sets on every stamped object. Unlike an
+// owner reference the labels also identify the deliverable of an object stamped into a
+// delivery resource's target namespace.
+func StampedObjectToDeliverableRequests(obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, namespace := labels["carto.run/deliverable-name"], labels["carto.run/deliverable-namespace"]
+	if name == "" || namespace == "" {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}},
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverable_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/controller/deliverable"
+)
+
+var _ = Describe("StampedObjectToDeliverableRequests", func() {
+	var obj *unstructured.Unstructured
+
+	BeforeEach(func() {
+		obj = &unstructured.Unstructured{}
+		obj.SetNamespace("target-ns")
+		obj.SetName("my-thing")
+	})
+
+	It("maps an object stamped into another namespace to its deliverable", func() {
+		obj.SetLabels(map[string]string{
+			"carto.run/deliverable-name":      "my-deliverable",
+			"carto.run/deliverable-namespace": "my-ns",
+		})
+
+		Expect(deliverable.StampedObjectToDeliverableRequests(obj)).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "my-deliverable", Namespace: "my-ns"}},
+		}))
+	})
+
+	It("maps an object without the deliverable labels to nothing", func() {
+		obj.SetLabels(map[string]string{"carto.run/deliverable-name": "my-deliverable"})
+
+		Expect(deliverable.StampedObjectToDeliverableRequests(obj)).To(BeEmpty())
+	})
+})
@@ -64,15 +64,16 @@ func (r *Reconciler) reconcileDelivery(ctx context.Context, delivery *v1alpha1.C
 	var resourcesNotFound []string
 
 	for _, resource := range delivery.Spec.Resources {
-		template, err := r.Repo.GetDeliveryClusterTemplate(ctx, resource.TemplateRef)
+		_, err := r.Repo.GetDeliveryClusterTemplate(ctx, resource.TemplateRef)
+		if repository.IsTemplateNotFound(err) {
+			log.Info("delivery cluster template does not exist", "template", resource.TemplateRef)
+			resourcesNotFound = append(resourcesNotFound, resource.Name)
+			continue
+		}
 		if err != nil {
 			log.Error(err, "failed to get delivery cluster template", "template", resource.TemplateRef)
 			return controller.NewUnhandledError(fmt.Errorf("failed to get delivery cluster template: %w", err))
 		}
-		if template == nil {
-			log.Info("delivery cluster template does not exist", "template", resource.TemplateRef)
-			resourcesNotFound = append(resourcesNotFound, resource.Name)
-		}
 	}
 
 	if len(resourcesNotFound) > 0 {
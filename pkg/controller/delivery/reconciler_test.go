@@ -30,6 +30,7 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/delivery"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 )
 
@@ -166,7 +167,7 @@ var _ = Describe("delivery reconciler", func() {
 
 		Context("cannot find cluster template", func() {
 			BeforeEach(func() {
-				repo.GetDeliveryClusterTemplateReturnsOnCall(0, nil, nil)
+				repo.GetDeliveryClusterTemplateReturnsOnCall(0, nil, repository.TemplateNotFoundError{Kind: "ClusterTemplate", Name: "my-template"})
 			})
 
 			It("adds a positive templates NOT found condition", func() {
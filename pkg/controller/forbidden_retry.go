@@ -0,0 +1,43 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"time"
+)
+
+// ForbiddenRetryOptions bounds how often a reconciler requeues an owner whose stamped
+// object was rejected as Forbidden. RBAC changes take a moment to reach the API server's
+// authorizer, so a Forbidden right after a RoleBinding is created is often transient.
+// A MaxRetries of zero disables retrying.
+type ForbiddenRetryOptions struct {
+	MaxRetries int64
+	Backoff    time.Duration
+}
+
+// Next returns the retry count to record on the owner's status and how long to wait before
+// requeueing, given the count recorded so far and whether the latest apply was Forbidden.
+// The delay doubles with every retry; a zero delay means the reconciler should not requeue.
+func (o ForbiddenRetryOptions) Next(retries int64, forbidden bool) (int64, time.Duration) {
+	if !forbidden {
+		return 0, 0
+	}
+
+	if retries >= o.MaxRetries {
+		return retries, 0
+	}
+
+	return retries + 1, o.Backoff << retries
+}
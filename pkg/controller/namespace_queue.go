@@ -0,0 +1,205 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+	pkgcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewNamespaceFairQueue returns a rate limited work queue that keeps the requests of each
+// namespace apart and hands them out round-robin, one namespace at a time, so a namespace
+// with many requests queued does not hold back the requests of the others. Within a
+// namespace requests are handed out in the order they were added.
+func NewNamespaceFairQueue(rateLimiter workqueue.RateLimiter, name string) workqueue.RateLimitingInterface {
+	return &rateLimitingQueue{
+		DelayingInterface: workqueue.NewDelayingQueueWithCustomQueue(newNamespaceFairQueue(), name),
+		rateLimiter:       rateLimiter,
+	}
+}
+
+// UseQueue makes the controller, which must not have been started yet, take its requests from
+// the queue made by makeQueue instead of from its default queue. controller-runtime has no
+// option for the queue, so the controller's MakeQueue field is set through reflection. A
+// controller-runtime whose controller has no such field is reported as an error, for the
+// caller to fail at startup rather than silently run without the queue.
+func UseQueue(ctrl pkgcontroller.Controller, makeQueue func() workqueue.RateLimitingInterface) error {
+	value := reflect.ValueOf(ctrl)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	var field reflect.Value
+	if value.Kind() == reflect.Struct {
+		field = value.FieldByName("MakeQueue")
+	}
+	if !field.IsValid() || !field.CanSet() || field.Type() != reflect.TypeOf(makeQueue) {
+		return fmt.Errorf("controller of type [%T] has no settable MakeQueue field of type [%T], the controller-runtime version in use does not support replacing the queue", ctrl, makeQueue)
+	}
+
+	field.Set(reflect.ValueOf(makeQueue))
+	return nil
+}
+
+type rateLimitingQueue struct {
+	workqueue.DelayingInterface
+	rateLimiter workqueue.RateLimiter
+}
+
+func (q *rateLimitingQueue) AddRateLimited(item interface{}) {
+	q.DelayingInterface.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *rateLimitingQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+func (q *rateLimitingQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+// namespaceFairQueue follows the contract of the client-go work queue: an item is only queued
+// once however often it is added, and an item added while it is being processed is queued
+// again once it is done.
+type namespaceFairQueue struct {
+	cond *sync.Cond
+
+	// queues holds the items waiting in each namespace, namespaces the namespaces with items
+	// waiting in the order they are next served.
+	queues     map[string][]interface{}
+	namespaces []string
+
+	dirty      map[interface{}]struct{}
+	processing map[interface{}]struct{}
+
+	shuttingDown bool
+}
+
+func newNamespaceFairQueue() *namespaceFairQueue {
+	return &namespaceFairQueue{
+		cond:       sync.NewCond(&sync.Mutex{}),
+		queues:     map[string][]interface{}{},
+		dirty:      map[interface{}]struct{}{},
+		processing: map[interface{}]struct{}{},
+	}
+}
+
+func (q *namespaceFairQueue) Add(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.shuttingDown {
+		return
+	}
+	if _, ok := q.dirty[item]; ok {
+		return
+	}
+
+	q.dirty[item] = struct{}{}
+	if _, ok := q.processing[item]; ok {
+		return
+	}
+
+	q.push(item)
+	q.cond.Signal()
+}
+
+func (q *namespaceFairQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	length := 0
+	for _, items := range q.queues {
+		length += len(items)
+	}
+	return length
+}
+
+func (q *namespaceFairQueue) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for len(q.namespaces) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.namespaces) == 0 {
+		return nil, true
+	}
+
+	namespace := q.namespaces[0]
+	q.namespaces = q.namespaces[1:]
+
+	items := q.queues[namespace]
+	item := items[0]
+	if len(items) > 1 {
+		q.queues[namespace] = items[1:]
+		q.namespaces = append(q.namespaces, namespace)
+	} else {
+		delete(q.queues, namespace)
+	}
+
+	q.processing[item] = struct{}{}
+	delete(q.dirty, item)
+
+	return item, false
+}
+
+func (q *namespaceFairQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	delete(q.processing, item)
+	if _, ok := q.dirty[item]; ok {
+		q.push(item)
+		q.cond.Signal()
+	}
+}
+
+func (q *namespaceFairQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *namespaceFairQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.shuttingDown
+}
+
+func (q *namespaceFairQueue) push(item interface{}) {
+	namespace := itemNamespace(item)
+	if _, ok := q.queues[namespace]; !ok {
+		q.namespaces = append(q.namespaces, namespace)
+	}
+	q.queues[namespace] = append(q.queues[namespace], item)
+}
+
+// itemNamespace is the namespace of a reconcile request, items of any other type share the
+// empty namespace.
+func itemNamespace(item interface{}) string {
+	if request, ok := item.(reconcile.Request); ok {
+		return request.Namespace
+	}
+	return ""
+}
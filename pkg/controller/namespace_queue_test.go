@@ -0,0 +1,165 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	pkgcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker/trackerfakes"
+)
+
+var _ = Describe("NamespaceFairQueue", func() {
+	var queue workqueue.RateLimitingInterface
+
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	get := func() reconcile.Request {
+		item, shutdown := queue.Get()
+		Expect(shutdown).To(BeFalse())
+		queue.Done(item)
+		return item.(reconcile.Request)
+	}
+
+	BeforeEach(func() {
+		queue = controller.NewNamespaceFairQueue(workqueue.DefaultControllerRateLimiter(), "test")
+	})
+
+	AfterEach(func() {
+		queue.ShutDown()
+	})
+
+	It("does not let a namespace with many requests hold back another namespace's request", func() {
+		for i := 0; i < 100; i++ {
+			queue.Add(request("noisy", fmt.Sprintf("runnable-%d", i)))
+		}
+		queue.Add(request("quiet", "runnable"))
+
+		Expect(get()).To(Equal(request("noisy", "runnable-0")))
+		Expect(get()).To(Equal(request("quiet", "runnable")))
+		Expect(get()).To(Equal(request("noisy", "runnable-1")))
+		Expect(queue.Len()).To(Equal(98))
+	})
+
+	It("hands out the namespaces in turn and the requests of a namespace in order", func() {
+		queue.Add(request("a", "1"))
+		queue.Add(request("a", "2"))
+		queue.Add(request("b", "1"))
+		queue.Add(request("c", "1"))
+		queue.Add(request("b", "2"))
+
+		var order []reconcile.Request
+		for i := 0; i < 5; i++ {
+			order = append(order, get())
+		}
+		Expect(order).To(Equal([]reconcile.Request{
+			request("a", "1"), request("b", "1"), request("c", "1"), request("a", "2"), request("b", "2"),
+		}))
+	})
+
+	It("queues a request added more than once only once", func() {
+		queue.Add(request("a", "1"))
+		queue.Add(request("a", "1"))
+
+		Expect(queue.Len()).To(Equal(1))
+	})
+
+	It("queues a request added while it is processed again once it is done", func() {
+		queue.Add(request("a", "1"))
+		item, _ := queue.Get()
+
+		queue.Add(request("a", "1"))
+		Expect(queue.Len()).To(Equal(0))
+
+		queue.Done(item)
+		Expect(queue.Len()).To(Equal(1))
+	})
+
+	It("adds a request after a delay", func() {
+		queue.AddAfter(request("a", "1"), 10*time.Millisecond)
+		Expect(queue.Len()).To(Equal(0))
+
+		Eventually(queue.Len).Should(Equal(1))
+	})
+
+	It("stops a waiting Get when shut down", func() {
+		shutdown := make(chan bool)
+		go func() {
+			_, isShutdown := queue.Get()
+			shutdown <- isShutdown
+		}()
+
+		queue.ShutDown()
+		Eventually(shutdown).Should(Receive(BeTrue()))
+	})
+})
+
+var _ = Describe("UseQueue", func() {
+	It("makes the controller take its requests from the given queue", func() {
+		mgr, err := manager.New(&rest.Config{Host: "http://127.0.0.1:1"}, manager.Options{
+			MetricsBindAddress: "0",
+			MapperProvider: func(*rest.Config) (meta.RESTMapper, error) {
+				return meta.NewDefaultRESTMapper(nil), nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctrl, err := pkgcontroller.NewUnmanaged("test", mgr, pkgcontroller.Options{
+			Reconciler: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, nil
+			}),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var made int32
+		err = controller.UseQueue(ctrl, func() workqueue.RateLimitingInterface {
+			atomic.StoreInt32(&made, 1)
+			return controller.NewNamespaceFairQueue(workqueue.DefaultControllerRateLimiter(), "test")
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			_ = ctrl.Start(ctx)
+		}()
+
+		Eventually(func() int32 { return atomic.LoadInt32(&made) }).Should(Equal(int32(1)))
+	})
+
+	It("returns an error for a controller without a queue to replace", func() {
+		err := controller.UseQueue(&trackerfakes.FakeController{}, func() workqueue.RateLimitingInterface {
+			return controller.NewNamespaceFairQueue(workqueue.DefaultControllerRateLimiter(), "test")
+		})
+		Expect(err).To(MatchError(ContainSubstring("controller of type [*trackerfakes.FakeController] has no settable MakeQueue field")))
+		Expect(err).To(MatchError(ContainSubstring("does not support replacing the queue")))
+	})
+})
@@ -0,0 +1,114 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// ReconcileCompleteMessage is the message of the one entry logged at the end of every reconcile.
+const ReconcileCompleteMessage = "reconcile complete"
+
+// ReconcileOutcome is reported under the outcome key of the reconcile complete entry.
+type ReconcileOutcome string
+
+const (
+	// ReconcileOutcomeReady is the outcome of a reconcile that left the object Ready.
+	ReconcileOutcomeReady ReconcileOutcome = "Ready"
+	// ReconcileOutcomeNotReady is the outcome of a reconcile that left the object not Ready.
+	ReconcileOutcomeNotReady ReconcileOutcome = "NotReady"
+	// ReconcileOutcomeUnknown is the outcome of a reconcile that left the readiness of the
+	// object unknown.
+	ReconcileOutcomeUnknown ReconcileOutcome = "Unknown"
+	// ReconcileOutcomeError is the outcome of a reconcile that returned an error, to be retried.
+	ReconcileOutcomeError ReconcileOutcome = "Error"
+	// ReconcileOutcomeNotFound is the outcome of a reconcile of an object that no longer exists.
+	ReconcileOutcomeNotFound ReconcileOutcome = "NotFound"
+	// ReconcileOutcomeCancelled is the outcome of a reconcile that stopped early because its
+	// context was cancelled, as it is while the manager shuts down.
+	ReconcileOutcomeCancelled ReconcileOutcome = "Cancelled"
+)
+
+// ReconcileReport is what a reconciler knows of a reconcile once it is over.
+type ReconcileReport struct {
+	Object types.NamespacedName
+	// Conditions of the object, nil when the object was not found.
+	Conditions    []metav1.Condition
+	Found         bool
+	Cancelled     bool
+	StampedObject *v1alpha1.ObjectReference
+	Duration      time.Duration
+	Err           error
+}
+
+// LogReconcileComplete logs the outcome of a reconcile with a stable set of keys, each of them
+// present even when empty, for log pipelines to parse:
+//
+//	object         namespace/name of the reconciled object
+//	outcome        one of the ReconcileOutcome values
+//	reason         reason of the Ready condition of the object
+//	durationMs     how long the reconcile took, in milliseconds
+//	stampedObject  kind/namespace/name of the object stamped for it
+func LogReconcileComplete(log logr.Logger, report ReconcileReport) {
+	reason := ""
+	if ready := meta.FindStatusCondition(report.Conditions, "Ready"); ready != nil {
+		reason = ready.Reason
+	}
+
+	stampedObject := ""
+	if report.StampedObject != nil {
+		stampedObject = fmt.Sprintf("%s/%s/%s", report.StampedObject.Kind, report.StampedObject.Namespace, report.StampedObject.Name)
+	}
+
+	log.Info(ReconcileCompleteMessage,
+		"object", report.Object.String(),
+		"outcome", string(report.outcome()),
+		"reason", reason,
+		"durationMs", report.Duration.Milliseconds(),
+		"stampedObject", stampedObject,
+	)
+}
+
+func (report ReconcileReport) outcome() ReconcileOutcome {
+	if report.Cancelled {
+		return ReconcileOutcomeCancelled
+	}
+	if report.Err != nil {
+		return ReconcileOutcomeError
+	}
+	if !report.Found {
+		return ReconcileOutcomeNotFound
+	}
+
+	ready := meta.FindStatusCondition(report.Conditions, "Ready")
+	switch {
+	case ready == nil:
+		return ReconcileOutcomeUnknown
+	case ready.Status == metav1.ConditionTrue:
+		return ReconcileOutcomeReady
+	case ready.Status == metav1.ConditionFalse:
+		return ReconcileOutcomeNotReady
+	default:
+		return ReconcileOutcomeUnknown
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+)
+
+var _ = Describe("LogReconcileComplete", func() {
+	var (
+		out    *Buffer
+		report controller.ReconcileReport
+	)
+
+	entry := func() map[string]interface{} {
+		controller.LogReconcileComplete(zap.New(zap.WriteTo(out)), report)
+
+		logged := map[string]interface{}{}
+		Expect(json.Unmarshal(out.Contents(), &logged)).To(Succeed())
+		Expect(logged).To(HaveKeyWithValue("msg", controller.ReconcileCompleteMessage))
+		return logged
+	}
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		report = controller.ReconcileReport{
+			Object: types.NamespacedName{Namespace: "my-ns", Name: "my-runnable"},
+			Found:  true,
+			Conditions: []metav1.Condition{
+				{Type: "RunTemplateReady", Status: metav1.ConditionTrue, Reason: "Ready"},
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"},
+			},
+			StampedObject: &v1alpha1.ObjectReference{Kind: "TaskRun", Namespace: "my-ns", Name: "my-run-abcde"},
+			Duration:      1500 * time.Microsecond,
+		}
+	})
+
+	It("logs the outcome of the reconcile with the stable set of keys", func() {
+		logged := entry()
+		Expect(logged).To(HaveKeyWithValue("object", "my-ns/my-runnable"))
+		Expect(logged).To(HaveKeyWithValue("outcome", "Ready"))
+		Expect(logged).To(HaveKeyWithValue("reason", "Ready"))
+		Expect(logged).To(HaveKeyWithValue("durationMs", BeNumerically("==", 1)))
+		Expect(logged).To(HaveKeyWithValue("stampedObject", "TaskRun/my-ns/my-run-abcde"))
+	})
+
+	Context("the object is not ready", func() {
+		BeforeEach(func() {
+			report.Conditions[1] = metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "TemplateRejectedByAPIServer"}
+		})
+
+		It("reports the reason of the Ready condition", func() {
+			logged := entry()
+			Expect(logged).To(HaveKeyWithValue("outcome", "NotReady"))
+			Expect(logged).To(HaveKeyWithValue("reason", "TemplateRejectedByAPIServer"))
+		})
+	})
+
+	Context("the object has no Ready condition", func() {
+		BeforeEach(func() {
+			report.Conditions = nil
+		})
+
+		It("reports an unknown outcome", func() {
+			Expect(entry()).To(HaveKeyWithValue("outcome", "Unknown"))
+		})
+	})
+
+	Context("the reconcile returned an error", func() {
+		BeforeEach(func() {
+			report.Err = errors.New("some error")
+		})
+
+		It("reports an error outcome", func() {
+			Expect(entry()).To(HaveKeyWithValue("outcome", "Error"))
+		})
+	})
+
+	Context("the reconcile was cancelled", func() {
+		BeforeEach(func() {
+			report.Cancelled = true
+			report.Err = errors.New("some error")
+		})
+
+		It("reports a cancelled outcome", func() {
+			Expect(entry()).To(HaveKeyWithValue("outcome", "Cancelled"))
+		})
+	})
+
+	Context("the object no longer exists", func() {
+		BeforeEach(func() {
+			report = controller.ReconcileReport{Object: types.NamespacedName{Namespace: "my-ns", Name: "my-runnable"}}
+		})
+
+		It("keeps every key, empty", func() {
+			logged := entry()
+			Expect(logged).To(HaveKeyWithValue("outcome", "NotFound"))
+			Expect(logged).To(HaveKeyWithValue("reason", ""))
+			Expect(logged).To(HaveKeyWithValue("durationMs", BeNumerically("==", 0)))
+			Expect(logged).To(HaveKeyWithValue("stampedObject", ""))
+		})
+	})
+})
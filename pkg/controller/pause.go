@@ -0,0 +1,97 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// PauseKey is the key of the pause config map that, set to "true", pauses every reconciler.
+const PauseKey = "paused"
+
+// Pause reads whether cartographer is paused for cluster maintenance from a config map, so that
+// operators can stop all activity without scaling the controller down and losing its leader
+// election and watches. A Pause without a Name never pauses.
+type Pause struct {
+	Reader    client.Reader
+	Namespace string
+	Name      string
+}
+
+// ParsePauseConfigMap parses a namespace/name reference to the pause config map, an empty
+// reference disabling the pause.
+func ParsePauseConfigMap(reference string) (Pause, error) {
+	if reference == "" {
+		return Pause{}, nil
+	}
+
+	parts := strings.Split(reference, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Pause{}, fmt.Errorf("pause config map [%s] must be namespace/name", reference)
+	}
+	return Pause{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// Enabled reports whether a pause config map is configured.
+func (p Pause) Enabled() bool {
+	return p.Name != ""
+}
+
+// Matches reports whether the object is the pause config map.
+func (p Pause) Matches(object client.Object) bool {
+	return p.Enabled() && object.GetNamespace() == p.Namespace && object.GetName() == p.Name
+}
+
+// Paused reports whether the pause config map sets paused to "true". A config map that does
+// not exist does not pause.
+func (p Pause) Paused(ctx context.Context) (bool, error) {
+	if !p.Enabled() {
+		return false, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := p.Reader.Get(ctx, client.ObjectKey{Namespace: p.Namespace, Name: p.Name}, configMap)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get pause config map [%s]: %w", p, err)
+	}
+
+	return configMap.Data[PauseKey] == "true", nil
+}
+
+func (p Pause) String() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// PausedCondition reports that the reconciler took no action as cartographer is paused.
+func (p Pause) PausedCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.ControllerPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.ConfigMapControllerPausedReason,
+		Message: fmt.Sprintf("cartographer is paused by key [%s] of config map [%s]", PauseKey, p),
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+)
+
+var _ = Describe("Pause", func() {
+	Describe("ParsePauseConfigMap", func() {
+		It("parses a namespace/name reference", func() {
+			pause, err := controller.ParsePauseConfigMap("cartographer-system/cartographer-pause")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pause).To(Equal(controller.Pause{Namespace: "cartographer-system", Name: "cartographer-pause"}))
+			Expect(pause.Enabled()).To(BeTrue())
+		})
+
+		It("disables the pause for an empty reference", func() {
+			pause, err := controller.ParsePauseConfigMap("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pause.Enabled()).To(BeFalse())
+		})
+
+		It("rejects a reference without a namespace", func() {
+			_, err := controller.ParsePauseConfigMap("cartographer-pause")
+			Expect(err).To(MatchError("pause config map [cartographer-pause] must be namespace/name"))
+		})
+	})
+
+	Describe("Paused", func() {
+		var (
+			pause     controller.Pause
+			configMap *corev1.ConfigMap
+		)
+
+		BeforeEach(func() {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "cartographer-system", Name: "cartographer-pause"},
+				Data:       map[string]string{controller.PauseKey: "true"},
+			}
+			pause = controller.Pause{Namespace: "cartographer-system", Name: "cartographer-pause"}
+		})
+
+		It("is paused when the config map sets paused to true", func() {
+			pause.Reader = fake.NewClientBuilder().WithObjects(configMap).Build()
+			Expect(pause.Paused(context.Background())).To(BeTrue())
+		})
+
+		It("is not paused when the config map sets paused to anything else", func() {
+			configMap.Data[controller.PauseKey] = "false"
+			pause.Reader = fake.NewClientBuilder().WithObjects(configMap).Build()
+			Expect(pause.Paused(context.Background())).To(BeFalse())
+		})
+
+		It("is not paused when the config map does not exist", func() {
+			pause.Reader = fake.NewClientBuilder().Build()
+			Expect(pause.Paused(context.Background())).To(BeFalse())
+		})
+
+		It("is never paused without a config map", func() {
+			Expect(controller.Pause{}.Paused(context.Background())).To(BeFalse())
+		})
+
+		It("returns an error when the config map cannot be read", func() {
+			pause.Reader = fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			_, err := pause.Paused(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("get pause config map [cartographer-system/cartographer-pause]")))
+		})
+	})
+
+	It("reports the paused condition with the config map", func() {
+		pause := controller.Pause{Namespace: "cartographer-system", Name: "cartographer-pause"}
+		Expect(pause.PausedCondition()).To(Equal(metav1.Condition{
+			Type:    v1alpha1.ControllerPaused,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.ConfigMapControllerPausedReason,
+			Message: "cartographer is paused by key [paused] of config map [cartographer-system/cartographer-pause]",
+		}))
+	})
+})
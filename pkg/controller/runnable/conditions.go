@@ -16,12 +16,12 @@ package runnable
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
-	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
 // -- ClusterRunTemplate conditions
@@ -34,85 +34,91 @@ func RunTemplateReadyCondition() metav1.Condition {
 	}
 }
 
-func RunTemplateMissingCondition(err error) metav1.Condition {
+func UnknownErrorCondition(err error) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.RunTemplateReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.NotFoundRunTemplateReason,
+		Reason:  v1alpha1.UnknownErrorReason,
 		Message: err.Error(),
 	}
 }
 
-func StampedObjectRejectedByAPIServerCondition(err error) metav1.Condition {
+func ServiceAccountSecretNotFoundCondition(err error) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.RunTemplateReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.StampedObjectRejectedByAPIServerRunTemplateReason,
+		Reason:  v1alpha1.ServiceAccountSecretErrorResourcesSubmittedReason,
 		Message: err.Error(),
 	}
 }
 
-func OutputPathNotSatisfiedCondition(obj *unstructured.Unstructured, errMsg string) metav1.Condition {
-	var namespaceMsg string
-	if obj.GetNamespace() != "" {
-		namespaceMsg = fmt.Sprintf(" in namespace [%s]", obj.GetNamespace())
-	}
-
-	name := obj.GetName()
-	if name == "" {
-		name = obj.GetGenerateName()
-	}
-
+func ClientBuilderErrorCondition(err error) metav1.Condition {
 	return metav1.Condition{
-		Type:   v1alpha1.RunTemplateReady,
-		Status: metav1.ConditionFalse,
-		Reason: v1alpha1.OutputPathNotSatisfiedRunTemplateReason,
-		Message: fmt.Sprintf("Waiting to read value from resource [%s/%s]%s: %s",
-			utils.GetFullyQualifiedType(obj), name, namespaceMsg, errMsg),
+		Type:    v1alpha1.RunTemplateReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ClientBuilderErrorResourcesSubmittedReason,
+		Message: err.Error(),
 	}
 }
 
-func FailedToListCreatedObjectsCondition(err error) metav1.Condition {
+func InputsImmutableCondition() metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.RunTemplateReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.FailedToListCreatedObjectsReason,
-		Message: err.Error(),
+		Reason:  v1alpha1.InputsImmutableRunTemplateReason,
+		Message: "inputs changed after the first successful run and spec.immutableInputs is set",
 	}
 }
 
-func TemplateStampFailureCondition(err error) metav1.Condition {
+func AwaitingOutputsCondition(err error, gracePeriodEnds time.Time) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.RunTemplateReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateStampFailureRunTemplateReason,
-		Message: err.Error(),
+		Status:  metav1.ConditionUnknown,
+		Reason:  v1alpha1.AwaitingOutputsRunTemplateReason,
+		Message: fmt.Sprintf("waiting until %s for the outputs of the newly stamped object: %s", gracePeriodEnds.UTC().Format(time.RFC3339), err.Error()),
 	}
 }
 
-func UnknownErrorCondition(err error) metav1.Condition {
+// -- StampedObjectMissing conditions
+
+func StampedObjectMissingCondition(ref *v1alpha1.ObjectReference) metav1.Condition {
 	return metav1.Condition{
-		Type:    v1alpha1.RunTemplateReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.UnknownErrorReason,
-		Message: err.Error(),
+		Type:    v1alpha1.RunnableStampedObjectMissing,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.StampedObjectDeletedStampedObjectMissingReason,
+		Message: fmt.Sprintf("stamped object [%s/%s] of kind [%s] no longer exists and could not be stamped again", ref.Namespace, ref.Name, ref.Kind),
 	}
 }
 
-func ServiceAccountSecretNotFoundCondition(err error) metav1.Condition {
+// -- OutputTruncated conditions
+
+func OutputTruncatedCondition(names []string) metav1.Condition {
 	return metav1.Condition{
-		Type:    v1alpha1.RunTemplateReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.ServiceAccountSecretErrorResourcesSubmittedReason,
-		Message: err.Error(),
+		Type:    v1alpha1.RunnableOutputTruncated,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.SizeLimitExceededOutputTruncatedReason,
+		Message: fmt.Sprintf("outputs [%s] exceed the size limit and were truncated, check the output paths of the run template", strings.Join(names, ", ")),
 	}
 }
 
-func ClientBuilderErrorCondition(err error) metav1.Condition {
+// -- ReconcileFailedPermanently conditions
+
+func ReconcileFailedPermanentlyCondition(class string, attempts int64, err error) metav1.Condition {
 	return metav1.Condition{
-		Type:    v1alpha1.RunTemplateReady,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.ClientBuilderErrorResourcesSubmittedReason,
-		Message: err.Error(),
+		Type:    v1alpha1.RunnableReconcileFailedPermanently,
+		Status:  metav1.ConditionTrue,
+		Reason:  class,
+		Message: fmt.Sprintf("gave up after %d attempts, change the runnable to try again: %s", attempts, err.Error()),
+	}
+}
+
+// -- RequeueHintInvalid conditions
+
+func RequeueHintInvalidCondition(path string, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.RunnableRequeueHintInvalid,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.MalformedRequeueHintInvalidReason,
+		Message: fmt.Sprintf("requeue hint at [%s] of the stamped object is malformed, polling at the default interval: %s", path, err.Error()),
 	}
 }
@@ -0,0 +1,33 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// hashInputs is stable across reconciles: json.Marshal writes map keys in sorted order
+func hashInputs(inputs map[string]apiextensionsv1.JSON) (string, error) {
+	serialized, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("marshal inputs: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(serialized)), nil
+}
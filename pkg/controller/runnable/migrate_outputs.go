@@ -0,0 +1,82 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+// migrateOutputs brings the outputs to be recorded in line with the outputs the run template
+// declares, returning them with the generation of the run template they were produced by.
+//
+// Until a run of a changed run template completes, the realizer hands back the outputs
+// recorded under an earlier generation of the template, which may name outputs the template
+// no longer declares. Those outputs are extracted again from the stamped object with the
+// current template. When that is not possible, the outputs the template no longer declares
+// are cleared and the others are kept.
+func (r *Reconciler) migrateOutputs(ctx context.Context, runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate, runnableRepo repository.Repository, stampedRef *v1alpha1.ObjectReference, outputs map[string]apiextensionsv1.JSON) (map[string]apiextensionsv1.JSON, int64) {
+	generation := runnable.Status.OutputsTemplateGeneration
+	if runTemplate == nil || len(outputs) == 0 {
+		return outputs, generation
+	}
+	if runTemplate.Generation == generation || sameOutputNames(outputs, runTemplate) {
+		return outputs, runTemplate.Generation
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+	log.Info("outputs declared by the run template changed, migrating outputs",
+		"recorded generation", generation, "run template generation", runTemplate.Generation)
+
+	if stampedRef != nil {
+		stampedObject, err := r.readStampedObjectLive(ctx, stampedRef)
+		if err != nil {
+			log.Info("failed to read stamped object to migrate outputs", "error", err.Error())
+		} else if stampedObject != nil {
+			extracted, err := extractOutputs(ctx, runnable, runTemplate, runnableRepo, stampedObject)
+			if err == nil && sameOutputNames(extracted, runTemplate) {
+				return extracted, runTemplate.Generation
+			}
+		}
+	}
+
+	declared := map[string]apiextensionsv1.JSON{}
+	for name, value := range outputs {
+		if _, ok := runTemplate.Spec.Outputs[name]; ok {
+			declared[name] = value
+		}
+	}
+	if len(declared) == 0 {
+		declared = nil
+	}
+	return declared, runTemplate.Generation
+}
+
+func sameOutputNames(outputs map[string]apiextensionsv1.JSON, runTemplate *v1alpha1.ClusterRunTemplate) bool {
+	if len(outputs) != len(runTemplate.Spec.Outputs) {
+		return false
+	}
+	for name := range runTemplate.Spec.Outputs {
+		if _, ok := outputs[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"encoding/json"
+	"sort"
+	"unicode/utf8"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// TruncatedOutputMarker ends the value of an output that was truncated to fit the output limits.
+const TruncatedOutputMarker = "...(truncated)"
+
+// OutputLimits caps the size of the outputs a runnable records in its status, so that an output
+// path capturing a whole object cannot bloat it past what the api server will store. Sizes are
+// of the json of the outputs, zero is unlimited.
+type OutputLimits struct {
+	// MaxBytes caps each output.
+	MaxBytes int
+	// MaxTotalBytes caps all outputs together. Outputs are counted in name order, so those
+	// sorting last are the ones truncated to fit.
+	MaxTotalBytes int
+}
+
+// truncate returns the outputs within the limits and the names of the outputs it truncated. A
+// truncated output is replaced by a string of the start of its json followed by the
+// TruncatedOutputMarker.
+func (l OutputLimits) truncate(outputs map[string]apiextensionsv1.JSON) (map[string]apiextensionsv1.JSON, []string) {
+	if l.MaxBytes <= 0 && l.MaxTotalBytes <= 0 {
+		return outputs, nil
+	}
+
+	var names []string
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	limited := make(map[string]apiextensionsv1.JSON, len(outputs))
+	var truncated []string
+	remaining := l.MaxTotalBytes
+	for _, name := range names {
+		output := outputs[name]
+
+		limit := l.MaxBytes
+		if l.MaxTotalBytes > 0 && (limit <= 0 || remaining < limit) {
+			limit = remaining
+		}
+		if limit < 0 {
+			limit = 0
+		}
+
+		if len(output.Raw) > limit {
+			output = apiextensionsv1.JSON{Raw: truncatedOutput(output.Raw, limit)}
+			truncated = append(truncated, name)
+		}
+
+		limited[name] = output
+		remaining -= len(output.Raw)
+	}
+
+	return limited, truncated
+}
+
+// truncatedOutput is a json string of the start of raw and the marker, no longer than limit
+// unless even the marker alone does not fit.
+func truncatedOutput(raw []byte, limit int) []byte {
+	prefix := string(raw)
+	for {
+		value, _ := json.Marshal(prefix + TruncatedOutputMarker)
+		excess := len(value) - limit
+		if excess <= 0 || prefix == "" {
+			return value
+		}
+
+		if excess > len(prefix) {
+			excess = len(prefix)
+		}
+		prefix = prefix[:len(prefix)-excess]
+		for len(prefix) > 0 && !utf8.ValidString(prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+}
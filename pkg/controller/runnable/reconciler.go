@@ -18,13 +18,19 @@ package runnable
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -34,6 +40,7 @@ import (
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 )
 
@@ -46,9 +53,23 @@ type Reconciler struct {
 	RepositoryBuilder       repository.RepositoryBuilder
 	ClientBuilder           realizerclient.ClientBuilder
 	RunnableCache           repository.RepoCache
+	ForbiddenRetry          controller.ForbiddenRetryOptions
+	// TransientErrorBackoff is how long to wait before requeueing a runnable that could not
+	// be read because of a transient API server error. Zero leaves the requeue to the rate limiter.
+	TransientErrorBackoff time.Duration
+	// OutputLimits caps the size of the outputs recorded in the runnable's status.
+	OutputLimits OutputLimits
+	// Clock defaults to the real clock.
+	Clock clock.PassiveClock
+	// Pause leaves the runnable alone while cartographer is paused for maintenance.
+	Pause controller.Pause
+	// MaxFailedAttempts is how many times in a row a runnable may fail with the same class
+	// of retried error before it is reported ReconcileFailedPermanently and no longer
+	// requeued, until its spec changes. Zero retries forever.
+	MaxFailedAttempts int64
 }
 
-func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.Info("started")
 	defer log.Info("finished")
@@ -56,7 +77,23 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	log = log.WithValues("runnable", req.NamespacedName)
 	ctx = logr.NewContext(ctx, log)
 
-	runnable, err := r.Repo.GetRunnable(ctx, req.Name, req.Namespace)
+	start := r.now()
+	var runnable *v1alpha1.Runnable
+	var cancelled bool
+	defer func() {
+		report := controller.ReconcileReport{Object: req.NamespacedName, Found: runnable != nil, Cancelled: cancelled, Duration: r.now().Sub(start), Err: err}
+		if runnable != nil {
+			report.Conditions = runnable.Status.Conditions
+			report.StampedObject = runnable.Status.StampedRef
+		}
+		controller.LogReconcileComplete(log, report)
+	}()
+
+	runnable, err = r.Repo.GetRunnable(ctx, req.Name, req.Namespace)
+	if err != nil && r.TransientErrorBackoff > 0 && repository.IsTransientError(err) {
+		log.Info("transient error getting runnable, requeueing", "error", err.Error(), "requeue after", r.TransientErrorBackoff)
+		return ctrl.Result{RequeueAfter: r.TransientErrorBackoff}, nil
+	}
 	if err != nil {
 		log.Error(err, "failed to get runnable")
 		return ctrl.Result{}, fmt.Errorf("failed to get runnable [%s]: %w", req.NamespacedName, err)
@@ -67,77 +104,430 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	if runnable.Spec.RunTemplateRef.Kind == "" {
+		// runnables created before the defaulting webhook are defaulted once, the update
+		// reconciles the runnable again
+		log.Info("defaulting the run template kind of the runnable")
+		if err := r.Repo.DefaultRunnable(ctx, runnable); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to default runnable [%s]: %w", req.NamespacedName, err)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	r.conditionManager = r.ConditionManagerBuilder(v1alpha1.RunnableReady, runnable.Status.Conditions)
 
+	paused, err := r.Pause.Paused(ctx)
+	if err != nil {
+		log.Error(err, "failed to read whether cartographer is paused")
+		return ctrl.Result{}, err
+	}
+	if paused {
+		r.conditionManager.AddNegative(r.Pause.PausedCondition())
+		log.Info("cartographer is paused")
+		return r.completeReconciliation(ctx, runnable, runnable.Status.DeepCopy(), nil)
+	}
+
 	serviceAccountName := "default"
 	if runnable.Spec.ServiceAccountName != "" {
 		serviceAccountName = runnable.Spec.ServiceAccountName
 	}
 
-	secret, err := r.Repo.GetServiceAccountSecret(ctx, serviceAccountName, req.Namespace)
+	secretCtx, secretLog := withStage(ctx, "service account secret")
+	secret, err := r.Repo.GetServiceAccountSecret(secretCtx, serviceAccountName, req.Namespace)
 	if err != nil {
+		secretLog.Info("failed to get service account secret", "service account", serviceAccountName)
 		r.conditionManager.AddPositive(ServiceAccountSecretNotFoundCondition(err))
-		return r.completeReconciliation(ctx, runnable, nil, fmt.Errorf("failed to get secret for service account [%s]: %w", serviceAccountName, err))
+		return r.completeReconciliation(ctx, runnable, withoutOutputs(runnable.Status), fmt.Errorf("failed to get secret for service account [%s]: %w", serviceAccountName, err))
 	}
 
+	_, clientLog := withStage(ctx, "client")
 	runnableClient, err := r.ClientBuilder(secret)
 	if err != nil {
+		clientLog.Error(err, "failed to build client")
 		r.conditionManager.AddPositive(ClientBuilderErrorCondition(err))
-		return r.completeReconciliation(ctx, runnable, nil, controller.NewUnhandledError(fmt.Errorf("failed to build resource realizer: %w", err)))
+		return r.completeReconciliation(ctx, runnable, withoutOutputs(runnable.Status), controller.NewUnhandledError(fmt.Errorf("failed to build resource realizer: %w", err)))
 	}
 
-	stampedObject, outputs, err := r.Realizer.Realize(ctx, runnable, r.Repo, r.RepositoryBuilder(runnableClient, r.RunnableCache))
+	inputsHash, err := hashInputs(runnable.Spec.Inputs)
 	if err != nil {
-		log.V(logger.DEBUG).Info("failed to realize")
-		switch typedErr := err.(type) {
-		case realizer.GetRunTemplateError:
-			r.conditionManager.AddPositive(RunTemplateMissingCondition(typedErr))
-			err = controller.NewUnhandledError(err)
-		case realizer.ResolveSelectorError:
-			r.conditionManager.AddPositive(TemplateStampFailureCondition(typedErr))
-		case realizer.StampError:
-			r.conditionManager.AddPositive(TemplateStampFailureCondition(typedErr))
-		case realizer.ApplyStampedObjectError:
-			r.conditionManager.AddPositive(StampedObjectRejectedByAPIServerCondition(typedErr))
-			if !kerrors.IsForbidden(typedErr.Err) {
-				err = controller.NewUnhandledError(err)
-			}
-		case realizer.ListCreatedObjectsError:
-			r.conditionManager.AddPositive(FailedToListCreatedObjectsCondition(typedErr))
-			err = controller.NewUnhandledError(err)
-		case realizer.RetrieveOutputError:
-			r.conditionManager.AddPositive(OutputPathNotSatisfiedCondition(typedErr.StampedObject, typedErr.Error()))
-		default:
-			r.conditionManager.AddPositive(UnknownErrorCondition(typedErr))
+		r.conditionManager.AddPositive(UnknownErrorCondition(err))
+		return r.completeReconciliation(ctx, runnable, runnable.Status.DeepCopy(), controller.NewUnhandledError(fmt.Errorf("failed to hash inputs: %w", err)))
+	}
+
+	if runnable.Spec.ImmutableInputs && runnable.Status.InputsHash != "" && runnable.Status.InputsHash != inputsHash {
+		r.conditionManager.AddPositive(InputsImmutableCondition())
+		return r.completeReconciliation(ctx, runnable, runnable.Status.DeepCopy(), fmt.Errorf("inputs of immutable runnable [%s] changed", req.NamespacedName))
+	}
+
+	var stampedMissing bool
+	if runnable.Status.StampedRef != nil {
+		stampedCtx, stampedLog := withStage(ctx, "stamped object")
+		if stampedMissing = r.stampedObjectMissing(stampedCtx, runnable.Status.StampedRef); stampedMissing {
+			stampedLog.Info("stamped object no longer exists, stamping it again", "stamped ref", runnable.Status.StampedRef)
+		}
+	}
+
+	if nonce := refreshOutputsNonce(runnable); nonce != "" && runnable.Status.StampedRef != nil {
+		refreshCtx, refreshLog := withStage(ctx, "refresh outputs")
+		refreshed, result, err := r.refreshOutputs(refreshCtx, runnable, runnableClient, nonce)
+		if refreshed {
+			return result, err
+		}
+		refreshLog.Info("stamped object or run template not found, reconciling without refreshing outputs", "nonce", nonce)
+	}
+
+	realizeCtx, realizeLog := withStage(ctx, "realize")
+	runnableRepo := r.RepositoryBuilder(runnableClient, r.RunnableCache)
+	stampedObject, outputs, outputsSource, runTemplate, err := r.Realizer.Realize(realizeCtx, runnable, r.Repo, runnableRepo)
+	if ctx.Err() != nil {
+		realizeLog.Info("reconcile cancelled, requeueing without updating status", "error", ctx.Err().Error())
+		cancelled = true
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	stampedRef := runnable.Status.StampedRef
+	if stampedMissing && stampedObject == nil {
+		r.conditionManager.AddNegative(StampedObjectMissingCondition(runnable.Status.StampedRef))
+	}
+	if stampedObject != nil {
+		stampedRef = &v1alpha1.ObjectReference{
+			Kind:       stampedObject.GetKind(),
+			Namespace:  stampedObject.GetNamespace(),
+			Name:       stampedObject.GetName(),
+			APIVersion: stampedObject.GetAPIVersion(),
+			UID:        stampedObject.GetUID(),
+		}
+	}
+	stampedAt := r.stampedAt(runnable, stampedRef)
+
+	var awaitingOutputs time.Duration
+	var outputsStaleSince *metav1.Time
+	if err != nil {
+		realizeLog.V(logger.DEBUG).Info("failed to realize")
+		if _, ok := err.(realizer.GetRunTemplateError); ok && runnable.Spec.KeepOutputsOnTemplateLoss {
+			outputs = runnable.Status.Outputs
+			outputsStaleSince = r.outputsStaleSince(runnable)
+		}
+
+		condition, handled := conditions.FromRealizeError(err)
+		if condition.Type == "" {
+			condition = UnknownErrorCondition(err)
+		}
+		if awaitingOutputs = r.outputGracePeriodLeft(runnable, stampedAt, err); awaitingOutputs > 0 {
+			realizeLog.Info("outputs not yet available, within the output grace period", "grace period left", awaitingOutputs)
+			condition = AwaitingOutputsCondition(err, r.now().Add(awaitingOutputs))
+		}
+		r.conditionManager.AddPositive(condition)
+		if !handled {
 			err = controller.NewUnhandledError(err)
 		}
 	} else {
-		log.V(logger.DEBUG).Info("realized object", "object", stampedObject)
+		realizeLog.V(logger.DEBUG).Info("realized object", "object", stampedObject)
 		r.conditionManager.AddPositive(RunTemplateReadyCondition())
 	}
 
+	outputs, outputsTemplateGeneration := r.migrateOutputs(ctx, runnable, runTemplate, runnableRepo, stampedRef, outputs)
+
+	outputs, truncated := r.OutputLimits.truncate(outputs)
+	if len(truncated) > 0 {
+		log.Info("outputs exceed the size limit, truncating them", "outputs", truncated)
+		r.conditionManager.AddNegative(OutputTruncatedCondition(truncated))
+	}
+	outputSources := r.outputSources(runnable, runTemplate, outputs, outputsSource)
+
+	requeueHint, hintErr := r.requeueHint(ctx, runTemplate, stampedObject, stampedRef)
+	if hintErr != nil {
+		log.Info("requeue hint of the stamped object is malformed, polling at the default interval", "path", runTemplate.Spec.RequeueAfterPath, "error", hintErr.Error())
+		r.conditionManager.AddNegative(RequeueHintInvalidCondition(runTemplate.Spec.RequeueAfterPath, hintErr))
+	}
+
+	recordedInputsHash := runnable.Status.InputsHash
+	if !runnable.Spec.ImmutableInputs {
+		recordedInputsHash = ""
+	} else if recordedInputsHash == "" && err == nil {
+		recordedInputsHash = inputsHash
+	}
+
 	var trackingError error
 	if stampedObject != nil {
-		trackingError = r.DynamicTracker.Watch(log, stampedObject, &handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Runnable{}})
-		if trackingError != nil {
-			log.Error(err, "failed to add informer for object", "object", stampedObject)
+		_, trackLog := withStage(ctx, "track")
+		trackingError = r.DynamicTracker.Watch(trackLog, stampedObject, &handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Runnable{}}, resyncPeriod(runTemplate),
+			CurrentStampedObject(r.Repo, trackLog))
+		if pendingErr, ok := trackingError.(tracker.WatchPendingError); ok {
+			trackLog.Info("watch on object pending", "object", stampedObject, "retry after", pendingErr.RetryAfter)
+			err = pendingErr
+		} else if trackingError != nil {
+			trackLog.Error(trackingError, "failed to add informer for object", "object", stampedObject)
 			err = controller.NewUnhandledError(trackingError)
 		} else {
-			log.V(logger.DEBUG).Info("added informer for object", "object", stampedObject)
+			trackLog.V(logger.DEBUG).Info("added informer for object", "object", stampedObject)
 		}
 	}
 
-	return r.completeReconciliation(ctx, runnable, outputs, err)
+	status := runnable.Status.DeepCopy()
+	status.Outputs = outputs
+	status.OutputSources = outputSources
+	status.InputsHash = recordedInputsHash
+	status.StampedRef = stampedRef
+	status.StampedAt = stampedAt
+	status.Debug = debugOutputs(runnable, runTemplate, stampedObject)
+	status.OutputsTemplateGeneration = outputsTemplateGeneration
+	status.OutputsStaleSince = outputsStaleSince
+	result, err = r.completeReconciliation(ctx, runnable, status, err)
+	if err == nil && result.RequeueAfter == 0 && awaitingOutputs > 0 {
+		// escalate to OutputPathNotSatisfied once the grace period is over, even if
+		// the stamped object does not change again
+		result.RequeueAfter = awaitingOutputs
+	}
+	if err == nil && requeueHint > 0 && (result.RequeueAfter == 0 || requeueHint < result.RequeueAfter) {
+		result.RequeueAfter = requeueHint
+	}
+	return result, err
 }
 
-func (r *Reconciler) completeReconciliation(ctx context.Context, runnable *v1alpha1.Runnable, outputs map[string]apiextensionsv1.JSON, err error) (ctrl.Result, error) {
+// refreshOutputs extracts the outputs of the runnable from a live read of its stamped object,
+// without stamping, honoring the nonce of the refresh-outputs annotation. It does not refresh
+// when the stamped object or the run template can no longer be found, leaving the runnable
+// to be reconciled as usual.
+func (r *Reconciler) refreshOutputs(ctx context.Context, runnable *v1alpha1.Runnable, runnableClient client.Client, nonce string) (bool, ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
-	var changed bool
-	runnable.Status.Conditions, changed = r.conditionManager.Finalize()
 
-	if changed || (runnable.Status.ObservedGeneration != runnable.Generation) || !reflect.DeepEqual(runnable.Status.Outputs, outputs) {
-		runnable.Status.Outputs = outputs
-		runnable.Status.ObservedGeneration = runnable.Generation
+	stampedObject, err := r.readStampedObjectLive(ctx, runnable.Status.StampedRef)
+	if err != nil {
+		log.Error(err, "failed to read stamped object", "stamped ref", runnable.Status.StampedRef)
+		r.conditionManager.AddPositive(UnknownErrorCondition(err))
+		result, err := r.completeReconciliation(ctx, runnable, runnable.Status.DeepCopy(), controller.NewUnhandledError(err))
+		return true, result, err
+	}
+	if stampedObject == nil {
+		return false, ctrl.Result{}, nil
+	}
+	runTemplate := r.getRunTemplate(ctx, runnable)
+	if runTemplate == nil {
+		return false, ctrl.Result{}, nil
+	}
+
+	log.Info("refreshing outputs from live stamped object", "nonce", nonce, "stamped ref", runnable.Status.StampedRef)
+	outputsTemplateGeneration := runTemplate.Generation
+	outputs, err := extractOutputs(ctx, runnable, runTemplate, r.RepositoryBuilder(runnableClient, r.RunnableCache), stampedObject)
+	outputsSource := stampedObject
+	if err != nil {
+		outputs = runnable.Status.Outputs
+		outputsSource = nil
+		outputsTemplateGeneration = runnable.Status.OutputsTemplateGeneration
+		condition, handled := conditions.FromRealizeError(err)
+		if condition.Type == "" {
+			condition = UnknownErrorCondition(err)
+		}
+		r.conditionManager.AddPositive(condition)
+		if !handled {
+			err = controller.NewUnhandledError(err)
+		}
+	} else {
+		r.conditionManager.AddPositive(RunTemplateReadyCondition())
+	}
+
+	outputs, truncated := r.OutputLimits.truncate(outputs)
+	if len(truncated) > 0 {
+		log.Info("outputs exceed the size limit, truncating them", "outputs", truncated)
+		r.conditionManager.AddNegative(OutputTruncatedCondition(truncated))
+	}
+	outputSources := r.outputSources(runnable, runTemplate, outputs, outputsSource)
+
+	status := runnable.Status.DeepCopy()
+	status.Outputs = outputs
+	status.OutputSources = outputSources
+	status.Debug = debugOutputs(runnable, runTemplate, stampedObject)
+	status.OutputsRefreshedNonce = nonce
+	status.OutputsTemplateGeneration = outputsTemplateGeneration
+	if outputsSource != nil {
+		status.OutputsStaleSince = nil
+	}
+	result, err := r.completeReconciliation(ctx, runnable, status, err)
+	return true, result, err
+}
+
+// stampedAt is when the object referred to by stampedRef was first seen stamped. It is
+// kept for as long as the runnable refers to the same object.
+func (r *Reconciler) stampedAt(runnable *v1alpha1.Runnable, stampedRef *v1alpha1.ObjectReference) *metav1.Time {
+	if stampedRef == nil {
+		return nil
+	}
+	if runnable.Status.StampedAt != nil && reflect.DeepEqual(runnable.Status.StampedRef, stampedRef) {
+		return runnable.Status.StampedAt
+	}
+
+	now := metav1.NewTime(r.now())
+	return &now
+}
+
+// outputsStaleSince is when the outputs kept while the run template cannot be found became
+// stale, kept for as long as the run template stays missing.
+func (r *Reconciler) outputsStaleSince(runnable *v1alpha1.Runnable) *metav1.Time {
+	if runnable.Status.OutputsStaleSince != nil {
+		return runnable.Status.OutputsStaleSince
+	}
+
+	now := metav1.NewTime(r.now())
+	return &now
+}
+
+// outputGracePeriodLeft is how much of the runnable's output grace period is left when the
+// outputs could not yet be read from the stamped object, zero for any other error.
+func (r *Reconciler) outputGracePeriodLeft(runnable *v1alpha1.Runnable, stampedAt *metav1.Time, err error) time.Duration {
+	if _, ok := err.(realizer.RetrieveOutputError); !ok {
+		return 0
+	}
+	if runnable.Spec.OutputGracePeriod == nil || stampedAt == nil {
+		return 0
+	}
+
+	left := stampedAt.Add(runnable.Spec.OutputGracePeriod.Duration).Sub(r.now())
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// outputSources records the object each output was read from when the run template asks for
+// it. The source of an output is kept for as long as its value, read again from the same
+// object or carried over from the status, does not change.
+func (r *Reconciler) outputSources(runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate, outputs map[string]apiextensionsv1.JSON, source *unstructured.Unstructured) map[string]v1alpha1.OutputSource {
+	if runTemplate == nil {
+		return runnable.Status.OutputSources
+	}
+	if !runTemplate.Spec.RecordOutputSources {
+		return nil
+	}
+
+	sources := map[string]v1alpha1.OutputSource{}
+	for name, value := range outputs {
+		previous, recorded := runnable.Status.OutputSources[name]
+		unchanged := recorded && reflect.DeepEqual(runnable.Status.Outputs[name], value)
+		if unchanged && (source == nil || previous.ObjectRef.UID == source.GetUID()) {
+			sources[name] = previous
+			continue
+		}
+		if source == nil {
+			continue
+		}
+
+		sources[name] = v1alpha1.OutputSource{
+			ObjectRef: v1alpha1.ObjectReference{
+				Kind:       source.GetKind(),
+				Namespace:  source.GetNamespace(),
+				Name:       source.GetName(),
+				APIVersion: source.GetAPIVersion(),
+				UID:        source.GetUID(),
+			},
+			ResourceVersion: source.GetResourceVersion(),
+			ExtractedAt:     metav1.NewTime(r.now()),
+		}
+	}
+
+	if len(sources) == 0 {
+		return nil
+	}
+	return sources
+}
+
+func (r *Reconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// resyncPeriod is the resync period the run template asks of its stamped kind, the default
+// when the run template could not be read.
+func resyncPeriod(runTemplate *v1alpha1.ClusterRunTemplate) time.Duration {
+	if runTemplate == nil || runTemplate.Spec.ResyncPeriod == nil {
+		return 0
+	}
+
+	return runTemplate.Spec.ResyncPeriod.Duration
+}
+
+// debugOutputs evaluates the output paths of the runnable's run template against the stamped
+// object when the runnable asks for it with the debug-outputs annotation.
+func debugOutputs(runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate, stampedObject *unstructured.Unstructured) *v1alpha1.RunnableDebug {
+	if !debugOutputsEnabled(runnable) || stampedObject == nil || runTemplate == nil {
+		return runnable.Status.Debug
+	}
+
+	return &v1alpha1.RunnableDebug{
+		Outputs: templates.EvaluateOutputPaths(runTemplate, stampedObject),
+	}
+}
+
+func debugOutputsEnabled(runnable *v1alpha1.Runnable) bool {
+	return runnable.Annotations[v1alpha1.RunnableDebugOutputsAnnotation] == "true"
+}
+
+// getRunTemplate reads the runnable's run template, returning nil when it cannot be read. It is
+// only needed when outputs are refreshed without realizing, which returns the run template
+// it stamped.
+func (r *Reconciler) getRunTemplate(ctx context.Context, runnable *v1alpha1.Runnable) *v1alpha1.ClusterRunTemplate {
+	runTemplateName, err := realizer.RunTemplateName(runnable)
+	if err != nil {
+		return nil
+	}
+
+	runTemplateRef := runnable.Spec.RunTemplateRef
+	runTemplateRef.Kind = "ClusterRunTemplate"
+	runTemplateRef.Name = runTemplateName
+
+	runTemplate, err := r.Repo.GetRunTemplate(ctx, runTemplateRef)
+	if err != nil {
+		return nil
+	}
+
+	return runTemplate
+}
+
+// stampedObjectMissing reports whether the object referenced by the runnable's status was
+// deleted. Failing to look the object up is logged and treated as the object being present.
+func (r *Reconciler) stampedObjectMissing(ctx context.Context, ref *v1alpha1.ObjectReference) bool {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	obj.SetNamespace(ref.Namespace)
+	obj.SetName(ref.Name)
+
+	existing, err := r.Repo.GetUnstructured(ctx, obj)
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to get stamped object", "stamped ref", ref)
+		return false
+	}
+
+	return existing == nil
+}
+
+// completeReconciliation records the status of the runnable, as computed by the reconcile
+// on a copy of the previous status, along with the conditions, the retries and the failed
+// attempts of the reconcile, writing it only when it changed.
+func (r *Reconciler) completeReconciliation(ctx context.Context, runnable *v1alpha1.Runnable, status *v1alpha1.RunnableStatus, err error) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	status.FailedAttempts, err = r.countFailedAttempts(ctx, runnable, err)
+
+	statusConditions, changed := r.conditionManager.Finalize()
+
+	if !debugOutputsEnabled(runnable) {
+		status.Debug = nil
+	}
+
+	var requeueAfter time.Duration
+	status.ForbiddenRetries, requeueAfter = r.ForbiddenRetry.Next(runnable.Status.ForbiddenRetries, isForbiddenApplyError(err))
+	status.ObservedGeneration = runnable.Generation
+
+	status.Conditions = runnable.Status.Conditions
+	changed = changed || !reflect.DeepEqual(&runnable.Status, status)
+	status.Conditions = statusConditions
+	runnable.Status.Conditions = statusConditions
+
+	if changed {
+		runnable.Status = *status
 		statusUpdateError := r.Repo.StatusUpdate(ctx, runnable)
 		if statusUpdateError != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to update status for runnable: %w", statusUpdateError)
@@ -152,5 +542,72 @@ func (r *Reconciler) completeReconciliation(ctx context.Context, runnable *v1alp
 		log.Info("handled error reconciling runnable", "handled error", err)
 	}
 
+	if pendingErr, ok := err.(tracker.WatchPendingError); ok {
+		log.Info("requeueing until the watch on the stamped object is established", "requeue after", pendingErr.RetryAfter)
+		return ctrl.Result{RequeueAfter: pendingErr.RetryAfter}, nil
+	}
+
+	if requeueAfter > 0 {
+		log.Info("stamped object was forbidden, requeueing in case RBAC has not yet propagated",
+			"retry", status.ForbiddenRetries, "max retries", r.ForbiddenRetry.MaxRetries, "requeue after", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
+
+// withoutOutputs is a copy of the status dropping the outputs, for reconciles that cannot
+// reach the stamped object to read them again.
+func withoutOutputs(status v1alpha1.RunnableStatus) *v1alpha1.RunnableStatus {
+	copied := status.DeepCopy()
+	copied.Outputs = nil
+	copied.OutputSources = nil
+	return copied
+}
+
+// countFailedAttempts counts the reconcile against the class of its error when the error is
+// retried, starting over for a new generation of the runnable. Once a class reaches
+// MaxFailedAttempts the runnable is reported ReconcileFailedPermanently and the error is
+// returned handled, so that the runnable is no longer requeued.
+func (r *Reconciler) countFailedAttempts(ctx context.Context, runnable *v1alpha1.Runnable, err error) (map[string]int64, error) {
+	if r.MaxFailedAttempts <= 0 || !controller.IsUnhandledError(err) {
+		return nil, err
+	}
+
+	previous := runnable.Status.FailedAttempts
+	if runnable.Status.ObservedGeneration != runnable.Generation {
+		previous = nil
+	}
+
+	class := errorClass(err)
+	failedAttempts := map[string]int64{class: previous[class] + 1}
+	if failedAttempts[class] < r.MaxFailedAttempts {
+		return failedAttempts, err
+	}
+
+	logr.FromContextOrDiscard(ctx).Info("giving up on runnable until its spec changes",
+		"error class", class, "attempts", failedAttempts[class], "error", err.Error())
+	r.conditionManager.AddNegative(ReconcileFailedPermanentlyCondition(class, failedAttempts[class], err))
+	return failedAttempts, errors.Unwrap(err)
+}
+
+// errorClass is the reason of the condition the error is reported with, or UnknownError for
+// errors that are not classified.
+func errorClass(err error) string {
+	if condition, _ := conditions.FromRealizeError(errors.Unwrap(err)); condition.Reason != "" {
+		return condition.Reason
+	}
+	return v1alpha1.UnknownErrorReason
+}
+
+func isForbiddenApplyError(err error) bool {
+	applyErr, ok := err.(realizer.ApplyStampedObjectError)
+	return ok && kerrors.IsForbidden(applyErr.Err)
+}
+
+// withStage derives a logger carrying the given pipeline stage and puts it on the returned
+// context, so that the repository and realizer calls made during that stage log with it.
+func withStage(ctx context.Context, stage string) (context.Context, logr.Logger) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("stage", stage)
+	return logr.NewContext(ctx, log), log
+}
@@ -16,7 +16,11 @@ package runnable_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
@@ -28,22 +32,28 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions/conditionsfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/runnable"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/runnable/runnablefakes"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/trackerfakes"
 )
 
@@ -170,6 +180,69 @@ var _ = Describe("Reconcile", func() {
 			}))
 		})
 
+		It("logs the outcome of the reconcile once, when it is complete", func() {
+			conditionManager.FinalizeReturns([]metav1.Condition{
+				{Type: "Ready", Status: "False", Reason: "TemplateRejectedByAPIServer"},
+			}, true)
+			stampedObject := &unstructured.Unstructured{}
+			stampedObject.SetKind("TaskRun")
+			stampedObject.SetNamespace("my-namespace")
+			stampedObject.SetName("my-run-abcde")
+			rlzr.RealizeReturns(stampedObject, nil, nil, nil, nil)
+
+			_, _ = reconciler.Reconcile(ctx, request)
+
+			var completed []map[string]interface{}
+			for _, line := range strings.Split(strings.TrimSpace(string(out.Contents())), "\n") {
+				entry := map[string]interface{}{}
+				Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+				if entry["msg"] == "reconcile complete" {
+					completed = append(completed, entry)
+				}
+			}
+
+			Expect(completed).To(HaveLen(1))
+			Expect(completed[0]).To(MatchKeys(IgnoreExtras, Keys{
+				"object":        Equal("my-namespace/my-runnable"),
+				"outcome":       Equal("NotReady"),
+				"reason":        Equal("TemplateRejectedByAPIServer"),
+				"durationMs":    BeNumerically(">=", 0),
+				"stampedObject": Equal("TaskRun/my-namespace/my-run-abcde"),
+			}))
+		})
+
+		Context("when the reconcile is cancelled while the runnable is realized", func() {
+			var cancel context.CancelFunc
+
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(ctx)
+				rlzr.RealizeCalls(func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error) {
+					cancel()
+					return nil, nil, nil, nil, context.Canceled
+				})
+			})
+
+			AfterEach(func() {
+				cancel()
+			})
+
+			It("requeues without recording a failure in the status", func() {
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerruntime.Result{Requeue: true}))
+
+				Expect(conditionManager.AddPositiveCallCount()).To(Equal(0))
+				Expect(conditionManager.FinalizeCallCount()).To(Equal(0))
+				Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+			})
+
+			It("logs the reconcile as cancelled", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				Expect(out).To(Say(`"msg":"reconcile complete".*"outcome":"Cancelled"`))
+			})
+		})
+
 		It("uses the service account specified by the workload for realizing resources", func() {
 			_, _ = reconciler.Reconcile(ctx, request)
 
@@ -200,6 +273,56 @@ var _ = Describe("Reconcile", func() {
 			}))
 		})
 
+		Context("logging each stage", func() {
+			BeforeEach(func() {
+				repo.GetServiceAccountSecretStub = func(ctx context.Context, _ string, _ string) (*corev1.Secret, error) {
+					logr.FromContextOrDiscard(ctx).Info("getting secret")
+					return serviceAccountSecret, nil
+				}
+				rlzr.RealizeStub = func(ctx context.Context, _ *v1alpha1.Runnable, _ repository.Repository, _ repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error) {
+					logr.FromContextOrDiscard(ctx).Info("realizing")
+					return &unstructured.Unstructured{}, nil, nil, nil, nil
+				}
+				dynamicTracker.WatchStub = func(log logr.Logger, _ runtime.Object, _ handler.EventHandler, _ time.Duration, _ ...predicate.Predicate) error {
+					log.Info("watching")
+					return nil
+				}
+			})
+
+			It("passes the service account secret stage to the repository", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(out).To(Say(`"msg":"getting secret","runnable":"my-namespace/my-runnable","stage":"service account secret"`))
+			})
+
+			It("passes the realize stage to the realizer", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(out).To(Say(`"msg":"realizing","runnable":"my-namespace/my-runnable","stage":"realize"`))
+			})
+
+			It("passes the track stage to the tracker", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(out).To(Say(`"msg":"watching","runnable":"my-namespace/my-runnable","stage":"track"`))
+			})
+
+			It("does not carry a stage into later stages", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(out).To(Say(`"msg":"realizing","runnable":"my-namespace/my-runnable","stage":"realize"}`))
+			})
+
+			Context("building the client fails", func() {
+				BeforeEach(func() {
+					reconciler.ClientBuilder = func(secret *corev1.Secret) (client.Client, error) {
+						return nil, errors.New("some error")
+					}
+				})
+
+				It("logs the failure with the client stage", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(out).To(Say(`"msg":"failed to build client","runnable":"my-namespace/my-runnable","stage":"client"`))
+				})
+			})
+		})
+
 		Context("watching does not cause an error", func() {
 			It("watches the stampedObject's kind", func() {
 				stampedObject := &unstructured.Unstructured{}
@@ -208,121 +331,929 @@ var _ = Describe("Reconcile", func() {
 					Version: "alphabeta1",
 					Kind:    "MyThing",
 				})
-				rlzr.RealizeReturns(stampedObject, nil, nil)
+				rlzr.RealizeReturns(stampedObject, nil, nil, nil, nil)
+
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(1))
+				_, obj, hndl, _, _ := dynamicTracker.WatchArgsForCall(0)
+
+				Expect(obj).To(Equal(stampedObject))
+				Expect(hndl).To(Equal(&handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Runnable{}}))
+			})
+
+			It("filters the events of the stampedObject's kind to the current stamped object", func() {
+				rlzr.RealizeReturns(&unstructured.Unstructured{}, nil, nil, nil, nil)
 
 				_, _ = reconciler.Reconcile(ctx, request)
 				Expect(dynamicTracker.WatchCallCount()).To(Equal(1))
-				_, obj, hndl := dynamicTracker.WatchArgsForCall(0)
+				_, _, _, _, predicates := dynamicTracker.WatchArgsForCall(0)
+				Expect(predicates).To(HaveLen(1))
+			})
+
+			It("watches with the default resync", func() {
+				rlzr.RealizeReturns(&unstructured.Unstructured{}, nil, nil, nil, nil)
+
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(1))
+				_, _, _, resync, _ := dynamicTracker.WatchArgsForCall(0)
+				Expect(resync).To(BeZero())
+			})
+
+			Context("the run template sets a resync period", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(&unstructured.Unstructured{}, nil, nil, &v1alpha1.ClusterRunTemplate{
+						Spec: v1alpha1.ClusterRunTemplateSpec{
+							ResyncPeriod: &metav1.Duration{Duration: 30 * time.Second},
+						},
+					}, nil)
+				})
+
+				It("watches the stampedObject's kind with the run template's resync period", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(dynamicTracker.WatchCallCount()).To(Equal(1))
+					_, _, _, resync, _ := dynamicTracker.WatchArgsForCall(0)
+					Expect(resync).To(Equal(30 * time.Second))
+				})
+
+				It("does not read the run template the realizer stamped again", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(repo.GetRunTemplateCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("the run template has outputs", func() {
+			var stampedObject *unstructured.Unstructured
+
+			BeforeEach(func() {
+				runTemplate := &v1alpha1.ClusterRunTemplate{
+					Spec: v1alpha1.ClusterRunTemplateSpec{
+						Outputs: map[string]string{
+							"image":    "status.image",
+							"password": "status.password",
+							"missing":  "status.nope",
+						},
+						SensitiveOutputs: []string{"password"},
+					},
+				}
+
+				stampedObject = &unstructured.Unstructured{Object: map[string]interface{}{
+					"status": map[string]interface{}{
+						"image":    "my-image",
+						"password": "hunter2",
+					},
+				}}
+				rlzr.RealizeReturns(stampedObject, nil, nil, runTemplate, nil)
+			})
+
+			Context("and the runnable asks to debug its outputs", func() {
+				BeforeEach(func() {
+					rb.Annotations = map[string]string{v1alpha1.RunnableDebugOutputsAnnotation: "true"}
+				})
+
+				It("reports what each output path evaluated to", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+					debug := updatedRunnable.(*v1alpha1.Runnable).Status.Debug
+					Expect(debug).NotTo(BeNil())
+					Expect(debug.Outputs).To(HaveLen(3))
+					Expect(debug.Outputs[0]).To(MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("image"),
+						"Path":  Equal("status.image"),
+						"Value": Equal(`"my-image"`),
+					}))
+					Expect(debug.Outputs[1]).To(MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("missing"),
+						"Value": BeEmpty(),
+						"Error": ContainSubstring("nope is not found"),
+					}))
+					Expect(debug.Outputs[2]).To(MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("password"),
+						"Value": Equal("<redacted>"),
+					}))
+				})
+			})
+
+			Context("and the runnable does not ask to debug its outputs", func() {
+				BeforeEach(func() {
+					rb.Status.Debug = &v1alpha1.RunnableDebug{
+						Outputs: []v1alpha1.OutputPathEvaluation{{Name: "image", Path: "status.image", Value: `"old-image"`}},
+					}
+				})
+
+				It("removes the debug status", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedRunnable.(*v1alpha1.Runnable).Status.Debug).To(BeNil())
+				})
+			})
+		})
+
+		Context("but the watch on the stamped kind is pending", func() {
+			BeforeEach(func() {
+				stampedObject := &unstructured.Unstructured{}
+				rlzr.RealizeReturns(stampedObject, nil, nil, nil, nil)
+
+				dynamicTracker.WatchReturns(tracker.WatchPendingError{
+					GroupVersionKind: schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"},
+					RetryAfter:       4 * time.Second,
+					Err:              errors.New("no matches for kind"),
+				})
+			})
+
+			It("requeues after the tracker's backoff without an error", func() {
+				result, err := reconciler.Reconcile(ctx, request)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerruntime.Result{RequeueAfter: 4 * time.Second}))
+				Expect(out).To(Say(`"msg":"watch on object pending"`))
+			})
+		})
+
+		Context("watching causes an error", func() {
+			BeforeEach(func() {
+				stampedObject := &unstructured.Unstructured{}
+				rlzr.RealizeReturns(stampedObject, nil, nil, nil, nil)
+
+				dynamicTracker.WatchReturns(errors.New("could not watch"))
+			})
+
+			It("logs the error message", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				Expect(out).To(Say(`"level":"error"`))
+				Expect(out).To(Say(`"msg":"failed to add informer for object".*"error":"could not watch"`))
+			})
+
+			It("returns an unhandled error and requeues", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+
+				Expect(err.Error()).To(ContainSubstring("could not watch"))
+			})
+		})
+
+		Context("the realizer stamps an object", func() {
+			var stampedObject *unstructured.Unstructured
+
+			BeforeEach(func() {
+				stampedObject = &unstructured.Unstructured{}
+				stampedObject.SetAPIVersion("thing.io/alphabeta1")
+				stampedObject.SetKind("MyThing")
+				stampedObject.SetNamespace("my-namespace")
+				stampedObject.SetName("my-thing-abcde")
+				stampedObject.SetUID("stamped-uid")
+				rlzr.RealizeReturns(stampedObject, nil, nil, nil, nil)
+			})
+
+			It("records a reference to the stamped object in the status", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedRunnable.(*v1alpha1.Runnable).Status.StampedRef).To(Equal(&v1alpha1.ObjectReference{
+					Kind:       "MyThing",
+					Namespace:  "my-namespace",
+					Name:       "my-thing-abcde",
+					APIVersion: "thing.io/alphabeta1",
+					UID:        "stamped-uid",
+				}))
+			})
+		})
+
+		Context("the status refers to a previously stamped object", func() {
+			var stampedRef *v1alpha1.ObjectReference
+
+			BeforeEach(func() {
+				stampedRef = &v1alpha1.ObjectReference{
+					Kind:       "MyThing",
+					Namespace:  "my-namespace",
+					Name:       "my-thing-old",
+					APIVersion: "thing.io/alphabeta1",
+				}
+				rb.Status.StampedRef = stampedRef
+
+				restamped := &unstructured.Unstructured{}
+				restamped.SetAPIVersion("thing.io/alphabeta1")
+				restamped.SetKind("MyThing")
+				restamped.SetNamespace("my-namespace")
+				restamped.SetName("my-thing-new")
+				rlzr.RealizeReturns(restamped, nil, nil, nil, nil)
+			})
+
+			It("looks the stamped object up through the repository", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				Expect(repo.GetUnstructuredCallCount()).To(Equal(1))
+				_, obj := repo.GetUnstructuredArgsForCall(0)
+				Expect(obj.GetAPIVersion()).To(Equal("thing.io/alphabeta1"))
+				Expect(obj.GetKind()).To(Equal("MyThing"))
+				Expect(obj.GetNamespace()).To(Equal("my-namespace"))
+				Expect(obj.GetName()).To(Equal("my-thing-old"))
+			})
+
+			Context("and the object still exists", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredReturns(&unstructured.Unstructured{}, nil)
+				})
+
+				It("does not report the object missing", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the object was deleted", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredReturns(nil, nil)
+				})
+
+				It("does not report the object missing once it is stamped again", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+				})
+
+				It("stamps the object again and records the new reference", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+
+					_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedRunnable.(*v1alpha1.Runnable).Status.StampedRef.Name).To(Equal("my-thing-new"))
+				})
+			})
+
+			Context("and the object was deleted and cannot be stamped again", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredReturns(nil, nil)
+					rlzr.RealizeReturns(nil, nil, nil, nil, errors.New("some error"))
+				})
+
+				It("sets the StampedObjectMissing condition", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(1))
+					Expect(conditionManager.AddNegativeArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunnableStampedObjectMissing,
+						Status:  metav1.ConditionTrue,
+						Reason:  v1alpha1.StampedObjectDeletedStampedObjectMissingReason,
+						Message: "stamped object [my-namespace/my-thing-old] of kind [MyThing] no longer exists and could not be stamped again",
+					}))
+				})
+			})
+
+			Context("and the object cannot be looked up", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredReturns(nil, errors.New("some error"))
+				})
+
+				It("does not report the object missing", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("the runnable asks to refresh its outputs", func() {
+			var liveObject *unstructured.Unstructured
+
+			BeforeEach(func() {
+				rb.Annotations = map[string]string{v1alpha1.RunnableRefreshOutputsAnnotation: "nonce-2"}
+				rb.Status.StampedRef = &v1alpha1.ObjectReference{
+					Kind:       "MyThing",
+					Namespace:  "my-namespace",
+					Name:       "my-thing-abcde",
+					APIVersion: "thing.io/alphabeta1",
+				}
+				rb.Status.OutputsRefreshedNonce = "nonce-1"
+				rb.Status.Outputs = map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"stale-image"`)}}
+
+				repo.GetRunTemplateReturns(&v1alpha1.ClusterRunTemplate{
+					Spec: v1alpha1.ClusterRunTemplateSpec{
+						Outputs: map[string]string{"image": "status.image"},
+					},
+				}, nil)
+
+				liveObject = &unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"creationTimestamp": "2021-09-01T00:00:00Z"},
+					"status": map[string]interface{}{
+						"image":      "fixed-image",
+						"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "True"}},
+					},
+				}}
+				repo.GetUnstructuredReturns(&unstructured.Unstructured{}, nil)
+				repo.GetUnstructuredLiveReturns(liveObject, nil)
+			})
+
+			It("reads the stamped object live", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				Expect(repo.GetUnstructuredLiveCallCount()).To(Equal(1))
+				_, obj := repo.GetUnstructuredLiveArgsForCall(0)
+				Expect(obj.GetAPIVersion()).To(Equal("thing.io/alphabeta1"))
+				Expect(obj.GetKind()).To(Equal("MyThing"))
+				Expect(obj.GetNamespace()).To(Equal("my-namespace"))
+				Expect(obj.GetName()).To(Equal("my-thing-abcde"))
+			})
+
+			It("records the outputs of the live object and the honored nonce without stamping", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+				status := updatedRunnable.(*v1alpha1.Runnable).Status
+				Expect(status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"fixed-image"`)}}))
+				Expect(status.OutputsRefreshedNonce).To(Equal("nonce-2"))
+				Expect(status.StampedRef.Name).To(Equal("my-thing-abcde"))
+			})
+
+			It("reports the run template ready", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.RunTemplateReadyCondition()))
+			})
+
+			It("reads the run template once", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(repo.GetRunTemplateCallCount()).To(Equal(1))
+			})
+
+			Context("and the nonce was already honored", func() {
+				BeforeEach(func() {
+					rb.Status.OutputsRefreshedNonce = "nonce-2"
+					rlzr.RealizeReturns(nil, nil, nil, nil, nil)
+				})
+
+				It("reconciles as usual", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(repo.GetUnstructuredLiveCallCount()).To(Equal(0))
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("and the live object does not have the outputs", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredLiveReturns(&unstructured.Unstructured{Object: map[string]interface{}{}}, nil)
+				})
+
+				It("keeps the last known outputs and reports the output path not satisfied", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+					status := updatedRunnable.(*v1alpha1.Runnable).Status
+					Expect(status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"stale-image"`)}}))
+					Expect(status.OutputsRefreshedNonce).To(Equal("nonce-2"))
+
+					Expect(conditionManager.AddPositiveArgsForCall(0).Reason).To(Equal(v1alpha1.OutputPathNotSatisfiedRunTemplateReason))
+				})
+			})
+
+			Context("and the live read fails", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredLiveReturns(nil, errors.New("api server unavailable"))
+				})
+
+				It("returns an unhandled error without honoring the nonce", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).To(MatchError(ContainSubstring("api server unavailable")))
+
+					Expect(rlzr.RealizeCallCount()).To(Equal(0))
+					_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedRunnable.(*v1alpha1.Runnable).Status.OutputsRefreshedNonce).To(Equal("nonce-1"))
+				})
+			})
+
+			Context("and the stamped object no longer exists", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredLiveReturns(nil, nil)
+					rlzr.RealizeReturns(nil, nil, nil, nil, nil)
+				})
+
+				It("stamps the object as usual without honoring the nonce", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+					_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedRunnable.(*v1alpha1.Runnable).Status.OutputsRefreshedNonce).To(Equal("nonce-1"))
+				})
+			})
+		})
+
+		Context("no outputs were returned from the realizer", func() {
+			BeforeEach(func() {
+				rlzr.RealizeReturns(nil, nil, nil, nil, nil)
+			})
+
+			It("fetches the runnable", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				Expect(repo.GetRunnableCallCount()).To(Equal(1))
+				_, actualName, actualNamespace := repo.GetRunnableArgsForCall(0)
+				Expect(actualName).To(Equal("my-runnable"))
+				Expect(actualNamespace).To(Equal("my-namespace"))
+			})
+
+			It("Starts and Finishes cleanly", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(out).To(Say(`"msg":"started"`))
+				Expect(out).To(Say(`"msg":"finished"`))
+			})
+
+			It("Updates the status with no outputs", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, obj := repo.StatusUpdateArgsForCall(0)
+				statusObject, ok := obj.(*v1alpha1.Runnable)
+				Expect(ok).To(BeTrue())
+
+				Expect(statusObject.Status.Outputs).To(HaveLen(0))
+			})
+		})
+
+		Context("outputs are returned from the realizer", func() {
+			BeforeEach(func() {
+				rlzr.RealizeReturns(nil, templates.Outputs{
+					"an-output": apiextensionsv1.JSON{Raw: []byte(`"the value"`)},
+				}, nil, nil, nil)
+			})
+
+			It("Updates the status with the outputs", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, obj := repo.StatusUpdateArgsForCall(0)
+				statusObject, ok := obj.(*v1alpha1.Runnable)
+				Expect(ok).To(BeTrue())
+
+				Expect(statusObject.Status.Outputs).To(HaveLen(1))
+				Expect(statusObject.Status.Outputs["an-output"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"the value"`)}))
+
+			})
+
+			Context("the runnable already had outputs in the status", func() {
+				BeforeEach(func() {
+					rb.Status.Outputs = map[string]apiextensionsv1.JSON{
+						"old-output": {Raw: []byte(`"old value"`)},
+					}
+					rb.Status.ObservedGeneration = 1
+				})
+
+				It("updates the status with the new outputs", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, obj := repo.StatusUpdateArgsForCall(0)
+					statusObject, ok := obj.(*v1alpha1.Runnable)
+					Expect(ok).To(BeTrue())
+
+					Expect(statusObject.Status.Outputs).To(HaveLen(1))
+					Expect(statusObject.Status.Outputs["an-output"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"the value"`)}))
+				})
+			})
+
+			It("does not record the sources of the outputs", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, obj := repo.StatusUpdateArgsForCall(0)
+				Expect(obj.(*v1alpha1.Runnable).Status.OutputSources).To(BeNil())
+			})
+
+			Context("the run template records output sources", func() {
+				var (
+					runTemplate   *v1alpha1.ClusterRunTemplate
+					outputsSource *unstructured.Unstructured
+					now           time.Time
+					statusOf      func() v1alpha1.RunnableStatus
+				)
+
+				BeforeEach(func() {
+					runTemplate = &v1alpha1.ClusterRunTemplate{
+						Spec: v1alpha1.ClusterRunTemplateSpec{
+							Outputs:             map[string]string{"an-output": "status.value"},
+							RecordOutputSources: true,
+						},
+					}
+
+					outputsSource = &unstructured.Unstructured{}
+					outputsSource.SetAPIVersion("thing.io/alphabeta1")
+					outputsSource.SetKind("MyThing")
+					outputsSource.SetNamespace("my-namespace")
+					outputsSource.SetName("my-thing-abcde")
+					outputsSource.SetUID("my-thing-uid")
+					outputsSource.SetResourceVersion("42")
+					rlzr.RealizeReturns(nil, templates.Outputs{
+						"an-output": apiextensionsv1.JSON{Raw: []byte(`"the value"`)},
+					}, outputsSource, runTemplate, nil)
+
+					now = time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+					reconciler.Clock = clock.NewFakeClock(now)
+
+					statusOf = func() v1alpha1.RunnableStatus {
+						Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+						_, obj := repo.StatusUpdateArgsForCall(0)
+						return obj.(*v1alpha1.Runnable).Status
+					}
+				})
+
+				It("records the object each output was read from", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(statusOf().OutputSources).To(Equal(map[string]v1alpha1.OutputSource{
+						"an-output": {
+							ObjectRef: v1alpha1.ObjectReference{
+								Kind:       "MyThing",
+								Namespace:  "my-namespace",
+								Name:       "my-thing-abcde",
+								APIVersion: "thing.io/alphabeta1",
+								UID:        "my-thing-uid",
+							},
+							ResourceVersion: "42",
+							ExtractedAt:     metav1.NewTime(now),
+						},
+					}))
+				})
+
+				Context("the output was already read from the same object", func() {
+					var previous v1alpha1.OutputSource
+
+					BeforeEach(func() {
+						previous = v1alpha1.OutputSource{
+							ObjectRef:       v1alpha1.ObjectReference{Name: "my-thing-abcde", UID: "my-thing-uid"},
+							ResourceVersion: "41",
+							ExtractedAt:     metav1.NewTime(now.Add(-time.Hour)),
+						}
+						rb.Status.OutputSources = map[string]v1alpha1.OutputSource{"an-output": previous}
+					})
+
+					Context("and its value has not changed", func() {
+						BeforeEach(func() {
+							rb.Status.Outputs = map[string]apiextensionsv1.JSON{"an-output": {Raw: []byte(`"the value"`)}}
+						})
+
+						It("keeps the recorded source", func() {
+							_, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(statusOf().OutputSources).To(Equal(map[string]v1alpha1.OutputSource{"an-output": previous}))
+						})
+					})
+
+					Context("and its value changed", func() {
+						BeforeEach(func() {
+							rb.Status.Outputs = map[string]apiextensionsv1.JSON{"an-output": {Raw: []byte(`"an older value"`)}}
+						})
+
+						It("records the version of the object the new value was read from", func() {
+							_, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+
+							source := statusOf().OutputSources["an-output"]
+							Expect(source.ResourceVersion).To(Equal("42"))
+							Expect(source.ExtractedAt).To(Equal(metav1.NewTime(now)))
+						})
+					})
+				})
+
+				Context("the outputs are carried over from the status", func() {
+					BeforeEach(func() {
+						rlzr.RealizeReturns(nil, templates.Outputs{
+							"an-output": apiextensionsv1.JSON{Raw: []byte(`"the value"`)},
+						}, nil, runTemplate, nil)
+					})
+
+					It("does not make up a source", func() {
+						_, err := reconciler.Reconcile(ctx, request)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(statusOf().OutputSources).To(BeNil())
+					})
+				})
+			})
+		})
+
+		Context("the run template renamed an output since the outputs were recorded", func() {
+			var (
+				runTemplate *v1alpha1.ClusterRunTemplate
+				statusOf    func() v1alpha1.RunnableStatus
+			)
+
+			BeforeEach(func() {
+				rb.Status.Outputs = map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"old-image"`)}}
+				rb.Status.OutputsTemplateGeneration = 1
+				rb.Status.StampedRef = &v1alpha1.ObjectReference{
+					Kind:       "MyThing",
+					Namespace:  "my-namespace",
+					Name:       "my-thing-abcde",
+					APIVersion: "thing.io/alphabeta1",
+				}
+
+				runTemplate = &v1alpha1.ClusterRunTemplate{
+					ObjectMeta: metav1.ObjectMeta{Generation: 2},
+					Spec: v1alpha1.ClusterRunTemplateSpec{
+						Outputs: map[string]string{"latestImage": "status.image"},
+					},
+				}
+				repo.GetUnstructuredReturns(&unstructured.Unstructured{}, nil)
+				repo.GetUnstructuredLiveReturns(&unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"creationTimestamp": "2021-09-01T00:00:00Z"},
+					"status": map[string]interface{}{
+						"image":      "new-image",
+						"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "True"}},
+					},
+				}}, nil)
+
+				// until a run of the changed template completes, the realizer hands back the recorded outputs
+				rlzr.RealizeReturns(nil, templates.Outputs{"image": {Raw: []byte(`"old-image"`)}}, nil, runTemplate, nil)
+
+				statusOf = func() v1alpha1.RunnableStatus {
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, obj := repo.StatusUpdateArgsForCall(0)
+					return obj.(*v1alpha1.Runnable).Status
+				}
+			})
+
+			It("extracts the outputs again with the current template, removing the old key", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				status := statusOf()
+				Expect(status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{"latestImage": {Raw: []byte(`"new-image"`)}}))
+				Expect(status.OutputsTemplateGeneration).To(Equal(int64(2)))
+			})
+
+			Context("and the stamped object does not have the renamed output", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredLiveReturns(&unstructured.Unstructured{Object: map[string]interface{}{}}, nil)
+				})
+
+				It("clears the outputs the template no longer declares", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+
+					status := statusOf()
+					Expect(status.Outputs).To(BeEmpty())
+					Expect(status.OutputsTemplateGeneration).To(Equal(int64(2)))
+				})
+			})
+
+			Context("and the realizer already returns the renamed output", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(nil, templates.Outputs{"latestImage": {Raw: []byte(`"newer-image"`)}}, nil, runTemplate, nil)
+				})
+
+				It("records the outputs and the template generation without reading the stamped object", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(repo.GetUnstructuredLiveCallCount()).To(Equal(0))
+					status := statusOf()
+					Expect(status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{"latestImage": {Raw: []byte(`"newer-image"`)}}))
+					Expect(status.OutputsTemplateGeneration).To(Equal(int64(2)))
+				})
+			})
+
+			Context("and the outputs were recorded under the current generation", func() {
+				BeforeEach(func() {
+					rb.Status.OutputsTemplateGeneration = 2
+				})
+
+				It("keeps the outputs", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
 
-				Expect(obj).To(Equal(stampedObject))
-				Expect(hndl).To(Equal(&handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Runnable{}}))
+					Expect(repo.GetUnstructuredLiveCallCount()).To(Equal(0))
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, obj := repo.StatusUpdateArgsForCall(0)
+					Expect(obj.(*v1alpha1.Runnable).Status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"old-image"`)}}))
+				})
 			})
 		})
 
-		Context("watching causes an error", func() {
+		Context("outputs exceed the output limits", func() {
+			var statusOutputs func() map[string]apiextensionsv1.JSON
+
 			BeforeEach(func() {
-				stampedObject := &unstructured.Unstructured{}
-				rlzr.RealizeReturns(stampedObject, nil, nil)
+				rlzr.RealizeReturns(nil, templates.Outputs{
+					"a-blob":      apiextensionsv1.JSON{Raw: []byte(`{"spec":{"data":"` + strings.Repeat("x", 100) + `"}}`)},
+					"b-small":     apiextensionsv1.JSON{Raw: []byte(`"small"`)},
+					"c-big-later": apiextensionsv1.JSON{Raw: []byte(`"` + strings.Repeat("y", 40) + `"`)},
+				}, nil, nil, nil)
+				reconciler.OutputLimits = runnable.OutputLimits{MaxBytes: 50, MaxTotalBytes: 90}
 
-				dynamicTracker.WatchReturns(errors.New("could not watch"))
+				statusOutputs = func() map[string]apiextensionsv1.JSON {
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, obj := repo.StatusUpdateArgsForCall(0)
+					return obj.(*v1alpha1.Runnable).Status.Outputs
+				}
 			})
 
-			It("logs the error message", func() {
-				_, _ = reconciler.Reconcile(ctx, request)
+			It("truncates each output to the limit with a marker", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
 
-				Expect(out).To(Say(`"level":"error"`))
-				Expect(out).To(Say(`"msg":"failed to add informer for object"`))
-			})
+				blob := statusOutputs()["a-blob"]
+				Expect(len(blob.Raw)).To(BeNumerically("<=", 50))
 
-			It("returns an unhandled error and requeues", func() {
-				_, err := reconciler.Reconcile(ctx, request)
+				var value string
+				Expect(json.Unmarshal(blob.Raw, &value)).To(Succeed())
+				Expect(value).To(HavePrefix(`{"spec":{"data":"xxx`))
+				Expect(value).To(HaveSuffix(runnable.TruncatedOutputMarker))
+			})
 
-				Expect(err.Error()).To(ContainSubstring("could not watch"))
+			It("leaves outputs within the limits as they are", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(statusOutputs()["b-small"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"small"`)}))
 			})
-		})
 
-		Context("no outputs were returned from the realizer", func() {
-			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, nil, nil)
+			It("truncates the outputs that do not fit in the total limit", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+
+				total := 0
+				for _, output := range statusOutputs() {
+					total += len(output.Raw)
+				}
+				Expect(total).To(BeNumerically("<=", 90))
+
+				var value string
+				Expect(json.Unmarshal(statusOutputs()["c-big-later"].Raw, &value)).To(Succeed())
+				Expect(value).To(HaveSuffix(runnable.TruncatedOutputMarker))
 			})
 
-			It("fetches the runnable", func() {
+			It("reports the truncated outputs in an OutputTruncated condition", func() {
 				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(conditionManager.AddNegativeCallCount()).To(Equal(1))
+				Expect(conditionManager.AddNegativeArgsForCall(0)).To(Equal(runnable.OutputTruncatedCondition([]string{"a-blob", "c-big-later"})))
+			})
 
-				Expect(repo.GetRunnableCallCount()).To(Equal(1))
-				_, actualName, actualNamespace := repo.GetRunnableArgsForCall(0)
-				Expect(actualName).To(Equal("my-runnable"))
-				Expect(actualNamespace).To(Equal("my-namespace"))
+			Context("and the controller has no output limits", func() {
+				BeforeEach(func() {
+					reconciler.OutputLimits = runnable.OutputLimits{}
+				})
+
+				It("records the outputs whole", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(statusOutputs()["a-blob"].Raw).To(HavePrefix(`{"spec"`))
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+				})
 			})
+		})
 
-			It("Starts and Finishes cleanly", func() {
-				_, err := reconciler.Reconcile(ctx, request)
-				Expect(err).NotTo(HaveOccurred())
+		Context("cartographer is paused", func() {
+			var pauseConfigMap *corev1.ConfigMap
 
-				Expect(out).To(Say(`"msg":"started"`))
-				Expect(out).To(Say(`"msg":"finished"`))
+			BeforeEach(func() {
+				rb.Status.Outputs = map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"my-image"`)}}
+
+				pauseConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "cartographer-system", Name: "cartographer-pause"},
+					Data:       map[string]string{controller.PauseKey: "true"},
+				}
+				reconciler.Pause = controller.Pause{
+					Reader:    fake.NewClientBuilder().WithObjects(pauseConfigMap).Build(),
+					Namespace: "cartographer-system",
+					Name:      "cartographer-pause",
+				}
 			})
 
-			It("Updates the status with no outputs", func() {
-				_, err := reconciler.Reconcile(ctx, request)
+			It("reports the controller is paused without stamping nor requeueing", func() {
+				result, err := reconciler.Reconcile(ctx, request)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerruntime.Result{}))
 
-				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				Expect(conditionManager.AddNegativeArgsForCall(0)).To(Equal(reconciler.Pause.PausedCondition()))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+
+			It("keeps the outputs", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
 				_, obj := repo.StatusUpdateArgsForCall(0)
-				statusObject, ok := obj.(*v1alpha1.Runnable)
-				Expect(ok).To(BeTrue())
+				Expect(obj.(*v1alpha1.Runnable).Status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{"image": {Raw: []byte(`"my-image"`)}}))
+			})
 
-				Expect(statusObject.Status.Outputs).To(HaveLen(0))
+			Context("and is resumed", func() {
+				BeforeEach(func() {
+					pauseConfigMap.Data[controller.PauseKey] = "false"
+					reconciler.Pause.Reader = fake.NewClientBuilder().WithObjects(pauseConfigMap).Build()
+				})
+
+				It("stamps the object again", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				})
 			})
 		})
 
-		Context("outputs are returned from the realizer", func() {
+		Context("the run template reads a requeue hint from the stamped object", func() {
+			var stampedObject *unstructured.Unstructured
+
 			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, templates.Outputs{
-					"an-output": apiextensionsv1.JSON{Raw: []byte(`"the value"`)},
-				}, nil)
+				runTemplate := &v1alpha1.ClusterRunTemplate{
+					Spec: v1alpha1.ClusterRunTemplateSpec{
+						RequeueAfterPath: "status.nextPollTime",
+					},
+				}
+
+				stampedObject = &unstructured.Unstructured{Object: map[string]interface{}{
+					"status": map[string]interface{}{"nextPollTime": "30s"},
+				}}
+				rlzr.RealizeReturns(stampedObject, nil, nil, runTemplate, nil)
 			})
 
-			It("Updates the status with the outputs", func() {
-				_, err := reconciler.Reconcile(ctx, request)
+			It("requeues after the hinted duration", func() {
+				result, err := reconciler.Reconcile(ctx, request)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+				Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+			})
 
-				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
-				_, obj := repo.StatusUpdateArgsForCall(0)
-				statusObject, ok := obj.(*v1alpha1.Runnable)
-				Expect(ok).To(BeTrue())
+			Context("and the hint is a timestamp", func() {
+				BeforeEach(func() {
+					now := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+					reconciler.Clock = clock.NewFakeClock(now)
+					stampedObject.Object["status"] = map[string]interface{}{"nextPollTime": "2021-09-01T00:02:00Z"}
+				})
 
-				Expect(statusObject.Status.Outputs).To(HaveLen(1))
-				Expect(statusObject.Status.Outputs["an-output"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"the value"`)}))
+				It("requeues at the hinted time", func() {
+					result, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(2 * time.Minute))
+				})
+			})
+
+			Context("and the hint is a number of seconds", func() {
+				BeforeEach(func() {
+					stampedObject.Object["status"] = map[string]interface{}{"nextPollTime": int64(90)}
+				})
 
+				It("requeues after that many seconds", func() {
+					result, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(90 * time.Second))
+				})
 			})
 
-			Context("the runnable already had outputs in the status", func() {
+			Context("and the stamped object has no hint yet", func() {
 				BeforeEach(func() {
-					rb.Status.Outputs = map[string]apiextensionsv1.JSON{
-						"old-output": {Raw: []byte(`"old value"`)},
-					}
-					rb.Status.ObservedGeneration = 1
+					stampedObject.Object["status"] = map[string]interface{}{}
 				})
 
-				It("updates the status with the new outputs", func() {
-					_, err := reconciler.Reconcile(ctx, request)
+				It("does not requeue", func() {
+					result, err := reconciler.Reconcile(ctx, request)
 					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+				})
+			})
 
-					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
-					_, obj := repo.StatusUpdateArgsForCall(0)
-					statusObject, ok := obj.(*v1alpha1.Runnable)
-					Expect(ok).To(BeTrue())
+			Context("and the hint is malformed", func() {
+				BeforeEach(func() {
+					stampedObject.Object["status"] = map[string]interface{}{"nextPollTime": "whenever"}
+				})
 
-					Expect(statusObject.Status.Outputs).To(HaveLen(1))
-					Expect(statusObject.Status.Outputs["an-output"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"the value"`)}))
+				It("falls back to the default poll", func() {
+					result, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+				})
+
+				It("reports the malformed hint in a RequeueHintInvalid condition", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(conditionManager.AddNegativeCallCount()).To(Equal(1))
+					condition := conditionManager.AddNegativeArgsForCall(0)
+					Expect(condition.Type).To(Equal(v1alpha1.RunnableRequeueHintInvalid))
+					Expect(condition.Reason).To(Equal(v1alpha1.MalformedRequeueHintInvalidReason))
+					Expect(condition.Message).To(ContainSubstring("[status.nextPollTime]"))
+					Expect(condition.Message).To(ContainSubstring("[whenever]"))
+				})
+
+				It("logs a warning", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(out).To(Say(`"msg":"requeue hint of the stamped object is malformed, polling at the default interval"`))
 				})
 			})
 		})
 
 		Context("updating the status fails", func() {
 			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, nil, nil)
+				rlzr.RealizeReturns(nil, nil, nil, nil, nil)
 				repo.StatusUpdateReturns(errors.New("bad status update error"))
 			})
 
@@ -342,7 +1273,7 @@ var _ = Describe("Reconcile", func() {
 
 		Context("the realizer returns an error", func() {
 			BeforeEach(func() {
-				rlzr.RealizeReturns(nil, nil, nil)
+				rlzr.RealizeReturns(nil, nil, nil, nil, nil)
 			})
 
 			It("Starts and Finishes cleanly", func() {
@@ -367,12 +1298,17 @@ var _ = Describe("Reconcile", func() {
 						Err:      errors.New("some error"),
 						Runnable: &v1alpha1.Runnable{ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: "my-ns"}},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.RunTemplateMissingCondition(err)))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunTemplateReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.NotFoundRunTemplateReason,
+						Message: "unable to get runnable [my-ns/my-runnable]: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -380,6 +1316,138 @@ var _ = Describe("Reconcile", func() {
 
 					Expect(err.Error()).To(ContainSubstring("unable to get runnable [my-ns/my-runnable]: some error"))
 				})
+
+				Context("the controller allows a maximum of failed attempts", func() {
+					var statusOf func() v1alpha1.RunnableStatus
+
+					BeforeEach(func() {
+						reconciler.MaxFailedAttempts = 3
+						rb.Status.ObservedGeneration = 1
+
+						statusOf = func() v1alpha1.RunnableStatus {
+							Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+							_, obj := repo.StatusUpdateArgsForCall(0)
+							return obj.(*v1alpha1.Runnable).Status
+						}
+					})
+
+					It("counts the attempt against the class of the error and requeues", func() {
+						_, err := reconciler.Reconcile(ctx, request)
+						Expect(controller.IsUnhandledError(err)).To(BeTrue())
+
+						Expect(statusOf().FailedAttempts).To(Equal(map[string]int64{"RunTemplateNotFound": 1}))
+						Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+					})
+
+					Context("and the attempt is the last allowed", func() {
+						BeforeEach(func() {
+							rb.Status.FailedAttempts = map[string]int64{"RunTemplateNotFound": 2}
+						})
+
+						It("reports the runnable failed permanently and stops requeueing", func() {
+							result, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(controllerruntime.Result{}))
+
+							Expect(statusOf().FailedAttempts).To(Equal(map[string]int64{"RunTemplateNotFound": 3}))
+							Expect(conditionManager.AddNegativeCallCount()).To(Equal(1))
+							condition := conditionManager.AddNegativeArgsForCall(0)
+							Expect(condition.Type).To(Equal("ReconcileFailedPermanently"))
+							Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+							Expect(condition.Reason).To(Equal("RunTemplateNotFound"))
+							Expect(condition.Message).To(ContainSubstring("gave up after 3 attempts"))
+							Expect(condition.Message).To(ContainSubstring("unable to get runnable [my-ns/my-runnable]: some error"))
+						})
+					})
+
+					Context("and the spec changed since the attempts were counted", func() {
+						BeforeEach(func() {
+							rb.Generation = 2
+							rb.Status.FailedAttempts = map[string]int64{"RunTemplateNotFound": 2}
+						})
+
+						It("counts the attempts over again", func() {
+							_, err := reconciler.Reconcile(ctx, request)
+							Expect(controller.IsUnhandledError(err)).To(BeTrue())
+
+							Expect(statusOf().FailedAttempts).To(Equal(map[string]int64{"RunTemplateNotFound": 1}))
+							Expect(conditionManager.AddNegativeCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("and the previous attempts failed with another class of error", func() {
+						BeforeEach(func() {
+							rb.Status.FailedAttempts = map[string]int64{"UnknownError": 2}
+						})
+
+						It("counts the attempts of this class only", func() {
+							_, err := reconciler.Reconcile(ctx, request)
+							Expect(controller.IsUnhandledError(err)).To(BeTrue())
+
+							Expect(statusOf().FailedAttempts).To(Equal(map[string]int64{"RunTemplateNotFound": 1}))
+						})
+					})
+				})
+
+				Context("the runnable already had outputs in the status", func() {
+					BeforeEach(func() {
+						rb.Status.Outputs = map[string]apiextensionsv1.JSON{
+							"old-output": {Raw: []byte(`"old value"`)},
+						}
+					})
+
+					Context("and keepOutputsOnTemplateLoss is not set", func() {
+						It("clears the outputs", func() {
+							_, _ = reconciler.Reconcile(ctx, request)
+
+							Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+							_, obj := repo.StatusUpdateArgsForCall(0)
+							statusObject, ok := obj.(*v1alpha1.Runnable)
+							Expect(ok).To(BeTrue())
+
+							Expect(statusObject.Status.Outputs).To(BeEmpty())
+							Expect(statusObject.Status.OutputsStaleSince).To(BeNil())
+						})
+					})
+
+					Context("and keepOutputsOnTemplateLoss is set", func() {
+						BeforeEach(func() {
+							rb.Spec.KeepOutputsOnTemplateLoss = true
+						})
+
+						It("keeps the last known outputs, marked stale from now", func() {
+							_, _ = reconciler.Reconcile(ctx, request)
+
+							Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+							_, obj := repo.StatusUpdateArgsForCall(0)
+							statusObject, ok := obj.(*v1alpha1.Runnable)
+							Expect(ok).To(BeTrue())
+
+							Expect(statusObject.Status.Outputs).To(Equal(map[string]apiextensionsv1.JSON{
+								"old-output": {Raw: []byte(`"old value"`)},
+							}))
+							Expect(statusObject.Status.OutputsStaleSince).NotTo(BeNil())
+							Expect(statusObject.Status.OutputsStaleSince.Time).To(BeTemporally("~", time.Now(), time.Minute))
+						})
+
+						Context("and the outputs were already stale", func() {
+							var staleSince metav1.Time
+
+							BeforeEach(func() {
+								staleSince = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+								rb.Status.OutputsStaleSince = &staleSince
+							})
+
+							It("keeps the time they became stale", func() {
+								_, _ = reconciler.Reconcile(ctx, request)
+
+								Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+								_, obj := repo.StatusUpdateArgsForCall(0)
+								Expect(obj.(*v1alpha1.Runnable).Status.OutputsStaleSince).To(Equal(&staleSince))
+							})
+						})
+					})
+				})
 			})
 
 			Context("of type ResolveSelectorError", func() {
@@ -395,12 +1463,17 @@ var _ = Describe("Reconcile", func() {
 							MatchingLabels: map[string]string{"foo": "bar", "moo": "cow"},
 						},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.TemplateStampFailureCondition(err)))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunTemplateReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateStampFailureRunTemplateReason,
+						Message: "unable to resolve selector [map[foo:bar moo:cow]], apiVersion [my-api-version], kind [my-kind]: some error",
+					}))
 				})
 
 				It("does not return an error", func() {
@@ -424,7 +1497,7 @@ var _ = Describe("Reconcile", func() {
 						Err:      errors.New("some error"),
 						Runnable: &v1alpha1.Runnable{ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: "my-ns"}},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("does not try to watch the stampedObjects", func() {
@@ -436,7 +1509,12 @@ var _ = Describe("Reconcile", func() {
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.TemplateStampFailureCondition(err)))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunTemplateReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateStampFailureRunTemplateReason,
+						Message: "unable to stamp object [my-ns/my-runnable]: some error",
+					}))
 				})
 
 				It("does not return an error", func() {
@@ -460,12 +1538,17 @@ var _ = Describe("Reconcile", func() {
 						Err:           errors.New("some error"),
 						StampedObject: &unstructured.Unstructured{},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.StampedObjectRejectedByAPIServerCondition(err)))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunTemplateReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.StampedObjectRejectedByAPIServerRunTemplateReason,
+						Message: "unable to apply stamped object [/]: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -497,12 +1580,17 @@ var _ = Describe("Reconcile", func() {
 						StampedObject: stampedObject,
 					}
 
-					rlzr.RealizeReturns(nil, nil, stampedObjectError)
+					rlzr.RealizeReturns(nil, nil, nil, nil, stampedObjectError)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.StampedObjectRejectedByAPIServerCondition(stampedObjectError)))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunTemplateReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.StampedObjectRejectedByAPIServerRunTemplateReason,
+						Message: "unable to apply stamped object [a-namespace/a-name]: fantastic error",
+					}))
 				})
 
 				It("handles the error and logs it", func() {
@@ -512,6 +1600,74 @@ var _ = Describe("Reconcile", func() {
 					Expect(out).To(Say(`"level":"info"`))
 					Expect(out).To(Say(`"handled error":"unable to apply stamped object \[a-namespace/a-name\]: fantastic error"`))
 				})
+
+				It("does not requeue", func() {
+					result, _ := reconciler.Reconcile(ctx, request)
+					Expect(result).To(Equal(controllerruntime.Result{}))
+					Expect(rb.Status.ForbiddenRetries).To(BeZero())
+				})
+
+				Context("and forbidden retries are enabled", func() {
+					BeforeEach(func() {
+						reconciler.ForbiddenRetry = controller.ForbiddenRetryOptions{
+							MaxRetries: 2,
+							Backoff:    time.Second,
+						}
+					})
+
+					It("requeues after the backoff and records the retry", func() {
+						result, err := reconciler.Reconcile(ctx, request)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result).To(Equal(controllerruntime.Result{RequeueAfter: time.Second}))
+						Expect(rb.Status.ForbiddenRetries).To(Equal(int64(1)))
+
+						Expect(out).To(Say(`"msg":"stamped object was forbidden, requeueing in case RBAC has not yet propagated".*"retry":1,"max retries":2,"requeue after":1`))
+					})
+
+					Context("and a retry has already been recorded", func() {
+						BeforeEach(func() {
+							rb.Status.ForbiddenRetries = 1
+						})
+
+						It("doubles the backoff", func() {
+							result, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(controllerruntime.Result{RequeueAfter: 2 * time.Second}))
+							Expect(rb.Status.ForbiddenRetries).To(Equal(int64(2)))
+						})
+					})
+
+					Context("and the retries are exhausted", func() {
+						BeforeEach(func() {
+							rb.Status.ForbiddenRetries = 2
+						})
+
+						It("treats the error as terminal", func() {
+							result, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(controllerruntime.Result{}))
+							Expect(rb.Status.ForbiddenRetries).To(Equal(int64(2)))
+						})
+					})
+
+					Context("and the apply succeeds once RBAC has propagated", func() {
+						BeforeEach(func() {
+							rlzr.RealizeReturnsOnCall(1, nil, nil, nil, nil, nil)
+						})
+
+						It("stops requeueing and resets the retries", func() {
+							result, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(time.Second))
+							Expect(rb.Status.ForbiddenRetries).To(Equal(int64(1)))
+
+							result, err = reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(controllerruntime.Result{}))
+							Expect(rb.Status.ForbiddenRetries).To(BeZero())
+						})
+					})
+				})
 			})
 
 			Context("of type ListCreatedObjectsError", func() {
@@ -522,12 +1678,17 @@ var _ = Describe("Reconcile", func() {
 						Namespace: "some-ns",
 						Labels:    map[string]string{"hi": "bye"},
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.FailedToListCreatedObjectsCondition(err)))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.RunTemplateReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.FailedToListCreatedObjectsReason,
+						Message: "unable to list objects in namespace [some-ns] with labels [map[hi:bye]]: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -556,12 +1717,12 @@ var _ = Describe("Reconcile", func() {
 						Runnable:      &v1alpha1.Runnable{ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: "my-ns"}},
 						StampedObject: stampedObject,
 					}
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, request)
-					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.OutputPathNotSatisfiedCondition(stampedObject, err.Error())))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.OutputPathNotSatisfiedCondition(stampedObject, err.Error())))
 				})
 
 				It("does not return an error", func() {
@@ -576,13 +1737,88 @@ var _ = Describe("Reconcile", func() {
 					Expect(out).To(Say(`"msg":"handled error reconciling runnable"`))
 					Expect(out).To(Say(`"handled error":"unable to retrieve outputs from stamped object \[my-ns/my-obj\] of type \[mything.thing.io\] for runnable \[my-ns/my-runnable\]: some error"`))
 				})
+
+				Context("and the runnable has an output grace period", func() {
+					var (
+						now       time.Time
+						stampedAt metav1.Time
+					)
+
+					BeforeEach(func() {
+						now = time.Now().Truncate(time.Second)
+						reconciler.Clock = clock.NewFakeClock(now)
+
+						rb.Spec.OutputGracePeriod = &metav1.Duration{Duration: time.Minute}
+						rb.Status.StampedRef = &v1alpha1.ObjectReference{
+							Kind:       "MyThing",
+							Namespace:  "my-ns",
+							Name:       "my-obj",
+							APIVersion: "thing.io/alphabeta1",
+						}
+						rlzr.RealizeReturns(stampedObject, nil, nil, nil, err)
+					})
+
+					Context("and the object was stamped within the grace period", func() {
+						BeforeEach(func() {
+							stampedAt = metav1.NewTime(now.Add(-10 * time.Second))
+							rb.Status.StampedAt = &stampedAt
+						})
+
+						It("reports the runnable as awaiting its outputs", func() {
+							_, _ = reconciler.Reconcile(ctx, request)
+							Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.AwaitingOutputsCondition(err, now.Add(50*time.Second))))
+						})
+
+						It("requeues for when the grace period is over", func() {
+							result, err := reconciler.Reconcile(ctx, request)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(controllerruntime.Result{RequeueAfter: 50 * time.Second}))
+						})
+
+						It("keeps the time the object was stamped", func() {
+							_, _ = reconciler.Reconcile(ctx, request)
+
+							_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+							Expect(updatedRunnable.(*v1alpha1.Runnable).Status.StampedAt).To(Equal(&stampedAt))
+						})
+					})
+
+					Context("and the object was stamped for the first time", func() {
+						BeforeEach(func() {
+							rb.Status.StampedRef = nil
+						})
+
+						It("starts the grace period", func() {
+							result, reconcileErr := reconciler.Reconcile(ctx, request)
+							Expect(reconcileErr).NotTo(HaveOccurred())
+							Expect(result).To(Equal(controllerruntime.Result{RequeueAfter: time.Minute}))
+							Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(runnable.AwaitingOutputsCondition(err, now.Add(time.Minute))))
+
+							_, updatedRunnable := repo.StatusUpdateArgsForCall(0)
+							Expect(updatedRunnable.(*v1alpha1.Runnable).Status.StampedAt.Time).To(Equal(now))
+						})
+					})
+
+					Context("and the grace period is over", func() {
+						BeforeEach(func() {
+							stampedAt = metav1.NewTime(now.Add(-2 * time.Minute))
+							rb.Status.StampedAt = &stampedAt
+						})
+
+						It("reports that the output path is not satisfied", func() {
+							result, _ := reconciler.Reconcile(ctx, request)
+							Expect(result).To(Equal(controllerruntime.Result{}))
+							Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.OutputPathNotSatisfiedCondition(stampedObject, err.Error())))
+						})
+					})
+				})
 			})
 
 			Context("of unknown type", func() {
 				var err error
 				BeforeEach(func() {
 					err = errors.New("some error")
-					rlzr.RealizeReturns(nil, nil, err)
+					rlzr.RealizeReturns(nil, nil, nil, nil, err)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -598,6 +1834,137 @@ var _ = Describe("Reconcile", func() {
 			})
 		})
 
+		Context("the runnable has immutable inputs", func() {
+			BeforeEach(func() {
+				rb.Spec.ImmutableInputs = true
+				rb.Spec.Inputs = map[string]apiextensionsv1.JSON{
+					"key": {Raw: []byte(`"val"`)},
+				}
+				rlzr.RealizeReturns(nil, nil, nil, nil, nil)
+			})
+
+			Context("on the first successful run", func() {
+				It("realizes the runnable", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				})
+
+				It("records a hash of the inputs in the status", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, obj := repo.StatusUpdateArgsForCall(0)
+					statusObject, ok := obj.(*v1alpha1.Runnable)
+					Expect(ok).To(BeTrue())
+					Expect(statusObject.Status.InputsHash).NotTo(BeEmpty())
+				})
+			})
+
+			Context("on a later run", func() {
+				var firstRunHash string
+
+				BeforeEach(func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					firstRunHash = rb.Status.InputsHash
+					Expect(firstRunHash).NotTo(BeEmpty())
+				})
+
+				Context("the inputs are unchanged", func() {
+					It("realizes the runnable again", func() {
+						_, err := reconciler.Reconcile(ctx, request)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(rlzr.RealizeCallCount()).To(Equal(2))
+						Expect(rb.Status.InputsHash).To(Equal(firstRunHash))
+					})
+				})
+
+				Context("the inputs are edited", func() {
+					BeforeEach(func() {
+						rb.Spec.Inputs = map[string]apiextensionsv1.JSON{
+							"key": {Raw: []byte(`"new-val"`)},
+						}
+					})
+
+					It("does not re-stamp", func() {
+						_, _ = reconciler.Reconcile(ctx, request)
+						Expect(rlzr.RealizeCallCount()).To(Equal(1))
+					})
+
+					It("calls the condition manager to report inputs immutable", func() {
+						_, _ = reconciler.Reconcile(ctx, request)
+						Expect(conditionManager.AddPositiveArgsForCall(conditionManager.AddPositiveCallCount() - 1)).
+							To(Equal(runnable.InputsImmutableCondition()))
+					})
+
+					It("keeps the hash of the first run inputs", func() {
+						_, _ = reconciler.Reconcile(ctx, request)
+						Expect(rb.Status.InputsHash).To(Equal(firstRunHash))
+					})
+
+					It("handles the error and logs it", func() {
+						_, err := reconciler.Reconcile(ctx, request)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out).To(Say(`"handled error":"inputs of immutable runnable \[my-namespace/my-runnable\] changed"`))
+					})
+				})
+			})
+
+			Context("the first run fails", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(nil, nil, nil, nil, realizer.StampError{Err: errors.New("some error"), Runnable: rb})
+				})
+
+				It("does not record a hash of the inputs", func() {
+					_, _ = reconciler.Reconcile(ctx, request)
+					Expect(rb.Status.InputsHash).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("the runnable does not have immutable inputs", func() {
+			BeforeEach(func() {
+				rb.Status.InputsHash = "some-old-hash"
+				rlzr.RealizeReturns(nil, nil, nil, nil, nil)
+			})
+
+			It("clears any recorded hash of the inputs", func() {
+				_, _ = reconciler.Reconcile(ctx, request)
+				Expect(rb.Status.InputsHash).To(BeEmpty())
+			})
+		})
+
+		Context("the runnable was stored without a run template kind", func() {
+			BeforeEach(func() {
+				rb.Spec.RunTemplateRef.Kind = ""
+			})
+
+			It("stores the runnable with its defaults applied instead of realizing it", func() {
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerruntime.Result{}))
+
+				Expect(repo.DefaultRunnableCallCount()).To(Equal(1))
+				_, defaulted := repo.DefaultRunnableArgsForCall(0)
+				Expect(defaulted.Name).To(Equal("my-runnable"))
+
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+			})
+
+			Context("and storing it fails", func() {
+				BeforeEach(func() {
+					repo.DefaultRunnableReturns(errors.New("some error"))
+				})
+
+				It("returns the error", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).To(MatchError("failed to default runnable [my-namespace/my-runnable]: some error"))
+				})
+			})
+		})
+
 		Context("the runnable does not specify a service account", func() {
 			BeforeEach(func() {
 				rb.Spec.ServiceAccountName = ""
@@ -651,5 +2018,32 @@ var _ = Describe("Reconcile", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("very bad runnable"))
 		})
+
+		Context("and a transient error backoff is configured", func() {
+			BeforeEach(func() {
+				reconciler.TransientErrorBackoff = 3 * time.Second
+			})
+
+			It("still returns an error that is not transient", func() {
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).To(MatchError(ContainSubstring("very bad runnable")))
+				Expect(result).To(Equal(controllerruntime.Result{}))
+			})
+
+			Context("and the error is transient", func() {
+				BeforeEach(func() {
+					repo.GetRunnableReturns(nil, fmt.Errorf("failed to get runnable object from api server [my-namespace/my-runnable]: %w",
+						kerrors.NewServiceUnavailable("etcd is restarting")))
+				})
+
+				It("requeues after the backoff without returning an error", func() {
+					result, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(controllerruntime.Result{RequeueAfter: 3 * time.Second}))
+
+					Expect(out).To(Say(`"msg":"transient error getting runnable, requeueing".*"requeue after":3`))
+				})
+			})
+		})
 	})
 })
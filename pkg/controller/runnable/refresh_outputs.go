@@ -0,0 +1,88 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// refreshOutputsNonce is the nonce of the runnable's refresh-outputs annotation when it has
+// not yet been honored, empty otherwise.
+func refreshOutputsNonce(runnable *v1alpha1.Runnable) string {
+	nonce := runnable.Annotations[v1alpha1.RunnableRefreshOutputsAnnotation]
+	if nonce == runnable.Status.OutputsRefreshedNonce {
+		return ""
+	}
+	return nonce
+}
+
+// readStampedObjectLive reads the object referenced by the runnable's status from the api
+// server, bypassing the cache. It returns nil when the object no longer exists.
+func (r *Reconciler) readStampedObjectLive(ctx context.Context, ref *v1alpha1.ObjectReference) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	obj.SetNamespace(ref.Namespace)
+	obj.SetName(ref.Name)
+
+	stampedObject, err := r.Repo.GetUnstructuredLive(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stamped object [%s/%s]: %w", ref.Namespace, ref.Name, err)
+	}
+	return stampedObject, nil
+}
+
+// extractOutputs evaluates the outputs of the run template against the stamped object,
+// as the realizer does after stamping.
+func extractOutputs(ctx context.Context, runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate, repo repository.Repository, stampedObject *unstructured.Unstructured) (templates.Outputs, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	template := templates.NewRunTemplateModel(runTemplate, repo)
+	outputs, evaluatedStampedObject, err := template.GetOutput(ctx, []*unstructured.Unstructured{stampedObject})
+	if err != nil {
+		log.Error(err, "failed to retrieve output from live stamped object")
+		return nil, realizer.RetrieveOutputError{
+			Err:           err,
+			Runnable:      runnable,
+			StampedObject: stampedObject,
+		}
+	}
+
+	if evaluatedStampedObject != nil {
+		outputs, err = template.TransformOutputs(outputs)
+		if err != nil {
+			log.Error(err, "failed to transform outputs")
+			return nil, realizer.OutputTransformError{
+				Err:           err,
+				Runnable:      runnable,
+				StampedObject: evaluatedStampedObject,
+			}
+		}
+	}
+
+	if len(outputs) == 0 {
+		outputs = runnable.Status.Outputs
+	}
+	return outputs, nil
+}
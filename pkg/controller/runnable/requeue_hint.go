@@ -0,0 +1,94 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+)
+
+// minRequeueHint keeps a hint in the past, or only just ahead, from requeueing the runnable
+// in a hot loop.
+const minRequeueHint = time.Second
+
+// requeueHint is how long until the controller of the object most recently stamped for the
+// runnable suggests it is looked at again, read at the requeueAfterPath of the run template.
+// The object is read at ref when the realizer did not return it. The hint is zero when the
+// template has no path or the object has no value at it yet, and an error when the value is
+// neither a duration, a timestamp nor a number of seconds.
+func (r *Reconciler) requeueHint(ctx context.Context, runTemplate *v1alpha1.ClusterRunTemplate, stampedObject *unstructured.Unstructured, ref *v1alpha1.ObjectReference) (time.Duration, error) {
+	if runTemplate == nil || runTemplate.Spec.RequeueAfterPath == "" {
+		return 0, nil
+	}
+
+	if stampedObject == nil {
+		if ref == nil {
+			return 0, nil
+		}
+		query := &unstructured.Unstructured{}
+		query.SetAPIVersion(ref.APIVersion)
+		query.SetKind(ref.Kind)
+		query.SetNamespace(ref.Namespace)
+		query.SetName(ref.Name)
+
+		var err error
+		stampedObject, err = r.Repo.GetUnstructured(ctx, query)
+		if err != nil || stampedObject == nil {
+			logr.FromContextOrDiscard(ctx).V(logger.DEBUG).Info("no stamped object to read the requeue hint from", "stamped ref", ref)
+			return 0, nil
+		}
+	}
+
+	value, err := eval.EvaluatorBuilder().EvaluateJsonPath(runTemplate.Spec.RequeueAfterPath, stampedObject.UnstructuredContent())
+	if err != nil || value == nil {
+		return 0, nil
+	}
+
+	after, err := parseRequeueHint(value, r.now())
+	if err != nil {
+		return 0, err
+	}
+	if after < minRequeueHint {
+		return minRequeueHint, nil
+	}
+	return after, nil
+}
+
+func parseRequeueHint(value interface{}, now time.Time) (time.Duration, error) {
+	switch v := value.(type) {
+	case string:
+		if after, err := time.ParseDuration(v); err == nil {
+			return after, nil
+		}
+		at, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return 0, fmt.Errorf("[%s] is neither a duration nor an RFC 3339 timestamp", v)
+		}
+		return at.Sub(now), nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("[%v] of type [%T] is neither a duration, a timestamp nor a number of seconds", value, value)
+}
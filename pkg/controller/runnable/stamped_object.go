@@ -0,0 +1,73 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+// CurrentStampedObject passes the events of objects stamped for runnables, except those of an
+// object that replaced the runnable's stamped object under the same name. The replacement is
+// not acted on until the runnable stamps again and records its UID. Events are passed when
+// the owning runnable cannot be read.
+func CurrentStampedObject(repo repository.Repository, log logr.Logger) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.Kind != "Runnable" {
+			return true
+		}
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil || gv.Group != v1alpha1.SchemeGroupVersion.Group {
+			return true
+		}
+
+		runnable, err := repo.GetRunnable(context.Background(), owner.Name, obj.GetNamespace())
+		if err != nil {
+			log.Error(err, "failed to get runnable owning stamped object", "runnable", owner.Name)
+			return true
+		}
+		if runnable == nil {
+			return true
+		}
+
+		if replacesStampedObject(runnable, obj) {
+			log.Info("ignoring event of object replacing the stamped object",
+				"runnable", owner.Name, "object", obj.GetName(), "uid", obj.GetUID(), "stamped uid", runnable.StampedUID())
+			return false
+		}
+		return true
+	})
+}
+
+func replacesStampedObject(runnable *v1alpha1.Runnable, obj client.Object) bool {
+	ref := runnable.Status.StampedRef
+	if ref == nil || ref.UID == "" {
+		return false
+	}
+
+	return ref.Name == obj.GetName() &&
+		ref.Namespace == obj.GetNamespace() &&
+		ref.Kind == obj.GetObjectKind().GroupVersionKind().Kind &&
+		ref.UID != obj.GetUID()
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable_test
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller/runnable"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("CurrentStampedObject", func() {
+	var (
+		repo     *repositoryfakes.FakeRepository
+		rb       *v1alpha1.Runnable
+		filter   predicate.Predicate
+		stamp    func(name string, uid types.UID) *unstructured.Unstructured
+		passes   func(obj *unstructured.Unstructured) bool
+		ownerRef metav1.OwnerReference
+	)
+
+	BeforeEach(func() {
+		repo = &repositoryfakes.FakeRepository{}
+		rb = &v1alpha1.Runnable{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: "my-namespace"},
+			Status: v1alpha1.RunnableStatus{
+				StampedRef: &v1alpha1.ObjectReference{
+					Kind:       "MyThing",
+					Namespace:  "my-namespace",
+					Name:       "my-thing",
+					APIVersion: "thing.io/alphabeta1",
+					UID:        "first-uid",
+				},
+			},
+		}
+		repo.GetRunnableReturns(rb, nil)
+		filter = runnable.CurrentStampedObject(repo, logr.Discard())
+
+		ownerRef = metav1.OwnerReference{
+			APIVersion: "carto.run/v1alpha1",
+			Kind:       "Runnable",
+			Name:       "my-runnable",
+			Controller: pointer.BoolPtr(true),
+		}
+		stamp = func(name string, uid types.UID) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("thing.io/alphabeta1")
+			obj.SetKind("MyThing")
+			obj.SetNamespace("my-namespace")
+			obj.SetName(name)
+			obj.SetUID(uid)
+			obj.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
+			return obj
+		}
+		passes = func(obj *unstructured.Unstructured) bool {
+			return filter.Update(event.UpdateEvent{ObjectOld: obj, ObjectNew: obj})
+		}
+	})
+
+	It("passes the events of the recorded stamped object", func() {
+		Expect(passes(stamp("my-thing", "first-uid"))).To(BeTrue())
+
+		Expect(repo.GetRunnableCallCount()).To(Equal(1))
+		_, name, namespace := repo.GetRunnableArgsForCall(0)
+		Expect(name).To(Equal("my-runnable"))
+		Expect(namespace).To(Equal("my-namespace"))
+	})
+
+	Context("the stamped object is recreated with a new UID", func() {
+		It("ignores the events of the replacement", func() {
+			replacement := stamp("my-thing", "second-uid")
+			Expect(passes(replacement)).To(BeFalse())
+			Expect(filter.Create(event.CreateEvent{Object: replacement})).To(BeFalse())
+			Expect(filter.Delete(event.DeleteEvent{Object: replacement})).To(BeFalse())
+		})
+
+		It("passes the events of the replacement once the runnable records its UID", func() {
+			rb.Status.StampedRef.UID = "second-uid"
+			Expect(passes(stamp("my-thing", "second-uid"))).To(BeTrue())
+		})
+	})
+
+	It("passes the events of other objects stamped for the runnable", func() {
+		Expect(passes(stamp("my-thing-earlier", "earlier-uid"))).To(BeTrue())
+	})
+
+	It("passes events when the runnable has not recorded a UID", func() {
+		rb.Status.StampedRef.UID = ""
+		Expect(passes(stamp("my-thing", "second-uid"))).To(BeTrue())
+	})
+
+	It("passes events of objects not owned by a runnable", func() {
+		ownerRef.Kind = "Workload"
+		Expect(passes(stamp("my-thing", "second-uid"))).To(BeTrue())
+		Expect(repo.GetRunnableCallCount()).To(Equal(0))
+	})
+
+	It("passes events when the runnable cannot be read", func() {
+		repo.GetRunnableReturns(nil, errors.New("no runnable for you"))
+		Expect(passes(stamp("my-thing", "second-uid"))).To(BeTrue())
+	})
+})
@@ -96,14 +96,19 @@ func (r *Reconciler) reconcileSupplyChain(ctx context.Context, chain *v1alpha1.C
 	var resourcesNotFound []string
 
 	for _, resource := range chain.Spec.Resources {
-		template, err := r.Repo.GetClusterTemplate(ctx, resource.TemplateRef)
-		if err != nil {
-			log.Error(err, "failed to get cluster template", "template", resource.TemplateRef)
-			return controller.NewUnhandledError(fmt.Errorf("failed to get cluster template: %w", err))
+		_, err := r.Repo.GetClusterTemplate(ctx, resource.TemplateRef)
+		if repository.IsTemplateNotFound(err) && resource.Optional {
+			log.Info("cluster template of optional resource does not exist", "template", resource.TemplateRef)
+			continue
 		}
-		if template == nil {
+		if repository.IsTemplateNotFound(err) {
 			log.Info("cluster template does not exist", "template", resource.TemplateRef)
 			resourcesNotFound = append(resourcesNotFound, resource.Name)
+			continue
+		}
+		if err != nil {
+			log.Error(err, "failed to get cluster template", "template", resource.TemplateRef)
+			return controller.NewUnhandledError(fmt.Errorf("failed to get cluster template: %w", err))
 		}
 	}
 
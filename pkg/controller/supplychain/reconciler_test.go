@@ -34,6 +34,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/conditions/conditionsfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/supplychain"
 	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 )
 
@@ -186,7 +187,7 @@ var _ = Describe("Reconciler", func() {
 	Context("cannot find cluster template", func() {
 		BeforeEach(func() {
 			repo.GetClusterTemplateReturnsOnCall(0, &v1alpha1.ClusterTemplate{}, nil)
-			repo.GetClusterTemplateReturnsOnCall(1, nil, nil)
+			repo.GetClusterTemplateReturnsOnCall(1, nil, repository.TemplateNotFoundError{Kind: "ClusterTemplate", Name: "second-template"})
 		})
 
 		It("adds a positive templates NOT found condition", func() {
@@ -198,6 +199,17 @@ var _ = Describe("Reconciler", func() {
 			_, err := reconciler.Reconcile(ctx, req)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("and the resource is optional", func() {
+			BeforeEach(func() {
+				sc.Spec.Resources[1].Optional = true
+			})
+
+			It("adds a positive templates found condition", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(supplychain.TemplatesFoundCondition()))
+			})
+		})
 	})
 
 	Context("when the update fails", func() {
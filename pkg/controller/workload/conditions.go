@@ -16,12 +16,12 @@ package workload
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
-	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
 // -- Supply Chain conditions
@@ -52,72 +52,67 @@ func SupplyChainNotFoundCondition(labels map[string]string) metav1.Condition {
 	}
 }
 
-func TooManySupplyChainMatchesCondition() metav1.Condition {
+func PinnedSupplyChainNotFoundCondition(name string) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.WorkloadSupplyChainReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.MultipleMatchesSupplyChainReadyReason,
-		Message: "workload may only match a single supply chain's selector",
+		Reason:  v1alpha1.PinnedSupplyChainInvalidSupplyChainReadyReason,
+		Message: fmt.Sprintf("supply chain [%s] pinned by annotation [%s] not found", name, v1alpha1.WorkloadSupplyChainAnnotation),
 	}
 }
 
-func MissingReadyInSupplyChainCondition(supplyChainReadyCondition metav1.Condition) metav1.Condition {
+func PinnedSupplyChainSelectorNotSatisfiedCondition(name string, labels map[string]string) metav1.Condition {
 	return metav1.Condition{
 		Type:    v1alpha1.WorkloadSupplyChainReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  supplyChainReadyCondition.Reason,
-		Message: supplyChainReadyCondition.Message,
+		Reason:  v1alpha1.PinnedSupplyChainInvalidSupplyChainReadyReason,
+		Message: fmt.Sprintf("selector of supply chain [%s] pinned by annotation [%s] is not satisfied by labels: %+v", name, v1alpha1.WorkloadSupplyChainAnnotation, labels),
 	}
 }
 
-// -- Resource conditions
-
-func ResourcesSubmittedCondition() metav1.Condition {
+func TooManySupplyChainMatchesCondition() metav1.Condition {
 	return metav1.Condition{
-		Type:   v1alpha1.WorkloadResourceSubmitted,
-		Status: metav1.ConditionTrue,
-		Reason: v1alpha1.CompleteResourcesSubmittedReason,
+		Type:    v1alpha1.WorkloadSupplyChainReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.MultipleMatchesSupplyChainReadyReason,
+		Message: "workload may only match a single supply chain's selector",
 	}
 }
 
-func TemplateObjectRetrievalFailureCondition(err error) metav1.Condition {
+func SupplyChainTerminatingCondition(name string) metav1.Condition {
 	return metav1.Condition{
-		Type:    v1alpha1.WorkloadResourceSubmitted,
+		Type:    v1alpha1.WorkloadSupplyChainReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason,
-		Message: err.Error(),
+		Reason:  v1alpha1.TerminatingSupplyChainReadyReason,
+		Message: fmt.Sprintf("supply chain [%s] is being deleted", name),
 	}
 }
 
-func MissingValueAtPathCondition(obj *unstructured.Unstructured, expression string) metav1.Condition {
-	var namespaceMsg string
-	if obj.GetNamespace() != "" {
-		namespaceMsg = fmt.Sprintf(" in namespace [%s]", obj.GetNamespace())
-	}
+func SupplyChainPausedCondition(name string) metav1.Condition {
 	return metav1.Condition{
-		Type:   v1alpha1.WorkloadResourceSubmitted,
-		Status: metav1.ConditionUnknown,
-		Reason: v1alpha1.MissingValueAtPathResourcesSubmittedReason,
-		Message: fmt.Sprintf("Waiting to read value [%s] from resource [%s/%s]%s",
-			expression, utils.GetFullyQualifiedType(obj), obj.GetName(), namespaceMsg),
+		Type:    v1alpha1.WorkloadSupplyChainReady,
+		Status:  metav1.ConditionUnknown,
+		Reason:  v1alpha1.PausedSupplyChainReadyReason,
+		Message: fmt.Sprintf("supply chain [%s] is paused by annotation [%s]", name, v1alpha1.SupplyChainPauseAnnotation),
 	}
 }
 
-func TemplateStampFailureCondition(err error) metav1.Condition {
+func MissingReadyInSupplyChainCondition(supplyChainReadyCondition metav1.Condition) metav1.Condition {
 	return metav1.Condition{
-		Type:    v1alpha1.WorkloadResourceSubmitted,
+		Type:    v1alpha1.WorkloadSupplyChainReady,
 		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
-		Message: err.Error(),
+		Reason:  supplyChainReadyCondition.Reason,
+		Message: supplyChainReadyCondition.Message,
 	}
 }
 
-func TemplateRejectedByAPIServerCondition(err error) metav1.Condition {
+// -- Resource conditions
+
+func ResourcesSubmittedCondition() metav1.Condition {
 	return metav1.Condition{
-		Type:    v1alpha1.WorkloadResourceSubmitted,
-		Status:  metav1.ConditionFalse,
-		Reason:  v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason,
-		Message: err.Error(),
+		Type:   v1alpha1.WorkloadResourceSubmitted,
+		Status: metav1.ConditionTrue,
+		Reason: v1alpha1.CompleteResourcesSubmittedReason,
 	}
 }
 
@@ -147,3 +142,34 @@ func ResourceRealizerBuilderErrorCondition(err error) metav1.Condition {
 		Message: err.Error(),
 	}
 }
+
+func OutputOverriddenCondition(resourceNames []string) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.WorkloadOutputOverridden,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.AnnotationOutputOverriddenReason,
+		Message: fmt.Sprintf("outputs of resources %v are overridden by workload annotations", resourceNames),
+	}
+}
+
+// OutputPathWarningCondition is informational, it is positive so that it never makes the
+// workload unready.
+func OutputPathWarningCondition(warnings map[string][]string) metav1.Condition {
+	var resourceNames []string
+	for name := range warnings {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+
+	var messages []string
+	for _, name := range resourceNames {
+		messages = append(messages, fmt.Sprintf("resource [%s]: %s", name, strings.Join(warnings[name], ", ")))
+	}
+
+	return metav1.Condition{
+		Type:    v1alpha1.WorkloadOutputPathWarning,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.OutputPathNotInStatusOutputPathWarningReason,
+		Message: strings.Join(messages, "; "),
+	}
+}
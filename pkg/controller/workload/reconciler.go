@@ -21,8 +21,11 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
@@ -36,13 +39,24 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
+// SupplyChainChangedEventReason is the reason of the event recorded when a workload is
+// realized by a different supply chain than the one recorded in its status.
+const SupplyChainChangedEventReason = "SupplyChainChanged"
+
 type Reconciler struct {
 	Repo                    repository.Repository
 	ConditionManagerBuilder conditions.ConditionManagerBuilder
 	ResourceRealizerBuilder realizer.ResourceRealizerBuilder
 	Realizer                realizer.Realizer
 	DynamicTracker          tracker.DynamicTracker
-	conditionManager        conditions.ConditionManager
+	ForbiddenRetry          controller.ForbiddenRetryOptions
+	EventRecorder           record.EventRecorder
+	// AllowOutputOverrides reports the outputs overridden by workload annotations in the
+	// OutputOverridden condition.
+	AllowOutputOverrides bool
+	// Pause leaves the workload alone while cartographer is paused for maintenance.
+	Pause            controller.Pause
+	conditionManager conditions.ConditionManager
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -67,9 +81,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	r.conditionManager = r.ConditionManagerBuilder(v1alpha1.WorkloadReady, workload.Status.Conditions)
 
+	paused, err := r.Pause.Paused(ctx)
+	if err != nil {
+		log.Error(err, "failed to read whether cartographer is paused")
+		return ctrl.Result{}, err
+	}
+	if paused {
+		r.conditionManager.AddNegative(r.Pause.PausedCondition())
+		log.Info("cartographer is paused")
+		return r.completeReconciliation(ctx, workload, pausedResources(workload), nil, nil, nil)
+	}
+
 	supplyChain, err := r.getSupplyChainsForWorkload(ctx, workload)
 	if err != nil {
-		return r.completeReconciliation(ctx, workload, err)
+		return r.completeReconciliation(ctx, workload, nil, nil, nil, err)
 	}
 
 	log = log.WithValues("supply chain", supplyChain.Name)
@@ -78,55 +103,75 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	supplyChainGVK, err := utils.GetObjectGVK(supplyChain, r.Repo.GetScheme())
 	if err != nil {
 		log.Error(err, "failed to get object gvk for supply chain")
-		return r.completeReconciliation(ctx, workload, controller.NewUnhandledError(
+		return r.completeReconciliation(ctx, workload, nil, nil, nil, controller.NewUnhandledError(
 			fmt.Errorf("failed to get object gvk for supply chain [%s]: %w", supplyChain.Name, err)))
 	}
 
+	if previous := workload.Status.SupplyChainRef.Name; previous != "" && previous != supplyChain.Name {
+		log.Info("supply chain selected for workload changed", "previous supply chain", previous)
+		r.EventRecorder.Eventf(workload, corev1.EventTypeNormal, SupplyChainChangedEventReason,
+			"supply chain changed from [%s] to [%s]", previous, supplyChain.Name)
+	}
+
 	workload.Status.SupplyChainRef.Kind = supplyChainGVK.Kind
 	workload.Status.SupplyChainRef.Name = supplyChain.Name
 
+	if supplyChain.DeletionTimestamp != nil {
+		r.conditionManager.AddPositive(SupplyChainTerminatingCondition(supplyChain.Name))
+		log.Info("supply chain is being deleted")
+		return r.completeReconciliation(ctx, workload, nil, nil, nil, fmt.Errorf("supply chain [%s] is being deleted", supplyChain.Name))
+	}
+
+	if supplyChain.Paused() {
+		r.conditionManager.AddPositive(SupplyChainPausedCondition(supplyChain.Name))
+		log.Info("supply chain is paused")
+		return r.completeReconciliation(ctx, workload, pausedResources(workload), nil, nil, nil)
+	}
+
 	if !r.isSupplyChainReady(supplyChain) {
 		r.conditionManager.AddPositive(MissingReadyInSupplyChainCondition(getSupplyChainReadyCondition(supplyChain)))
 		log.Info("supply chain is not in ready state")
-		return r.completeReconciliation(ctx, workload, fmt.Errorf("supply chain [%s] is not in ready state", supplyChain.Name))
+		return r.completeReconciliation(ctx, workload, nil, nil, nil, fmt.Errorf("supply chain [%s] is not in ready state", supplyChain.Name))
 	}
 	r.conditionManager.AddPositive(SupplyChainReadyCondition())
 
-	serviceAccountName, serviceAccountNS := getServiceAccountNameAndNamespace(workload, supplyChain)
+	serviceAccountName, serviceAccountNS := ServiceAccountNameAndNamespace(workload, supplyChain)
+
+	getServiceAccountSecret := r.Repo.GetServiceAccountSecret
+	if workload.Status.ForbiddenRetries > 0 {
+		// a forbidden apply may be down to a rotated token the cache has not caught up with
+		getServiceAccountSecret = r.Repo.GetServiceAccountSecretLive
+	}
 
-	secret, err := r.Repo.GetServiceAccountSecret(ctx, serviceAccountName, serviceAccountNS)
+	secret, err := getServiceAccountSecret(ctx, serviceAccountName, serviceAccountNS)
 	if err != nil {
 		r.conditionManager.AddPositive(ServiceAccountSecretNotFoundCondition(err))
 		log.Info("failed to get service account secret", "service account", workload.Spec.ServiceAccountName)
-		return r.completeReconciliation(ctx, workload, fmt.Errorf("failed to get service account secret [%s]: %w", workload.Spec.ServiceAccountName, err))
+		return r.completeReconciliation(ctx, workload, nil, nil, nil, fmt.Errorf("failed to get service account secret [%s]: %w", workload.Spec.ServiceAccountName, err))
 	}
 
 	resourceRealizer, err := r.ResourceRealizerBuilder(secret, workload, r.Repo, supplyChain.Spec.Params)
 	if err != nil {
 		r.conditionManager.AddPositive(ResourceRealizerBuilderErrorCondition(err))
 		log.Error(err, "failed to build resource realizer")
-		return r.completeReconciliation(ctx, workload, controller.NewUnhandledError(
+		return r.completeReconciliation(ctx, workload, nil, nil, nil, controller.NewUnhandledError(
 			fmt.Errorf("failed to build resource realizer: %w", err)))
 	}
 
-	stampedObjects, err := r.Realizer.Realize(ctx, resourceRealizer, supplyChain)
+	result, err := r.Realizer.Realize(ctx, resourceRealizer, supplyChain)
+	if ctx.Err() != nil {
+		log.Info("reconcile cancelled, requeueing without updating status", "error", ctx.Err().Error())
+		return ctrl.Result{Requeue: true}, nil
+	}
+	stampedObjects := result.StampedObjects
 	if err != nil {
 		log.V(logger.DEBUG).Info("failed to realize")
-		switch typedErr := err.(type) {
-		case realizer.GetClusterTemplateError:
-			r.conditionManager.AddPositive(TemplateObjectRetrievalFailureCondition(typedErr))
-			err = controller.NewUnhandledError(err)
-		case realizer.StampError:
-			r.conditionManager.AddPositive(TemplateStampFailureCondition(typedErr))
-		case realizer.ApplyStampedObjectError:
-			r.conditionManager.AddPositive(TemplateRejectedByAPIServerCondition(typedErr))
-			if !kerrors.IsForbidden(typedErr.Err) {
-				err = controller.NewUnhandledError(err)
-			}
-		case realizer.RetrieveOutputError:
-			r.conditionManager.AddPositive(MissingValueAtPathCondition(typedErr.StampedObject, typedErr.JsonPathExpression()))
-		default:
-			r.conditionManager.AddPositive(UnknownResourceErrorCondition(typedErr))
+		condition, handled := conditions.FromRealizeError(err)
+		if condition.Type == "" {
+			condition = UnknownResourceErrorCondition(err)
+		}
+		r.conditionManager.AddPositive(condition)
+		if !handled {
 			err = controller.NewUnhandledError(err)
 		}
 	} else {
@@ -138,12 +183,30 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		r.conditionManager.AddPositive(ResourcesSubmittedCondition())
 	}
+	resources := resourceSummaries(supplyChain, result.RealizationOrder, stampedObjects, result.Skipped, err)
+	for i := range resources {
+		resources[i].OutputHash = result.OutputHashes[resources[i].Name]
+	}
+
+	if r.AllowOutputOverrides {
+		if overridden := overriddenResources(workload, supplyChain, result.Skipped); len(overridden) > 0 {
+			r.conditionManager.AddPositive(OutputOverriddenCondition(overridden))
+		}
+	}
+
+	if len(result.OutputWarnings) > 0 {
+		r.conditionManager.AddPositive(OutputPathWarningCondition(result.OutputWarnings))
+	}
 
 	var trackingError error
 	if len(stampedObjects) > 0 {
 		for _, stampedObject := range stampedObjects {
-			trackingError = r.DynamicTracker.Watch(log, stampedObject, &handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Workload{}})
-			if trackingError != nil {
+			trackingError = r.DynamicTracker.Watch(log, stampedObject, &handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Workload{}}, 0)
+			if pendingErr, ok := trackingError.(tracker.WatchPendingError); ok {
+				log.Info("watch on object pending",
+					"object", stampedObject, "retry after", pendingErr.RetryAfter)
+				err = pendingErr
+			} else if trackingError != nil {
 				log.Error(err, "failed to add informer for object",
 					"object", stampedObject)
 				err = controller.NewUnhandledError(trackingError)
@@ -154,14 +217,45 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
-	return r.completeReconciliation(ctx, workload, err)
+	return r.completeReconciliation(ctx, workload, resources, result.RealizationOrder, debugParams(workload, resourceRealizer), err)
 }
 
-func (r *Reconciler) completeReconciliation(ctx context.Context, workload *v1alpha1.Workload, err error) (ctrl.Result, error) {
+// completeReconciliation records the outcome of the reconcile in the workload's status. A nil
+// realizationOrder leaves the recorded order as it is, as does a nil debug while the workload
+// asks for debug params; without the annotation the debug report is removed.
+func (r *Reconciler) completeReconciliation(ctx context.Context, workload *v1alpha1.Workload, resources []v1alpha1.ResourceSummary, realizationOrder []string, debug *v1alpha1.WorkloadDebug, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	var changed bool
 	workload.Status.Conditions, changed = r.conditionManager.Finalize()
 
+	forbiddenRetries, requeueAfter := r.ForbiddenRetry.Next(workload.Status.ForbiddenRetries, isForbiddenApplyError(err))
+	if workload.Status.ForbiddenRetries != forbiddenRetries {
+		workload.Status.ForbiddenRetries = forbiddenRetries
+		changed = true
+	}
+
+	if realizationOrder != nil && !equality.Semantic.DeepEqual(workload.Status.RealizationOrder, realizationOrder) {
+		workload.Status.RealizationOrder = realizationOrder
+		changed = true
+	}
+
+	if workload.Annotations[v1alpha1.WorkloadDebugParamsAnnotation] != "true" {
+		debug = nil
+	} else if debug == nil {
+		debug = workload.Status.Debug
+	}
+	if !equality.Semantic.DeepEqual(workload.Status.Debug, debug) {
+		workload.Status.Debug = debug
+		changed = true
+	}
+
+	keepTransitionTimes(workload.Status.Summary, resources)
+	summary := workloadSummary(workload.Status.Conditions, resources)
+	if !equality.Semantic.DeepEqual(workload.Status.Summary, summary) {
+		workload.Status.Summary = summary
+		changed = true
+	}
+
 	var updateErr error
 	if changed || (workload.Status.ObservedGeneration != workload.Generation) {
 		workload.Status.ObservedGeneration = workload.Generation
@@ -180,9 +274,37 @@ func (r *Reconciler) completeReconciliation(ctx context.Context, workload *v1alp
 		log.Info("handled error reconciling workload", "handled error", err)
 	}
 
+	if pendingErr, ok := err.(tracker.WatchPendingError); ok {
+		log.Info("requeueing until the watch on the stamped object is established", "requeue after", pendingErr.RetryAfter)
+		return ctrl.Result{RequeueAfter: pendingErr.RetryAfter}, nil
+	}
+
+	if requeueAfter > 0 {
+		log.Info("stamped object was forbidden, requeueing in case RBAC has not yet propagated",
+			"retry", forbiddenRetries, "max retries", r.ForbiddenRetry.MaxRetries, "requeue after", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// pausedResources keeps the summary of the resources as it was when the supply chain, or
+// cartographer, was paused
+func pausedResources(workload *v1alpha1.Workload) []v1alpha1.ResourceSummary {
+	if workload.Status.Summary == nil {
+		return nil
+	}
+	return workload.Status.Summary.Resources
+}
+
+func isForbiddenApplyError(err error) bool {
+	if _, ok := err.(realizer.InsufficientPermissionsError); ok {
+		return true
+	}
+	applyErr, ok := err.(realizer.ApplyStampedObjectError)
+	return ok && kerrors.IsForbidden(applyErr.Err)
+}
+
 func (r *Reconciler) isSupplyChainReady(supplyChain *v1alpha1.ClusterSupplyChain) bool {
 	supplyChainReadyCondition := getSupplyChainReadyCondition(supplyChain)
 	return supplyChainReadyCondition.Status == "True"
@@ -206,6 +328,10 @@ func (r *Reconciler) getSupplyChainsForWorkload(ctx context.Context, workload *v
 			workload.Namespace, workload.Name)
 	}
 
+	if pinnedName, ok := workload.Annotations[v1alpha1.WorkloadSupplyChainAnnotation]; ok {
+		return r.getPinnedSupplyChain(ctx, workload, pinnedName)
+	}
+
 	supplyChains, err := r.Repo.GetSupplyChainsForWorkload(ctx, workload)
 	if err != nil {
 		log.Error(err, "failed to get supply chains for workload")
@@ -233,6 +359,34 @@ func (r *Reconciler) getSupplyChainsForWorkload(ctx context.Context, workload *v
 	return supplyChains[0], nil
 }
 
+func (r *Reconciler) getPinnedSupplyChain(ctx context.Context, workload *v1alpha1.Workload, name string) (*v1alpha1.ClusterSupplyChain, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("pinned supply chain", name)
+
+	supplyChain, err := r.Repo.GetSupplyChain(ctx, name)
+	if err != nil {
+		log.Error(err, "failed to get pinned supply chain")
+		return nil, controller.NewUnhandledError(fmt.Errorf("failed to get supply chain [%s] pinned by workload [%s/%s]: %w",
+			name, workload.Namespace, workload.Name, err))
+	}
+
+	if supplyChain == nil {
+		r.conditionManager.AddPositive(PinnedSupplyChainNotFoundCondition(name))
+		log.Info("pinned supply chain not found")
+		return nil, fmt.Errorf("supply chain [%s] pinned by workload [%s/%s] not found",
+			name, workload.Namespace, workload.Name)
+	}
+
+	if !repository.SelectorSatisfied(workload, supplyChain) {
+		r.conditionManager.AddPositive(PinnedSupplyChainSelectorNotSatisfiedCondition(name, workload.Labels))
+		log.Info("pinned supply chain selector is not satisfied by labels", "labels", workload.Labels)
+		return nil, fmt.Errorf("selector of supply chain [%s] pinned by workload [%s/%s] is not satisfied by labels: %v",
+			name, workload.Namespace, workload.Name, workload.Labels)
+	}
+
+	log.V(logger.DEBUG).Info("pinned supply chain selected for workload")
+	return supplyChain, nil
+}
+
 func getSupplyChainNames(objs []*v1alpha1.ClusterSupplyChain) []string {
 	var names []string
 	for _, obj := range objs {
@@ -242,7 +396,8 @@ func getSupplyChainNames(objs []*v1alpha1.ClusterSupplyChain) []string {
 	return names
 }
 
-func getServiceAccountNameAndNamespace(workload *v1alpha1.Workload, supplyChain *v1alpha1.ClusterSupplyChain) (string, string) {
+// ServiceAccountNameAndNamespace is the service account the workload's objects are stamped as
+func ServiceAccountNameAndNamespace(workload *v1alpha1.Workload, supplyChain *v1alpha1.ClusterSupplyChain) (string, string) {
 	serviceAccountName := "default"
 	serviceAccountNS := workload.Namespace
 
@@ -257,3 +412,34 @@ func getServiceAccountNameAndNamespace(workload *v1alpha1.Workload, supplyChain
 
 	return serviceAccountName, serviceAccountNS
 }
+
+// overriddenResources returns the names of the supply chain's resources, other than the skipped
+// ones, whose outputs the workload's annotations override.
+func overriddenResources(workload *v1alpha1.Workload, supplyChain *v1alpha1.ClusterSupplyChain, skipped []string) []string {
+	skippedResources := make(map[string]bool)
+	for _, name := range skipped {
+		skippedResources[name] = true
+	}
+
+	var names []string
+	for _, resource := range supplyChain.Spec.Resources {
+		if skippedResources[resource.Name] {
+			continue
+		}
+		if len(realizer.OutputOverrides(workload, resource.Name)) > 0 {
+			names = append(names, resource.Name)
+		}
+	}
+
+	return names
+}
+
+// debugParams reports the param resolutions of the stamped resources when the workload asks for
+// them with the carto.run/debug-params annotation.
+func debugParams(workload *v1alpha1.Workload, resourceRealizer realizer.ResourceRealizer) *v1alpha1.WorkloadDebug {
+	if workload.Annotations[v1alpha1.WorkloadDebugParamsAnnotation] != "true" {
+		return nil
+	}
+
+	return &v1alpha1.WorkloadDebug{Params: resourceRealizer.ParamResolutions()}
+}
@@ -17,6 +17,7 @@ package workload_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
@@ -30,13 +31,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions/conditionsfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/workload"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/workload/workloadfakes"
@@ -44,6 +48,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/trackerfakes"
 )
 
@@ -64,6 +69,7 @@ var _ = Describe("Reconciler", func() {
 		serviceAccountSecret         *corev1.Secret
 		serviceAccountName           string
 		resourceRealizerBuilderError error
+		eventRecorder                *record.FakeRecorder
 	)
 
 	BeforeEach(func() {
@@ -78,10 +84,12 @@ var _ = Describe("Reconciler", func() {
 		}
 
 		rlzr = &workloadfakes.FakeRealizer{}
-		rlzr.RealizeReturns(nil, nil)
+		rlzr.RealizeReturns(realizer.Result{}, nil)
 
 		dynamicTracker = &trackerfakes.FakeDynamicTracker{}
 
+		eventRecorder = record.NewFakeRecorder(10)
+
 		repo = &repositoryfakes.FakeRepository{}
 		scheme := runtime.NewScheme()
 		err := registrar.AddToScheme(scheme)
@@ -107,6 +115,7 @@ var _ = Describe("Reconciler", func() {
 			ResourceRealizerBuilder: resourceRealizerBuilder,
 			Realizer:                rlzr,
 			DynamicTracker:          dynamicTracker,
+			EventRecorder:           eventRecorder,
 		}
 
 		req = ctrl.Request{
@@ -235,7 +244,7 @@ var _ = Describe("Reconciler", func() {
 				Version: "goodbye",
 				Kind:    "NiceToSeeYou",
 			})
-			rlzr.RealizeReturns([]*unstructured.Unstructured{stampedObject1, stampedObject2}, nil)
+			rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1, stampedObject2}}, nil)
 		})
 
 		It("dynamically creates a resource realizer", func() {
@@ -246,6 +255,56 @@ var _ = Describe("Reconciler", func() {
 			Expect(resourceRealizer).To(Equal(builtResourceRealizer))
 		})
 
+		It("reads the service account secret through the cache", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.GetServiceAccountSecretCallCount()).To(Equal(1))
+			Expect(repo.GetServiceAccountSecretLiveCallCount()).To(Equal(0))
+		})
+
+		Context("when the reconcile is cancelled while the supply chain is realized", func() {
+			var cancel context.CancelFunc
+
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(ctx)
+				rlzr.RealizeCalls(func(context.Context, realizer.ResourceRealizer, *v1alpha1.ClusterSupplyChain) (realizer.Result, error) {
+					cancel()
+					return realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1}}, context.Canceled
+				})
+			})
+
+			AfterEach(func() {
+				cancel()
+			})
+
+			It("requeues without recording a failure in the status", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{Requeue: true}))
+
+				Expect(conditionManager.AddPositiveCallCount()).To(Equal(1))
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.SupplyChainReadyCondition()))
+				Expect(conditionManager.FinalizeCallCount()).To(Equal(0))
+				Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a stamped object was forbidden in the previous reconcile", func() {
+			BeforeEach(func() {
+				wl.Status.ForbiddenRetries = 1
+				repo.GetServiceAccountSecretLiveReturns(serviceAccountSecret, nil)
+			})
+
+			It("reads the service account secret live", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.GetServiceAccountSecretCallCount()).To(Equal(0))
+				Expect(repo.GetServiceAccountSecretLiveCallCount()).To(Equal(1))
+				Expect(resourceRealizerSecret).To(Equal(serviceAccountSecret))
+			})
+		})
+
 		It("uses the service account specified by the workload for realizing resources", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 
@@ -335,20 +394,523 @@ var _ = Describe("Reconciler", func() {
 			Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(workload.ResourcesSubmittedCondition()))
 		})
 
+		Context("when the workload was previously realized by another supply chain", func() {
+			BeforeEach(func() {
+				wl.Labels = map[string]string{"app": "worker"}
+				wl.Status.SupplyChainRef = v1alpha1.ObjectReference{Kind: "ClusterSupplyChain", Name: "previous-supply-chain"}
+			})
+
+			It("records a SupplyChainChanged event naming both supply chains", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(eventRecorder.Events).To(Receive(Equal("Normal SupplyChainChanged supply chain changed from [previous-supply-chain] to [some-supply-chain]")))
+				Expect(wl.Status.SupplyChainRef.Name).To(Equal(supplyChainName))
+			})
+		})
+
+		Context("when the workload was previously realized by the same supply chain", func() {
+			BeforeEach(func() {
+				wl.Status.SupplyChainRef = v1alpha1.ObjectReference{Kind: "ClusterSupplyChain", Name: supplyChainName}
+			})
+
+			It("does not record an event", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(eventRecorder.Events).NotTo(Receive())
+			})
+		})
+
+		It("does not record an event the first time a supply chain is selected", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+			Expect(eventRecorder.Events).NotTo(Receive())
+		})
+
+		Context("when the workload overrides the output of a resource", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{
+					{Name: "source-provider"},
+					{Name: "image-builder"},
+				}
+				wl.Annotations = map[string]string{
+					"carto.run/override-output.image-builder.image": "my-registry/known-good@sha256:abc",
+				}
+			})
+
+			Context("and overrides are allowed", func() {
+				BeforeEach(func() {
+					reconciler.AllowOutputOverrides = true
+				})
+
+				It("reports the overridden resource in the OutputOverridden condition", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveCallCount()).To(Equal(3))
+					Expect(conditionManager.AddPositiveArgsForCall(2)).To(Equal(workload.OutputOverriddenCondition([]string{"image-builder"})))
+				})
+
+				It("does not report a resource that was skipped", func() {
+					rlzr.RealizeReturns(realizer.Result{Skipped: []string{"image-builder"}}, nil)
+
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("and overrides are not allowed", func() {
+				It("does not report an OutputOverridden condition", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when an output path of a resource reads the spec of its stamped object", func() {
+			BeforeEach(func() {
+				rlzr.RealizeReturns(realizer.Result{OutputWarnings: map[string][]string{
+					"image-builder": {"output path [.spec.image] reads .spec of the stamped object rather than .status"},
+				}}, nil)
+			})
+
+			It("reports the warning in the OutputPathWarning condition", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveCallCount()).To(Equal(3))
+				condition := conditionManager.AddPositiveArgsForCall(2)
+				Expect(condition.Type).To(Equal(v1alpha1.WorkloadOutputPathWarning))
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(Equal("resource [image-builder]: output path [.spec.image] reads .spec of the stamped object rather than .status"))
+			})
+		})
+
+		It("does not report an OutputPathWarning condition when no output path warns", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+			Expect(conditionManager.AddPositiveCallCount()).To(Equal(2))
+		})
+
 		It("watches the stampedObjects kinds", func() {
 			_, _ = reconciler.Reconcile(ctx, req)
 			Expect(dynamicTracker.WatchCallCount()).To(Equal(2))
-			_, obj, hndl := dynamicTracker.WatchArgsForCall(0)
+			_, obj, hndl, _, _ := dynamicTracker.WatchArgsForCall(0)
 
 			Expect(obj).To(Equal(stampedObject1))
 			Expect(hndl).To(Equal(&handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Workload{}}))
 
-			_, obj, hndl = dynamicTracker.WatchArgsForCall(1)
+			_, obj, hndl, _, _ = dynamicTracker.WatchArgsForCall(1)
 
 			Expect(obj).To(Equal(stampedObject2))
 			Expect(hndl).To(Equal(&handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Workload{}}))
 		})
 
+		Context("recording the realization order", func() {
+			BeforeEach(func() {
+				rlzr.RealizeReturns(realizer.Result{
+					StampedObjects:   []*unstructured.Unstructured{stampedObject1, stampedObject2},
+					RealizationOrder: []string{"source-provider", "image-builder", "deployer"},
+				}, nil)
+				conditionManager.FinalizeReturns([]metav1.Condition{
+					{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionTrue},
+				}, false)
+				wl.Status.ObservedGeneration = wl.Generation
+			})
+
+			It("records the order the resources were realized in", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedWorkload.(*v1alpha1.Workload).Status.RealizationOrder).To(Equal([]string{"source-provider", "image-builder", "deployer"}))
+			})
+
+			It("does not update the status again while the order is unchanged", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("reporting how params were resolved", func() {
+			var resolutions []v1alpha1.ParamResolution
+
+			BeforeEach(func() {
+				resolutions = []v1alpha1.ParamResolution{{
+					Resource:   "image-builder",
+					Name:       "registry",
+					Layer:      v1alpha1.ParamLayerResource,
+					Overridden: []v1alpha1.ParamLayer{v1alpha1.ParamLayerTemplate, v1alpha1.ParamLayerBlueprint},
+				}}
+				rlzr.RealizeCalls(func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, supplyChain *v1alpha1.ClusterSupplyChain) (realizer.Result, error) {
+					resourceRealizer.(*workloadfakes.FakeResourceRealizer).ParamResolutionsReturns(resolutions)
+					return realizer.Result{}, nil
+				})
+			})
+
+			Context("when the workload asks for debug params", func() {
+				BeforeEach(func() {
+					wl.Annotations = map[string]string{v1alpha1.WorkloadDebugParamsAnnotation: "true"}
+				})
+
+				It("records the winning layer of each conflicting param in status.debug", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.Debug).To(Equal(&v1alpha1.WorkloadDebug{Params: resolutions}))
+				})
+			})
+
+			Context("when the workload does not ask for debug params", func() {
+				BeforeEach(func() {
+					wl.Status.Debug = &v1alpha1.WorkloadDebug{Params: resolutions}
+				})
+
+				It("removes the debug report", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+					_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+					Expect(updatedWorkload.(*v1alpha1.Workload).Status.Debug).To(BeNil())
+				})
+			})
+		})
+
+		Context("summarizing the supply chain resources", func() {
+			var updatedSummary = func() *v1alpha1.WorkloadSummary {
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				return updatedWorkload.(*v1alpha1.Workload).Status.Summary
+			}
+
+			BeforeEach(func() {
+				supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{
+					{Name: "source-provider"},
+					{Name: "image-builder"},
+					{Name: "deployer"},
+				}
+				conditionManager.FinalizeReturns([]metav1.Condition{
+					{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionTrue},
+				}, true)
+			})
+
+			Context("when every resource is realized", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1, stampedObject2, stampedObject1}}, nil)
+				})
+
+				It("reports every resource as having its output available and the overall ready status", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary()).To(Equal(&v1alpha1.WorkloadSummary{
+						Ready: metav1.ConditionTrue,
+						Resources: []v1alpha1.ResourceSummary{
+							{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+							{Name: "image-builder", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+							{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+						},
+					}))
+				})
+
+				It("records the hash of each output that was read", func() {
+					rlzr.RealizeReturns(realizer.Result{
+						StampedObjects: []*unstructured.Unstructured{stampedObject1, stampedObject2, stampedObject1},
+						OutputHashes:   map[string]string{"source-provider": "aaaa1111", "image-builder": "bbbb2222"},
+					}, nil)
+
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary().Resources).To(Equal([]v1alpha1.ResourceSummary{
+						{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable, OutputHash: "aaaa1111"},
+						{Name: "image-builder", Phase: v1alpha1.ResourcePhaseOutputAvailable, OutputHash: "bbbb2222"},
+						{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+					}))
+				})
+
+				Context("and the status already holds the same summary", func() {
+					BeforeEach(func() {
+						wl.Status.ObservedGeneration = wl.Generation
+						wl.Status.Summary = &v1alpha1.WorkloadSummary{
+							Ready: metav1.ConditionTrue,
+							Resources: []v1alpha1.ResourceSummary{
+								{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+								{Name: "image-builder", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+								{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+							},
+						}
+						conditionManager.FinalizeReturns([]metav1.Condition{
+							{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionTrue},
+						}, false)
+					})
+
+					It("does not update the status", func() {
+						_, _ = reconciler.Reconcile(ctx, req)
+						Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when a resource's output is not available yet", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1, stampedObject2}}, realizer.RetrieveOutputError{
+						Err:           errors.New("some error"),
+						Resource:      &supplyChain.Spec.Resources[1],
+						StampedObject: stampedObject2,
+					})
+					conditionManager.FinalizeReturns([]metav1.Condition{
+						{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionUnknown},
+					}, true)
+				})
+
+				It("reports the resource as healthy and the following resources as stamping", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary()).To(Equal(&v1alpha1.WorkloadSummary{
+						Ready: metav1.ConditionUnknown,
+						Resources: []v1alpha1.ResourceSummary{
+							{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+							{Name: "image-builder", Phase: v1alpha1.ResourcePhaseHealthy},
+							{Name: "deployer", Phase: v1alpha1.ResourcePhaseStamping},
+						},
+					}))
+				})
+			})
+
+			Context("when a resource is waiting on the output of an upstream resource", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1, stampedObject2}}, realizer.ResourceErrors{
+						Errors: []realizer.ResourceError{
+							{
+								Err: realizer.RetrieveOutputError{
+									Err:           templates.NewJsonPathError("status.artifact", errors.New("not found")),
+									Resource:      &supplyChain.Spec.Resources[0],
+									StampedObject: stampedObject1,
+								},
+								ResourceName: "source-provider",
+							},
+						},
+						Waiting: []realizer.UpstreamOutputNotAvailableError{
+							{
+								Resource:         &supplyChain.Spec.Resources[1],
+								UpstreamResource: "source-provider",
+							},
+						},
+					})
+				})
+
+				It("reports the resource as stamping with a condition naming the upstream resource", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					resources := updatedSummary().Resources
+					Expect(resources).To(HaveLen(3))
+
+					Expect(resources[0].Phase).To(Equal(v1alpha1.ResourcePhaseHealthy))
+
+					Expect(resources[1].Name).To(Equal("image-builder"))
+					Expect(resources[1].Phase).To(Equal(v1alpha1.ResourcePhaseStamping))
+					Expect(resources[1].Condition).NotTo(BeNil())
+					Expect(resources[1].Condition.Reason).To(Equal(v1alpha1.WaitingOnUpstreamResourcesSubmittedReason))
+					Expect(resources[1].Condition.Message).To(ContainSubstring("source-provider"))
+
+					Expect(resources[2]).To(Equal(v1alpha1.ResourceSummary{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable}))
+				})
+
+				It("reports the workload as waiting on the upstream resource", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionUnknown,
+						Reason:  v1alpha1.WaitingOnUpstreamResourcesSubmittedReason,
+						Message: "Resource [image-builder] waiting on output from upstream resource [source-provider]",
+					}))
+				})
+			})
+
+			Context("when the supply chain stops at an upstream resource whose output is not available yet", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1}}, realizer.UpstreamOutputNotAvailableError{
+						Resource:         &supplyChain.Spec.Resources[1],
+						UpstreamResource: "source-provider",
+						Err: realizer.RetrieveOutputError{
+							Err:           templates.NewJsonPathError("status.artifact", errors.New("not found")),
+							Resource:      &supplyChain.Spec.Resources[0],
+							StampedObject: stampedObject1,
+						},
+					})
+				})
+
+				It("reports the workload as waiting on the upstream resource", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionUnknown,
+						Reason:  v1alpha1.WaitingOnUpstreamResourcesSubmittedReason,
+						Message: "Resource [image-builder] waiting on output from upstream resource [source-provider]",
+					}))
+				})
+
+				It("reports the upstream resource as healthy and the following resources as stamping", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary().Resources).To(Equal([]v1alpha1.ResourceSummary{
+						{Name: "source-provider", Phase: v1alpha1.ResourcePhaseHealthy},
+						{Name: "image-builder", Phase: v1alpha1.ResourcePhaseStamping},
+						{Name: "deployer", Phase: v1alpha1.ResourcePhaseStamping},
+					}))
+				})
+			})
+
+			Context("when the resources are realized in a different order than they are declared", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{
+						StampedObjects:   []*unstructured.Unstructured{stampedObject1},
+						RealizationOrder: []string{"deployer", "source-provider", "image-builder"},
+					}, realizer.StampError{
+						Err:      errors.New("some error"),
+						Resource: &supplyChain.Spec.Resources[0],
+					})
+				})
+
+				It("reports the phase of each resource by its place in the realization order", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary().Resources).To(Equal([]v1alpha1.ResourceSummary{
+						{Name: "source-provider", Phase: v1alpha1.ResourcePhaseFailed},
+						{Name: "image-builder", Phase: v1alpha1.ResourcePhaseStamping},
+						{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+					}))
+				})
+			})
+
+			Context("when a resource is skipped", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1, stampedObject2}, Skipped: []string{"image-builder"}}, nil)
+				})
+
+				It("reports the resource as skipped and the others as having their output available", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary().Resources).To(Equal([]v1alpha1.ResourceSummary{
+						{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+						{Name: "image-builder", Phase: v1alpha1.ResourcePhaseSkipped},
+						{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+					}))
+				})
+
+				Context("and a later resource fails to be realized", func() {
+					BeforeEach(func() {
+						supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, v1alpha1.SupplyChainResource{Name: "notifier"})
+						rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1}, Skipped: []string{"image-builder"}}, realizer.StampError{
+							Err:      errors.New("some error"),
+							Resource: &supplyChain.Spec.Resources[2],
+						})
+					})
+
+					It("reports the failing resource by its position among the included resources", func() {
+						_, _ = reconciler.Reconcile(ctx, req)
+						Expect(updatedSummary().Resources).To(Equal([]v1alpha1.ResourceSummary{
+							{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+							{Name: "image-builder", Phase: v1alpha1.ResourcePhaseSkipped},
+							{Name: "deployer", Phase: v1alpha1.ResourcePhaseFailed},
+							{Name: "notifier", Phase: v1alpha1.ResourcePhaseStamping},
+						}))
+					})
+				})
+			})
+
+			Context("when a resource fails to be realized", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1}}, realizer.StampError{
+						Err:      errors.New("some error"),
+						Resource: &supplyChain.Spec.Resources[1],
+					})
+					conditionManager.FinalizeReturns([]metav1.Condition{
+						{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionFalse},
+					}, true)
+				})
+
+				It("reports the resource as failed and the following resources as stamping", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary()).To(Equal(&v1alpha1.WorkloadSummary{
+						Ready: metav1.ConditionFalse,
+						Resources: []v1alpha1.ResourceSummary{
+							{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+							{Name: "image-builder", Phase: v1alpha1.ResourcePhaseFailed},
+							{Name: "deployer", Phase: v1alpha1.ResourcePhaseStamping},
+						},
+					}))
+				})
+			})
+
+			Context("when the errors of every failed resource are collected", func() {
+				BeforeEach(func() {
+					rlzr.RealizeReturns(realizer.Result{StampedObjects: []*unstructured.Unstructured{stampedObject1}}, realizer.ResourceErrors{
+						Errors: []realizer.ResourceError{
+							{
+								Err: realizer.StampError{
+									Err:      errors.New("some error"),
+									Resource: &supplyChain.Spec.Resources[0],
+								},
+								ResourceName: "source-provider",
+							},
+						},
+						Blocked: []string{"image-builder"},
+					})
+					conditionManager.FinalizeReturns([]metav1.Condition{
+						{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionFalse},
+					}, true)
+				})
+
+				It("reports a condition for the failed resource and the independent resources as realized", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					resources := updatedSummary().Resources
+					Expect(resources).To(HaveLen(3))
+
+					Expect(resources[0].Name).To(Equal("source-provider"))
+					Expect(resources[0].Phase).To(Equal(v1alpha1.ResourcePhaseFailed))
+					Expect(resources[0].Condition).NotTo(BeNil())
+					Expect(resources[0].Condition.Reason).To(Equal(v1alpha1.TemplateStampFailureResourcesSubmittedReason))
+					Expect(resources[0].Condition.Message).To(ContainSubstring("some error"))
+
+					Expect(resources[1]).To(Equal(v1alpha1.ResourceSummary{Name: "image-builder", Phase: v1alpha1.ResourcePhaseStamping}))
+					Expect(resources[2]).To(Equal(v1alpha1.ResourceSummary{Name: "deployer", Phase: v1alpha1.ResourcePhaseOutputAvailable}))
+				})
+
+				Context("and the status already reports the same failure", func() {
+					var transitionTime metav1.Time
+
+					BeforeEach(func() {
+						transitionTime = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+						wl.Status.Summary = &v1alpha1.WorkloadSummary{
+							Resources: []v1alpha1.ResourceSummary{
+								{
+									Name:  "source-provider",
+									Phase: v1alpha1.ResourcePhaseFailed,
+									Condition: &metav1.Condition{
+										Type:               v1alpha1.WorkloadResourceSubmitted,
+										Status:             metav1.ConditionFalse,
+										Reason:             v1alpha1.TemplateStampFailureResourcesSubmittedReason,
+										Message:            "unable to stamp object for resource [source-provider]: some error",
+										LastTransitionTime: transitionTime,
+									},
+								},
+							},
+						}
+					})
+
+					It("keeps the transition time of the condition", func() {
+						_, _ = reconciler.Reconcile(ctx, req)
+						Expect(updatedSummary().Resources[0].Condition.LastTransitionTime).To(Equal(transitionTime))
+					})
+				})
+			})
+
+			Context("when the supply chain is not ready", func() {
+				BeforeEach(func() {
+					supplyChain.Status.Conditions[0].Status = "False"
+					conditionManager.FinalizeReturns([]metav1.Condition{
+						{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionFalse},
+					}, true)
+				})
+
+				It("reports the overall status without resources", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(updatedSummary()).To(Equal(&v1alpha1.WorkloadSummary{
+						Ready: metav1.ConditionFalse,
+					}))
+				})
+			})
+		})
+
 		Context("but getting the object GVK fails", func() {
 			BeforeEach(func() {
 				repo.GetSchemeReturns(runtime.NewScheme())
@@ -400,6 +962,169 @@ var _ = Describe("Reconciler", func() {
 			})
 		})
 
+		Context("but the supply chain is paused", func() {
+			var previousResources []v1alpha1.ResourceSummary
+
+			BeforeEach(func() {
+				supplyChain.Annotations = map[string]string{v1alpha1.SupplyChainPauseAnnotation: "true"}
+				repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+
+				previousResources = []v1alpha1.ResourceSummary{
+					{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+				}
+				wl.Status.Summary = &v1alpha1.WorkloadSummary{
+					Ready:     metav1.ConditionTrue,
+					Resources: previousResources,
+				}
+			})
+
+			It("does not return an error", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+			})
+
+			It("calls the condition manager to report the supply chain is paused", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.SupplyChainPausedCondition("some-supply-chain")))
+			})
+
+			It("does not stamp any resources", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+
+			It("keeps the summary of the resources", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedWorkload.(*v1alpha1.Workload).Status.Summary.Resources).To(Equal(previousResources))
+			})
+
+			Context("and is unpaused", func() {
+				BeforeEach(func() {
+					supplyChain.Annotations[v1alpha1.SupplyChainPauseAnnotation] = "false"
+				})
+
+				It("stamps the resources again", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.SupplyChainReadyCondition()))
+				})
+			})
+		})
+
+		Context("but cartographer is paused", func() {
+			var (
+				pauseConfigMap    *corev1.ConfigMap
+				previousResources []v1alpha1.ResourceSummary
+			)
+
+			BeforeEach(func() {
+				pauseConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "cartographer-system", Name: "cartographer-pause"},
+					Data:       map[string]string{controller.PauseKey: "true"},
+				}
+				reconciler.Pause = controller.Pause{
+					Reader:    fake.NewClientBuilder().WithObjects(pauseConfigMap).Build(),
+					Namespace: "cartographer-system",
+					Name:      "cartographer-pause",
+				}
+
+				previousResources = []v1alpha1.ResourceSummary{
+					{Name: "source-provider", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+				}
+				wl.Status.Summary = &v1alpha1.WorkloadSummary{
+					Ready:     metav1.ConditionTrue,
+					Resources: previousResources,
+				}
+			})
+
+			It("does not return an error nor requeue", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+			})
+
+			It("calls the condition manager to report the controller is paused", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddNegativeCallCount()).To(Equal(1))
+				Expect(conditionManager.AddNegativeArgsForCall(0)).To(Equal(reconciler.Pause.PausedCondition()))
+				Expect(conditionManager.AddPositiveCallCount()).To(Equal(0))
+			})
+
+			It("does not stamp any resources", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(repo.GetSupplyChainsForWorkloadCallCount()).To(Equal(0))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+
+			It("keeps the summary of the resources", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				_, updatedWorkload := repo.StatusUpdateArgsForCall(0)
+				Expect(updatedWorkload.(*v1alpha1.Workload).Status.Summary.Resources).To(Equal(previousResources))
+			})
+
+			Context("and is resumed", func() {
+				BeforeEach(func() {
+					pauseConfigMap.Data[controller.PauseKey] = "false"
+					reconciler.Pause.Reader = fake.NewClientBuilder().WithObjects(pauseConfigMap).Build()
+				})
+
+				It("stamps the resources again", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rlzr.RealizeCallCount()).To(Equal(1))
+					Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.SupplyChainReadyCondition()))
+				})
+			})
+
+			Context("and the pause config map cannot be read", func() {
+				BeforeEach(func() {
+					reconciler.Pause.Reader = fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+				})
+
+				It("returns an error without stamping nor updating the status", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).To(MatchError(ContainSubstring("get pause config map [cartographer-system/cartographer-pause]")))
+					Expect(rlzr.RealizeCallCount()).To(Equal(0))
+					Expect(repo.StatusUpdateCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("but the supply chain is being deleted", func() {
+			BeforeEach(func() {
+				deletionTimestamp := metav1.Now()
+				supplyChain.DeletionTimestamp = &deletionTimestamp
+				repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+			})
+
+			It("does not return an error", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("calls the condition manager to report the supply chain is terminating", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.SupplyChainTerminatingCondition("some-supply-chain")))
+			})
+
+			It("does not stamp any resources", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+				Expect(dynamicTracker.WatchCallCount()).To(Equal(0))
+			})
+
+			It("logs the handled error message", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(out).To(Say(`"handled error":"supply chain \[some-supply-chain\] is being deleted"`))
+			})
+		})
+
 		Context("but the realizer returns an error", func() {
 			Context("of type GetClusterTemplateError", func() {
 				var templateError error
@@ -407,12 +1132,17 @@ var _ = Describe("Reconciler", func() {
 					templateError = realizer.GetClusterTemplateError{
 						Err: errors.New("some error"),
 					}
-					rlzr.RealizeReturns(nil, templateError)
+					rlzr.RealizeReturns(realizer.Result{}, templateError)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(workload.TemplateObjectRetrievalFailureCondition(templateError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateObjectRetrievalFailureResourcesSubmittedReason,
+						Message: "unable to get template []: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -429,7 +1159,7 @@ var _ = Describe("Reconciler", func() {
 						Err:      errors.New("some error"),
 						Resource: &v1alpha1.SupplyChainResource{Name: "some-name"},
 					}
-					rlzr.RealizeReturns(nil, stampError)
+					rlzr.RealizeReturns(realizer.Result{}, stampError)
 				})
 
 				It("does not try to watch the stampedObjects", func() {
@@ -441,7 +1171,12 @@ var _ = Describe("Reconciler", func() {
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(workload.TemplateStampFailureCondition(stampError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateStampFailureResourcesSubmittedReason,
+						Message: "unable to stamp object for resource [some-name]: some error",
+					}))
 				})
 
 				It("does not return an error", func() {
@@ -465,12 +1200,17 @@ var _ = Describe("Reconciler", func() {
 						Err:           errors.New("some error"),
 						StampedObject: &unstructured.Unstructured{},
 					}
-					rlzr.RealizeReturns(nil, stampedObjectError)
+					rlzr.RealizeReturns(realizer.Result{}, stampedObjectError)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(workload.TemplateRejectedByAPIServerCondition(stampedObjectError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason,
+						Message: "unable to apply object [/]: some error",
+					}))
 				})
 
 				It("returns an unhandled error and requeues", func() {
@@ -497,12 +1237,17 @@ var _ = Describe("Reconciler", func() {
 						StampedObject: stampedObject1,
 					}
 
-					rlzr.RealizeReturns(nil, stampedObjectError)
+					rlzr.RealizeReturns(realizer.Result{}, stampedObjectError)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
-					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(workload.TemplateRejectedByAPIServerCondition(stampedObjectError)))
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.TemplateRejectedByAPIServerResourcesSubmittedReason,
+						Message: "unable to apply object [a-namespace/a-name]: fantastic error",
+					}))
 				})
 
 				It("handles the error and logs it", func() {
@@ -512,6 +1257,112 @@ var _ = Describe("Reconciler", func() {
 					Expect(out).To(Say(`"level":"info"`))
 					Expect(out).To(Say(`"handled error":"unable to apply object \[a-namespace/a-name\]: fantastic error"`))
 				})
+
+				It("does not requeue", func() {
+					result, _ := reconciler.Reconcile(ctx, req)
+					Expect(result).To(Equal(ctrl.Result{}))
+					Expect(wl.Status.ForbiddenRetries).To(BeZero())
+				})
+
+				Context("and forbidden retries are enabled", func() {
+					BeforeEach(func() {
+						reconciler.ForbiddenRetry = controller.ForbiddenRetryOptions{
+							MaxRetries: 2,
+							Backoff:    time.Second,
+						}
+					})
+
+					It("requeues after the backoff and records the retry", func() {
+						result, err := reconciler.Reconcile(ctx, req)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+						Expect(wl.Status.ForbiddenRetries).To(Equal(int64(1)))
+
+						Expect(out).To(Say(`"msg":"stamped object was forbidden, requeueing in case RBAC has not yet propagated".*"retry":1,"max retries":2,"requeue after":1`))
+					})
+
+					Context("and a retry has already been recorded", func() {
+						BeforeEach(func() {
+							wl.Status.ForbiddenRetries = 1
+						})
+
+						It("doubles the backoff", func() {
+							result, err := reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(ctrl.Result{RequeueAfter: 2 * time.Second}))
+							Expect(wl.Status.ForbiddenRetries).To(Equal(int64(2)))
+						})
+					})
+
+					Context("and the retries are exhausted", func() {
+						BeforeEach(func() {
+							wl.Status.ForbiddenRetries = 2
+						})
+
+						It("treats the error as terminal", func() {
+							result, err := reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(ctrl.Result{}))
+							Expect(wl.Status.ForbiddenRetries).To(Equal(int64(2)))
+						})
+					})
+
+					Context("and the apply succeeds once RBAC has propagated", func() {
+						BeforeEach(func() {
+							rlzr.RealizeReturnsOnCall(1, realizer.Result{}, nil)
+						})
+
+						It("stops requeueing and resets the retries", func() {
+							result, err := reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(time.Second))
+							Expect(wl.Status.ForbiddenRetries).To(Equal(int64(1)))
+
+							result, err = reconciler.Reconcile(ctx, req)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result).To(Equal(ctrl.Result{}))
+							Expect(wl.Status.ForbiddenRetries).To(BeZero())
+						})
+					})
+				})
+			})
+
+			Context("of type InsufficientPermissionsError", func() {
+				var permissionsError realizer.InsufficientPermissionsError
+				BeforeEach(func() {
+					stampedObject1 = &unstructured.Unstructured{}
+					stampedObject1.SetNamespace("a-namespace")
+					stampedObject1.SetName("a-name")
+
+					permissionsError = realizer.InsufficientPermissionsError{
+						Err:           repository.AccessDeniedError{Verb: "create", Resource: "images.kpack.io", Namespace: "a-namespace"},
+						Resource:      &v1alpha1.SupplyChainResource{Name: "image-builder"},
+						StampedObject: stampedObject1,
+					}
+
+					rlzr.RealizeReturns(realizer.Result{}, permissionsError)
+					reconciler.ForbiddenRetry = controller.ForbiddenRetryOptions{
+						MaxRetries: 2,
+						Backoff:    time.Second,
+					}
+				})
+
+				It("calls the condition manager to report", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(metav1.Condition{
+						Type:    v1alpha1.WorkloadResourceSubmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  v1alpha1.InsufficientPermissionsResourcesSubmittedReason,
+						Message: "service account may not apply stamped object [a-namespace/a-name] of type [] for resource [image-builder]: not permitted to [create] resource [images.kpack.io] in namespace [a-namespace]",
+					}))
+				})
+
+				It("requeues as a forbidden apply would be", func() {
+					result, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+					Expect(wl.Status.ForbiddenRetries).To(Equal(int64(1)))
+				})
 			})
 
 			Context("of type RetrieveOutputError", func() {
@@ -532,13 +1383,13 @@ var _ = Describe("Reconciler", func() {
 						Resource:      &v1alpha1.SupplyChainResource{Name: "some-resource"},
 						StampedObject: stampedObject,
 					}
-					rlzr.RealizeReturns(nil, retrieveError)
+					rlzr.RealizeReturns(realizer.Result{}, retrieveError)
 				})
 
 				It("calls the condition manager to report", func() {
 					_, _ = reconciler.Reconcile(ctx, req)
 					Expect(conditionManager.AddPositiveArgsForCall(1)).To(
-						Equal(workload.MissingValueAtPathCondition(stampedObject, "this.wont.find.anything")))
+						Equal(conditions.MissingValueAtPathCondition(v1alpha1.WorkloadResourceSubmitted, stampedObject, "this.wont.find.anything")))
 				})
 
 				It("does not return an error", func() {
@@ -559,7 +1410,7 @@ var _ = Describe("Reconciler", func() {
 				var realizerError error
 				BeforeEach(func() {
 					realizerError = errors.New("some error")
-					rlzr.RealizeReturns(nil, realizerError)
+					rlzr.RealizeReturns(realizer.Result{}, realizerError)
 				})
 
 				It("calls the condition manager to report", func() {
@@ -575,6 +1426,24 @@ var _ = Describe("Reconciler", func() {
 			})
 		})
 
+		Context("but the watch on the stamped kind is pending", func() {
+			BeforeEach(func() {
+				dynamicTracker.WatchReturns(tracker.WatchPendingError{
+					GroupVersionKind: schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"},
+					RetryAfter:       4 * time.Second,
+					Err:              errors.New("no matches for kind"),
+				})
+			})
+
+			It("requeues after the tracker's backoff without an error", func() {
+				result, err := reconciler.Reconcile(ctx, req)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{RequeueAfter: 4 * time.Second}))
+				Expect(out).To(Say(`"msg":"watch on object pending"`))
+			})
+		})
+
 		Context("but the watcher returns an error", func() {
 			BeforeEach(func() {
 				dynamicTracker.WatchReturns(errors.New("could not watch"))
@@ -715,6 +1584,101 @@ var _ = Describe("Reconciler", func() {
 		})
 	})
 
+	Context("and the workload pins a supply chain by annotation", func() {
+		var pinnedSupplyChain *v1alpha1.ClusterSupplyChain
+
+		BeforeEach(func() {
+			wl.Annotations = map[string]string{v1alpha1.WorkloadSupplyChainAnnotation: "pinned-supply-chain"}
+
+			pinnedSupplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "pinned-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Selector: map[string]string{"some-key": "some-val"},
+				},
+				Status: v1alpha1.SupplyChainStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   "Ready",
+							Status: "True",
+							Reason: "Ready",
+						},
+					},
+				},
+			}
+			repo.GetSupplyChainReturns(pinnedSupplyChain, nil)
+
+			otherSupplyChain := v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-supply-chain"},
+			}
+			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&otherSupplyChain, &otherSupplyChain}, nil)
+		})
+
+		It("selects the pinned supply chain without matching selectors", func() {
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.GetSupplyChainsForWorkloadCallCount()).To(Equal(0))
+			Expect(repo.GetSupplyChainCallCount()).To(Equal(1))
+			_, name := repo.GetSupplyChainArgsForCall(0)
+			Expect(name).To(Equal("pinned-supply-chain"))
+
+			Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.SupplyChainReadyCondition()))
+			Expect(wl.Status.SupplyChainRef.Name).To(Equal("pinned-supply-chain"))
+		})
+
+		Context("but the pinned supply chain does not exist", func() {
+			BeforeEach(func() {
+				repo.GetSupplyChainReturns(nil, nil)
+			})
+
+			It("calls the condition manager to report the invalid pin", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.PinnedSupplyChainNotFoundCondition("pinned-supply-chain")))
+			})
+
+			It("does not fall back to matching selectors", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(repo.GetSupplyChainsForWorkloadCallCount()).To(Equal(0))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+			})
+
+			It("logs the handled error message", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(out).To(Say(`"handled error":"supply chain \[pinned-supply-chain\] pinned by workload \[my-namespace/my-workload-name\] not found"`))
+			})
+		})
+
+		Context("but the workload does not satisfy the pinned supply chain's selector", func() {
+			BeforeEach(func() {
+				pinnedSupplyChain.Spec.Selector = map[string]string{"some-key": "another-val"}
+			})
+
+			It("calls the condition manager to report the invalid pin", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(workload.PinnedSupplyChainSelectorNotSatisfiedCondition("pinned-supply-chain", workloadLabels)))
+			})
+
+			It("does not realize the pinned supply chain", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("but getting the pinned supply chain fails", func() {
+			BeforeEach(func() {
+				repo.GetSupplyChainReturns(nil, errors.New("some error"))
+			})
+
+			It("returns an unhandled error and requeues", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).To(MatchError(ContainSubstring("failed to get supply chain [pinned-supply-chain] pinned by workload [my-namespace/my-workload-name]: some error")))
+			})
+		})
+	})
+
 	Context("but status update fails", func() {
 		BeforeEach(func() {
 			repo.StatusUpdateReturns(errors.New("some error"))
@@ -0,0 +1,185 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/conditions"
+	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
+)
+
+// resourceSummaries projects the outcome of realizing a supply chain onto its resources.
+// The realizer stops at the first resource that errors and only returns a stamped object
+// for resources that were applied, so, walking the resources in the order they were
+// realized, every included resource before the failing one has its output available and
+// every resource after it has not been stamped. Skipped resources are reported as such
+// wherever they appear. Without a realization order the resources are walked in the order
+// of the supply chain.
+func resourceSummaries(supplyChain *v1alpha1.ClusterSupplyChain, realizationOrder []string, stampedObjects []*unstructured.Unstructured, skipped []string, realizeErr error) []v1alpha1.ResourceSummary {
+	if resourceErrors, ok := realizeErr.(realizer.ResourceErrors); ok {
+		return collectedResourceSummaries(supplyChain, skipped, resourceErrors)
+	}
+
+	if len(realizationOrder) == 0 {
+		for _, resource := range supplyChain.Spec.Resources {
+			realizationOrder = append(realizationOrder, resource.Name)
+		}
+	}
+
+	failedIndex := len(realizationOrder)
+	failedPhase := v1alpha1.ResourcePhaseFailed
+	if realizeErr != nil {
+		failedIndex = len(stampedObjects)
+		switch realizeErr.(type) {
+		case realizer.RetrieveOutputError, realizer.StampedObjectNotObservedError, realizer.UpstreamOutputNotAvailableError:
+			failedIndex = len(stampedObjects) - 1
+			failedPhase = v1alpha1.ResourcePhaseHealthy
+		}
+	}
+
+	skippedResources := make(map[string]bool)
+	for _, name := range skipped {
+		skippedResources[name] = true
+	}
+
+	phases := make(map[string]v1alpha1.ResourcePhase)
+	i := 0
+	for _, name := range realizationOrder {
+		if skippedResources[name] {
+			phases[name] = v1alpha1.ResourcePhaseSkipped
+			continue
+		}
+
+		phase := v1alpha1.ResourcePhaseOutputAvailable
+		switch {
+		case i == failedIndex:
+			phase = failedPhase
+		case i > failedIndex:
+			phase = v1alpha1.ResourcePhaseStamping
+		}
+		phases[name] = phase
+		i++
+	}
+
+	var summaries []v1alpha1.ResourceSummary
+	for _, resource := range supplyChain.Spec.Resources {
+		summaries = append(summaries, v1alpha1.ResourceSummary{
+			Name:  resource.Name,
+			Phase: phases[resource.Name],
+		})
+	}
+
+	return summaries
+}
+
+// collectedResourceSummaries projects the outcome of realizing a supply chain with the
+// collectAll strategy, where the errors name the resources that failed, the resources
+// they kept from being realized and the resources waiting on an output not available yet.
+// Every other included resource had its output read.
+func collectedResourceSummaries(supplyChain *v1alpha1.ClusterSupplyChain, skipped []string, resourceErrors realizer.ResourceErrors) []v1alpha1.ResourceSummary {
+	phases := make(map[string]v1alpha1.ResourcePhase)
+	for _, name := range skipped {
+		phases[name] = v1alpha1.ResourcePhaseSkipped
+	}
+	for _, name := range resourceErrors.Blocked {
+		phases[name] = v1alpha1.ResourcePhaseStamping
+	}
+
+	resourceErrorList := resourceErrors.Errors
+	for _, waiting := range resourceErrors.Waiting {
+		resourceErrorList = append(resourceErrorList, realizer.ResourceError{Err: waiting, ResourceName: waiting.Resource.Name})
+	}
+
+	failures := make(map[string]error)
+	for _, resourceError := range resourceErrorList {
+		failures[resourceError.ResourceName] = resourceError.Err
+		switch resourceError.Err.(type) {
+		case realizer.RetrieveOutputError, realizer.StampedObjectNotObservedError:
+			phases[resourceError.ResourceName] = v1alpha1.ResourcePhaseHealthy
+		case realizer.UpstreamOutputNotAvailableError:
+			phases[resourceError.ResourceName] = v1alpha1.ResourcePhaseStamping
+		default:
+			phases[resourceError.ResourceName] = v1alpha1.ResourcePhaseFailed
+		}
+	}
+
+	var summaries []v1alpha1.ResourceSummary
+	for _, resource := range supplyChain.Spec.Resources {
+		summary := v1alpha1.ResourceSummary{
+			Name:  resource.Name,
+			Phase: v1alpha1.ResourcePhaseOutputAvailable,
+		}
+		if phase, ok := phases[resource.Name]; ok {
+			summary.Phase = phase
+		}
+		if err, ok := failures[resource.Name]; ok {
+			condition, _ := conditions.FromRealizeError(err)
+			if condition.Type == "" {
+				condition = UnknownResourceErrorCondition(err)
+			}
+			summary.Condition = &condition
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// keepTransitionTimes stamps the resource conditions with the current time, keeping the
+// time recorded in the previous summary for conditions that have not changed since.
+func keepTransitionTimes(previous *v1alpha1.WorkloadSummary, resources []v1alpha1.ResourceSummary) {
+	previousConditions := make(map[string]*metav1.Condition)
+	if previous != nil {
+		for _, resource := range previous.Resources {
+			if resource.Condition != nil {
+				previousConditions[resource.Name] = resource.Condition
+			}
+		}
+	}
+
+	now := metav1.Now()
+	for _, resource := range resources {
+		condition := resource.Condition
+		if condition == nil {
+			continue
+		}
+
+		condition.LastTransitionTime = now
+		if previousCondition, ok := previousConditions[resource.Name]; ok {
+			unchanged := *condition
+			unchanged.LastTransitionTime = previousCondition.LastTransitionTime
+			if equality.Semantic.DeepEqual(&unchanged, previousCondition) {
+				condition.LastTransitionTime = previousCondition.LastTransitionTime
+			}
+		}
+	}
+}
+
+func workloadSummary(conditions []metav1.Condition, resources []v1alpha1.ResourceSummary) *v1alpha1.WorkloadSummary {
+	ready := metav1.ConditionUnknown
+	if readyCondition := meta.FindStatusCondition(conditions, v1alpha1.WorkloadReady); readyCondition != nil {
+		ready = readyCondition.Status
+	}
+
+	return &v1alpha1.WorkloadSummary{
+		Ready:     ready,
+		Resources: resources,
+	}
+}
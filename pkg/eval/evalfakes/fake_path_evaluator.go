@@ -0,0 +1,203 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package evalfakes
+
+import (
+	"sync"
+
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+)
+
+type FakePathEvaluator struct {
+	EvaluateJsonPathStub        func(string, interface{}) (interface{}, error)
+	evaluateJsonPathMutex       sync.RWMutex
+	evaluateJsonPathArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
+	evaluateJsonPathReturns struct {
+		result1 interface{}
+		result2 error
+	}
+	evaluateJsonPathReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 error
+	}
+	EvaluateJsonPathSortedStub        func(string, string, string, interface{}) (interface{}, error)
+	evaluateJsonPathSortedMutex       sync.RWMutex
+	evaluateJsonPathSortedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 interface{}
+	}
+	evaluateJsonPathSortedReturns struct {
+		result1 interface{}
+		result2 error
+	}
+	evaluateJsonPathSortedReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPath(arg1 string, arg2 interface{}) (interface{}, error) {
+	fake.evaluateJsonPathMutex.Lock()
+	ret, specificReturn := fake.evaluateJsonPathReturnsOnCall[len(fake.evaluateJsonPathArgsForCall)]
+	fake.evaluateJsonPathArgsForCall = append(fake.evaluateJsonPathArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.EvaluateJsonPathStub
+	fakeReturns := fake.evaluateJsonPathReturns
+	fake.recordInvocation("EvaluateJsonPath", []interface{}{arg1, arg2})
+	fake.evaluateJsonPathMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathCallCount() int {
+	fake.evaluateJsonPathMutex.RLock()
+	defer fake.evaluateJsonPathMutex.RUnlock()
+	return len(fake.evaluateJsonPathArgsForCall)
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathCalls(stub func(string, interface{}) (interface{}, error)) {
+	fake.evaluateJsonPathMutex.Lock()
+	defer fake.evaluateJsonPathMutex.Unlock()
+	fake.EvaluateJsonPathStub = stub
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathArgsForCall(i int) (string, interface{}) {
+	fake.evaluateJsonPathMutex.RLock()
+	defer fake.evaluateJsonPathMutex.RUnlock()
+	argsForCall := fake.evaluateJsonPathArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathReturns(result1 interface{}, result2 error) {
+	fake.evaluateJsonPathMutex.Lock()
+	defer fake.evaluateJsonPathMutex.Unlock()
+	fake.EvaluateJsonPathStub = nil
+	fake.evaluateJsonPathReturns = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathReturnsOnCall(i int, result1 interface{}, result2 error) {
+	fake.evaluateJsonPathMutex.Lock()
+	defer fake.evaluateJsonPathMutex.Unlock()
+	fake.EvaluateJsonPathStub = nil
+	if fake.evaluateJsonPathReturnsOnCall == nil {
+		fake.evaluateJsonPathReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 error
+		})
+	}
+	fake.evaluateJsonPathReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathSorted(arg1 string, arg2 string, arg3 string, arg4 interface{}) (interface{}, error) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	ret, specificReturn := fake.evaluateJsonPathSortedReturnsOnCall[len(fake.evaluateJsonPathSortedArgsForCall)]
+	fake.evaluateJsonPathSortedArgsForCall = append(fake.evaluateJsonPathSortedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.EvaluateJsonPathSortedStub
+	fakeReturns := fake.evaluateJsonPathSortedReturns
+	fake.recordInvocation("EvaluateJsonPathSorted", []interface{}{arg1, arg2, arg3, arg4})
+	fake.evaluateJsonPathSortedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathSortedCallCount() int {
+	fake.evaluateJsonPathSortedMutex.RLock()
+	defer fake.evaluateJsonPathSortedMutex.RUnlock()
+	return len(fake.evaluateJsonPathSortedArgsForCall)
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathSortedCalls(stub func(string, string, string, interface{}) (interface{}, error)) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	defer fake.evaluateJsonPathSortedMutex.Unlock()
+	fake.EvaluateJsonPathSortedStub = stub
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathSortedArgsForCall(i int) (string, string, string, interface{}) {
+	fake.evaluateJsonPathSortedMutex.RLock()
+	defer fake.evaluateJsonPathSortedMutex.RUnlock()
+	argsForCall := fake.evaluateJsonPathSortedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathSortedReturns(result1 interface{}, result2 error) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	defer fake.evaluateJsonPathSortedMutex.Unlock()
+	fake.EvaluateJsonPathSortedStub = nil
+	fake.evaluateJsonPathSortedReturns = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePathEvaluator) EvaluateJsonPathSortedReturnsOnCall(i int, result1 interface{}, result2 error) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	defer fake.evaluateJsonPathSortedMutex.Unlock()
+	fake.EvaluateJsonPathSortedStub = nil
+	if fake.evaluateJsonPathSortedReturnsOnCall == nil {
+		fake.evaluateJsonPathSortedReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 error
+		})
+	}
+	fake.evaluateJsonPathSortedReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePathEvaluator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.evaluateJsonPathMutex.RLock()
+	defer fake.evaluateJsonPathMutex.RUnlock()
+	fake.evaluateJsonPathSortedMutex.RLock()
+	defer fake.evaluateJsonPathSortedMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePathEvaluator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ eval.PathEvaluator = new(FakePathEvaluator)
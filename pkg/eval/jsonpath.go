@@ -18,8 +18,11 @@ package eval
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"k8s.io/client-go/util/jsonpath"
+
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
@@ -36,7 +39,41 @@ func EvaluatorBuilder() Evaluator {
 	}
 }
 
+// AmbiguousJsonPathError is returned when a path expected to read a single value matches
+// several, usually because it is missing the index into a list.
+type AmbiguousJsonPathError struct {
+	Path  string
+	Count int
+}
+
+func (e AmbiguousJsonPathError) Error() string {
+	return fmt.Sprintf("jsonpath [%s] matches %d values, index into the list to read one of them", e.Path, e.Count)
+}
+
+func (e AmbiguousJsonPathError) JsonPathExpression() string {
+	return e.Path
+}
+
+// EvaluateJsonPath returns the single value the path matches in obj.
 func (e Evaluator) EvaluateJsonPath(path string, obj interface{}) (interface{}, error) {
+	interfaceList, err := e.EvaluateJsonPathAll(path, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(interfaceList) > 1 {
+		return "", AmbiguousJsonPathError{Path: path, Count: len(interfaceList)}
+	}
+
+	if len(interfaceList) == 0 {
+		return "", fmt.Errorf("jsonpath returned empty list: %s", path)
+	}
+
+	return interfaceList[0], nil
+}
+
+// EvaluateJsonPathAll returns every value the path matches in obj.
+func (e Evaluator) EvaluateJsonPathAll(path string, obj interface{}) ([]interface{}, error) {
 	if path == "" {
 		return nil, fmt.Errorf("empty jsonpath not allowed")
 	}
@@ -48,15 +85,95 @@ func (e Evaluator) EvaluateJsonPath(path string, obj interface{}) (interface{},
 		return nil, fmt.Errorf("evaluate: %w", err)
 	}
 
-	if len(interfaceList) > 1 {
-		return "", fmt.Errorf("too many results for the query: %s", path)
+	return interfaceList, nil
+}
+
+// ParseJsonPath parses the path as EvaluateJsonPath reads it, for callers that inspect the
+// path rather than evaluate it.
+func ParseJsonPath(path string) (*jsonpath.Parser, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty jsonpath not allowed")
 	}
 
-	if len(interfaceList) == 0 {
-		return "", fmt.Errorf("jsonpath returned empty list: %s", path)
+	return jsonpath.Parse("", ensureValidWrapping(path))
+}
+
+const (
+	PickFirst = "first"
+	PickLast  = "last"
+)
+
+// EvaluateJsonPathSorted sorts the values the path matches in obj by the value sortBy reads
+// from each of them and returns the first or last of them, as pick says. A path that matches
+// a single list is treated as matching each element of the list. Values that sort equally
+// keep the order they were matched in.
+func (e Evaluator) EvaluateJsonPathSorted(path string, sortBy string, pick string, obj interface{}) (interface{}, error) {
+	if pick != "" && pick != PickFirst && pick != PickLast {
+		return nil, fmt.Errorf("unknown pick [%s], expected one of [%s, %s]", pick, PickFirst, PickLast)
 	}
 
-	return interfaceList[0], nil
+	values, err := e.EvaluateJsonPathAll(path, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 1 {
+		if list, ok := values[0].([]interface{}); ok {
+			values = list
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("jsonpath returned empty list: %s", path)
+	}
+
+	keys := make([]interface{}, len(values))
+	for i, value := range values {
+		keys[i], err = e.EvaluateJsonPath(sortBy, value)
+		if err != nil {
+			return nil, fmt.Errorf("sort key [%s] of value %d at jsonpath [%s]: %w", sortBy, i, path, err)
+		}
+	}
+
+	indexes := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return lessSortKey(keys[indexes[i]], keys[indexes[j]])
+	})
+
+	if pick == PickLast {
+		return values[indexes[len(indexes)-1]], nil
+	}
+	return values[indexes[0]], nil
+}
+
+// lessSortKey orders numbers numerically and everything else by its printed form
+func lessSortKey(a, b interface{}) bool {
+	aNumber, aIsNumber := sortKeyNumber(a)
+	bNumber, bIsNumber := sortKeyNumber(b)
+	if aIsNumber && bIsNumber {
+		return aNumber < bNumber
+	}
+
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func sortKeyNumber(key interface{}) (float64, bool) {
+	switch n := key.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
 }
 
 func ensureValidWrapping(jsonpathExpression string) string {
@@ -128,5 +128,169 @@ var _ = Describe("JsonPath", func() {
 
 			ItReturnsAHelpfulError("empty jsonpath not allowed")
 		})
+
+		Context("when evaluate returns a list of many items", func() {
+			BeforeEach(func() {
+				evaluate.Returns([]interface{}{"True", "False", "Unknown"}, nil)
+				result, err = evaluator.EvaluateJsonPath(path, obj)
+			})
+
+			It("returns an AmbiguousJsonPathError counting the items", func() {
+				Expect(err).To(Equal(eval.AmbiguousJsonPathError{Path: "some.path", Count: 3}))
+				Expect(err).To(MatchError(ContainSubstring("matches 3 values")))
+			})
+		})
+	})
+
+	Describe("EvaluateJsonPathAll", func() {
+		var (
+			obj     map[string]interface{}
+			results []interface{}
+		)
+
+		BeforeEach(func() {
+			evaluator = eval.EvaluatorBuilder()
+			obj = map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{},
+				},
+			}
+		})
+
+		withConditions := func(statuses ...string) {
+			var conditions []interface{}
+			for _, status := range statuses {
+				conditions = append(conditions, map[string]interface{}{"status": status})
+			}
+			obj["status"].(map[string]interface{})["conditions"] = conditions
+		}
+
+		It("returns no values when nothing matches", func() {
+			results, err = evaluator.EvaluateJsonPathAll("status.conditions[*].status", obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+		})
+
+		It("returns the value of a single match", func() {
+			withConditions("True")
+			results, err = evaluator.EvaluateJsonPathAll("status.conditions[*].status", obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]interface{}{"True"}))
+		})
+
+		It("returns every value of many matches", func() {
+			withConditions("True", "False")
+			results, err = evaluator.EvaluateJsonPathAll("status.conditions[*].status", obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]interface{}{"True", "False"}))
+		})
+
+		It("returns a single value when the path indexes into the list", func() {
+			withConditions("True", "False")
+			results, err = evaluator.EvaluateJsonPathAll("status.conditions[1].status", obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]interface{}{"False"}))
+		})
+
+		It("rejects an empty path", func() {
+			_, err = evaluator.EvaluateJsonPathAll("", obj)
+			Expect(err).To(MatchError("empty jsonpath not allowed"))
+		})
+	})
+
+	Describe("EvaluateJsonPathSorted", func() {
+		var obj map[string]interface{}
+
+		image := func(platform string, digest string) map[string]interface{} {
+			return map[string]interface{}{"platform": platform, "image": "my-image@sha256:" + digest}
+		}
+
+		BeforeEach(func() {
+			evaluator = eval.EvaluatorBuilder()
+			obj = map[string]interface{}{
+				"status": map[string]interface{}{
+					"images": []interface{}{
+						image("linux/arm64", "bbb"),
+						image("linux/amd64", "aaa"),
+						image("windows/amd64", "ccc"),
+					},
+				},
+			}
+		})
+
+		It("picks the first value of the sorted list", func() {
+			result, err = evaluator.EvaluateJsonPathSorted("status.images[*]", "platform", eval.PickFirst, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(image("linux/amd64", "aaa")))
+		})
+
+		It("picks the last value of the sorted list", func() {
+			result, err = evaluator.EvaluateJsonPathSorted("status.images[*]", "platform", eval.PickLast, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(image("windows/amd64", "ccc")))
+		})
+
+		It("picks the first value when pick is not given", func() {
+			result, err = evaluator.EvaluateJsonPathSorted("status.images[*]", "platform", "", obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(image("linux/amd64", "aaa")))
+		})
+
+		It("sorts the elements of a path that matches the list itself", func() {
+			result, err = evaluator.EvaluateJsonPathSorted("status.images", "platform", eval.PickFirst, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(image("linux/amd64", "aaa")))
+		})
+
+		It("picks the same value whatever order the list is in", func() {
+			obj["status"].(map[string]interface{})["images"] = []interface{}{
+				image("windows/amd64", "ccc"),
+				image("linux/amd64", "aaa"),
+				image("linux/arm64", "bbb"),
+			}
+			result, err = evaluator.EvaluateJsonPathSorted("status.images[*]", "platform", eval.PickFirst, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(image("linux/amd64", "aaa")))
+		})
+
+		It("keeps the matched order of values that sort equally", func() {
+			result, err = evaluator.EvaluateJsonPathSorted("images[*]", "platform", eval.PickFirst, map[string]interface{}{
+				"images": []interface{}{image("linux/amd64", "bbb"), image("linux/amd64", "aaa")},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(image("linux/amd64", "bbb")))
+		})
+
+		It("sorts numbers numerically", func() {
+			result, err = evaluator.EvaluateJsonPathSorted("builds[*]", "number", eval.PickLast, map[string]interface{}{
+				"builds": []interface{}{
+					map[string]interface{}{"number": int64(9)},
+					map[string]interface{}{"number": int64(10)},
+					map[string]interface{}{"number": int64(2)},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(map[string]interface{}{"number": float64(10)}))
+		})
+
+		It("returns an error when a value has no sort key", func() {
+			obj["status"].(map[string]interface{})["images"] = []interface{}{
+				image("linux/amd64", "aaa"),
+				map[string]interface{}{"image": "my-image@sha256:ddd"},
+			}
+			_, err = evaluator.EvaluateJsonPathSorted("status.images[*]", "platform", eval.PickFirst, obj)
+			Expect(err).To(MatchError(ContainSubstring("sort key [platform] of value 1 at jsonpath [status.images[*]]")))
+		})
+
+		It("returns an error when the list is empty", func() {
+			obj["status"].(map[string]interface{})["images"] = []interface{}{}
+			_, err = evaluator.EvaluateJsonPathSorted("status.images", "platform", eval.PickFirst, obj)
+			Expect(err).To(MatchError("jsonpath returned empty list: status.images"))
+		})
+
+		It("rejects an unknown pick", func() {
+			_, err = evaluator.EvaluateJsonPathSorted("status.images[*]", "platform", "middle", obj)
+			Expect(err).To(MatchError("unknown pick [middle], expected one of [first, last]"))
+		})
 	})
 })
@@ -0,0 +1,81 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const DefaultLanguage = "jsonpath"
+
+// PathEvaluator reads a single value out of an object with an expression
+// written in the evaluator's language.
+//
+//counterfeiter:generate . PathEvaluator
+type PathEvaluator interface {
+	EvaluateJsonPath(expression string, obj interface{}) (interface{}, error)
+	EvaluateJsonPathSorted(expression string, sortBy string, pick string, obj interface{}) (interface{}, error)
+}
+
+type UnknownLanguageError struct {
+	Language string
+	Known    []string
+}
+
+func (e UnknownLanguageError) Error() string {
+	return fmt.Sprintf("unknown output language [%s], expected one of [%s]", e.Language, strings.Join(e.Known, ", "))
+}
+
+// EvaluatorRegistry hands out the evaluator for the output language a template
+// declares. jsonpath is always registered and is used when no language is given.
+type EvaluatorRegistry struct {
+	evaluators map[string]PathEvaluator
+}
+
+func NewEvaluatorRegistry() *EvaluatorRegistry {
+	return &EvaluatorRegistry{
+		evaluators: map[string]PathEvaluator{
+			DefaultLanguage: EvaluatorBuilder(),
+		},
+	}
+}
+
+func (r *EvaluatorRegistry) Register(language string, evaluator PathEvaluator) {
+	r.evaluators[language] = evaluator
+}
+
+func (r *EvaluatorRegistry) For(language string) (PathEvaluator, error) {
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	evaluator, ok := r.evaluators[language]
+	if !ok {
+		return nil, UnknownLanguageError{Language: language, Known: r.languages()}
+	}
+
+	return evaluator, nil
+}
+
+func (r *EvaluatorRegistry) languages() []string {
+	var languages []string
+	for language := range r.evaluators {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/eval/evalfakes"
+)
+
+var _ = Describe("EvaluatorRegistry", func() {
+	var (
+		registry *eval.EvaluatorRegistry
+		obj      map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		registry = eval.NewEvaluatorRegistry()
+		obj = map[string]interface{}{
+			"status": map[string]interface{}{"latestImage": "my-image"},
+		}
+	})
+
+	It("evaluates jsonpath when no language is given", func() {
+		evaluator, err := registry.For("")
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := evaluator.EvaluateJsonPath(".status.latestImage", obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("my-image"))
+	})
+
+	It("evaluates jsonpath when asked for it by name", func() {
+		evaluator, err := registry.For("jsonpath")
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := evaluator.EvaluateJsonPath("status.latestImage", obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("my-image"))
+	})
+
+	It("hands out the evaluator registered for a language", func() {
+		other := &evalfakes.FakePathEvaluator{}
+		other.EvaluateJsonPathReturns("from-other", nil)
+		registry.Register("other", other)
+
+		evaluator, err := registry.For("other")
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := evaluator.EvaluateJsonPath("latest image please", obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("from-other"))
+
+		expression, evaluatedObj := other.EvaluateJsonPathArgsForCall(0)
+		Expect(expression).To(Equal("latest image please"))
+		Expect(evaluatedObj).To(Equal(obj))
+	})
+
+	It("errors on an unknown language, listing the known ones", func() {
+		registry.Register("other", &evalfakes.FakePathEvaluator{})
+
+		_, err := registry.For("cel")
+		Expect(err).To(MatchError("unknown output language [cel], expected one of [jsonpath, other]"))
+		Expect(err).To(BeAssignableToTypeOf(eval.UnknownLanguageError{}))
+	})
+})
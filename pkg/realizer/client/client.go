@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -24,13 +25,24 @@ import (
 
 type ClientBuilder func(secret *corev1.Secret) (client.Client, error)
 
-func NewClientBuilder(restConfig *rest.Config) ClientBuilder {
+// TokenRefresher requests a new token for a service account, returned as a service account
+// token secret.
+type TokenRefresher func(ctx context.Context, serviceAccountName, namespace string) (*corev1.Secret, error)
+
+// NewClientBuilder returns a ClientBuilder for clients that act as the service account of the
+// secret. When refreshToken is set, a client whose token the api server rejects as
+// unauthorized requests a new one and retries the request with it.
+func NewClientBuilder(restConfig *rest.Config, refreshToken TokenRefresher) ClientBuilder {
 	return func(secret *corev1.Secret) (client.Client, error) {
 		config, err := AddBearerToken(secret, restConfig)
 		if err != nil {
 			return nil, fmt.Errorf("adding bearer token: %w", err)
 		}
 
+		if serviceAccountName := secret.Annotations[corev1.ServiceAccountNameKey]; refreshToken != nil && serviceAccountName != "" {
+			config.Wrap(RefreshTokenOnUnauthorized(serviceAccountName, secret.Namespace, refreshToken))
+		}
+
 		cl, err := client.New(config, client.Options{})
 		if err != nil {
 			return nil, fmt.Errorf("creating client: %w", err)
@@ -15,6 +15,12 @@
 package client_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -69,4 +75,135 @@ var _ = Describe("Pkg/Realizer/Client/Client", func() {
 			Expect(newConfig).To(Equal(oldConfig))
 		})
 	})
+
+	Describe("RefreshTokenOnUnauthorized", func() {
+		var (
+			requests      []*http.Request
+			bodies        []string
+			refreshCalls  int
+			refreshErr    error
+			validToken    string
+			roundTripper  http.RoundTripper
+			refreshedName string
+			refreshedNS   string
+		)
+
+		BeforeEach(func() {
+			requests = nil
+			bodies = nil
+			refreshCalls = 0
+			refreshErr = nil
+			validToken = "new-token"
+
+			wrapped := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req)
+				if req.Body != nil {
+					body, err := ioutil.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+					bodies = append(bodies, string(body))
+				}
+				status := http.StatusOK
+				if req.Header.Get("Authorization") != "Bearer "+validToken {
+					status = http.StatusUnauthorized
+				}
+				return &http.Response{StatusCode: status, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+			})
+
+			refresh := func(ctx context.Context, serviceAccountName, namespace string) (*corev1.Secret, error) {
+				refreshCalls++
+				refreshedName, refreshedNS = serviceAccountName, namespace
+				if refreshErr != nil {
+					return nil, refreshErr
+				}
+				return &corev1.Secret{
+					Data: map[string][]byte{corev1.ServiceAccountTokenKey: []byte("new-token")},
+				}, nil
+			}
+
+			roundTripper = realizerclient.RefreshTokenOnUnauthorized("my-sa", "my-ns", refresh)(wrapped)
+		})
+
+		newRequest := func(body string) *http.Request {
+			req, err := http.NewRequest(http.MethodPatch, "https://some-host/api/v1/namespaces/my-ns/configmaps/my-cm", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer old-token")
+			return req
+		}
+
+		Context("the api server accepts the token", func() {
+			BeforeEach(func() {
+				validToken = "old-token"
+			})
+
+			It("does not request a new token", func() {
+				resp, err := roundTripper.RoundTrip(newRequest("some-body"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(requests).To(HaveLen(1))
+				Expect(refreshCalls).To(Equal(0))
+			})
+		})
+
+		Context("the api server rejects the token as unauthorized", func() {
+			It("retries the request once with a new token for the service account", func() {
+				resp, err := roundTripper.RoundTrip(newRequest("some-body"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(refreshCalls).To(Equal(1))
+				Expect(refreshedName).To(Equal("my-sa"))
+				Expect(refreshedNS).To(Equal("my-ns"))
+
+				Expect(requests).To(HaveLen(2))
+				Expect(requests[1].Header.Get("Authorization")).To(Equal("Bearer new-token"))
+				Expect(bodies).To(Equal([]string{"some-body", "some-body"}))
+			})
+
+			It("uses the new token for later requests", func() {
+				_, err := roundTripper.RoundTrip(newRequest("some-body"))
+				Expect(err).NotTo(HaveOccurred())
+
+				resp, err := roundTripper.RoundTrip(newRequest("other-body"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(refreshCalls).To(Equal(1))
+				Expect(requests).To(HaveLen(3))
+				Expect(requests[2].Header.Get("Authorization")).To(Equal("Bearer new-token"))
+			})
+
+			Context("and the new token is rejected too", func() {
+				BeforeEach(func() {
+					validToken = "some-other-token"
+				})
+
+				It("returns the unauthorized response without retrying again", func() {
+					resp, err := roundTripper.RoundTrip(newRequest("some-body"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(requests).To(HaveLen(2))
+					Expect(refreshCalls).To(Equal(1))
+				})
+			})
+
+			Context("and a new token cannot be requested", func() {
+				BeforeEach(func() {
+					refreshErr = errors.New("some error")
+				})
+
+				It("returns the unauthorized response", func() {
+					resp, err := roundTripper.RoundTrip(newRequest("some-body"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(requests).To(HaveLen(1))
+				})
+			})
+		})
+	})
 })
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/transport"
+)
+
+// RefreshTokenOnUnauthorized wraps a transport so that a request the api server rejects as
+// unauthorized, as it does once the token expires or its signing key is rotated, is retried
+// once with a new token for the service account. Later requests carry the new token.
+func RefreshTokenOnUnauthorized(serviceAccountName, namespace string, refreshToken TokenRefresher) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &refreshTokenRoundTripper{
+			rt:                 rt,
+			serviceAccountName: serviceAccountName,
+			namespace:          namespace,
+			refreshToken:       refreshToken,
+		}
+	}
+}
+
+type refreshTokenRoundTripper struct {
+	rt                 http.RoundTripper
+	serviceAccountName string
+	namespace          string
+	refreshToken       TokenRefresher
+
+	mu    sync.Mutex
+	token string
+}
+
+func (r *refreshTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	token := r.token
+	r.mu.Unlock()
+	if token != "" {
+		req = withBearerToken(req, token)
+	}
+
+	resp, err := r.rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	token, err = r.newToken(req)
+	if err != nil {
+		return resp, nil
+	}
+
+	retry := withBearerToken(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_ = resp.Body.Close()
+
+	return r.rt.RoundTrip(retry)
+}
+
+func (r *refreshTokenRoundTripper) newToken(req *http.Request) (string, error) {
+	secret, err := r.refreshToken(req.Context(), r.serviceAccountName, r.namespace)
+	if err != nil {
+		return "", err
+	}
+
+	tokenBytes, found := secret.Data[corev1.ServiceAccountTokenKey]
+	if !found {
+		return "", fmt.Errorf("couldn't find service account token value")
+	}
+
+	r.mu.Lock()
+	r.token = string(tokenBytes)
+	r.mu.Unlock()
+
+	return string(tokenBytes), nil
+}
+
+func withBearerToken(req *http.Request, token string) *http.Request {
+	req = utilnet.CloneRequest(req)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return req
+}
+
+func (r *refreshTokenRoundTripper) WrappedRoundTripper() http.RoundTripper { return r.rt }
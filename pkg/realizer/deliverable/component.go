@@ -21,9 +21,11 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
@@ -37,34 +39,61 @@ type ResourceRealizer interface {
 
 type resourceRealizer struct {
 	deliverable     *v1alpha1.Deliverable
+	workload        *v1alpha1.Workload
 	systemRepo      repository.Repository
 	deliverableRepo repository.Repository
 	deliveryParams  []v1alpha1.DelegatableParam
+	kindPolicy      kindpolicy.Policy
+	// templates holds the templates fetched so far. A resource realizer is built for each
+	// reconcile, so resources sharing a template fetch it once without reading stale
+	// templates in later reconciles.
+	templates map[v1alpha1.DeliveryClusterTemplateReference]client.Object
 }
 
-type ResourceRealizerBuilder func(secret *corev1.Secret, deliverable *v1alpha1.Deliverable, repo repository.Repository, deliveryParams []v1alpha1.DelegatableParam) (ResourceRealizer, error)
+// ResourceRealizerBuilder builds the realizer of a deliverable's resources. The workload is the one
+// linked by the deliverable's spec.source.workloadRef, nil when it links none.
+type ResourceRealizerBuilder func(secret *corev1.Secret, deliverable *v1alpha1.Deliverable, workload *v1alpha1.Workload, repo repository.Repository, deliveryParams []v1alpha1.DelegatableParam) (ResourceRealizer, error)
 
-func NewResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder, clientBuilder realizerclient.ClientBuilder, cache repository.RepoCache) ResourceRealizerBuilder {
-	return func(secret *corev1.Secret, deliverable *v1alpha1.Deliverable, systemRepo repository.Repository, deliveryParams []v1alpha1.DelegatableParam) (ResourceRealizer, error) {
-		client, err := clientBuilder(secret)
+func NewResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder, clientBuilder realizerclient.ClientBuilder, cache repository.RepoCache, kindPolicy kindpolicy.Policy) ResourceRealizerBuilder {
+	return func(secret *corev1.Secret, deliverable *v1alpha1.Deliverable, workload *v1alpha1.Workload, systemRepo repository.Repository, deliveryParams []v1alpha1.DelegatableParam) (ResourceRealizer, error) {
+		deliverableClient, err := clientBuilder(secret)
 		if err != nil {
 			return nil, fmt.Errorf("can't build client: %w", err)
 		}
-		deliverableRepo := repositoryBuilder(client, cache)
+		deliverableRepo := repositoryBuilder(deliverableClient, cache)
 		return &resourceRealizer{
 			deliverable:     deliverable,
+			workload:        workload,
 			systemRepo:      systemRepo,
 			deliverableRepo: deliverableRepo,
 			deliveryParams:  deliveryParams,
+			kindPolicy:      kindPolicy,
+			templates:       map[v1alpha1.DeliveryClusterTemplateReference]client.Object{},
 		}, nil
 	}
 }
 
+// getDeliveryClusterTemplate returns a copy of the referenced template, fetching it only
+// the first time it is referenced.
+func (r *resourceRealizer) getDeliveryClusterTemplate(ctx context.Context, ref v1alpha1.DeliveryClusterTemplateReference) (client.Object, error) {
+	if apiTemplate, ok := r.templates[ref]; ok {
+		return apiTemplate.DeepCopyObject().(client.Object), nil
+	}
+
+	apiTemplate, err := r.systemRepo.GetDeliveryClusterTemplate(ctx, ref)
+	if err != nil || apiTemplate == nil {
+		return apiTemplate, err
+	}
+	r.templates[ref] = apiTemplate.DeepCopyObject().(client.Object)
+
+	return apiTemplate, nil
+}
+
 func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.ClusterDeliveryResource, deliveryName string, outputs Outputs) (*unstructured.Unstructured, *templates.Output, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("template", resource.TemplateRef)
 	ctx = logr.NewContext(ctx, log)
 
-	apiTemplate, err := r.systemRepo.GetDeliveryClusterTemplate(ctx, resource.TemplateRef)
+	apiTemplate, err := r.getDeliveryClusterTemplate(ctx, resource.TemplateRef)
 	if err != nil {
 		log.Error(err, "failed to get delivery cluster template")
 		return nil, nil, GetDeliveryClusterTemplateError{
@@ -73,7 +102,7 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.ClusterDel
 		}
 	}
 
-	template, err := templates.NewModelFromAPI(apiTemplate)
+	template, err := templates.NewModelFromAPI(apiTemplate, r.systemRepo)
 	if err != nil {
 		log.Error(err, "failed to get delivery cluster template")
 		return nil, nil, fmt.Errorf("failed to get delivery cluster template [%+v]: %w", resource.TemplateRef, err)
@@ -97,6 +126,10 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.ClusterDel
 		"deployment":  inputs.Deployment,
 	}
 
+	if r.workload != nil {
+		templatingContext["workload"] = r.workload
+	}
+
 	// Todo: this belongs in Stamp.
 	if inputs.OnlyConfig() != nil {
 		templatingContext["config"] = inputs.OnlyConfig()
@@ -106,6 +139,17 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.ClusterDel
 	}
 
 	stampContext := templates.StamperBuilder(r.deliverable, templatingContext, labels)
+	if resource.TargetNamespace != "" {
+		stampContext.TargetNamespace, err = templates.RenderNamespace(resource.TargetNamespace, templatingContext)
+		if err != nil {
+			log.Error(err, "failed to render target namespace", "target namespace", resource.TargetNamespace)
+			return nil, nil, StampError{
+				Err:      err,
+				Resource: resource,
+			}
+		}
+	}
+
 	stampedObject, err := stampContext.Stamp(ctx, template.GetResourceTemplate())
 	if err != nil {
 		log.Error(err, "failed to stamp resource")
@@ -115,6 +159,14 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.ClusterDel
 		}
 	}
 
+	if !r.kindPolicy.Allows(stampedObject.GroupVersionKind().GroupKind()) {
+		log.Info("stamped object kind is not allowed", "object", stampedObject)
+		return nil, nil, StampedKindNotAllowedError{
+			Resource:      resource,
+			StampedObject: stampedObject,
+		}
+	}
+
 	err = r.deliverableRepo.EnsureObjectExistsOnCluster(ctx, stampedObject, true)
 	if err != nil {
 		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
@@ -127,7 +179,7 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.ClusterDel
 	template.SetInputs(inputs)
 	template.SetStampedObject(stampedObject)
 
-	output, err := template.GetOutput()
+	output, err := template.GetOutput(ctx)
 	if err != nil {
 		log.Error(err, "failed to retrieve output from object", "object", stampedObject)
 		return stampedObject, nil, RetrieveOutputError{
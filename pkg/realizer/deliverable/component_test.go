@@ -24,13 +24,16 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
@@ -87,14 +90,14 @@ var _ = Describe("Resource", func() {
 		}
 
 		repoCache = &repositoryfakes.FakeRepoCache{} //TODO: can we verify right cache used?
-		resourceRealizerBuilder := realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache)
+		resourceRealizerBuilder := realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, kindpolicy.Policy{})
 
 		deliverable = v1alpha1.Deliverable{}
 
 		theSecret = &corev1.Secret{StringData: map[string]string{"blah": "blah"}}
 
 		var err error
-		r, err = resourceRealizerBuilder(theSecret, &deliverable, &fakeSystemRepo, deliveryParams)
+		r, err = resourceRealizerBuilder(theSecret, &deliverable, nil, &fakeSystemRepo, deliveryParams)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -162,6 +165,17 @@ var _ = Describe("Resource", func() {
 				fakeDeliverableRepo.EnsureObjectExistsOnClusterReturns(nil)
 			})
 
+			It("fetches a template shared by several resources once", func() {
+				otherResource := resource
+				otherResource.Name = "resource-2"
+
+				_, _, err := r.Do(ctx, &resource, deliveryName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+				_, _, err = r.Do(ctx, &otherResource, deliveryName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeSystemRepo.GetDeliveryClusterTemplateCallCount()).To(Equal(1))
+			})
+
 			It("creates a stamped object and returns the outputs and stampedObjects", func() {
 				returnedStampedObject, out, err := r.Do(ctx, &resource, deliveryName, outputs)
 				Expect(err).ToNot(HaveOccurred())
@@ -199,6 +213,145 @@ var _ = Describe("Resource", func() {
 				Expect(out.Source.Revision).To(Equal("some-revision"))
 				Expect(out.Source.URL).To(Equal("some-url"))
 			})
+
+			Context("and the kind policy denies the stamped kind", func() {
+				BeforeEach(func() {
+					var err error
+					repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+						return &fakeDeliverableRepo
+					}
+					clientBuilder := func(*corev1.Secret) (client.Client, error) {
+						return builtClient, nil
+					}
+					kindPolicy := kindpolicy.Policy{Denied: []schema.GroupKind{{Kind: "ConfigMap"}}}
+					r, err = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, kindPolicy)(theSecret, &deliverable, nil, &fakeSystemRepo, deliveryParams)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns StampedKindNotAllowedError without applying the stamped object", func() {
+					stampedObject, out, err := r.Do(ctx, &resource, deliveryName, outputs)
+					Expect(stampedObject).To(BeNil())
+					Expect(out).To(BeNil())
+
+					Expect(err).To(MatchError(ContainSubstring("is of type [configmap], which cartographer is not allowed to create")))
+					Expect(reflect.TypeOf(err).String()).To(Equal("deliverable.StampedKindNotAllowedError"))
+					Expect(fakeDeliverableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the deliverable links a workload", func() {
+				var stampWithWorkload func(workload *v1alpha1.Workload) *unstructured.Unstructured
+
+				BeforeEach(func() {
+					configMap := &corev1.ConfigMap{
+						TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{Name: "example-config-map"},
+						Data: map[string]string{
+							"image":      `$(workload.spec.image)$`,
+							"generation": `$(workload.status.observedGeneration)$`,
+						},
+					}
+					dbytes, err := json.Marshal(configMap)
+					Expect(err).ToNot(HaveOccurred())
+
+					fakeSystemRepo.GetDeliveryClusterTemplateReturns(&v1alpha1.ClusterTemplate{
+						TypeMeta:   metav1.TypeMeta{Kind: "ClusterTemplate", APIVersion: "carto.run/v1alpha1"},
+						ObjectMeta: metav1.ObjectMeta{Name: "source-template-1"},
+						Spec: v1alpha1.TemplateSpec{
+							Template: &runtime.RawExtension{Raw: dbytes},
+						},
+					}, nil)
+
+					stampWithWorkload = func(workload *v1alpha1.Workload) *unstructured.Unstructured {
+						repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+							return &fakeDeliverableRepo
+						}
+						clientBuilder := func(*corev1.Secret) (client.Client, error) {
+							return builtClient, nil
+						}
+						r, err := realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, kindpolicy.Policy{})(theSecret, &deliverable, workload, &fakeSystemRepo, deliveryParams)
+						Expect(err).NotTo(HaveOccurred())
+
+						stampedObject, _, err := r.Do(ctx, &resource, deliveryName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+						return stampedObject
+					}
+				})
+
+				It("makes the workload, status included, available to the template", func() {
+					image := "some-image"
+					stampedObject := stampWithWorkload(&v1alpha1.Workload{
+						Spec:   v1alpha1.WorkloadSpec{Image: &image},
+						Status: v1alpha1.WorkloadStatus{ObservedGeneration: 3},
+					})
+
+					Expect(stampedObject.Object["data"]).To(Equal(map[string]interface{}{"image": "some-image", "generation": float64(3)}))
+				})
+
+				It("stamps a different object when the workload changes", func() {
+					image := "some-image"
+					workload := &v1alpha1.Workload{
+						Spec:   v1alpha1.WorkloadSpec{Image: &image},
+						Status: v1alpha1.WorkloadStatus{ObservedGeneration: 3},
+					}
+					first := stampWithWorkload(workload)
+
+					workload.Status.ObservedGeneration = 4
+					second := stampWithWorkload(workload)
+
+					Expect(first.Object["data"]).NotTo(Equal(second.Object["data"]))
+					Expect(second.Object["data"]).To(HaveKeyWithValue("generation", float64(4)))
+				})
+			})
+
+			Context("and the resource sets a target namespace", func() {
+				BeforeEach(func() {
+					deliverable.Name = "my-deliverable"
+					deliverable.Namespace = "my-ns"
+					deliverable.Labels = map[string]string{"env": "Staging"}
+					resource.TargetNamespace = "$(deliverable.metadata.labels.env)$-apps"
+				})
+
+				It("stamps the object into the rendered namespace without an owner reference to the deliverable", func() {
+					_, _, err := r.Do(ctx, &resource, deliveryName, outputs)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, stampedObject, _ := fakeDeliverableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+					Expect(stampedObject.GetNamespace()).To(Equal("staging-apps"))
+					Expect(stampedObject.GetOwnerReferences()).To(BeEmpty())
+					Expect(stampedObject.GetLabels()).To(HaveKeyWithValue("carto.run/deliverable-name", "my-deliverable"))
+					Expect(stampedObject.GetLabels()).To(HaveKeyWithValue("carto.run/deliverable-namespace", "my-ns"))
+				})
+
+				Context("that renders the deliverable's own namespace", func() {
+					BeforeEach(func() {
+						resource.TargetNamespace = "$(deliverable.metadata.namespace)$"
+					})
+
+					It("keeps the deliverable as the owner", func() {
+						_, _, err := r.Do(ctx, &resource, deliveryName, outputs)
+						Expect(err).ToNot(HaveOccurred())
+
+						_, stampedObject, _ := fakeDeliverableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+						Expect(stampedObject.GetNamespace()).To(Equal("my-ns"))
+						Expect(stampedObject.GetOwnerReferences()).To(HaveLen(1))
+						Expect(stampedObject.GetOwnerReferences()[0].Name).To(Equal("my-deliverable"))
+					})
+				})
+
+				Context("that cannot be rendered", func() {
+					BeforeEach(func() {
+						resource.TargetNamespace = "$(deliverable.metadata.labels.missing)$"
+					})
+
+					It("returns StampError without applying an object", func() {
+						_, _, err := r.Do(ctx, &resource, deliveryName, outputs)
+						Expect(err).To(BeAssignableToTypeOf(realizer.StampError{}))
+						Expect(err).To(MatchError(ContainSubstring("interpolate namespace template [$(deliverable.metadata.labels.missing)$]")))
+						Expect(fakeDeliverableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+					})
+				})
+			})
 		})
 
 		When("unable to get the template ref from systemRepo", func() {
@@ -52,6 +52,17 @@ func (e StampError) Error() string {
 	return fmt.Errorf("unable to stamp object for resource [%s]: %w", e.Resource.Name, e.Err).Error()
 }
 
+type StampedKindNotAllowedError struct {
+	Resource      *v1alpha1.ClusterDeliveryResource
+	StampedObject *unstructured.Unstructured
+}
+
+func (e StampedKindNotAllowedError) Error() string {
+	return fmt.Errorf("stamped object [%s/%s] for resource [%s] is of type [%s], which cartographer is not allowed to create",
+		e.StampedObject.GetNamespace(), e.StampedObject.GetName(), e.Resource.Name,
+		utils.GetFullyQualifiedType(e.StampedObject)).Error()
+}
+
 type RetrieveOutputError struct {
 	Err           error
 	Resource      *v1alpha1.ClusterDeliveryResource
@@ -59,6 +59,7 @@ func (o Outputs) GenerateInputs(resource *v1alpha1.ClusterDeliveryResource) *tem
 			inputs.Sources[referenceSource.Name] = templates.SourceInput{
 				URL:      source.URL,
 				Revision: source.Revision,
+				Digest:   source.Digest,
 				Name:     referenceSource.Name,
 			}
 		}
@@ -80,6 +81,7 @@ func (o Outputs) GenerateInputs(resource *v1alpha1.ClusterDeliveryResource) *tem
 			inputs.Deployment = &templates.SourceInput{
 				URL:      deployment.URL,
 				Revision: deployment.Revision,
+				Digest:   deployment.Digest,
 			}
 		}
 	}
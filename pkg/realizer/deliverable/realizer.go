@@ -45,6 +45,11 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 	var stampedObjects []*unstructured.Unstructured
 
 	for i := range delivery.Spec.Resources {
+		if err := ctx.Err(); err != nil {
+			log.Info("realize cancelled", "error", err.Error())
+			return stampedObjects, err
+		}
+
 		resource := delivery.Spec.Resources[i]
 		log = log.WithValues("resource", resource.Name)
 		ctx = logr.NewContext(ctx, log)
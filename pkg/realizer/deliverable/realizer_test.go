@@ -94,4 +94,18 @@ var _ = Describe("Realize", func() {
 		Expect(err).To(MatchError("realizing is hard"))
 		Expect(stampedObjects).To(HaveLen(0))
 	})
+
+	It("realizes no further resource once the context is cancelled", func() {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		resourceRealizer.DoCalls(func(context.Context, *v1alpha1.ClusterDeliveryResource, string, realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+			cancel()
+			return &unstructured.Unstructured{}, &templates.Output{}, nil
+		})
+
+		stampedObjects, err := rlzr.Realize(cancelCtx, resourceRealizer, delivery)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(resourceRealizer.DoCallCount()).To(Equal(1))
+		Expect(stampedObjects).To(HaveLen(1))
+	})
 })
@@ -0,0 +1,61 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindpolicy
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Policy restricts the kinds of objects that templates may stamp.
+// The zero value allows every kind.
+type Policy struct {
+	// Allowed, when not empty, lists the only kinds that may be stamped.
+	Allowed []schema.GroupKind
+	// Denied lists kinds that may never be stamped, even when they are allowed.
+	Denied []schema.GroupKind
+}
+
+// ParseGroupKinds parses a comma separated list of kinds in the form Kind.group,
+// e.g. "ClusterRole.rbac.authorization.k8s.io,Secret". Kinds of the core group have no group suffix.
+func ParseGroupKinds(list string) []schema.GroupKind {
+	var groupKinds []schema.GroupKind
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		groupKinds = append(groupKinds, schema.ParseGroupKind(item))
+	}
+	return groupKinds
+}
+
+func (p Policy) Allows(groupKind schema.GroupKind) bool {
+	if contains(p.Denied, groupKind) {
+		return false
+	}
+
+	return len(p.Allowed) == 0 || contains(p.Allowed, groupKind)
+}
+
+func contains(groupKinds []schema.GroupKind, groupKind schema.GroupKind) bool {
+	for _, candidate := range groupKinds {
+		if candidate == groupKind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kindpolicy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
+)
+
+var _ = Describe("Policy", func() {
+	var (
+		secret      = schema.GroupKind{Kind: "Secret"}
+		clusterRole = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}
+		deployment  = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	)
+
+	Describe("ParseGroupKinds", func() {
+		It("parses kinds with and without a group", func() {
+			Expect(kindpolicy.ParseGroupKinds("ClusterRole.rbac.authorization.k8s.io, Secret,")).To(Equal([]schema.GroupKind{clusterRole, secret}))
+		})
+
+		It("returns nothing for an empty list", func() {
+			Expect(kindpolicy.ParseGroupKinds("")).To(BeEmpty())
+		})
+	})
+
+	Context("the policy is empty", func() {
+		It("allows every kind", func() {
+			Expect(kindpolicy.Policy{}.Allows(secret)).To(BeTrue())
+			Expect(kindpolicy.Policy{}.Allows(deployment)).To(BeTrue())
+		})
+	})
+
+	Context("the policy denies kinds", func() {
+		var policy = kindpolicy.Policy{Denied: []schema.GroupKind{secret, clusterRole}}
+
+		It("does not allow a denied kind", func() {
+			Expect(policy.Allows(secret)).To(BeFalse())
+			Expect(policy.Allows(clusterRole)).To(BeFalse())
+		})
+
+		It("allows any other kind", func() {
+			Expect(policy.Allows(deployment)).To(BeTrue())
+		})
+
+		It("matches on the group as well as the kind", func() {
+			Expect(policy.Allows(schema.GroupKind{Group: "example.com", Kind: "Secret"})).To(BeTrue())
+		})
+	})
+
+	Context("the policy allows kinds", func() {
+		var policy kindpolicy.Policy
+
+		BeforeEach(func() {
+			policy = kindpolicy.Policy{Allowed: []schema.GroupKind{deployment, secret}}
+		})
+
+		It("allows an allowed kind", func() {
+			Expect(policy.Allows(deployment)).To(BeTrue())
+		})
+
+		It("does not allow a kind missing from the list", func() {
+			Expect(policy.Allows(clusterRole)).To(BeFalse())
+		})
+
+		Context("and denies one of them", func() {
+			BeforeEach(func() {
+				policy.Denied = []schema.GroupKind{secret}
+			})
+
+			It("does not allow the denied kind", func() {
+				Expect(policy.Allows(secret)).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,87 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mirror rewrites the images whose reference starts with From to start with To instead,
+// e.g. From "docker.io/library" and To "mirror.example.com/dockerhub" rewrites
+// "docker.io/library/nginx@sha256:..." to "mirror.example.com/dockerhub/nginx@sha256:...".
+type Mirror struct {
+	From string
+	To   string
+}
+
+// Mirrors rewrites image references to the registry mirrors they are pulled from.
+// The zero value rewrites nothing.
+type Mirrors []Mirror
+
+// Parse parses a comma separated list of mirrors in the form from=to,
+// e.g. "docker.io/library=mirror.example.com/dockerhub,gcr.io=mirror.example.com/gcr".
+func Parse(list string) (Mirrors, error) {
+	var mirrors Mirrors
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		from, to, found := cut(item, "=")
+		from, to = strings.TrimSuffix(strings.TrimSpace(from), "/"), strings.TrimSuffix(strings.TrimSpace(to), "/")
+		if !found || from == "" || to == "" {
+			return nil, fmt.Errorf("registry mirror [%s] is not of the form from=to", item)
+		}
+		mirrors = append(mirrors, Mirror{From: from, To: to})
+	}
+	return mirrors, nil
+}
+
+// Rewrite returns the image with the From prefix of the mirror matching the most of it
+// replaced by its To prefix. A prefix only matches whole path components, the repository
+// or the registry host, so the tag or digest following it is kept as is. An image no mirror
+// matches is returned unchanged.
+func (m Mirrors) Rewrite(image string) string {
+	var match *Mirror
+	for i := range m {
+		if matches(image, m[i].From) && (match == nil || len(m[i].From) > len(match.From)) {
+			match = &m[i]
+		}
+	}
+
+	if match == nil {
+		return image
+	}
+	return match.To + strings.TrimPrefix(image, match.From)
+}
+
+func matches(image, from string) bool {
+	if !strings.HasPrefix(image, from) {
+		return false
+	}
+
+	rest := image[len(from):]
+	return rest == "" || strings.ContainsAny(rest[:1], "/:@")
+}
+
+// cut is strings.Cut, which go 1.17 does not have
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirrors_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/mirrors"
+)
+
+const digest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+var _ = Describe("Mirrors", func() {
+	Describe("Parse", func() {
+		It("parses a comma separated list of from=to mirrors", func() {
+			parsed, err := mirrors.Parse(" docker.io/library=mirror.internal/dockerhub/ , gcr.io=mirror.internal/gcr,")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(mirrors.Mirrors{
+				{From: "docker.io/library", To: "mirror.internal/dockerhub"},
+				{From: "gcr.io", To: "mirror.internal/gcr"},
+			}))
+		})
+
+		It("parses an empty list to no mirrors", func() {
+			parsed, err := mirrors.Parse("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(BeEmpty())
+		})
+
+		DescribeTable("rejects malformed mirrors",
+			func(list string) {
+				_, err := mirrors.Parse(list)
+				Expect(err).To(MatchError(ContainSubstring("is not of the form from=to")))
+			},
+			Entry("without a separator", "docker.io"),
+			Entry("without a from", "=mirror.internal"),
+			Entry("without a to", "docker.io="),
+		)
+	})
+
+	Describe("Rewrite", func() {
+		m := mirrors.Mirrors{
+			{From: "docker.io/library", To: "mirror.internal/dockerhub"},
+			{From: "docker.io", To: "mirror.internal/docker"},
+			{From: "gcr.io/project/app", To: "mirror.internal/app"},
+		}
+
+		DescribeTable("rewrites images to their mirror",
+			func(image, rewritten string) {
+				Expect(m.Rewrite(image)).To(Equal(rewritten))
+			},
+			Entry("keeping the digest", "docker.io/library/nginx"+digest, "mirror.internal/dockerhub/nginx"+digest),
+			Entry("keeping the tag", "docker.io/library/nginx:1.21", "mirror.internal/dockerhub/nginx:1.21"),
+			Entry("with the longest matching prefix", "docker.io/bitnami/redis"+digest, "mirror.internal/docker/bitnami/redis"+digest),
+			Entry("of a whole repository", "gcr.io/project/app"+digest, "mirror.internal/app"+digest),
+			Entry("of a whole repository with a tag", "gcr.io/project/app:v1", "mirror.internal/app:v1"),
+			Entry("not of a prefix ending mid component", "gcr.io/project/application"+digest, "gcr.io/project/application"+digest),
+			Entry("not of another registry", "quay.io/org/app"+digest, "quay.io/org/app"+digest),
+			Entry("not of a registry the prefix only starts", "docker.iox/app", "docker.iox/app"),
+		)
+
+		It("rewrites nothing without mirrors", func() {
+			Expect(mirrors.Mirrors(nil).Rewrite("docker.io/library/nginx")).To(Equal("docker.io/library/nginx"))
+		})
+	})
+})
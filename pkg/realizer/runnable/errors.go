@@ -46,6 +46,29 @@ func (e ResolveSelectorError) Error() string {
 		e.Err).Error()
 }
 
+type MissingRequiredInputError struct {
+	Input       string
+	Runnable    *v1alpha1.Runnable
+	RunTemplate *v1alpha1.ClusterRunTemplate
+}
+
+func (e MissingRequiredInputError) Error() string {
+	return fmt.Sprintf("runnable [%s/%s] does not provide input [%s] required by run template [%s]",
+		e.Runnable.Namespace, e.Runnable.Name, e.Input, e.RunTemplate.Name)
+}
+
+type InvalidInputError struct {
+	Input       string
+	Err         error
+	Runnable    *v1alpha1.Runnable
+	RunTemplate *v1alpha1.ClusterRunTemplate
+}
+
+func (e InvalidInputError) Error() string {
+	return fmt.Errorf("runnable [%s/%s] input [%s] does not match the schema of run template [%s]: %w",
+		e.Runnable.Namespace, e.Runnable.Name, e.Input, e.RunTemplate.Name, e.Err).Error()
+}
+
 type StampError struct {
 	Err      error
 	Runnable *v1alpha1.Runnable
@@ -70,6 +93,21 @@ func (e ApplyStampedObjectError) Error() string {
 		e.StampedObject.GetNamespace(), name, e.Err).Error()
 }
 
+type StampedKindNotAllowedError struct {
+	Runnable      *v1alpha1.Runnable
+	StampedObject *unstructured.Unstructured
+}
+
+func (e StampedKindNotAllowedError) Error() string {
+	name := e.StampedObject.GetName()
+	if name == "" {
+		name = e.StampedObject.GetGenerateName()
+	}
+	return fmt.Errorf("stamped object [%s/%s] for runnable [%s/%s] is of type [%s], which cartographer is not allowed to create",
+		e.StampedObject.GetNamespace(), name, e.Runnable.Namespace, e.Runnable.Name,
+		utils.GetFullyQualifiedType(e.StampedObject)).Error()
+}
+
 type ListCreatedObjectsError struct {
 	Err       error
 	Namespace string
@@ -81,6 +119,17 @@ func (e ListCreatedObjectsError) Error() string {
 		e.Namespace, e.Labels, e.Err).Error()
 }
 
+type CancelPreviousRunError struct {
+	Err         error
+	PreviousRun *unstructured.Unstructured
+}
+
+func (e CancelPreviousRunError) Error() string {
+	return fmt.Errorf("unable to cancel previous run [%s/%s] of type [%s]: %w",
+		e.PreviousRun.GetNamespace(), e.PreviousRun.GetName(),
+		utils.GetFullyQualifiedType(e.PreviousRun), e.Err).Error()
+}
+
 type RetrieveOutputError struct {
 	Err           error
 	Runnable      *v1alpha1.Runnable
@@ -98,3 +147,19 @@ func (e RetrieveOutputError) Error() string {
 		utils.GetFullyQualifiedType(e.StampedObject),
 		e.Runnable.Namespace, e.Runnable.Name, e.Err).Error()
 }
+
+type OutputTransformError struct {
+	Err           error
+	Runnable      *v1alpha1.Runnable
+	StampedObject *unstructured.Unstructured
+}
+
+func (e OutputTransformError) Error() string {
+	name := e.StampedObject.GetName()
+	if name == "" {
+		name = e.StampedObject.GetGenerateName()
+	}
+	return fmt.Errorf("unable to transform outputs of stamped object [%s/%s] of type [%s] for runnable [%s/%s]: %w",
+		e.StampedObject.GetNamespace(), name, utils.GetFullyQualifiedType(e.StampedObject),
+		e.Runnable.Namespace, e.Runnable.Name, e.Err).Error()
+}
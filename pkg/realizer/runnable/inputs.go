@@ -0,0 +1,83 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// validateInputs validates the inputs of the runnable, defaults applied, against the schemas
+// the run template declares for them. Inputs without a schema are not validated.
+func validateInputs(runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate) error {
+	for _, input := range runTemplate.Spec.Inputs {
+		if input.Schema == nil {
+			continue
+		}
+
+		invalid := func(err error) error {
+			return InvalidInputError{
+				Input:       input.Name,
+				Err:         err,
+				Runnable:    runnable,
+				RunTemplate: runTemplate,
+			}
+		}
+
+		validator, err := inputSchemaValidator(input.Schema)
+		if err != nil {
+			return invalid(err)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(runnable.Spec.Inputs[input.Name].Raw, &value); err != nil {
+			return invalid(fmt.Errorf("unmarshal value: %w", err))
+		}
+
+		fieldPath := field.NewPath("spec", "inputs").Key(input.Name)
+		if errs := validation.ValidateCustomResource(fieldPath, value, validator); len(errs) > 0 {
+			return invalid(errs.ToAggregate())
+		}
+	}
+
+	return nil
+}
+
+func inputSchemaValidator(schema *apiextensionsv1.JSON) (*validate.SchemaValidator, error) {
+	props := &apiextensionsv1.JSONSchemaProps{}
+	if err := json.Unmarshal(schema.Raw, props); err != nil {
+		return nil, fmt.Errorf("malformed schema: %w", err)
+	}
+
+	internalValidation := &apiextensions.CustomResourceValidation{}
+	err := apiextensionsv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(&apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: props}, internalValidation, nil)
+	if err != nil {
+		return nil, fmt.Errorf("convert schema: %w", err)
+	}
+
+	validator, _, err := validation.NewSchemaValidator(internalValidation)
+	if err != nil {
+		return nil, fmt.Errorf("build schema validator: %w", err)
+	}
+	return validator, nil
+}
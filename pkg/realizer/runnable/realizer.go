@@ -21,57 +21,126 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
 //counterfeiter:generate . Realizer
 type Realizer interface {
-	Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository) (*unstructured.Unstructured, templates.Outputs, error)
+	// Realize returns the stamped object, the runnable's outputs, the object those outputs
+	// were read from, which is nil when they are carried over from the status, and the run
+	// template it stamped, which is nil when the run template could not be read.
+	Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error)
 }
 
-func NewRealizer() Realizer {
-	return &runnableRealizer{}
+func NewRealizer(kindPolicy kindpolicy.Policy) Realizer {
+	return &runnableRealizer{kindPolicy: kindPolicy}
 }
 
-type runnableRealizer struct{}
+type runnableRealizer struct {
+	kindPolicy kindpolicy.Policy
+}
 
 type TemplatingContext struct {
-	Runnable *v1alpha1.Runnable     `json:"runnable"`
-	Selected map[string]interface{} `json:"selected"`
+	Runnable     *v1alpha1.Runnable     `json:"runnable"`
+	Selected     map[string]interface{} `json:"selected"`
+	Cartographer CartographerContext    `json:"cartographer"`
+}
+
+// CartographerContext tells a run template about the workload and supply chain that stamped
+// its runnable, read from the labels a supply chain sets on the objects it stamps. Values are
+// empty for a runnable that was not stamped by a supply chain.
+type CartographerContext struct {
+	Workload    WorkloadContext    `json:"workload"`
+	SupplyChain SupplyChainContext `json:"supplyChain"`
+}
+
+type WorkloadContext struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type SupplyChainContext struct {
+	Name string `json:"name"`
+}
+
+func cartographerContext(runnable *v1alpha1.Runnable) CartographerContext {
+	labels := runnable.GetLabels()
+	return CartographerContext{
+		Workload: WorkloadContext{
+			Name:      labels["carto.run/workload-name"],
+			Namespace: labels["carto.run/workload-namespace"],
+		},
+		SupplyChain: SupplyChainContext{
+			Name: labels["carto.run/cluster-supply-chain-name"],
+		},
+	}
 }
 
-func (p *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository) (*unstructured.Unstructured, templates.Outputs, error) {
+func (p *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runnable, systemRepo repository.Repository, runnableRepo repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("template", runnable.Spec.RunTemplateRef)
 	ctx = logr.NewContext(ctx, log)
 
-	runnable.Spec.RunTemplateRef.Kind = "ClusterRunTemplate"
-	apiRunTemplate, err := systemRepo.GetRunTemplate(ctx, runnable.Spec.RunTemplateRef)
+	if kind := runnable.Spec.RunTemplateRef.Kind; kind != "ClusterRunTemplate" {
+		err := fmt.Errorf("run template kind [%s] is not supported, the runTemplateRef must be of kind [ClusterRunTemplate]", kind)
+		log.Error(err, "unsupported run template kind")
+		return nil, nil, nil, nil, GetRunTemplateError{
+			Err:      err,
+			Runnable: runnable,
+		}
+	}
+
+	runTemplateName, err := RunTemplateName(runnable)
+	if err != nil {
+		log.Error(err, "failed to resolve runnable cluster template name")
+		return nil, nil, nil, nil, GetRunTemplateError{
+			Err:      err,
+			Runnable: runnable,
+		}
+	}
+
+	runTemplateRef := runnable.Spec.RunTemplateRef
+	runTemplateRef.Name = runTemplateName
+
+	apiRunTemplate, err := systemRepo.GetRunTemplate(ctx, runTemplateRef)
 
 	if err != nil {
 		log.Error(err, "failed to get runnable cluster template")
-		return nil, nil, GetRunTemplateError{
+		return nil, nil, nil, nil, GetRunTemplateError{
 			Err:      err,
 			Runnable: runnable,
 		}
 	}
 
-	template := templates.NewRunTemplateModel(apiRunTemplate)
+	runnable, err = defaultInputs(runnable, apiRunTemplate)
+	if err != nil {
+		log.Info("runnable is missing a required input", "error", err.Error())
+		return nil, nil, nil, apiRunTemplate, err
+	}
+
+	if err = validateInputs(runnable, apiRunTemplate); err != nil {
+		log.Info("runnable input does not match its schema", "error", err.Error())
+		return nil, nil, nil, apiRunTemplate, err
+	}
+
+	template := templates.NewRunTemplateModel(apiRunTemplate, runnableRepo)
 
 	labels := map[string]string{
-		"carto.run/runnable-name":     runnable.Name,
+		repository.RunnableNameLabel:  runnable.Name,
 		"carto.run/run-template-name": template.GetName(),
 	}
 
 	selected, err := resolveSelector(ctx, runnable.Spec.Selector, runnableRepo, runnable.GetNamespace())
 	if err != nil {
 		log.Error(err, "failed to resolve selector", "selector", runnable.Spec.Selector)
-		return nil, nil, ResolveSelectorError{
+		return nil, nil, nil, apiRunTemplate, ResolveSelectorError{
 			Err:      err,
 			Selector: runnable.Spec.Selector,
 		}
@@ -80,8 +149,9 @@ func (p *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	stampContext := templates.StamperBuilder(
 		runnable,
 		TemplatingContext{
-			Runnable: runnable,
-			Selected: selected,
+			Runnable:     runnable,
+			Selected:     selected,
+			Cartographer: cartographerContext(runnable),
 		},
 		labels,
 	)
@@ -89,17 +159,28 @@ func (p *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	stampedObject, err := stampContext.Stamp(ctx, template.GetResourceTemplate())
 	if err != nil {
 		log.Error(err, "failed to stamp resource")
-		return nil, nil, StampError{
+		return nil, nil, nil, apiRunTemplate, StampError{
 			Err:      err,
 			Runnable: runnable,
 		}
 	}
 
+	applyDefaultMetadata(stampedObject, apiRunTemplate.Spec.ObjectMeta)
+
+	if !p.kindPolicy.Allows(stampedObject.GroupVersionKind().GroupKind()) {
+		log.Info("stamped object kind is not allowed", "object", stampedObject)
+		return nil, nil, nil, apiRunTemplate, StampedKindNotAllowedError{
+			Runnable:      runnable,
+			StampedObject: stampedObject,
+		}
+	}
+
 	// FIXME: why are we taking a DeepCopy?
-	err = runnableRepo.EnsureObjectExistsOnCluster(ctx, stampedObject.DeepCopy(), false)
+	currentRun := stampedObject.DeepCopy()
+	err = runnableRepo.EnsureObjectExistsOnCluster(ctx, currentRun, false)
 	if err != nil {
 		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
-		return nil, nil, ApplyStampedObjectError{
+		return nil, nil, nil, apiRunTemplate, ApplyStampedObjectError{
 			Err:           err,
 			StampedObject: stampedObject,
 		}
@@ -111,20 +192,27 @@ func (p *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	allRunnableStampedObjects, err := runnableRepo.ListUnstructured(ctx, objectForListCall)
 	if err != nil {
 		log.Error(err, "failed to list objects")
-		return stampedObject, nil, ListCreatedObjectsError{
+		return stampedObject, nil, nil, apiRunTemplate, ListCreatedObjectsError{
 			Err:       err,
 			Namespace: objectForListCall.GetNamespace(),
 			Labels:    objectForListCall.GetLabels(),
 		}
 	}
 
-	outputs, evaluatedStampedObject, err := template.GetOutput(allRunnableStampedObjects)
+	if runnable.Spec.CancelPreviousRuns {
+		allRunnableStampedObjects, err = cancelPreviousRuns(ctx, runnableRepo, currentRun, allRunnableStampedObjects)
+		if err != nil {
+			return stampedObject, nil, nil, apiRunTemplate, err
+		}
+	}
+
+	outputs, evaluatedStampedObject, err := template.GetOutput(ctx, allRunnableStampedObjects)
 	if err != nil {
 		for _, obj := range allRunnableStampedObjects {
 			log.V(logger.DEBUG).Info("failed to retrieve output from any object", "considered", obj)
 		}
 		log.Error(err, "failed to retrieve output from object")
-		return stampedObject, nil, RetrieveOutputError{
+		return stampedObject, nil, nil, apiRunTemplate, RetrieveOutputError{
 			Err:           err,
 			Runnable:      runnable,
 			StampedObject: stampedObject,
@@ -132,12 +220,124 @@ func (p *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 	}
 	log.V(logger.DEBUG).Info("retrieved output from stamped object", "stamped object", evaluatedStampedObject)
 
+	if evaluatedStampedObject != nil {
+		outputs, err = template.TransformOutputs(outputs)
+		if err != nil {
+			log.Error(err, "failed to transform outputs")
+			return stampedObject, nil, nil, apiRunTemplate, OutputTransformError{
+				Err:           err,
+				Runnable:      runnable,
+				StampedObject: evaluatedStampedObject,
+			}
+		}
+	}
+
 	if len(outputs) == 0 {
 		log.V(logger.DEBUG).Info("no outputs retrieved, getting outputs from runnable.Status.Outputs")
-		outputs = runnable.Status.Outputs
+		return stampedObject, runnable.Status.Outputs, nil, apiRunTemplate, nil
+	}
+
+	return stampedObject, outputs, evaluatedStampedObject, apiRunTemplate, nil
+}
+
+// defaultInputs returns the runnable with the defaults of the run template's declared
+// inputs applied to any inputs the runnable omits. The runnable passed in is not modified.
+func defaultInputs(runnable *v1alpha1.Runnable, runTemplate *v1alpha1.ClusterRunTemplate) (*v1alpha1.Runnable, error) {
+	var missing []v1alpha1.RunTemplateInput
+	for _, input := range runTemplate.Spec.Inputs {
+		if _, ok := runnable.Spec.Inputs[input.Name]; !ok {
+			missing = append(missing, input)
+		}
+	}
+
+	if len(missing) == 0 {
+		return runnable, nil
+	}
+
+	defaulted := runnable.DeepCopy()
+	if defaulted.Spec.Inputs == nil {
+		defaulted.Spec.Inputs = map[string]apiextensionsv1.JSON{}
+	}
+
+	for _, input := range missing {
+		if input.Default == nil {
+			return nil, MissingRequiredInputError{
+				Input:       input.Name,
+				Runnable:    runnable,
+				RunTemplate: runTemplate,
+			}
+		}
+		defaulted.Spec.Inputs[input.Name] = *input.Default.DeepCopy()
+	}
+
+	return defaulted, nil
+}
+
+// cancelPreviousRuns deletes every run other than currentRun that is still running, as
+// currentRun was stamped from newer inputs. It returns the runs that remain.
+func cancelPreviousRuns(ctx context.Context, runnableRepo repository.Repository, currentRun *unstructured.Unstructured, runs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if currentRun.GetName() == "" {
+		log.V(logger.DEBUG).Info("current run has no name, not cancelling previous runs")
+		return runs, nil
+	}
+
+	var remaining []*unstructured.Unstructured
+	for _, run := range runs {
+		if run.GetName() == currentRun.GetName() || !runInFlight(run) {
+			remaining = append(remaining, run)
+			continue
+		}
+
+		log.V(logger.DEBUG).Info("cancelling previous run", "previous run", run)
+		err := runnableRepo.DeleteUnstructured(ctx, run)
+		if err != nil {
+			log.Error(err, "failed to cancel previous run", "previous run", run)
+			return nil, CancelPreviousRunError{
+				Err:         err,
+				PreviousRun: run,
+			}
+		}
 	}
 
-	return stampedObject, outputs, nil
+	return remaining, nil
+}
+
+// runInFlight tells a run that is known to still be running: one whose Succeeded condition,
+// as reported by tekton runs, is Unknown, or a batch Job with active pods that has neither
+// completed nor failed. A run of a kind reporting neither is never taken to be in flight, so
+// that finished runs are not deleted.
+func runInFlight(run *unstructured.Unstructured) bool {
+	if status, ok := conditionStatus(run, "Succeeded"); ok {
+		return status == "Unknown"
+	}
+
+	active, _, _ := unstructured.NestedInt64(run.UnstructuredContent(), "status", "active")
+	if active == 0 {
+		return false
+	}
+	for _, finished := range []string{"Complete", "Failed"} {
+		if status, _ := conditionStatus(run, finished); status == "True" {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionStatus returns the status of the run's condition of the given type, and whether
+// the run has one.
+func conditionStatus(run *unstructured.Unstructured, conditionType string) (string, bool) {
+	conditions, _, _ := unstructured.NestedSlice(run.UnstructuredContent(), "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		return status, true
+	}
+	return "", false
 }
 
 func resolveSelector(ctx context.Context, selector *v1alpha1.ResourceSelector, repository repository.Repository, namespace string) (map[string]interface{}, error) {
@@ -189,3 +389,29 @@ func resolveClusterScopedSelector(ctx context.Context, selector *v1alpha1.Resour
 	}
 	return results[0].Object, nil
 }
+
+// applyDefaultMetadata adds the run template's labels and annotations to the stamped object
+// without overriding any the object already carries, so the tracking labels are kept.
+func applyDefaultMetadata(obj *unstructured.Unstructured, defaults *v1alpha1.RunTemplateObjectMeta) {
+	if defaults == nil {
+		return
+	}
+
+	if len(defaults.Labels) > 0 {
+		obj.SetLabels(withDefaults(obj.GetLabels(), defaults.Labels))
+	}
+	if len(defaults.Annotations) > 0 {
+		obj.SetAnnotations(withDefaults(obj.GetAnnotations(), defaults.Annotations))
+	}
+}
+
+func withDefaults(values, defaults map[string]string) map[string]string {
+	merged := make(map[string]string, len(values)+len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	return merged
+}
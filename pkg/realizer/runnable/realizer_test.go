@@ -29,8 +29,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/tests/resources"
@@ -50,7 +52,7 @@ var _ = Describe("Realizer", func() {
 		ctx = context.Background()
 		systemRepo = &repositoryfakes.FakeRepository{}
 		runnableRepo = &repositoryfakes.FakeRepository{}
-		rlzr = realizer.NewRealizer()
+		rlzr = realizer.NewRealizer(kindpolicy.Policy{})
 
 		runnable = &v1alpha1.Runnable{
 			ObjectMeta: metav1.ObjectMeta{
@@ -67,6 +69,8 @@ var _ = Describe("Realizer", func() {
 	})
 
 	Context("with a valid ClusterRunTemplate", func() {
+		var templateAPI *v1alpha1.ClusterRunTemplate
+
 		BeforeEach(func() {
 			testObj := resources.TestObj{
 				TypeMeta: metav1.TypeMeta{
@@ -93,7 +97,7 @@ var _ = Describe("Realizer", func() {
 			dbytes, err := json.Marshal(testObj)
 			Expect(err).ToNot(HaveOccurred())
 
-			var templateAPI = &v1alpha1.ClusterRunTemplate{
+			templateAPI = &v1alpha1.ClusterRunTemplate{
 				Spec: v1alpha1.ClusterRunTemplateSpec{
 					Outputs: map[string]string{
 						"myout": "spec.foo",
@@ -117,7 +121,7 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("stamps out the resource from the template", func() {
-			_, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			_, _, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 
 			Expect(systemRepo.GetRunTemplateCallCount()).To(Equal(1))
 			_, actualTemplate := systemRepo.GetRunTemplateArgsForCall(0)
@@ -146,17 +150,17 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("does not return an error", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
 		It("returns the outputs", func() {
-			_, outputs, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			_, outputs, _, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 			Expect(outputs["myout"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}))
 		})
 
 		It("returns the stampedObject", func() {
-			stampedObject, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			stampedObject, _, _, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 			Expect(stampedObject.Object["spec"]).To(Equal(map[string]interface{}{
 				"foo":   "is a string",
 				"value": nil,
@@ -165,13 +169,205 @@ var _ = Describe("Realizer", func() {
 			Expect(stampedObject.Object["kind"]).To(Equal("TestObj"))
 		})
 
+		It("returns the object the outputs were read from", func() {
+			_, _, outputsSource, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(outputsSource).To(Equal(createdUnstructured))
+		})
+
+		It("returns the run template it stamped", func() {
+			_, _, _, runTemplate, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(runTemplate).To(Equal(templateAPI))
+		})
+
+		Context("no outputs can be read from the stamped objects", func() {
+			BeforeEach(func() {
+				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{}, nil)
+				runnable.Status.Outputs = map[string]apiextensionsv1.JSON{
+					"myout": {Raw: []byte(`"an earlier value"`)},
+				}
+			})
+
+			It("carries the outputs over from the status without a source", func() {
+				_, outputs, outputsSource, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(HaveKeyWithValue("myout", apiextensionsv1.JSON{Raw: []byte(`"an earlier value"`)}))
+				Expect(outputsSource).To(BeNil())
+			})
+		})
+
+		Context("the runnable was stamped by a supply chain", func() {
+			BeforeEach(func() {
+				runnable.Labels = map[string]string{
+					"carto.run/workload-name":             "my-workload",
+					"carto.run/workload-namespace":        "my-workload-ns",
+					"carto.run/cluster-supply-chain-name": "my-supply-chain",
+				}
+				templateAPI.Spec.Template = runtime.RawExtension{Raw: []byte(`{
+					"apiVersion": "test.run/v1alpha1",
+					"kind": "TestObj",
+					"metadata": {"generateName": "$(cartographer.workload.name)$-"},
+					"spec": {
+						"foo": "is a string",
+						"value": {
+							"workload": "$(cartographer.workload.namespace)$/$(cartographer.workload.name)$",
+							"supplyChain": "$(cartographer.supplyChain.name)$"
+						}
+					}
+				}`)}
+			})
+
+			It("makes the workload and supply chain available to the template under cartographer", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+				_, stamped, _ := runnableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+				Expect(stamped.GetGenerateName()).To(Equal("my-workload-"))
+				Expect(stamped.Object["spec"]).To(MatchKeys(IgnoreExtras, Keys{
+					"value": Equal(map[string]interface{}{
+						"workload":    "my-workload-ns/my-workload",
+						"supplyChain": "my-supply-chain",
+					}),
+				}))
+			})
+
+			Context("the runnable was not stamped by a supply chain", func() {
+				BeforeEach(func() {
+					runnable.Labels = nil
+				})
+
+				It("leaves the values empty", func() {
+					_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, stamped, _ := runnableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+					Expect(stamped.Object["spec"]).To(MatchKeys(IgnoreExtras, Keys{
+						"value": Equal(map[string]interface{}{
+							"workload":    "/",
+							"supplyChain": "",
+						}),
+					}))
+				})
+			})
+		})
+
+		Context("the template declares default object metadata", func() {
+			BeforeEach(func() {
+				templateAPI.Spec.ObjectMeta = &v1alpha1.RunTemplateObjectMeta{
+					Labels: map[string]string{
+						"team":                    "platform",
+						"carto.run/runnable-name": "not-my-runnable",
+					},
+					Annotations: map[string]string{
+						"example.com/owner": "platform-team",
+					},
+				}
+			})
+
+			It("adds the labels and annotations to the stamped object", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, stamped, _ := runnableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+				Expect(stamped.GetLabels()).To(HaveKeyWithValue("team", "platform"))
+				Expect(stamped.GetAnnotations()).To(HaveKeyWithValue("example.com/owner", "platform-team"))
+			})
+
+			It("does not override the labels used to track the stamped object", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, stamped, _ := runnableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+				Expect(stamped.GetLabels()).To(HaveKeyWithValue("carto.run/runnable-name", "my-runnable"))
+			})
+		})
+
+		Context("the template transforms an output", func() {
+			BeforeEach(func() {
+				templateAPI.Spec.OutputTransforms = map[string]string{
+					"myout": "$(value)$ and more",
+				}
+			})
+
+			It("returns the transformed outputs", func() {
+				_, outputs, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs["myout"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string and more"`)}))
+			})
+		})
+
+		Context("the template reads an output from a referenced object", func() {
+			BeforeEach(func() {
+				templateAPI.Spec.ReferencedOutputs = map[string]v1alpha1.ReferencedOutput{
+					"referenced": {
+						NamePath: "spec.foo",
+						Resource: v1alpha1.ResourceType{APIVersion: "test.run/v1alpha1", Kind: "TestObj"},
+						Path:     "metadata.name",
+					},
+				}
+
+				referencedObject := &unstructured.Unstructured{}
+				referencedObject.SetName("the-referenced-object")
+				runnableRepo.GetUnstructuredReturns(referencedObject, nil)
+			})
+
+			It("fetches the referenced object with the runnable's repository", func() {
+				_, outputs, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs["referenced"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"the-referenced-object"`)}))
+
+				Expect(systemRepo.GetUnstructuredCallCount()).To(Equal(0))
+				Expect(runnableRepo.GetUnstructuredCallCount()).To(Equal(1))
+				_, query := runnableRepo.GetUnstructuredArgsForCall(0)
+				Expect(query.GetName()).To(Equal("is a string"))
+			})
+		})
+
+		Context("the template output transform fails", func() {
+			BeforeEach(func() {
+				templateAPI.Spec.OutputTransforms = map[string]string{
+					"myout": "$(outputs.missing)$",
+				}
+			})
+
+			It("returns OutputTransformError", func() {
+				stampedObject, outputs, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(stampedObject).NotTo(BeNil())
+				Expect(outputs).To(BeNil())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to transform outputs of stamped object [my-important-ns/my-stamped-resource-] of type [testobj.test.run] for runnable [my-important-ns/my-runnable]: failed to transform output [myout]"))
+				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.OutputTransformError"))
+			})
+		})
+
+		Context("the runTemplateRef uses a name expression", func() {
+			BeforeEach(func() {
+				runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+					Kind:           "ClusterRunTemplate",
+					NameExpression: "$(runnable.spec.inputs.flavor)$-template",
+				}
+				runnable.Spec.Inputs = map[string]apiextensionsv1.JSON{
+					"flavor": {Raw: []byte(`"python"`)},
+				}
+			})
+
+			It("fetches the template with the resolved name", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(systemRepo.GetRunTemplateCallCount()).To(Equal(1))
+				_, actualTemplate := systemRepo.GetRunTemplateArgsForCall(0)
+				Expect(actualTemplate.Name).To(Equal("python-template"))
+			})
+		})
+
 		Context("error on EnsureObjectExistsOnCluster", func() {
 			BeforeEach(func() {
 				runnableRepo.EnsureObjectExistsOnClusterReturns(errors.New("some bad error"))
 			})
 
 			It("returns ApplyStampedObjectError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("some bad error"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.ApplyStampedObjectError"))
@@ -184,13 +380,142 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ListCreatedObjectsError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("some list error"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.ListCreatedObjectsError"))
 			})
 		})
 
+		Context("the kind policy denies the stamped kind", func() {
+			BeforeEach(func() {
+				rlzr = realizer.NewRealizer(kindpolicy.Policy{
+					Denied: []schema.GroupKind{{Group: "test.run", Kind: "TestObj"}},
+				})
+			})
+
+			It("returns StampedKindNotAllowedError without applying the stamped object", func() {
+				stampedObject, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(stampedObject).To(BeNil())
+				Expect(err).To(MatchError("stamped object [my-important-ns/my-stamped-resource-] for runnable [my-important-ns/my-runnable] is of type [testobj.test.run], which cartographer is not allowed to create"))
+				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.StampedKindNotAllowedError"))
+				Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("the kind policy allows the stamped kind", func() {
+			BeforeEach(func() {
+				rlzr = realizer.NewRealizer(kindpolicy.Policy{
+					Allowed: []schema.GroupKind{{Group: "test.run", Kind: "TestObj"}},
+				})
+			})
+
+			It("applies the stamped object", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("previous runs exist", func() {
+			var runningRun, completedRun, runningJob, completedJob, unknownRun *unstructured.Unstructured
+
+			BeforeEach(func() {
+				runnableRepo.EnsureObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, allowUpdate bool) error {
+					obj.SetName("my-stamped-resource-current")
+					createdUnstructured.Object = obj.Object
+					return nil
+				}
+
+				runningRun = &unstructured.Unstructured{}
+				runningRun.SetAPIVersion("test.run/v1alpha1")
+				runningRun.SetKind("TestObj")
+				runningRun.SetName("my-stamped-resource-running")
+				runningRun.SetNamespace("my-important-ns")
+				Expect(unstructured.SetNestedSlice(runningRun.Object, []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "Unknown"},
+				}, "status", "conditions")).To(Succeed())
+
+				completedRun = &unstructured.Unstructured{}
+				completedRun.SetAPIVersion("test.run/v1alpha1")
+				completedRun.SetKind("TestObj")
+				completedRun.SetName("my-stamped-resource-completed")
+				completedRun.SetNamespace("my-important-ns")
+				Expect(unstructured.SetNestedSlice(completedRun.Object, []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "False"},
+				}, "status", "conditions")).To(Succeed())
+
+				runningJob = &unstructured.Unstructured{}
+				runningJob.SetAPIVersion("test.run/v1alpha1")
+				runningJob.SetKind("TestObj")
+				runningJob.SetName("my-stamped-resource-running-job")
+				runningJob.SetNamespace("my-important-ns")
+				Expect(unstructured.SetNestedField(runningJob.Object, int64(1), "status", "active")).To(Succeed())
+
+				completedJob = &unstructured.Unstructured{}
+				completedJob.SetAPIVersion("test.run/v1alpha1")
+				completedJob.SetKind("TestObj")
+				completedJob.SetName("my-stamped-resource-completed-job")
+				completedJob.SetNamespace("my-important-ns")
+				Expect(unstructured.SetNestedField(completedJob.Object, int64(1), "status", "succeeded")).To(Succeed())
+				Expect(unstructured.SetNestedSlice(completedJob.Object, []interface{}{
+					map[string]interface{}{"type": "Complete", "status": "True"},
+				}, "status", "conditions")).To(Succeed())
+
+				unknownRun = &unstructured.Unstructured{}
+				unknownRun.SetAPIVersion("test.run/v1alpha1")
+				unknownRun.SetKind("TestObj")
+				unknownRun.SetName("my-stamped-resource-unknown")
+				unknownRun.SetNamespace("my-important-ns")
+
+				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{completedRun, runningRun, completedJob, runningJob, unknownRun, createdUnstructured}, nil)
+			})
+
+			Context("and cancelPreviousRuns is not set", func() {
+				It("does not delete any run", func() {
+					_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(runnableRepo.DeleteUnstructuredCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and cancelPreviousRuns is set", func() {
+				BeforeEach(func() {
+					runnable.Spec.CancelPreviousRuns = true
+				})
+
+				It("deletes only the superseded runs known to be running", func() {
+					_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(runnableRepo.DeleteUnstructuredCallCount()).To(Equal(2))
+					_, deleted := runnableRepo.DeleteUnstructuredArgsForCall(0)
+					Expect(deleted).To(Equal(runningRun))
+					_, deleted = runnableRepo.DeleteUnstructuredArgsForCall(1)
+					Expect(deleted).To(Equal(runningJob))
+				})
+
+				It("returns the outputs of the current run", func() {
+					_, outputs, _, _, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+					Expect(outputs["myout"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}))
+				})
+
+				Context("deleting the superseded run fails", func() {
+					BeforeEach(func() {
+						runnableRepo.DeleteUnstructuredReturns(errors.New("some delete error"))
+					})
+
+					It("returns CancelPreviousRunError", func() {
+						stampedObject, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+						Expect(stampedObject).NotTo(BeNil())
+						Expect(err).To(MatchError(ContainSubstring("some delete error")))
+						Expect(err.Error()).To(ContainSubstring("my-stamped-resource-running"))
+						Expect(reflect.TypeOf(err).String()).To(Equal("runnable.CancelPreviousRunError"))
+					})
+				})
+			})
+		})
+
 		Context("runnable selector resolves successfully", func() {
 			BeforeEach(func() {
 				runnable.Spec.Selector = &v1alpha1.ResourceSelector{
@@ -200,11 +525,11 @@ var _ = Describe("Realizer", func() {
 					},
 					MatchingLabels: map[string]string{"expected-label": "expected-value"},
 				}
-				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{{map[string]interface{}{"useful-value": "from-selected-object"}}}, nil)
+				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{{Object: map[string]interface{}{"useful-value": "from-selected-object"}}}, nil)
 			})
 
 			It("makes the selected object available in the templating context", func() {
-				_, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				_, _, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 
 				Expect(runnableRepo.ListUnstructuredCallCount()).To(Equal(2))
 				_, clientQueryObjectForSelector := runnableRepo.ListUnstructuredArgsForCall(0)
@@ -242,11 +567,11 @@ var _ = Describe("Realizer", func() {
 					},
 					MatchingLabels: map[string]string{"expected-label": "expected-value"},
 				}
-				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{{map[string]interface{}{}}, {map[string]interface{}{}}}, nil)
+				runnableRepo.ListUnstructuredReturns([]*unstructured.Unstructured{{Object: map[string]interface{}{}}, {Object: map[string]interface{}{}}}, nil)
 			})
 
 			It("returns ResolveSelectorError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(`unable to resolve selector [map[expected-label:expected-value]], apiVersion [apiversion-to-be-selected], kind [kind-to-be-selected]: selector matched multiple objects`))
 				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.ResolveSelectorError"))
@@ -266,7 +591,7 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ResolveSelectorError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(`unable to resolve selector [map[expected-label:expected-value]], apiVersion [apiversion-to-be-selected], kind [kind-to-be-selected]: selector did not match any objects`))
 				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.ResolveSelectorError"))
@@ -286,7 +611,7 @@ var _ = Describe("Realizer", func() {
 			})
 
 			It("returns ResolveSelectorError", func() {
-				_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(`unable to resolve selector [map[expected-label:expected-value]], apiVersion [apiversion-to-be-selected], kind [kind-to-be-selected]: failed to list objects matching selector [map[expected-label:expected-value]]: listing unstructured is hard`))
 				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.ResolveSelectorError"))
@@ -294,6 +619,226 @@ var _ = Describe("Realizer", func() {
 		})
 	})
 
+	Context("with a ClusterRunTemplate that declares inputs", func() {
+		BeforeEach(func() {
+			templateAPI := &v1alpha1.ClusterRunTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-template",
+				},
+				Spec: v1alpha1.ClusterRunTemplateSpec{
+					Inputs: []v1alpha1.RunTemplateInput{
+						{Name: "required-greeting"},
+						{Name: "optional-name", Default: &apiextensionsv1.JSON{Raw: []byte(`"world"`)}},
+					},
+					Template: runtime.RawExtension{
+						Raw: []byte(D(`{
+								"apiVersion": "v1",
+								"kind": "ConfigMap",
+								"metadata": { "generateName": "my-stamped-resource-" },
+								"data": {
+									"greeting": "$(runnable.spec.inputs.required-greeting)$",
+									"name": "$(runnable.spec.inputs.optional-name)$"
+								}
+							}`,
+						)),
+					},
+				},
+			}
+
+			systemRepo.GetRunTemplateReturns(templateAPI, nil)
+
+			runnable.Spec.Inputs = map[string]apiextensionsv1.JSON{
+				"required-greeting": {Raw: []byte(`"hello"`)},
+			}
+		})
+
+		stampedData := func() interface{} {
+			Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+			_, stamped, _ := runnableRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+			return stamped.Object["data"]
+		}
+
+		Context("the runnable omits an input that has a default", func() {
+			It("stamps the default", func() {
+				_, _, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(stampedData()).To(Equal(map[string]interface{}{
+					"greeting": "hello",
+					"name":     "world",
+				}))
+			})
+
+			It("does not modify the runnable", func() {
+				_, _, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(runnable.Spec.Inputs).NotTo(HaveKey("optional-name"))
+			})
+		})
+
+		Context("the runnable provides an input that has a default", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs["optional-name"] = apiextensionsv1.JSON{Raw: []byte(`"cartographer"`)}
+			})
+
+			It("stamps the provided value", func() {
+				_, _, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(stampedData()).To(Equal(map[string]interface{}{
+					"greeting": "hello",
+					"name":     "cartographer",
+				}))
+			})
+		})
+
+		Context("the runnable omits an input that has no default", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs = nil
+			})
+
+			It("returns MissingRequiredInputError without stamping", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("runnable [my-important-ns/my-runnable] does not provide input [required-greeting] required by run template [my-template]"))
+				Expect(reflect.TypeOf(err).String()).To(Equal("runnable.MissingRequiredInputError"))
+				Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("with a ClusterRunTemplate that declares input schemas", func() {
+		var templateAPI *v1alpha1.ClusterRunTemplate
+
+		BeforeEach(func() {
+			templateAPI = &v1alpha1.ClusterRunTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-template",
+				},
+				Spec: v1alpha1.ClusterRunTemplateSpec{
+					Inputs: []v1alpha1.RunTemplateInput{
+						{
+							Name:   "replicas",
+							Schema: &apiextensionsv1.JSON{Raw: []byte(`{"type": "integer", "minimum": 1}`)},
+						},
+						{
+							Name:    "config",
+							Default: &apiextensionsv1.JSON{Raw: []byte(`{"port": 8080}`)},
+							Schema: &apiextensionsv1.JSON{Raw: []byte(D(`{
+								"type": "object",
+								"required": ["port"],
+								"properties": {"port": {"type": "integer"}}
+							}`))},
+						},
+						{Name: "unchecked", Default: &apiextensionsv1.JSON{Raw: []byte(`"anything"`)}},
+					},
+					Template: runtime.RawExtension{
+						Raw: []byte(D(`{
+								"apiVersion": "v1",
+								"kind": "ConfigMap",
+								"metadata": { "generateName": "my-stamped-resource-" },
+								"data": { "replicas": "$(runnable.spec.inputs.replicas)$" }
+							}`,
+						)),
+					},
+				},
+			}
+
+			systemRepo.GetRunTemplateReturns(templateAPI, nil)
+
+			runnable.Spec.Inputs = map[string]apiextensionsv1.JSON{
+				"replicas": {Raw: []byte(`3`)},
+			}
+		})
+
+		expectInvalidInput := func(substrings ...string) {
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(err).To(BeAssignableToTypeOf(realizer.InvalidInputError{}))
+			for _, substring := range substrings {
+				Expect(err.Error()).To(ContainSubstring(substring))
+			}
+			Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+		}
+
+		Context("the inputs match their schemas", func() {
+			It("stamps the object", func() {
+				_, _, _, _, _ = rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("the runnable omits a required input with a schema", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs = nil
+			})
+
+			It("returns MissingRequiredInputError", func() {
+				_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+				Expect(err).To(BeAssignableToTypeOf(realizer.MissingRequiredInputError{}))
+				Expect(runnableRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("an input has the wrong type", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs["replicas"] = apiextensionsv1.JSON{Raw: []byte(`"three"`)}
+			})
+
+			It("returns InvalidInputError naming the input without stamping", func() {
+				expectInvalidInput(
+					"runnable [my-important-ns/my-runnable] input [replicas] does not match the schema of run template [my-template]",
+					"spec.inputs[replicas]",
+					"must be of type integer",
+				)
+			})
+		})
+
+		Context("an input violates a constraint of its schema", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs["replicas"] = apiextensionsv1.JSON{Raw: []byte(`0`)}
+			})
+
+			It("returns InvalidInputError", func() {
+				expectInvalidInput("spec.inputs[replicas]", "should be greater than or equal to 1")
+			})
+		})
+
+		Context("a field of an object input has the wrong type", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs["config"] = apiextensionsv1.JSON{Raw: []byte(`{"port": "http"}`)}
+			})
+
+			It("returns InvalidInputError naming the field", func() {
+				expectInvalidInput("input [config]", "spec.inputs[config].port", "must be of type integer")
+			})
+		})
+
+		Context("an object input misses a required field", func() {
+			BeforeEach(func() {
+				runnable.Spec.Inputs["config"] = apiextensionsv1.JSON{Raw: []byte(`{}`)}
+			})
+
+			It("returns InvalidInputError naming the field", func() {
+				expectInvalidInput("spec.inputs[config].port", "Required value")
+			})
+		})
+
+		Context("the default of an input does not match its schema", func() {
+			BeforeEach(func() {
+				templateAPI.Spec.Inputs[1].Default = &apiextensionsv1.JSON{Raw: []byte(`{"port": "http"}`)}
+			})
+
+			It("returns InvalidInputError", func() {
+				expectInvalidInput("input [config]", "spec.inputs[config].port")
+			})
+		})
+
+		Context("the schema of an input is malformed", func() {
+			BeforeEach(func() {
+				templateAPI.Spec.Inputs[0].Schema = &apiextensionsv1.JSON{Raw: []byte(`"not a schema"`)}
+			})
+
+			It("returns InvalidInputError", func() {
+				expectInvalidInput("input [replicas]", "malformed schema")
+			})
+		})
+	})
+
 	Context("with unsatisfied output paths", func() {
 		BeforeEach(func() {
 			templateAPI := &v1alpha1.ClusterRunTemplate{
@@ -326,7 +871,7 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("returns RetrieveOutputError", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(`unable to retrieve outputs from stamped object [my-important-ns/my-stamped-resource-] of type [configmap] for runnable [my-important-ns/my-runnable]: failed to evaluate path [data.hasnot]: evaluate: failed to find results: hasnot is not found`))
 			Expect(reflect.TypeOf(err).String()).To(Equal("runnable.RetrieveOutputError"))
@@ -344,11 +889,48 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("returns StampError", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(`unable to stamp object [my-important-ns/my-runnable]: failed to unmarshal json resource template: unexpected end of JSON input`))
 			Expect(reflect.TypeOf(err).String()).To(Equal("runnable.StampError"))
 		})
+
+		It("still returns the run template", func() {
+			_, _, _, runTemplate, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(runTemplate).NotTo(BeNil())
+		})
+	})
+
+	Context("the runTemplateRef is not of kind ClusterRunTemplate", func() {
+		BeforeEach(func() {
+			runnable.Spec.RunTemplateRef.Kind = "ClusterRunTemplates"
+		})
+
+		It("returns GetRunTemplateError without fetching a template", func() {
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("run template kind [ClusterRunTemplates] is not supported"))
+			Expect(reflect.TypeOf(err).String()).To(Equal("runnable.GetRunTemplateError"))
+			Expect(systemRepo.GetRunTemplateCallCount()).To(Equal(0))
+			Expect(runnable.Spec.RunTemplateRef.Kind).To(Equal("ClusterRunTemplates"))
+		})
+	})
+
+	Context("the runTemplateRef name expression cannot be resolved", func() {
+		BeforeEach(func() {
+			runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+				Kind:           "ClusterRunTemplate",
+				NameExpression: "$(runnable.spec.inputs.flavor)$-template",
+			}
+		})
+
+		It("returns GetRunTemplateError without fetching a template", func() {
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("interpolate name expression [$(runnable.spec.inputs.flavor)$-template]"))
+			Expect(reflect.TypeOf(err).String()).To(Equal("runnable.GetRunTemplateError"))
+			Expect(systemRepo.GetRunTemplateCallCount()).To(Equal(0))
+		})
 	})
 
 	Context("the ClusterRunTemplate cannot be fetched", func() {
@@ -364,10 +946,15 @@ var _ = Describe("Realizer", func() {
 		})
 
 		It("returns GetRunTemplateError", func() {
-			_, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			_, _, _, _, err := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(`unable to get runnable [my-important-ns/my-runnable]: Errol mcErrorFace`))
 			Expect(reflect.TypeOf(err).String()).To(Equal("runnable.GetRunTemplateError"))
 		})
+
+		It("returns no run template", func() {
+			_, _, _, runTemplate, _ := rlzr.Realize(ctx, runnable, systemRepo, runnableRepo)
+			Expect(runTemplate).To(BeNil())
+		})
 	})
 })
@@ -13,7 +13,7 @@ import (
 )
 
 type FakeRealizer struct {
-	RealizeStub        func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository) (*unstructured.Unstructured, templates.Outputs, error)
+	RealizeStub        func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error)
 	realizeMutex       sync.RWMutex
 	realizeArgsForCall []struct {
 		arg1 context.Context
@@ -24,18 +24,22 @@ type FakeRealizer struct {
 	realizeReturns struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
+		result3 *unstructured.Unstructured
+		result4 *v1alpha1.ClusterRunTemplate
+		result5 error
 	}
 	realizeReturnsOnCall map[int]struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
+		result3 *unstructured.Unstructured
+		result4 *v1alpha1.ClusterRunTemplate
+		result5 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 *v1alpha1.Runnable, arg3 repository.Repository, arg4 repository.Repository) (*unstructured.Unstructured, templates.Outputs, error) {
+func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 *v1alpha1.Runnable, arg3 repository.Repository, arg4 repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error) {
 	fake.realizeMutex.Lock()
 	ret, specificReturn := fake.realizeReturnsOnCall[len(fake.realizeArgsForCall)]
 	fake.realizeArgsForCall = append(fake.realizeArgsForCall, struct {
@@ -52,9 +56,9 @@ func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 *v1alpha1.Runnable,
 		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1, ret.result2, ret.result3, ret.result4, ret.result5
 	}
-	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4, fakeReturns.result5
 }
 
 func (fake *FakeRealizer) RealizeCallCount() int {
@@ -63,7 +67,7 @@ func (fake *FakeRealizer) RealizeCallCount() int {
 	return len(fake.realizeArgsForCall)
 }
 
-func (fake *FakeRealizer) RealizeCalls(stub func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository) (*unstructured.Unstructured, templates.Outputs, error)) {
+func (fake *FakeRealizer) RealizeCalls(stub func(context.Context, *v1alpha1.Runnable, repository.Repository, repository.Repository) (*unstructured.Unstructured, templates.Outputs, *unstructured.Unstructured, *v1alpha1.ClusterRunTemplate, error)) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = stub
@@ -76,18 +80,20 @@ func (fake *FakeRealizer) RealizeArgsForCall(i int) (context.Context, *v1alpha1.
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *FakeRealizer) RealizeReturns(result1 *unstructured.Unstructured, result2 templates.Outputs, result3 error) {
+func (fake *FakeRealizer) RealizeReturns(result1 *unstructured.Unstructured, result2 templates.Outputs, result3 *unstructured.Unstructured, result4 *v1alpha1.ClusterRunTemplate, result5 error) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = nil
 	fake.realizeReturns = struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
-	}{result1, result2, result3}
+		result3 *unstructured.Unstructured
+		result4 *v1alpha1.ClusterRunTemplate
+		result5 error
+	}{result1, result2, result3, result4, result5}
 }
 
-func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 templates.Outputs, result3 error) {
+func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 templates.Outputs, result3 *unstructured.Unstructured, result4 *v1alpha1.ClusterRunTemplate, result5 error) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = nil
@@ -95,14 +101,18 @@ func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 *unstructured.Unst
 		fake.realizeReturnsOnCall = make(map[int]struct {
 			result1 *unstructured.Unstructured
 			result2 templates.Outputs
-			result3 error
+			result3 *unstructured.Unstructured
+			result4 *v1alpha1.ClusterRunTemplate
+			result5 error
 		})
 	}
 	fake.realizeReturnsOnCall[i] = struct {
 		result1 *unstructured.Unstructured
 		result2 templates.Outputs
-		result3 error
-	}{result1, result2, result3}
+		result3 *unstructured.Unstructured
+		result4 *v1alpha1.ClusterRunTemplate
+		result5 error
+	}{result1, result2, result3, result4, result5}
 }
 
 func (fake *FakeRealizer) Invocations() map[string][][]interface{} {
@@ -0,0 +1,54 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable
+
+import (
+	"fmt"
+
+	"github.com/valyala/fasttemplate"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// RunTemplateName returns the name of the ClusterRunTemplate the runnable refers to,
+// interpolating runTemplateRef.nameExpression against the runnable when it is set.
+func RunTemplateName(runnable *v1alpha1.Runnable) (string, error) {
+	ref := runnable.Spec.RunTemplateRef
+	if ref.NameExpression == "" {
+		if ref.Name == "" {
+			return "", fmt.Errorf("runTemplateRef must specify a name or a nameExpression")
+		}
+		return ref.Name, nil
+	}
+
+	tagInterpolator := templates.StandardTagInterpolator{
+		Context:   TemplatingContext{Runnable: runnable, Cartographer: cartographerContext(runnable)},
+		Evaluator: eval.EvaluatorBuilder(),
+	}
+
+	name, err := templates.InterpolateLeafNode(fasttemplate.ExecuteFuncStringWithErr, []byte(ref.NameExpression), tagInterpolator)
+	if err != nil {
+		return "", fmt.Errorf("interpolate name expression [%s]: %w", ref.NameExpression, err)
+	}
+
+	nameString, ok := name.(string)
+	if !ok || nameString == "" {
+		return "", fmt.Errorf("name expression [%s] did not resolve to a non-empty string: %v", ref.NameExpression, name)
+	}
+
+	return nameString, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runnable_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
+)
+
+var _ = Describe("RunTemplateName", func() {
+	var runnable *v1alpha1.Runnable
+
+	BeforeEach(func() {
+		runnable = &v1alpha1.Runnable{
+			Spec: v1alpha1.RunnableSpec{
+				Inputs: map[string]apiextensionsv1.JSON{
+					"flavor": {Raw: []byte(`"python"`)},
+					"nested": {Raw: []byte(`{"some": "object"}`)},
+				},
+			},
+		}
+	})
+
+	Context("the runTemplateRef has a name", func() {
+		It("returns the name", func() {
+			runnable.Spec.RunTemplateRef.Name = "my-template"
+			Expect(realizer.RunTemplateName(runnable)).To(Equal("my-template"))
+		})
+	})
+
+	Context("the runTemplateRef has a name expression", func() {
+		It("interpolates the expression against the runnable", func() {
+			runnable.Spec.RunTemplateRef.NameExpression = "$(runnable.spec.inputs.flavor)$-template"
+			Expect(realizer.RunTemplateName(runnable)).To(Equal("python-template"))
+		})
+
+		It("interpolates the context of the supply chain that stamped the runnable", func() {
+			runnable.Labels = map[string]string{"carto.run/cluster-supply-chain-name": "web"}
+			runnable.Spec.RunTemplateRef.NameExpression = "$(cartographer.supplyChain.name)$-tests"
+			Expect(realizer.RunTemplateName(runnable)).To(Equal("web-tests"))
+		})
+
+		It("prefers the expression over the name", func() {
+			runnable.Spec.RunTemplateRef.Name = "my-template"
+			runnable.Spec.RunTemplateRef.NameExpression = "$(runnable.spec.inputs.flavor)$"
+			Expect(realizer.RunTemplateName(runnable)).To(Equal("python"))
+		})
+
+		Context("the expression refers to a missing input", func() {
+			It("returns an error", func() {
+				runnable.Spec.RunTemplateRef.NameExpression = "$(runnable.spec.inputs.missing)$"
+				_, err := realizer.RunTemplateName(runnable)
+				Expect(err).To(MatchError(ContainSubstring("interpolate name expression [$(runnable.spec.inputs.missing)$]")))
+			})
+		})
+
+		Context("the expression does not resolve to a string", func() {
+			It("returns an error", func() {
+				runnable.Spec.RunTemplateRef.NameExpression = "$(runnable.spec.inputs.nested)$"
+				_, err := realizer.RunTemplateName(runnable)
+				Expect(err).To(MatchError(ContainSubstring("name expression [$(runnable.spec.inputs.nested)$] did not resolve to a non-empty string")))
+			})
+		})
+	})
+
+	Context("the runTemplateRef has neither a name nor a name expression", func() {
+		It("returns an error", func() {
+			_, err := realizer.RunTemplateName(runnable)
+			Expect(err).To(MatchError("runTemplateRef must specify a name or a nameExpression"))
+		})
+	})
+})
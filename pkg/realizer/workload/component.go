@@ -16,14 +16,25 @@ package workload
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/mirrors"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
@@ -33,19 +44,45 @@ import (
 //counterfeiter:generate . ResourceRealizer
 type ResourceRealizer interface {
 	Do(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs Outputs) (*unstructured.Unstructured, *templates.Output, error)
+	ConditionMet(ctx context.Context, resource *v1alpha1.SupplyChainResource) bool
+	// ParamResolutions reports, for each resource stamped so far, the params that more than
+	// one layer set to different values.
+	ParamResolutions() []v1alpha1.ParamResolution
 }
 
 type resourceRealizer struct {
-	workload          *v1alpha1.Workload
-	systemRepo        repository.Repository
-	workloadRepo      repository.Repository
-	supplyChainParams []v1alpha1.DelegatableParam
+	workload             *v1alpha1.Workload
+	systemRepo           repository.Repository
+	workloadRepo         repository.Repository
+	supplyChainParams    []v1alpha1.DelegatableParam
+	kindPolicy           kindpolicy.Policy
+	allowOutputOverrides bool
+	checkPermissions     bool
+	registryMirrors      mirrors.Mirrors
+	cache                repository.RepoCache
+	// templates holds the templates fetched so far. A resource realizer is built for each
+	// reconcile, so resources sharing a template fetch it once without reading stale
+	// templates in later reconciles.
+	templates        map[v1alpha1.ClusterTemplateReference]client.Object
+	paramResolutions []v1alpha1.ParamResolution
 }
 
 type ResourceRealizerBuilder func(secret *corev1.Secret, workload *v1alpha1.Workload, systemRepo repository.Repository, supplyChainParams []v1alpha1.DelegatableParam) (ResourceRealizer, error)
 
+// ResourceRealizerOptions configures the resource realizers built by NewResourceRealizerBuilder.
+type ResourceRealizerOptions struct {
+	KindPolicy kindpolicy.Policy
+	// AllowOutputOverrides lets the workload override the outputs of its resources.
+	AllowOutputOverrides bool
+	// CheckPermissions reviews the access of the workload's service account to each
+	// stamped object before applying it.
+	CheckPermissions bool
+	// RegistryMirrors rewrite the image outputs of the resources.
+	RegistryMirrors mirrors.Mirrors
+}
+
 //counterfeiter:generate sigs.k8s.io/controller-runtime/pkg/client.Client
-func NewResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder, clientBuilder realizerclient.ClientBuilder, cache repository.RepoCache) ResourceRealizerBuilder {
+func NewResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder, clientBuilder realizerclient.ClientBuilder, cache repository.RepoCache, options ResourceRealizerOptions) ResourceRealizerBuilder {
 	return func(secret *corev1.Secret, workload *v1alpha1.Workload, systemRepo repository.Repository, supplyChainParams []v1alpha1.DelegatableParam) (ResourceRealizer, error) {
 		workloadClient, err := clientBuilder(secret)
 		if err != nil {
@@ -55,19 +92,74 @@ func NewResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder,
 		workloadRepo := repositoryBuilder(workloadClient, cache)
 
 		return &resourceRealizer{
-			workload:          workload,
-			systemRepo:        systemRepo,
-			workloadRepo:      workloadRepo,
-			supplyChainParams: supplyChainParams,
+			workload:             workload,
+			systemRepo:           systemRepo,
+			workloadRepo:         workloadRepo,
+			supplyChainParams:    supplyChainParams,
+			kindPolicy:           options.KindPolicy,
+			allowOutputOverrides: options.AllowOutputOverrides,
+			checkPermissions:     options.CheckPermissions,
+			registryMirrors:      options.RegistryMirrors,
+			cache:                cache,
+			templates:            map[v1alpha1.ClusterTemplateReference]client.Object{},
 		}, nil
 	}
 }
 
+// ConditionMet reports whether the workload satisfies the condition of the resource.
+// A resource without a condition is always included; a key that cannot be read from
+// the workload does not satisfy the condition.
+func (r *resourceRealizer) ConditionMet(ctx context.Context, resource *v1alpha1.SupplyChainResource) bool {
+	return conditionMet(ctx, r.workload, resource)
+}
+
+func conditionMet(ctx context.Context, workloadObject *v1alpha1.Workload, resource *v1alpha1.SupplyChainResource) bool {
+	if resource.Condition == nil {
+		return true
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithValues("condition", resource.Condition)
+
+	workload, err := runtime.DefaultUnstructuredConverter.ToUnstructured(workloadObject)
+	if err != nil {
+		log.Error(err, "failed to convert workload to unstructured")
+		return false
+	}
+
+	observed, err := eval.EvaluatorBuilder().EvaluateJsonPath(resource.Condition.Key, workload)
+	if err != nil {
+		log.V(logger.DEBUG).Info("condition key not found on workload", "error", err.Error())
+		return false
+	}
+
+	return fmt.Sprintf("%v", observed) == resource.Condition.Value
+}
+
+func (r *resourceRealizer) ParamResolutions() []v1alpha1.ParamResolution {
+	return r.paramResolutions
+}
+
+// getClusterTemplate returns a copy of the referenced template, fetching it only the
+// first time it is referenced.
+func (r *resourceRealizer) getClusterTemplate(ctx context.Context, ref v1alpha1.ClusterTemplateReference) (client.Object, error) {
+	if apiTemplate, ok := r.templates[ref]; ok {
+		return apiTemplate.DeepCopyObject().(client.Object), nil
+	}
+
+	apiTemplate, err := r.systemRepo.GetClusterTemplate(ctx, ref)
+	if err != nil || apiTemplate == nil {
+		return apiTemplate, err
+	}
+	r.templates[ref] = apiTemplate.DeepCopyObject().(client.Object)
+
+	return apiTemplate, nil
+}
+
 func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs Outputs) (*unstructured.Unstructured, *templates.Output, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("template", resource.TemplateRef)
 	ctx = logr.NewContext(ctx, log)
 
-	apiTemplate, err := r.systemRepo.GetClusterTemplate(ctx, resource.TemplateRef)
+	apiTemplate, err := r.getClusterTemplate(ctx, resource.TemplateRef)
 	if err != nil {
 		log.Error(err, "failed to get cluster template")
 		return nil, nil, GetClusterTemplateError{
@@ -76,7 +168,7 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.SupplyChai
 		}
 	}
 
-	template, err := templates.NewModelFromAPI(apiTemplate)
+	template, err := templates.NewModelFromAPI(apiTemplate, r.systemRepo)
 	if err != nil {
 		log.Error(err, "failed to get cluster template")
 		return nil, nil, fmt.Errorf("failed to get cluster template [%+v]: %w", resource.TemplateRef, err)
@@ -92,10 +184,16 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.SupplyChai
 		"carto.run/cluster-template-name":     template.GetName(),
 	}
 
+	params, resolutions := templates.ResolveParams(template.GetDefaultParams(), r.supplyChainParams, resource.Params, r.workload.Spec.Params)
+	for _, resolution := range resolutions {
+		resolution.Resource = resource.Name
+		r.paramResolutions = append(r.paramResolutions, resolution)
+	}
+
 	inputs := outputs.GenerateInputs(resource)
 	workloadTemplatingContext := map[string]interface{}{
 		"workload": r.workload,
-		"params":   templates.ParamsBuilder(template.GetDefaultParams(), r.supplyChainParams, resource.Params, r.workload.Spec.Params),
+		"params":   params,
 		"sources":  inputs.Sources,
 		"images":   inputs.Images,
 		"configs":  inputs.Configs,
@@ -122,7 +220,70 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.SupplyChai
 		}
 	}
 
+	if resource.NameTemplate != "" {
+		err = r.nameStampedObject(resource, workloadTemplatingContext, stampedObject)
+		if err != nil {
+			log.Error(err, "failed to render name of stamped object")
+			return nil, nil, StampError{
+				Err:      err,
+				Resource: resource,
+			}
+		}
+	}
+
+	sourceRevision := outputs.SourceRevision(resource)
+	if sourceRevision != "" {
+		annotations := stampedObject.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[v1alpha1.SourceRevisionAnnotation] = sourceRevision
+		stampedObject.SetAnnotations(annotations)
+	}
+
+	if !r.kindPolicy.Allows(stampedObject.GroupVersionKind().GroupKind()) {
+		log.Info("stamped object kind is not allowed", "object", stampedObject)
+		return nil, nil, StampedKindNotAllowedError{
+			Resource:      resource,
+			StampedObject: stampedObject,
+		}
+	}
+
+	if resource.ValidateStampedObject {
+		schemaErrs, err := stampedObjectSchemaErrors(ctx, r.systemRepo, stampedObject)
+		if err != nil {
+			log.Error(err, "failed to get schema for stamped object", "object", stampedObject)
+			return nil, nil, fmt.Errorf("failed to get schema for stamped object of resource [%s]: %w", resource.Name, err)
+		}
+		if len(schemaErrs) > 0 {
+			log.V(logger.DEBUG).Info("stamped object does not match its schema", "object", stampedObject, "errors", schemaErrs)
+			return nil, nil, StampedObjectSchemaInvalidError{
+				Err:           schemaErrs.ToAggregate(),
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+		}
+	}
+
+	if upstream := consumedResource(resource, outputs.changedSince(r.workload.Status.Summary)); upstream != "" {
+		// the stamped object may not carry the changed value, so it would be left alone as
+		// unchanged since it was cached; apply it again so its controller sees the new output
+		log.V(logger.DEBUG).Info("output of upstream resource changed, applying stamped object again",
+			"upstream", upstream)
+		r.cache.Forget(stampedObject)
+	}
+
+	if r.checkPermissions {
+		if err := r.reviewApplyAccess(ctx, resource, stampedObject); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	err = r.workloadRepo.EnsureObjectExistsOnCluster(ctx, stampedObject, true)
+	if err != nil && resource.RecreateOnImmutableChange && isImmutableFieldError(err) {
+		log.Info("stamped object changes an immutable field, recreating it", "object", stampedObject)
+		err = r.recreate(ctx, stampedObject)
+	}
 	if err != nil {
 		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
 		return nil, nil, ApplyStampedObjectError{
@@ -131,10 +292,29 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.SupplyChai
 		}
 	}
 
+	if resource.WaitForObservedGeneration {
+		generation, observedGeneration := generations(stampedObject)
+		if observedGeneration < generation {
+			log.V(logger.DEBUG).Info("stamped object generation not observed yet",
+				"generation", generation, "observed generation", observedGeneration)
+			return stampedObject, nil, StampedObjectNotObservedError{
+				Resource:           resource,
+				StampedObject:      stampedObject,
+				Generation:         generation,
+				ObservedGeneration: observedGeneration,
+			}
+		}
+	}
+
 	template.SetStampedObject(stampedObject)
 
-	output, err := template.GetOutput()
-	if err != nil {
+	var overrides map[string]string
+	if r.allowOutputOverrides {
+		overrides = OutputOverrides(r.workload, resource.Name)
+	}
+
+	output, err := template.GetOutput(ctx)
+	if err != nil && len(overrides) == 0 {
 		log.Error(err, "failed to retrieve output from object", "object", stampedObject)
 		return stampedObject, nil, RetrieveOutputError{
 			Err:           err,
@@ -143,5 +323,159 @@ func (r *resourceRealizer) Do(ctx context.Context, resource *v1alpha1.SupplyChai
 		}
 	}
 
+	// the image is rewritten before overrides, which are used as annotated
+	if image, ok := imageOutput(output); ok {
+		if mirrored := r.registryMirrors.Rewrite(image); mirrored != image {
+			log.V(logger.DEBUG).Info("rewriting image output to registry mirror", "image", image, "mirrored", mirrored)
+			output.Image = mirrored
+		}
+	}
+
+	if len(overrides) > 0 {
+		log.Info("overriding outputs of resource from workload annotations", "overrides", overrides)
+		output, err = overrideOutput(output, overrides)
+		if err != nil {
+			return stampedObject, nil, RetrieveOutputError{
+				Err:           err,
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+		}
+	}
+
+	if output != nil {
+		output.SourceRevision = sourceRevision
+	}
+
 	return stampedObject, output, nil
 }
+
+// imageOutput returns the image of an output read from an image template, when it is a string
+func imageOutput(output *templates.Output) (string, bool) {
+	if output == nil {
+		return "", false
+	}
+	image, ok := output.Image.(string)
+	return image, ok
+}
+
+// applyVerbs are the verbs the service account needs to apply a stamped object, which is
+// created when it does not exist and patched when it does.
+var applyVerbs = []string{"create", "patch"}
+
+// reviewApplyAccess asks the api server whether the service account of the workload may apply
+// the stamped object, so that a missing permission is reported before the apply is attempted.
+func (r *resourceRealizer) reviewApplyAccess(ctx context.Context, resource *v1alpha1.SupplyChainResource, stampedObject *unstructured.Unstructured) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	for _, verb := range applyVerbs {
+		err := r.workloadRepo.ReviewAccess(ctx, stampedObject, verb)
+		var denied repository.AccessDeniedError
+		if errors.As(err, &denied) {
+			log.Info("service account is not permitted to apply stamped object", "object", stampedObject, "verb", verb)
+			return InsufficientPermissionsError{
+				Err:           denied,
+				Resource:      resource,
+				StampedObject: stampedObject,
+			}
+		}
+		if err != nil {
+			log.Error(err, "failed to review access to stamped object", "object", stampedObject, "verb", verb)
+			return fmt.Errorf("failed to review access to stamped object of resource [%s]: %w", resource.Name, err)
+		}
+	}
+	return nil
+}
+
+// generations reads the generation of the stamped object and the generation its controller
+// last observed, which is zero until the controller reports one.
+func generations(stampedObject *unstructured.Unstructured) (int64, int64) {
+	generation := stampedObject.GetGeneration()
+	observedGeneration, _, _ := unstructured.NestedInt64(stampedObject.UnstructuredContent(), "status", "observedGeneration")
+	return generation, observedGeneration
+}
+
+// recreate deletes the object a patch could not be applied to and creates the stamped object
+// in its place. While the deleted object is still terminating the create fails, and the next
+// reconcile tries again.
+func (r *resourceRealizer) recreate(ctx context.Context, stampedObject *unstructured.Unstructured) error {
+	if err := r.workloadRepo.DeleteUnstructured(ctx, stampedObject.DeepCopy()); err != nil {
+		return fmt.Errorf("recreate: %w", err)
+	}
+
+	stampedObject.SetResourceVersion("")
+	if err := r.workloadRepo.EnsureObjectExistsOnCluster(ctx, stampedObject, false); err != nil {
+		return fmt.Errorf("recreate: %w", err)
+	}
+	return nil
+}
+
+// immutableFieldMessages are the wordings the api server uses for a rejected change to a
+// field that cannot be updated: "field is immutable" for most fields, "spec is immutable after
+// creation..." for a persistent volume claim, "may not change once set" for fields such as a
+// service's cluster ip and "updates to statefulset spec for fields other than..." for a stateful
+// set. Validation has no dedicated cause type for immutability, so a resource whose validation
+// words it differently is not recognized and is not recreated.
+var immutableFieldMessages = []string{
+	"is immutable",
+	"may not change once set",
+	"updates to statefulset spec for fields other than",
+}
+
+// isImmutableFieldError reports whether the api server rejected a change to a field that
+// cannot be updated, such as the template of a job. It looks at the field causes of the
+// Invalid error, falling back on the error message when the status has no causes.
+func isImmutableFieldError(err error) bool {
+	var status kerrors.APIStatus
+	if !errors.As(err, &status) || !kerrors.IsInvalid(err) {
+		return false
+	}
+
+	details := status.Status().Details
+	if details == nil || len(details.Causes) == 0 {
+		return isImmutableFieldMessage(status.Status().Message)
+	}
+	for _, cause := range details.Causes {
+		if cause.Type != metav1.CauseType(field.ErrorTypeInvalid) && cause.Type != metav1.CauseType(field.ErrorTypeForbidden) {
+			continue
+		}
+		if isImmutableFieldMessage(cause.Message) {
+			return true
+		}
+	}
+	return false
+}
+
+func isImmutableFieldMessage(message string) bool {
+	for _, immutable := range immutableFieldMessages {
+		if strings.Contains(message, immutable) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resourceRealizer) nameStampedObject(resource *v1alpha1.SupplyChainResource, templatingContext map[string]interface{}, stampedObject *unstructured.Unstructured) error {
+	inputHash, err := templates.InputHash(map[string]interface{}{
+		"params":  templatingContext["params"],
+		"sources": templatingContext["sources"],
+		"images":  templatingContext["images"],
+		"configs": templatingContext["configs"],
+	})
+	if err != nil {
+		return err
+	}
+
+	name, err := templates.RenderName(resource.NameTemplate, map[string]interface{}{
+		"workload":  r.workload,
+		"resource":  map[string]interface{}{"name": resource.Name},
+		"inputHash": inputHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	stampedObject.SetName(name)
+	stampedObject.SetGenerateName("")
+	return nil
+}
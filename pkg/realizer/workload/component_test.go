@@ -18,18 +18,26 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/mirrors"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
@@ -50,6 +58,7 @@ var _ = Describe("Resource", func() {
 		cacheForBuiltRepository         repository.RepoCache
 		theSecret, secretForBuiltClient *corev1.Secret
 		r                               realizer.ResourceRealizer
+		resourceRealizerBuilder         realizer.ResourceRealizerBuilder
 		out                             *Buffer
 		repoCache                       repository.RepoCache
 		supplyChainParams               []v1alpha1.DelegatableParam
@@ -91,7 +100,7 @@ var _ = Describe("Resource", func() {
 		logger := zap.New(zap.WriteTo(out))
 
 		repoCache = repository.NewCache(logger)
-		resourceRealizerBuilder := realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache)
+		resourceRealizerBuilder = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, realizer.ResourceRealizerOptions{})
 
 		theSecret = &corev1.Secret{StringData: map[string]string{"blah": "blah"}}
 
@@ -163,6 +172,38 @@ var _ = Describe("Resource", func() {
 				fakeWorkloadRepo.EnsureObjectExistsOnClusterReturns(nil)
 			})
 
+			It("fetches a template shared by several resources once per reconcile", func() {
+				otherResource := resource
+				otherResource.Name = "resource-2"
+
+				_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+				_, _, err = r.Do(ctx, &otherResource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeSystemRepo.GetClusterTemplateCallCount()).To(Equal(1))
+
+				nextReconcile, err := resourceRealizerBuilder(theSecret, &workload, &fakeSystemRepo, supplyChainParams)
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = nextReconcile.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeSystemRepo.GetClusterTemplateCallCount()).To(Equal(2))
+			})
+
+			It("reports the params that layers set differently for each resource", func() {
+				resource.Params = []v1alpha1.DelegatableParam{{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`"from-resource"`)}}}
+				workload.Spec.Params = []v1alpha1.Param{{Name: "registry", Value: apiextensionsv1.JSON{Raw: []byte(`"from-workload"`)}}}
+
+				_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(r.ParamResolutions()).To(Equal([]v1alpha1.ParamResolution{{
+					Resource:   "resource-1",
+					Name:       "registry",
+					Layer:      v1alpha1.ParamLayerResource,
+					Overridden: []v1alpha1.ParamLayer{v1alpha1.ParamLayerOwner},
+				}}))
+			})
+
 			It("creates a stamped object using the workload repository and returns the outputs and stampedObjects", func() {
 				returnedStampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
 				Expect(err).ToNot(HaveOccurred())
@@ -198,6 +239,433 @@ var _ = Describe("Resource", func() {
 
 				Expect(out.Image).To(Equal("some-revision"))
 			})
+
+			It("annotates the stamped object with the revision of the upstream source and passes it on", func() {
+				stampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(stampedObject.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.SourceRevisionAnnotation, "some-revision"))
+				_, appliedObject, _ := fakeWorkloadRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+				Expect(appliedObject.GetAnnotations()).To(HaveKeyWithValue(v1alpha1.SourceRevisionAnnotation, "some-revision"))
+				Expect(out.SourceRevision).To(Equal("some-revision"))
+			})
+
+			Context("and registry mirrors are configured", func() {
+				const digest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+				BeforeEach(func() {
+					outputs.AddOutput("previous-resource", &templates.Output{Source: &templates.Source{
+						URL:      "some-url",
+						Revision: "docker.io/library/nginx" + digest,
+					}})
+				})
+
+				JustBeforeEach(func() {
+					var err error
+					repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+						return &fakeWorkloadRepo
+					}
+					clientBuilder := func(*corev1.Secret) (client.Client, error) {
+						return &repositoryfakes.FakeClient{}, nil
+					}
+					registryMirrors := mirrors.Mirrors{{From: "docker.io/library", To: "mirror.internal/dockerhub"}}
+					r, err = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, realizer.ResourceRealizerOptions{AllowOutputOverrides: true, RegistryMirrors: registryMirrors})(theSecret, &workload, &fakeSystemRepo, supplyChainParams)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns the image rewritten to the mirror, keeping its digest", func() {
+					_, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.Image).To(Equal("mirror.internal/dockerhub/nginx" + digest))
+				})
+
+				It("does not rewrite the stamped object", func() {
+					stampedObject, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stampedObject.Object["data"]).To(HaveKeyWithValue("some_other_info", "docker.io/library/nginx"+digest))
+				})
+
+				It("does not rewrite an image the workload overrides the output with", func() {
+					workload.Annotations = map[string]string{"carto.run/override-output.resource-1.image": "docker.io/library/known-good" + digest}
+
+					_, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.Image).To(Equal("docker.io/library/known-good" + digest))
+				})
+
+				It("leaves images no mirror matches as they are", func() {
+					outputs.AddOutput("previous-resource", &templates.Output{Source: &templates.Source{Revision: "quay.io/org/app" + digest}})
+
+					_, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.Image).To(Equal("quay.io/org/app" + digest))
+				})
+			})
+
+			Context("and the upstream source has no revision", func() {
+				BeforeEach(func() {
+					outputs.AddOutput("previous-resource", &templates.Output{Source: &templates.Source{URL: "some-url"}})
+				})
+
+				It("does not annotate the stamped object", func() {
+					// the image is read from the revision, so no output is produced either
+					_, _, _ = r.Do(ctx, &resource, supplyChainName, outputs)
+
+					Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+					_, appliedObject, _ := fakeWorkloadRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+					Expect(appliedObject.GetAnnotations()).NotTo(HaveKey(v1alpha1.SourceRevisionAnnotation))
+				})
+			})
+
+			Context("and the output of the upstream resource was recorded", func() {
+				var fakeCache *repositoryfakes.FakeRepoCache
+
+				JustBeforeEach(func() {
+					var err error
+					fakeCache = &repositoryfakes.FakeRepoCache{}
+					repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+						return &fakeWorkloadRepo
+					}
+					clientBuilder := func(*corev1.Secret) (client.Client, error) {
+						return &repositoryfakes.FakeClient{}, nil
+					}
+					r, err = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, fakeCache, realizer.ResourceRealizerOptions{})(theSecret, &workload, &fakeSystemRepo, supplyChainParams)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				Context("with a value that has since changed", func() {
+					BeforeEach(func() {
+						workload.Status.Summary = &v1alpha1.WorkloadSummary{
+							Resources: []v1alpha1.ResourceSummary{
+								{Name: "previous-resource", OutputHash: realizer.OutputHash(&templates.Output{Source: &templates.Source{
+									URL:      "some-old-url",
+									Revision: "some-revision",
+								}})},
+							},
+						}
+					})
+
+					It("stamps the resource again, applying it even if unchanged since cached", func() {
+						stampedObject, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeCache.ForgetCallCount()).To(Equal(1))
+						Expect(fakeCache.ForgetArgsForCall(0)).To(Equal(stampedObject))
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+						Expect(stampedObject.Object["data"]).To(HaveKeyWithValue("player_current_lives", "some-url"))
+					})
+				})
+
+				Context("with the same value", func() {
+					BeforeEach(func() {
+						workload.Status.Summary = &v1alpha1.WorkloadSummary{
+							Resources: []v1alpha1.ResourceSummary{
+								{Name: "previous-resource", OutputHash: realizer.OutputHash(outputs["previous-resource"])},
+							},
+						}
+					})
+
+					It("leaves the cache alone", func() {
+						_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeCache.ForgetCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("and the resource waits for the stamped object's generation to be observed", func() {
+				var observedGeneration int64
+
+				BeforeEach(func() {
+					resource.WaitForObservedGeneration = true
+					fakeWorkloadRepo.EnsureObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, allowUpdate bool) error {
+						obj.SetGeneration(2)
+						Expect(unstructured.SetNestedField(obj.Object, observedGeneration, "status", "observedGeneration")).To(Succeed())
+						return nil
+					}
+				})
+
+				Context("and its controller has not observed the generation yet", func() {
+					BeforeEach(func() {
+						observedGeneration = 1
+					})
+
+					It("returns StampedObjectNotObservedError with the stamped object and no outputs", func() {
+						returnedStampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).To(BeAssignableToTypeOf(realizer.StampedObjectNotObservedError{}))
+						Expect(err.Error()).To(ContainSubstring("is at generation [2], which its controller has not observed yet (observedGeneration [1])"))
+						Expect(returnedStampedObject).NotTo(BeNil())
+						Expect(out).To(BeNil())
+					})
+				})
+
+				Context("and its controller has observed the generation", func() {
+					BeforeEach(func() {
+						observedGeneration = 2
+					})
+
+					It("returns the outputs", func() {
+						_, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(out.Image).To(Equal("some-revision"))
+					})
+				})
+			})
+
+			Context("and a kind policy is configured", func() {
+				var kindPolicy kindpolicy.Policy
+
+				JustBeforeEach(func() {
+					var err error
+					repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+						return &fakeWorkloadRepo
+					}
+					clientBuilder := func(*corev1.Secret) (client.Client, error) {
+						return &repositoryfakes.FakeClient{}, nil
+					}
+					r, err = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, realizer.ResourceRealizerOptions{KindPolicy: kindPolicy})(theSecret, &workload, &fakeSystemRepo, supplyChainParams)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				Context("that allows the stamped kind", func() {
+					BeforeEach(func() {
+						kindPolicy = kindpolicy.Policy{Allowed: []schema.GroupKind{{Kind: "ConfigMap"}}}
+					})
+
+					It("applies the stamped object", func() {
+						_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("that denies the stamped kind", func() {
+					BeforeEach(func() {
+						kindPolicy = kindpolicy.Policy{Denied: []schema.GroupKind{{Kind: "ConfigMap"}}}
+					})
+
+					It("returns StampedKindNotAllowedError", func() {
+						stampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(stampedObject).To(BeNil())
+						Expect(out).To(BeNil())
+
+						Expect(err).To(MatchError("stamped object [some-namespace/example-config-map] for resource [resource-1] is of type [configmap], which cartographer is not allowed to create"))
+						Expect(reflect.TypeOf(err).String()).To(Equal("workload.StampedKindNotAllowedError"))
+					})
+
+					It("does not apply the stamped object", func() {
+						_, _, _ = r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("and permissions are checked before applying", func() {
+				JustBeforeEach(func() {
+					var err error
+					repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+						return &fakeWorkloadRepo
+					}
+					clientBuilder := func(*corev1.Secret) (client.Client, error) {
+						return &repositoryfakes.FakeClient{}, nil
+					}
+					r, err = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, realizer.ResourceRealizerOptions{CheckPermissions: true})(theSecret, &workload, &fakeSystemRepo, supplyChainParams)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				Context("and the service account may create and patch the stamped kind", func() {
+					It("reviews both verbs with the workload's client and applies the stamped object", func() {
+						_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeWorkloadRepo.ReviewAccessCallCount()).To(Equal(2))
+						_, obj, verb := fakeWorkloadRepo.ReviewAccessArgsForCall(0)
+						Expect(obj.GetName()).To(Equal("example-config-map"))
+						Expect(verb).To(Equal("create"))
+						_, _, verb = fakeWorkloadRepo.ReviewAccessArgsForCall(1)
+						Expect(verb).To(Equal("patch"))
+
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("and the access review denies a verb", func() {
+					BeforeEach(func() {
+						fakeWorkloadRepo.ReviewAccessReturnsOnCall(1, repository.AccessDeniedError{
+							Verb:      "patch",
+							Resource:  "configmaps",
+							Namespace: "some-namespace",
+						})
+					})
+
+					It("returns InsufficientPermissionsError naming the verb and resource", func() {
+						stampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(stampedObject).To(BeNil())
+						Expect(out).To(BeNil())
+
+						Expect(err).To(MatchError("service account may not apply stamped object [some-namespace/example-config-map] of type [configmap] for resource [resource-1]: not permitted to [patch] resource [configmaps] in namespace [some-namespace]"))
+						Expect(reflect.TypeOf(err).String()).To(Equal("workload.InsufficientPermissionsError"))
+					})
+
+					It("does not apply the stamped object", func() {
+						_, _, _ = r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("and the access review fails", func() {
+					BeforeEach(func() {
+						fakeWorkloadRepo.ReviewAccessReturns(errors.New("no mapping"))
+					})
+
+					It("returns an unhandled error without applying the stamped object", func() {
+						_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).To(MatchError("failed to review access to stamped object of resource [resource-1]: no mapping"))
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+					})
+				})
+			})
+		})
+
+		When("the resource validates the stamped object against its schema", func() {
+			var stampedSpec map[string]interface{}
+
+			BeforeEach(func() {
+				resource.ValidateStampedObject = true
+				stampedSpec = map[string]interface{}{"value": "some-value"}
+			})
+
+			JustBeforeEach(func() {
+				dbytes, err := json.Marshal(map[string]interface{}{
+					"apiVersion": "test.run/v1alpha1",
+					"kind":       "TestObj",
+					"metadata": map[string]interface{}{
+						"name": "my-test-obj",
+					},
+					"spec": stampedSpec,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				templateAPI := &v1alpha1.ClusterTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "image-template-1",
+					},
+					Spec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: dbytes},
+					},
+				}
+
+				fakeSystemRepo.GetClusterTemplateReturns(templateAPI, nil)
+			})
+
+			Context("and the kind has a custom resource definition with a schema", func() {
+				BeforeEach(func() {
+					fakeSystemRepo.GetCustomResourceDefinitionReturns(&apiextensionsv1.CustomResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "testobjs.test.run",
+						},
+						Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+							Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+								{
+									Name: "v1alpha1",
+									Schema: &apiextensionsv1.CustomResourceValidation{
+										OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+											Type: "object",
+											Properties: map[string]apiextensionsv1.JSONSchemaProps{
+												"spec": {
+													Type:     "object",
+													Required: []string{"foo"},
+													Properties: map[string]apiextensionsv1.JSONSchemaProps{
+														"foo":   {Type: "string"},
+														"value": {Type: "string"},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				})
+
+				It("looks up the definition for the stamped object's kind", func() {
+					_, _, _ = r.Do(ctx, &resource, supplyChainName, outputs)
+
+					Expect(fakeSystemRepo.GetCustomResourceDefinitionCallCount()).To(Equal(1))
+					_, gvk := fakeSystemRepo.GetCustomResourceDefinitionArgsForCall(0)
+					Expect(gvk).To(Equal(schema.GroupVersionKind{Group: "test.run", Version: "v1alpha1", Kind: "TestObj"}))
+				})
+
+				Context("and the stamped object is missing a required field", func() {
+					It("returns StampedObjectSchemaInvalidError naming the field", func() {
+						stampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(stampedObject).To(BeNil())
+						Expect(out).To(BeNil())
+
+						Expect(err).To(MatchError(ContainSubstring("stamped object [/my-test-obj] of type [testobj.test.run] for resource [resource-1] does not match its schema")))
+						Expect(err).To(MatchError(ContainSubstring("spec.foo: Required value")))
+						Expect(reflect.TypeOf(err).String()).To(Equal("workload.StampedObjectSchemaInvalidError"))
+					})
+
+					It("does not apply the stamped object", func() {
+						_, _, _ = r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("and the stamped object matches the schema", func() {
+					BeforeEach(func() {
+						stampedSpec["foo"] = "some-foo"
+					})
+
+					It("applies the stamped object", func() {
+						_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("and the kind has no custom resource definition", func() {
+				BeforeEach(func() {
+					fakeSystemRepo.GetCustomResourceDefinitionReturns(nil, nil)
+				})
+
+				It("applies the stamped object", func() {
+					_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("and the custom resource definition cannot be fetched", func() {
+				BeforeEach(func() {
+					fakeSystemRepo.GetCustomResourceDefinitionReturns(nil, errors.New("no mapping"))
+				})
+
+				It("returns an error without applying the stamped object", func() {
+					_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).To(MatchError(ContainSubstring("failed to get schema for stamped object of resource [resource-1]: get custom resource definition: no mapping")))
+					Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and validation is not enabled for the resource", func() {
+				BeforeEach(func() {
+					resource.ValidateStampedObject = false
+				})
+
+				It("does not look up the schema", func() {
+					_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeSystemRepo.GetCustomResourceDefinitionCallCount()).To(Equal(0))
+				})
+			})
 		})
 
 		When("unable to get the template ref from repo", func() {
@@ -266,6 +734,43 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
+		When("the template overrides the owner references of the stamped object", func() {
+			BeforeEach(func() {
+				templateAPI := &v1alpha1.ClusterImageTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterImageTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "image-template-1",
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.ImageTemplateSpec{
+						TemplateSpec: v1alpha1.TemplateSpec{
+							Template: &runtime.RawExtension{Raw: []byte(`{
+								"apiVersion": "v1",
+								"kind": "ConfigMap",
+								"metadata": {
+									"name": "example-config-map",
+									"ownerReferences": [{"apiVersion": "v1", "kind": "Secret", "name": "other", "uid": "abc", "controller": true}]
+								}
+							}`)},
+						},
+						ImagePath: "data.image",
+					},
+				}
+
+				fakeSystemRepo.GetClusterTemplateReturns(templateAPI, nil)
+			})
+
+			It("returns a StampError for the missing owner reference without applying the stamped object", func() {
+				_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).To(BeAssignableToTypeOf(realizer.StampError{}))
+				Expect(err.(realizer.StampError).Err).To(BeAssignableToTypeOf(templates.MissingOwnerReferenceError{}))
+				Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+
 		When("unable to retrieve the output from the stamped object", func() {
 			BeforeEach(func() {
 				configMap := &corev1.ConfigMap{
@@ -313,6 +818,63 @@ var _ = Describe("Resource", func() {
 				Expect(err.Error()).To(ContainSubstring("find results: does-not-exist is not found"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("workload.RetrieveOutputError"))
 			})
+
+			Context("and the workload overrides the output of the resource", func() {
+				var allowOutputOverrides bool
+
+				BeforeEach(func() {
+					workload.Annotations = map[string]string{
+						"carto.run/override-output.resource-1.image":     "my-registry/known-good@sha256:abc",
+						"carto.run/override-output.other-resource.image": "my-registry/other@sha256:def",
+					}
+				})
+
+				JustBeforeEach(func() {
+					var err error
+					repositoryBuilder := func(client.Client, repository.RepoCache) repository.Repository {
+						return &fakeWorkloadRepo
+					}
+					clientBuilder := func(*corev1.Secret) (client.Client, error) {
+						return &repositoryfakes.FakeClient{}, nil
+					}
+					r, err = realizer.NewResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache, realizer.ResourceRealizerOptions{AllowOutputOverrides: allowOutputOverrides})(theSecret, &workload, &fakeSystemRepo, supplyChainParams)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				Context("and overrides are allowed", func() {
+					BeforeEach(func() {
+						allowOutputOverrides = true
+					})
+
+					It("returns the annotated value instead of the output of the stamped object", func() {
+						stampedObject, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(stampedObject).NotTo(BeNil())
+						Expect(out.Image).To(Equal("my-registry/known-good@sha256:abc"))
+						Expect(out.Source).To(BeNil())
+					})
+
+					It("returns RetrieveOutputError when the annotation names an unknown output", func() {
+						workload.Annotations["carto.run/override-output.resource-1.tag"] = "latest"
+
+						_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).To(MatchError(ContainSubstring("annotation overrides unknown output [tag]")))
+						Expect(reflect.TypeOf(err).String()).To(Equal("workload.RetrieveOutputError"))
+					})
+				})
+
+				Context("and overrides are not allowed", func() {
+					BeforeEach(func() {
+						allowOutputOverrides = false
+					})
+
+					It("ignores the annotation and returns RetrieveOutputError", func() {
+						_, out, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(out).To(BeNil())
+						Expect(reflect.TypeOf(err).String()).To(Equal("workload.RetrieveOutputError"))
+					})
+				})
+			})
 		})
 
 		When("unable to EnsureObjectExistsOnCluster the stamped object", func() {
@@ -374,6 +936,287 @@ var _ = Describe("Resource", func() {
 				Expect(err.Error()).To(ContainSubstring("bad object"))
 				Expect(reflect.TypeOf(err).String()).To(Equal("workload.ApplyStampedObjectError"))
 			})
+
+			Context("because the patch changes an immutable field", func() {
+				var (
+					createErr error
+					fieldErr  *field.Error
+				)
+
+				BeforeEach(func() {
+					createErr = nil
+					fieldErr = field.Invalid(field.NewPath("data"), "some-revision", "field is immutable")
+					fakeWorkloadRepo.EnsureObjectExistsOnClusterStub = func(ctx context.Context, obj *unstructured.Unstructured, allowUpdate bool) error {
+						if allowUpdate {
+							obj.SetResourceVersion("some-resource-version")
+							return fmt.Errorf("patch: %w", kerrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "example-config-map", field.ErrorList{fieldErr}))
+						}
+						return createErr
+					}
+				})
+
+				It("returns ApplyStampedObjectError without deleting the object", func() {
+					_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).To(MatchError(ContainSubstring("field is immutable")))
+					Expect(reflect.TypeOf(err).String()).To(Equal("workload.ApplyStampedObjectError"))
+					Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(0))
+				})
+
+				Context("and the resource recreates objects on immutable changes", func() {
+					BeforeEach(func() {
+						resource.RecreateOnImmutableChange = true
+					})
+
+					It("deletes the object and creates the stamped object in its place", func() {
+						stampedObject, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(1))
+						_, deleted := fakeWorkloadRepo.DeleteUnstructuredArgsForCall(0)
+						Expect(deleted.GetName()).To(Equal("example-config-map"))
+
+						Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(2))
+						_, created, allowUpdate := fakeWorkloadRepo.EnsureObjectExistsOnClusterArgsForCall(1)
+						Expect(allowUpdate).To(BeFalse())
+						Expect(created).To(Equal(stampedObject))
+						Expect(created.GetResourceVersion()).To(BeEmpty())
+					})
+
+					Context("and the immutable field is a persistent volume claim's spec", func() {
+						BeforeEach(func() {
+							fieldErr = field.Forbidden(field.NewPath("spec"), "spec is immutable after creation except resources.requests for bound claims")
+						})
+
+						It("recreates the object", func() {
+							_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("and the immutable field is a service's cluster ip", func() {
+						BeforeEach(func() {
+							fieldErr = field.Invalid(field.NewPath("spec", "clusterIP"), "10.0.0.2", "may not change once set")
+						})
+
+						It("recreates the object", func() {
+							_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("and the immutable field is a stateful set's spec", func() {
+						BeforeEach(func() {
+							fieldErr = field.Forbidden(field.NewPath("spec"), "updates to statefulset spec for fields other than 'replicas', 'template', 'updateStrategy' and 'minReadySeconds' are forbidden")
+						})
+
+						It("recreates the object", func() {
+							_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("but the invalid field is not immutable", func() {
+						BeforeEach(func() {
+							fieldErr = field.Invalid(field.NewPath("metadata", "labels"), "some-label", "must be no more than 63 characters")
+						})
+
+						It("does not delete the object", func() {
+							_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+							Expect(err).To(MatchError(ContainSubstring("must be no more than 63 characters")))
+							Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("but the object cannot be created again", func() {
+						BeforeEach(func() {
+							createErr = errors.New("already exists")
+						})
+
+						It("returns ApplyStampedObjectError", func() {
+							_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+							Expect(err).To(MatchError(ContainSubstring("recreate: already exists")))
+							Expect(reflect.TypeOf(err).String()).To(Equal("workload.ApplyStampedObjectError"))
+						})
+					})
+				})
+			})
+
+			Context("and the resource recreates objects on immutable changes, but the error is another", func() {
+				BeforeEach(func() {
+					resource.RecreateOnImmutableChange = true
+				})
+
+				It("does not delete the object", func() {
+					_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+					Expect(err).To(MatchError(ContainSubstring("bad object")))
+					Expect(fakeWorkloadRepo.DeleteUnstructuredCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		When("passed a workload with a structured param", func() {
+			BeforeEach(func() {
+				template := map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"name":      "example-config-map",
+						"namespace": "some-namespace",
+					},
+					"spec": map[string]interface{}{
+						"args":     "$(params.args)$",
+						"settings": "$(params.settings)$",
+					},
+				}
+				dbytes, err := json.Marshal(template)
+				Expect(err).ToNot(HaveOccurred())
+
+				templateAPI := &v1alpha1.ClusterTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "template-with-structured-params",
+					},
+					Spec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: dbytes},
+						Params: v1alpha1.TemplateParams{
+							{Name: "args", DefaultValue: apiextensionsv1.JSON{Raw: []byte(`"--verbose"`)}},
+							{Name: "settings", DefaultValue: apiextensionsv1.JSON{Raw: []byte(`{"replicas": 1}`)}},
+						},
+					},
+				}
+
+				workload.Spec.Params = []v1alpha1.Param{
+					{Name: "args", Value: apiextensionsv1.JSON{Raw: []byte(`["--port", 8080]`)}},
+				}
+
+				fakeSystemRepo.GetClusterTemplateReturns(templateAPI, nil)
+				fakeWorkloadRepo.EnsureObjectExistsOnClusterReturns(nil)
+			})
+
+			It("stamps the structure of the param values", func() {
+				_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, stampedObject, _ := fakeWorkloadRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+				Expect(stampedObject.Object["spec"]).To(Equal(map[string]interface{}{
+					"args":     []interface{}{"--port", float64(8080)},
+					"settings": map[string]interface{}{"replicas": float64(1)},
+				}))
+			})
+		})
+		When("the resource has a name template", func() {
+			BeforeEach(func() {
+				template := map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"generateName": "example-config-map-",
+						"namespace":    "some-namespace",
+					},
+				}
+				dbytes, err := json.Marshal(template)
+				Expect(err).ToNot(HaveOccurred())
+
+				templateAPI := &v1alpha1.ClusterTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "template-with-generate-name",
+					},
+					Spec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: dbytes},
+					},
+				}
+
+				workload.Name = "My_Workload"
+				fakeSystemRepo.GetClusterTemplateReturns(templateAPI, nil)
+				fakeWorkloadRepo.EnsureObjectExistsOnClusterReturns(nil)
+			})
+
+			It("names the stamped object with the rendered template", func() {
+				resource.NameTemplate = "$(workload.metadata.name)$-$(resource.name)$-$(inputHash)$"
+
+				_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, stampedObject, _ := fakeWorkloadRepo.EnsureObjectExistsOnClusterArgsForCall(0)
+				Expect(stampedObject.GetName()).To(MatchRegexp(`^my-workload-resource-1-[0-9a-f]{8}$`))
+				Expect(stampedObject.GetGenerateName()).To(BeEmpty())
+			})
+
+			It("returns a StampError when the name cannot be rendered", func() {
+				resource.NameTemplate = "$(workload.metadata.nope)$"
+
+				_, _, err := r.Do(ctx, &resource, supplyChainName, outputs)
+				Expect(err).To(HaveOccurred())
+				Expect(reflect.TypeOf(err).String()).To(Equal("workload.StampError"))
+				Expect(fakeWorkloadRepo.EnsureObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ConditionMet", func() {
+		BeforeEach(func() {
+			workload.Spec.Params = []v1alpha1.Param{
+				{
+					Name:  "workload-type",
+					Value: apiextensionsv1.JSON{Raw: []byte(`"library"`)},
+				},
+			}
+			workload.Spec.ServiceAccountName = "some-service-account"
+		})
+
+		Context("when the resource has no condition", func() {
+			It("includes the resource", func() {
+				Expect(r.ConditionMet(ctx, &resource)).To(BeTrue())
+			})
+		})
+
+		Context("when the workload value at the key equals the condition value", func() {
+			BeforeEach(func() {
+				resource.Condition = &v1alpha1.Condition{
+					Key:   `spec.params[?(@.name=="workload-type")].value`,
+					Value: "library",
+				}
+			})
+
+			It("includes the resource", func() {
+				Expect(r.ConditionMet(ctx, &resource)).To(BeTrue())
+			})
+		})
+
+		Context("when the workload value at the key differs from the condition value", func() {
+			BeforeEach(func() {
+				resource.Condition = &v1alpha1.Condition{
+					Key:   "spec.serviceAccountName",
+					Value: "another-service-account",
+				}
+			})
+
+			It("excludes the resource", func() {
+				Expect(r.ConditionMet(ctx, &resource)).To(BeFalse())
+			})
+		})
+
+		Context("when the key is not present on the workload", func() {
+			BeforeEach(func() {
+				resource.Condition = &v1alpha1.Condition{
+					Key:   "spec.image",
+					Value: "some-image",
+				}
+			})
+
+			It("excludes the resource", func() {
+				Expect(r.ConditionMet(ctx, &resource)).To(BeFalse())
+			})
 		})
 	})
 })
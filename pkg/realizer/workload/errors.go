@@ -16,10 +16,12 @@ package workload
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
@@ -32,6 +34,26 @@ func (e GetClusterTemplateError) Error() string {
 	return fmt.Errorf("unable to get template [%s]: %w", e.TemplateRef.Name, e.Err).Error()
 }
 
+// ResourceNotFoundError is returned when the supply chain has no resource of the given name.
+type ResourceNotFoundError struct {
+	ResourceName    string
+	SupplyChainName string
+}
+
+func (e ResourceNotFoundError) Error() string {
+	return fmt.Sprintf("supply chain [%s] has no resource [%s]", e.SupplyChainName, e.ResourceName)
+}
+
+// ResourceSkippedError is returned when the resource is not realized for the workload, as its
+// condition is not met.
+type ResourceSkippedError struct {
+	Resource *v1alpha1.SupplyChainResource
+}
+
+func (e ResourceSkippedError) Error() string {
+	return fmt.Sprintf("resource [%s] is skipped, the workload does not meet its condition", e.Resource.Name)
+}
+
 type ApplyStampedObjectError struct {
 	Err           error
 	StampedObject *unstructured.Unstructured
@@ -50,6 +72,76 @@ func (e StampError) Error() string {
 	return fmt.Errorf("unable to stamp object for resource [%s]: %w", e.Resource.Name, e.Err).Error()
 }
 
+type StampedObjectSchemaInvalidError struct {
+	Err           error
+	Resource      *v1alpha1.SupplyChainResource
+	StampedObject *unstructured.Unstructured
+}
+
+func (e StampedObjectSchemaInvalidError) Error() string {
+	return fmt.Errorf("stamped object [%s/%s] of type [%s] for resource [%s] does not match its schema: %w",
+		e.StampedObject.GetNamespace(), e.StampedObject.GetName(),
+		utils.GetFullyQualifiedType(e.StampedObject),
+		e.Resource.Name, e.Err).Error()
+}
+
+type StampedKindNotAllowedError struct {
+	Resource      *v1alpha1.SupplyChainResource
+	StampedObject *unstructured.Unstructured
+}
+
+func (e StampedKindNotAllowedError) Error() string {
+	return fmt.Errorf("stamped object [%s/%s] for resource [%s] is of type [%s], which cartographer is not allowed to create",
+		e.StampedObject.GetNamespace(), e.StampedObject.GetName(), e.Resource.Name,
+		utils.GetFullyQualifiedType(e.StampedObject)).Error()
+}
+
+// InsufficientPermissionsError is returned when the service account of the workload is not
+// permitted to apply the stamped object, found by reviewing its access before the apply.
+type InsufficientPermissionsError struct {
+	Err           repository.AccessDeniedError
+	Resource      *v1alpha1.SupplyChainResource
+	StampedObject *unstructured.Unstructured
+}
+
+func (e InsufficientPermissionsError) Error() string {
+	return fmt.Errorf("service account may not apply stamped object [%s/%s] of type [%s] for resource [%s]: %w",
+		e.StampedObject.GetNamespace(), e.StampedObject.GetName(),
+		utils.GetFullyQualifiedType(e.StampedObject),
+		e.Resource.Name, e.Err).Error()
+}
+
+type UpstreamOutputNotAvailableError struct {
+	Resource         *v1alpha1.SupplyChainResource
+	UpstreamResource string
+	// Err is the error reading the output of the upstream resource, when the supply chain
+	// stopped on it.
+	Err error
+}
+
+func (e UpstreamOutputNotAvailableError) Error() string {
+	if e.Err != nil {
+		return fmt.Errorf("resource [%s] is waiting on output from upstream resource [%s]: %w",
+			e.Resource.Name, e.UpstreamResource, e.Err).Error()
+	}
+	return fmt.Errorf("resource [%s] is waiting on output from upstream resource [%s]",
+		e.Resource.Name, e.UpstreamResource).Error()
+}
+
+type StampedObjectNotObservedError struct {
+	Resource           *v1alpha1.SupplyChainResource
+	StampedObject      *unstructured.Unstructured
+	Generation         int64
+	ObservedGeneration int64
+}
+
+func (e StampedObjectNotObservedError) Error() string {
+	return fmt.Errorf("stamped object [%s/%s] of type [%s] for resource [%s] is at generation [%d], which its controller has not observed yet (observedGeneration [%d])",
+		e.StampedObject.GetNamespace(), e.StampedObject.GetName(),
+		utils.GetFullyQualifiedType(e.StampedObject),
+		e.Resource.Name, e.Generation, e.ObservedGeneration).Error()
+}
+
 type RetrieveOutputError struct {
 	Err           error
 	Resource      *v1alpha1.SupplyChainResource
@@ -74,3 +166,30 @@ func (e RetrieveOutputError) JsonPathExpression() string {
 	}
 	return "<no jsonpath context>"
 }
+
+// ResourceErrors is returned when a supply chain is realized with the collectAll strategy and
+// one or more of its resources failed. Errors are in supply chain order. Blocked lists the
+// resources that were not realized because they consume the output of a failed resource.
+type ResourceErrors struct {
+	Errors []ResourceError
+	// Blocked are the resources not realized because they consume the output of a resource
+	// that failed.
+	Blocked []string
+	// Waiting are the resources not realized because they consume an output that is not
+	// available yet: the upstream resource was stamped, but its object has not produced the
+	// output.
+	Waiting []UpstreamOutputNotAvailableError
+}
+
+type ResourceError struct {
+	Err          error
+	ResourceName string
+}
+
+func (e ResourceErrors) Error() string {
+	var messages []string
+	for _, resourceError := range e.Errors {
+		messages = append(messages, resourceError.Err.Error())
+	}
+	return fmt.Sprintf("%d resources failed: %s", len(e.Errors), strings.Join(messages, "; "))
+}
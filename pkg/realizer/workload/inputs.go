@@ -0,0 +1,51 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// ComputeResourceInputs resolves the inputs the named resource of the supply chain receives for
+// the workload: the outputs of the resources it consumes, keyed by the names the resource gives
+// them, as they are passed to its template when it is stamped. The outputs are those of the
+// workload's resources, such as the ones a Realize read.
+func ComputeResourceInputs(ctx context.Context, supplyChain *v1alpha1.ClusterSupplyChain, workload *v1alpha1.Workload, resourceName string, outputs Outputs) (*templates.Inputs, error) {
+	var resource *v1alpha1.SupplyChainResource
+	for i := range supplyChain.Spec.Resources {
+		if supplyChain.Spec.Resources[i].Name == resourceName {
+			resource = &supplyChain.Spec.Resources[i]
+		}
+	}
+	if resource == nil {
+		return nil, ResourceNotFoundError{ResourceName: resourceName, SupplyChainName: supplyChain.Name}
+	}
+
+	if !conditionMet(ctx, workload, resource) {
+		return nil, ResourceSkippedError{Resource: resource}
+	}
+
+	if upstream := outputs.MissingUpstream(resource); upstream != "" {
+		return nil, UpstreamOutputNotAvailableError{
+			Resource:         resource,
+			UpstreamResource: upstream,
+		}
+	}
+
+	return outputs.GenerateInputs(resource), nil
+}
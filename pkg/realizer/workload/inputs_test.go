@@ -0,0 +1,120 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+var _ = Describe("ComputeResourceInputs", func() {
+	var (
+		supplyChain *v1alpha1.ClusterSupplyChain
+		workload    *v1alpha1.Workload
+		outputs     realizer.Outputs
+	)
+
+	BeforeEach(func() {
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				Resources: []v1alpha1.SupplyChainResource{
+					{Name: "source-provider"},
+					{Name: "image-builder", Sources: []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}}},
+					{Name: "config-provider", Images: []v1alpha1.ResourceReference{{Name: "image", Resource: "image-builder"}}},
+					{
+						Name:    "deployer",
+						Sources: []v1alpha1.ResourceReference{{Name: "app-source", Resource: "source-provider"}},
+						Configs: []v1alpha1.ResourceReference{{Name: "app-config", Resource: "config-provider"}},
+					},
+				},
+			},
+		}
+		workload = &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "my-workload", Namespace: "my-ns"}}
+
+		outputs = realizer.NewOutputs()
+		outputs.AddOutput("source-provider", &templates.Output{Source: &templates.Source{URL: "https://example.com/src.tar.gz", Revision: "abc123"}})
+		outputs.AddOutput("image-builder", &templates.Output{Image: "my-registry/app@sha256:def"})
+		outputs.AddOutput("config-provider", &templates.Output{Config: map[string]interface{}{"replicas": 2}})
+	})
+
+	It("wires the outputs of upstream resources under the names the resource gives them", func() {
+		inputs, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "deployer", outputs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inputs.Sources).To(Equal(map[string]templates.SourceInput{
+			"app-source": {URL: "https://example.com/src.tar.gz", Revision: "abc123", Name: "app-source"},
+		}))
+		Expect(inputs.Configs).To(Equal(map[string]templates.ConfigInput{
+			"app-config": {Config: map[string]interface{}{"replicas": 2}, Name: "app-config"},
+		}))
+		Expect(inputs.Images).To(BeEmpty())
+	})
+
+	It("reflects the latest upstream outputs", func() {
+		outputs.AddOutput("image-builder", &templates.Output{Image: "my-registry/app@sha256:fed"})
+
+		inputs, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "config-provider", outputs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inputs.OnlyImage()).To(Equal("my-registry/app@sha256:fed"))
+	})
+
+	It("returns empty inputs for a resource that consumes nothing", func() {
+		inputs, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "source-provider", outputs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inputs.Sources).To(BeEmpty())
+		Expect(inputs.Images).To(BeEmpty())
+		Expect(inputs.Configs).To(BeEmpty())
+	})
+
+	It("returns an error for a resource the supply chain does not have", func() {
+		_, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "scanner", outputs)
+		Expect(err).To(MatchError("supply chain [my-supply-chain] has no resource [scanner]"))
+		Expect(err).To(BeAssignableToTypeOf(realizer.ResourceNotFoundError{}))
+	})
+
+	It("returns an error when an upstream output is not available", func() {
+		delete(outputs, "config-provider")
+
+		_, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "deployer", outputs)
+		Expect(err).To(BeAssignableToTypeOf(realizer.UpstreamOutputNotAvailableError{}))
+		Expect(err.(realizer.UpstreamOutputNotAvailableError).UpstreamResource).To(Equal("config-provider"))
+	})
+
+	Context("the resource has a condition", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[3].Condition = &v1alpha1.Condition{Key: `metadata.labels.deploy`, Value: "true"}
+		})
+
+		It("returns an error when the workload does not meet it", func() {
+			_, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "deployer", outputs)
+			Expect(err).To(MatchError("resource [deployer] is skipped, the workload does not meet its condition"))
+		})
+
+		It("computes the inputs when the workload meets it", func() {
+			workload.Labels = map[string]string{"deploy": "true"}
+
+			inputs, err := realizer.ComputeResourceInputs(context.TODO(), supplyChain, workload, "deployer", outputs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs.Sources).To(HaveKey("app-source"))
+		})
+	})
+})
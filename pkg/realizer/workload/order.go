@@ -0,0 +1,79 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// realizationOrder returns the indexes of the resources in the order they are realized: each
+// resource after the resources whose outputs it consumes. Resources keep their supply chain
+// order wherever their inputs allow, so a supply chain that lists every resource after the
+// resources it consumes is realized as listed. References to unknown resources are ignored,
+// and resources caught in a cycle are realized last, in supply chain order.
+func realizationOrder(resources []v1alpha1.SupplyChainResource) []int {
+	known := map[string]bool{}
+	for _, resource := range resources {
+		known[resource.Name] = true
+	}
+
+	placed := make([]bool, len(resources))
+	realized := map[string]bool{}
+	var order []int
+
+	for len(order) < len(resources) {
+		next := -1
+		for i := range resources {
+			if !placed[i] && inputsRealized(&resources[i], known, realized) {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			for i := range resources {
+				if !placed[i] {
+					order = append(order, i)
+				}
+			}
+			break
+		}
+
+		placed[next] = true
+		realized[resources[next].Name] = true
+		order = append(order, next)
+	}
+
+	return order
+}
+
+func inputsRealized(resource *v1alpha1.SupplyChainResource, known map[string]bool, realized map[string]bool) bool {
+	for _, reference := range resourceReferences(resource) {
+		if known[reference.Resource] && !realized[reference.Resource] {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceReferences are the references of the resource to the resources whose outputs it
+// consumes.
+func resourceReferences(resource *v1alpha1.SupplyChainResource) []v1alpha1.ResourceReference {
+	var references []v1alpha1.ResourceReference
+	references = append(references, resource.Sources...)
+	references = append(references, resource.Images...)
+	references = append(references, resource.Configs...)
+	return references
+}
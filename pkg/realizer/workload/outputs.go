@@ -15,6 +15,8 @@
 package workload
 
 import (
+	"encoding/json"
+
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
@@ -29,6 +31,70 @@ func (o Outputs) AddOutput(name string, output *templates.Output) {
 	o[name] = output
 }
 
+// OutputHash is a short, stable hash of the value of an output.
+func OutputHash(output *templates.Output) string {
+	hash, err := templates.InputHash(output)
+	if err != nil {
+		// an output only holds values read from a json object, so it always marshals
+		return ""
+	}
+	return hash
+}
+
+// changedSince returns the names of the resources whose output hashes differently than
+// recorded in the summary. Resources without a recorded hash are not reported.
+func (o Outputs) changedSince(summary *v1alpha1.WorkloadSummary) []string {
+	if summary == nil {
+		return nil
+	}
+
+	var changed []string
+	for _, resource := range summary.Resources {
+		output, ok := o[resource.Name]
+		if !ok || resource.OutputHash == "" {
+			continue
+		}
+		if OutputHash(output) != resource.OutputHash {
+			changed = append(changed, resource.Name)
+		}
+	}
+	return changed
+}
+
+// SourceRevision is the revision of the source the resource is produced from: that of the
+// first resource it consumes whose output is a source, or was produced from one. Empty when no
+// source is upstream of the resource.
+func (o Outputs) SourceRevision(resource *v1alpha1.SupplyChainResource) string {
+	for _, reference := range resourceReferences(resource) {
+		if revision := outputSourceRevision(o[reference.Resource]); revision != "" {
+			return revision
+		}
+	}
+	return ""
+}
+
+// outputSourceRevision is the revision of a source output, or the revision the output was
+// produced from
+func outputSourceRevision(output *templates.Output) string {
+	if output == nil {
+		return ""
+	}
+	if output.Source != nil {
+		switch revision := output.Source.Revision.(type) {
+		case nil:
+		case string:
+			if revision != "" {
+				return revision
+			}
+		default:
+			if encoded, err := json.Marshal(revision); err == nil {
+				return string(encoded)
+			}
+		}
+	}
+	return output.SourceRevision
+}
+
 func (o Outputs) getResourceSource(resourceName string) *templates.Source {
 	output := o[resourceName]
 	if output == nil {
@@ -54,6 +120,31 @@ func (o Outputs) getResourceConfig(resourceName string) templates.Config {
 	return output.Config
 }
 
+// MissingUpstream returns the name of the first resource that the given resource
+// consumes an output from, where that output has not been produced yet.
+// An empty string means every input of the resource is available.
+func (o Outputs) MissingUpstream(resource *v1alpha1.SupplyChainResource) string {
+	for _, referenceSource := range resource.Sources {
+		if o.getResourceSource(referenceSource.Resource) == nil {
+			return referenceSource.Resource
+		}
+	}
+
+	for _, referenceImage := range resource.Images {
+		if o.getResourceImage(referenceImage.Resource) == nil {
+			return referenceImage.Resource
+		}
+	}
+
+	for _, referenceConfig := range resource.Configs {
+		if o.getResourceConfig(referenceConfig.Resource) == nil {
+			return referenceConfig.Resource
+		}
+	}
+
+	return ""
+}
+
 func (o Outputs) GenerateInputs(resource *v1alpha1.SupplyChainResource) *templates.Inputs {
 	inputs := &templates.Inputs{
 		Sources: map[string]templates.SourceInput{},
@@ -67,6 +158,7 @@ func (o Outputs) GenerateInputs(resource *v1alpha1.SupplyChainResource) *templat
 			inputs.Sources[referenceSource.Name] = templates.SourceInput{
 				URL:      source.URL,
 				Revision: source.Revision,
+				Digest:   source.Digest,
 				Name:     referenceSource.Name,
 			}
 		}
@@ -33,6 +33,7 @@ var _ = Describe("Outputs", func() {
 					Source: &templates.Source{
 						URL:      "source-url",
 						Revision: "source-revision",
+						Digest:   "sha256:source-digest",
 					},
 				}
 				outs.AddOutput("source-output", sourceOutput)
@@ -53,6 +54,7 @@ var _ = Describe("Outputs", func() {
 					Expect(inputs.Sources["source-ref"].Name).To(Equal("source-ref"))
 					Expect(inputs.Sources["source-ref"].URL).To(Equal("source-url"))
 					Expect(inputs.Sources["source-ref"].Revision).To(Equal("source-revision"))
+					Expect(inputs.Sources["source-ref"].Digest).To(Equal("sha256:source-digest"))
 				})
 			})
 
@@ -159,4 +161,103 @@ var _ = Describe("Outputs", func() {
 			})
 		})
 	})
+
+	Describe("MissingUpstream", func() {
+		var (
+			outs     realizer.Outputs
+			resource *v1alpha1.SupplyChainResource
+		)
+
+		BeforeEach(func() {
+			outs = realizer.NewOutputs()
+			outs.AddOutput("source-resource", &templates.Output{Source: &templates.Source{URL: "some-url"}})
+			resource = &v1alpha1.SupplyChainResource{
+				Sources: []v1alpha1.ResourceReference{
+					{
+						Name:     "source-ref",
+						Resource: "source-resource",
+					},
+				},
+			}
+		})
+
+		Context("When every referenced output has been produced", func() {
+			It("returns an empty string", func() {
+				Expect(outs.MissingUpstream(resource)).To(BeEmpty())
+			})
+		})
+
+		Context("When a referenced resource has not produced an output yet", func() {
+			It("returns the name of that resource", func() {
+				resource.Configs = []v1alpha1.ResourceReference{
+					{
+						Name:     "config-ref",
+						Resource: "config-resource",
+					},
+				}
+				Expect(outs.MissingUpstream(resource)).To(Equal("config-resource"))
+			})
+		})
+
+		Context("When a referenced resource produced an output of a different kind", func() {
+			It("returns the name of that resource", func() {
+				resource.Images = []v1alpha1.ResourceReference{
+					{
+						Name:     "image-ref",
+						Resource: "source-resource",
+					},
+				}
+				Expect(outs.MissingUpstream(resource)).To(Equal("source-resource"))
+			})
+		})
+	})
+
+	Describe("SourceRevision", func() {
+		var (
+			outs     realizer.Outputs
+			resource *v1alpha1.SupplyChainResource
+		)
+
+		BeforeEach(func() {
+			outs = realizer.NewOutputs()
+			outs.AddOutput("source-resource", &templates.Output{Source: &templates.Source{URL: "some-url", Revision: "abc123"}})
+			outs.AddOutput("image-resource", &templates.Output{Image: "some-image", SourceRevision: "def456"})
+			outs.AddOutput("config-resource", &templates.Output{Config: "some-config"})
+			resource = &v1alpha1.SupplyChainResource{}
+		})
+
+		Context("When the resource consumes a source", func() {
+			It("returns the revision of the source", func() {
+				resource.Images = []v1alpha1.ResourceReference{{Name: "image-ref", Resource: "image-resource"}}
+				resource.Sources = []v1alpha1.ResourceReference{{Name: "source-ref", Resource: "source-resource"}}
+				Expect(outs.SourceRevision(resource)).To(Equal("abc123"))
+			})
+		})
+
+		Context("When the resource consumes an output produced from a source", func() {
+			It("returns the revision that output was produced from", func() {
+				resource.Configs = []v1alpha1.ResourceReference{{Name: "config-ref", Resource: "config-resource"}}
+				resource.Images = []v1alpha1.ResourceReference{{Name: "image-ref", Resource: "image-resource"}}
+				Expect(outs.SourceRevision(resource)).To(Equal("def456"))
+			})
+		})
+
+		Context("When the revision of the source is not a string", func() {
+			It("returns the revision as json", func() {
+				outs.AddOutput("source-resource", &templates.Output{Source: &templates.Source{
+					URL:      "some-url",
+					Revision: map[string]interface{}{"branch": "main", "commit": "abc123"},
+				}})
+				resource.Sources = []v1alpha1.ResourceReference{{Name: "source-ref", Resource: "source-resource"}}
+				Expect(outs.SourceRevision(resource)).To(Equal(`{"branch":"main","commit":"abc123"}`))
+			})
+		})
+
+		Context("When no source is upstream of the resource", func() {
+			It("returns an empty string", func() {
+				resource.Configs = []v1alpha1.ResourceReference{{Name: "config-ref", Resource: "config-resource"}}
+				Expect(outs.SourceRevision(resource)).To(BeEmpty())
+			})
+		})
+	})
 })
@@ -0,0 +1,89 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// OutputOverrides returns the values the workload's override-output annotations set for the
+// outputs of the resource, keyed by output name.
+func OutputOverrides(workload *v1alpha1.Workload, resourceName string) map[string]string {
+	prefix := v1alpha1.WorkloadOverrideOutputAnnotationPrefix + resourceName + "."
+
+	var overrides map[string]string
+	for key, value := range workload.Annotations {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if overrides == nil {
+			overrides = map[string]string{}
+		}
+		overrides[strings.TrimPrefix(key, prefix)] = value
+	}
+
+	return overrides
+}
+
+// overrideOutput returns a copy of the output with the overridden values substituted.
+// The output may be nil when it could not be retrieved from the stamped object.
+func overrideOutput(output *templates.Output, overrides map[string]string) (*templates.Output, error) {
+	overridden := &templates.Output{}
+	if output != nil {
+		*overridden = *output
+		if output.Source != nil {
+			source := *output.Source
+			overridden.Source = &source
+		}
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	source := func() *templates.Source {
+		if overridden.Source == nil {
+			overridden.Source = &templates.Source{}
+		}
+		return overridden.Source
+	}
+
+	for _, name := range names {
+		value := overrides[name]
+		switch name {
+		case "url":
+			source().URL = value
+		case "revision":
+			source().Revision = value
+		case "digest":
+			source().Digest = value
+		case "image":
+			overridden.Image = value
+		case "config":
+			overridden.Config = value
+		default:
+			return nil, fmt.Errorf("annotation overrides unknown output [%s], must be one of url, revision, digest, image or config", name)
+		}
+	}
+
+	return overridden, nil
+}
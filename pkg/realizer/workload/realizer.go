@@ -18,17 +18,43 @@ package workload
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
 //counterfeiter:generate . Realizer
 type Realizer interface {
-	Realize(ctx context.Context, resourceRealizer ResourceRealizer, supplyChain *v1alpha1.ClusterSupplyChain) ([]*unstructured.Unstructured, error)
+	Realize(ctx context.Context, resourceRealizer ResourceRealizer, supplyChain *v1alpha1.ClusterSupplyChain) (Result, error)
+}
+
+// Result is what was realized of a supply chain, also when realizing it failed.
+type Result struct {
+	StampedObjects []*unstructured.Unstructured
+	// Skipped are the names of the resources that were not realized because their condition
+	// was not met, because they are optional and their template does not exist, or because
+	// they consume the output of a skipped resource.
+	Skipped []string
+	// Durations holds, by resource name, the time spent stamping each realized resource and
+	// retrieving its output.
+	Durations map[string]time.Duration
+	// OutputHashes holds, by resource name, the OutputHash of each output that was read.
+	OutputHashes map[string]string
+	// RealizationOrder lists the names of the resources in the order they were realized,
+	// each after the resources whose outputs it consumes.
+	RealizationOrder []string
+	// Inputs holds, by resource name, the inputs each realized resource received from the
+	// outputs of the resources it consumes.
+	Inputs map[string]*templates.Inputs
+	// OutputWarnings holds, by resource name, the warnings about the output paths of each
+	// output that was read.
+	OutputWarnings map[string][]string
 }
 
 type realizer struct{}
@@ -37,28 +63,177 @@ func NewRealizer() Realizer {
 	return &realizer{}
 }
 
-func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealizer, supplyChain *v1alpha1.ClusterSupplyChain) ([]*unstructured.Unstructured, error) {
+// Realize stamps the resources of the supply chain in dependency order, returning the stamped objects,
+// the names of the resources that were skipped and how long each resource took to realize.
+// With the collectAll strategy a failing resource does not stop the supply chain: every
+// resource that does not consume its output, directly or through another resource, is still
+// realized and the errors are returned together as ResourceErrors. Once ctx is cancelled no
+// further resource is realized and the context's error is returned.
+func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealizer, supplyChain *v1alpha1.ClusterSupplyChain) (Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("Realize")
 
+	ctx = repository.WithFieldManager(ctx, supplyChain.GetFieldManager())
+
+	collectAll := supplyChain.Spec.RealizeStrategy == v1alpha1.RealizeStrategyCollectAll
+
 	outs := NewOutputs()
-	var stampedObjects []*unstructured.Unstructured
+	result := Result{Durations: map[string]time.Duration{}, OutputHashes: map[string]string{}, Inputs: map[string]*templates.Inputs{}, OutputWarnings: map[string][]string{}}
+	var unrealized []string
+	// pending are the resources whose output is not available yet without anything having
+	// failed, and the resources consuming them
+	var pending []string
+	var resourceErrors ResourceErrors
+
+	order := realizationOrder(supplyChain.Spec.Resources)
+	for _, i := range order {
+		result.RealizationOrder = append(result.RealizationOrder, supplyChain.Spec.Resources[i].Name)
+	}
+
+	for _, i := range order {
+		if err := ctx.Err(); err != nil {
+			log.Info("realize cancelled", "error", err.Error())
+			return result, err
+		}
 
-	for i := range supplyChain.Spec.Resources {
 		resource := supplyChain.Spec.Resources[i]
+
+		if upstream := consumedResource(&resource, result.Skipped); upstream != "" {
+			log.V(logger.DEBUG).Info("skipping resource consuming a skipped resource",
+				"resource", resource.Name, "upstream", upstream)
+			result.Skipped = append(result.Skipped, resource.Name)
+			continue
+		}
+
+		if !resourceRealizer.ConditionMet(ctx, &resource) {
+			log.V(logger.DEBUG).Info("skipping resource whose condition is not met",
+				"resource", resource.Name)
+			result.Skipped = append(result.Skipped, resource.Name)
+			continue
+		}
+
+		if upstream := consumedResource(&resource, unrealized); upstream != "" {
+			log.V(logger.DEBUG).Info("not realizing resource consuming a failed resource",
+				"resource", resource.Name, "upstream", upstream)
+			unrealized = append(unrealized, resource.Name)
+			resourceErrors.Blocked = append(resourceErrors.Blocked, resource.Name)
+			continue
+		}
+
+		if upstream := consumedResource(&resource, pending); upstream != "" {
+			log.V(logger.DEBUG).Info("not realizing resource waiting on the output of an upstream resource",
+				"resource", resource.Name, "upstream", upstream)
+			pending = append(pending, resource.Name)
+			resourceErrors.Waiting = append(resourceErrors.Waiting, UpstreamOutputNotAvailableError{
+				Resource:         &supplyChain.Spec.Resources[i],
+				UpstreamResource: upstream,
+			})
+			continue
+		}
+
+		result.Inputs[resource.Name] = outs.GenerateInputs(&resource)
+
+		start := time.Now()
 		stampedObject, out, err := resourceRealizer.Do(ctx, &resource, supplyChain.Name, outs)
+		result.Durations[resource.Name] = time.Since(start)
+		log.V(logger.DEBUG).Info("realized resource",
+			"resource", resource.Name, "duration", result.Durations[resource.Name])
 		if stampedObject != nil {
 			log.V(logger.DEBUG).Info("realized resource as object",
 				"object", stampedObject)
-			stampedObjects = append(stampedObjects, stampedObject)
+			result.StampedObjects = append(result.StampedObjects, stampedObject)
+		}
+		if resource.Optional && isTemplateNotFound(err) {
+			log.Info("skipping optional resource whose template does not exist",
+				"resource", resource.Name, "template", resource.TemplateRef)
+			result.Skipped = append(result.Skipped, resource.Name)
+			continue
 		}
 		if err != nil {
 			log.Error(err, "failed to realize resource")
-			return stampedObjects, err
+			if !collectAll {
+				if OutputPending(err) {
+					if waiting := waitingResource(ctx, resourceRealizer, supplyChain, order, resource.Name, result.Skipped); waiting != nil {
+						return result, UpstreamOutputNotAvailableError{
+							Resource:         waiting,
+							UpstreamResource: resource.Name,
+							Err:              err,
+						}
+					}
+				}
+				return result, err
+			}
+			if OutputPending(err) {
+				pending = append(pending, resource.Name)
+			} else {
+				unrealized = append(unrealized, resource.Name)
+			}
+			resourceErrors.Errors = append(resourceErrors.Errors, ResourceError{
+				Err:          err,
+				ResourceName: resource.Name,
+			})
+			continue
 		}
 
 		outs.AddOutput(resource.Name, out)
+		result.OutputHashes[resource.Name] = OutputHash(out)
+		if out != nil && len(out.Warnings) > 0 {
+			result.OutputWarnings[resource.Name] = out.Warnings
+		}
+	}
+
+	if len(resourceErrors.Errors) > 0 {
+		return result, resourceErrors
+	}
+
+	return result, nil
+}
+
+func isTemplateNotFound(err error) bool {
+	getTemplateErr, ok := err.(GetClusterTemplateError)
+	return ok && repository.IsTemplateNotFound(getTemplateErr.Err)
+}
+
+// OutputPending tells an error reading an output the stamped object has not produced yet,
+// which its controller is expected to produce, from a failure of the resource.
+func OutputPending(err error) bool {
+	switch typedErr := err.(type) {
+	case StampedObjectNotObservedError:
+		return true
+	case RetrieveOutputError:
+		switch typedErr.Err.(type) {
+		case templates.JsonPathError, templates.ReadinessGatesNotSatisfiedError:
+			return true
+		}
+	}
+	return false
+}
+
+// waitingResource returns the first resource, in realization order, that consumes the output
+// of the upstream resource and whose condition is met, or nil if there is none.
+func waitingResource(ctx context.Context, resourceRealizer ResourceRealizer, supplyChain *v1alpha1.ClusterSupplyChain, order []int, upstream string, skipped []string) *v1alpha1.SupplyChainResource {
+	for _, i := range order {
+		resource := &supplyChain.Spec.Resources[i]
+		if consumedResource(resource, []string{upstream}) == "" || consumedResource(resource, skipped) != "" {
+			continue
+		}
+		if resourceRealizer.ConditionMet(ctx, resource) {
+			return resource
+		}
+	}
+	return nil
+}
+
+// consumedResource returns the first of the given resource names that the resource
+// consumes an output from, or an empty string if it consumes none of them.
+func consumedResource(resource *v1alpha1.SupplyChainResource, names []string) string {
+	for _, reference := range resourceReferences(resource) {
+		for _, name := range names {
+			if reference.Resource == name {
+				return name
+			}
+		}
 	}
 
-	return stampedObjects, nil
+	return ""
 }
@@ -17,6 +17,7 @@ package workload_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,6 +27,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	realizer "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/workload/workloadfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
@@ -41,6 +43,7 @@ var _ = Describe("Realize", func() {
 		rlzr = realizer.NewRealizer()
 
 		resourceRealizer = &workloadfakes.FakeResourceRealizer{}
+		resourceRealizer.ConditionMetReturns(true)
 		resource1 = v1alpha1.SupplyChainResource{
 			Name: "resource1",
 		}
@@ -77,18 +80,464 @@ var _ = Describe("Realize", func() {
 			return &unstructured.Unstructured{}, &templates.Output{}, nil
 		})
 
-		stampedObjects, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect(executedResourceOrder).To(Equal([]string{"resource1", "resource2"}))
 
-		Expect(stampedObjects).To(HaveLen(2))
+		Expect(result.StampedObjects).To(HaveLen(2))
+		Expect(result.Skipped).To(BeEmpty())
+	})
+
+	Context("when the context is cancelled while a resource is realized", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			resourceRealizer.DoCalls(func(context.Context, *v1alpha1.SupplyChainResource, string, realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+				cancel()
+				return &unstructured.Unstructured{}, &templates.Output{}, nil
+			})
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("realizes no further resource and returns the context's error", func() {
+			result, err := rlzr.Realize(ctx, resourceRealizer, supplyChain)
+			Expect(err).To(MatchError(context.Canceled))
+
+			Expect(resourceRealizer.DoCallCount()).To(Equal(1))
+			Expect(result.StampedObjects).To(HaveLen(1))
+		})
+	})
+
+	Context("when resources are listed before the resources whose outputs they consume", func() {
+		BeforeEach(func() {
+			ref := func(resource string) []v1alpha1.ResourceReference {
+				return []v1alpha1.ResourceReference{{Name: resource + "-output", Resource: resource}}
+			}
+			// deploy <- config <- image <- source, listed out of order, with an independent lint
+			supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{
+				{Name: "deploy", Configs: ref("config")},
+				{Name: "image", Sources: ref("source")},
+				{Name: "lint"},
+				{Name: "config", Images: ref("image")},
+				{Name: "source"},
+			}
+			resourceRealizer.DoReturns(&unstructured.Unstructured{}, &templates.Output{}, nil)
+		})
+
+		It("realizes each resource after its upstream resources, otherwise keeping supply chain order", func() {
+			result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+			Expect(err).ToNot(HaveOccurred())
+
+			var executedResourceOrder []string
+			for i := 0; i < resourceRealizer.DoCallCount(); i++ {
+				_, resource, _, _ := resourceRealizer.DoArgsForCall(i)
+				executedResourceOrder = append(executedResourceOrder, resource.Name)
+			}
+			Expect(executedResourceOrder).To(Equal([]string{"lint", "source", "image", "config", "deploy"}))
+			Expect(result.RealizationOrder).To(Equal(executedResourceOrder))
+		})
+
+		Context("and resources consume each other's outputs", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[4].Configs = []v1alpha1.ResourceReference{{Name: "deploy-output", Resource: "deploy"}}
+			})
+
+			It("realizes the resources in the cycle last, in supply chain order", func() {
+				result, _ := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+				Expect(result.RealizationOrder).To(Equal([]string{"lint", "deploy", "image", "config", "source"}))
+			})
+		})
+	})
+
+	It("records the inputs each resource received from the outputs of upstream resources", func() {
+		supplyChain.Spec.Resources[1].Images = []v1alpha1.ResourceReference{{Name: "built-image", Resource: "resource1"}}
+		resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+			if resource.Name == "resource1" {
+				return &unstructured.Unstructured{}, &templates.Output{Image: "my-image@sha256:abc"}, nil
+			}
+			return &unstructured.Unstructured{}, &templates.Output{}, nil
+		})
+
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Inputs).To(HaveLen(2))
+		Expect(result.Inputs["resource1"].Images).To(BeEmpty())
+		Expect(result.Inputs["resource2"].Images).To(Equal(map[string]templates.ImageInput{
+			"built-image": {Image: "my-image@sha256:abc", Name: "built-image"},
+		}))
+	})
+
+	It("records the supply chain order when the resources are listed in dependency order", func() {
+		resourceRealizer.DoReturns(&unstructured.Unstructured{}, &templates.Output{}, nil)
+
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RealizationOrder).To(Equal([]string{"resource1", "resource2"}))
+	})
+
+	It("returns how long each realized resource took", func() {
+		resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+			time.Sleep(time.Millisecond)
+			return &unstructured.Unstructured{}, &templates.Output{}, nil
+		})
+
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(result.Durations).To(HaveLen(2))
+		Expect(result.Durations).To(HaveKeyWithValue("resource1", BeNumerically(">=", time.Millisecond)))
+		Expect(result.Durations).To(HaveKeyWithValue("resource2", BeNumerically(">=", time.Millisecond)))
+	})
+
+	It("returns a hash of each output that was read", func() {
+		resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+			return &unstructured.Unstructured{}, &templates.Output{Image: resource.Name + "-image"}, nil
+		})
+
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(result.OutputHashes).To(Equal(map[string]string{
+			"resource1": realizer.OutputHash(&templates.Output{Image: "resource1-image"}),
+			"resource2": realizer.OutputHash(&templates.Output{Image: "resource2-image"}),
+		}))
+		Expect(result.OutputHashes["resource1"]).NotTo(Equal(result.OutputHashes["resource2"]))
+	})
+
+	It("returns the output warnings of each resource that has any", func() {
+		resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+			if resource.Name == "resource1" {
+				return &unstructured.Unstructured{}, &templates.Output{Warnings: []string{"some warning"}}, nil
+			}
+			return &unstructured.Unstructured{}, &templates.Output{}, nil
+		})
+
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(result.OutputWarnings).To(Equal(map[string][]string{"resource1": {"some warning"}}))
+	})
+
+	It("realizes the resources as the field manager of the supply chain", func() {
+		resourceRealizer.DoReturns(&unstructured.Unstructured{}, &templates.Output{}, nil)
+
+		_, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+		ctx, _, _, _ := resourceRealizer.DoArgsForCall(0)
+		Expect(repository.FieldManagerFromContext(ctx)).To(Equal(v1alpha1.DefaultFieldManager))
+
+		supplyChain.Spec.FieldManager = "cartographer-staging"
+		_, err = rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		Expect(err).ToNot(HaveOccurred())
+		ctx, _, _, _ = resourceRealizer.DoArgsForCall(2)
+		Expect(repository.FieldManagerFromContext(ctx)).To(Equal("cartographer-staging"))
 	})
 
 	It("returns any error encountered realizing a resource", func() {
 		resourceRealizer.DoReturns(nil, nil, errors.New("realizing is hard"))
-		stampedObjects, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+		result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
 		Expect(err).To(MatchError("realizing is hard"))
-		Expect(stampedObjects).To(HaveLen(0))
+		Expect(result.StampedObjects).To(HaveLen(0))
+	})
+
+	Context("when the supply chain collects every error", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.RealizeStrategy = v1alpha1.RealizeStrategyCollectAll
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+				if resource.Name == "resource1" {
+					return nil, nil, errors.New("realizing is hard")
+				}
+				return &unstructured.Unstructured{}, &templates.Output{}, nil
+			})
+		})
+
+		It("realizes the resources independent of the failed one and returns every error", func() {
+			result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+
+			Expect(resourceRealizer.DoCallCount()).To(Equal(2))
+			Expect(result.StampedObjects).To(HaveLen(1))
+			Expect(err).To(Equal(realizer.ResourceErrors{
+				Errors: []realizer.ResourceError{
+					{Err: errors.New("realizing is hard"), ResourceName: "resource1"},
+				},
+			}))
+		})
+
+		Context("and a resource consumes the output of the failed one", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].Images = []v1alpha1.ResourceReference{
+					{Name: "image", Resource: "resource1"},
+				}
+				supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, v1alpha1.SupplyChainResource{
+					Name:    "resource3",
+					Configs: []v1alpha1.ResourceReference{{Name: "config", Resource: "resource2"}},
+				}, v1alpha1.SupplyChainResource{
+					Name: "resource4",
+				})
+			})
+
+			It("does not realize the resources downstream of the failed one", func() {
+				result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+
+				Expect(resourceRealizer.DoCallCount()).To(Equal(2))
+				_, lastRealized, _, _ := resourceRealizer.DoArgsForCall(1)
+				Expect(lastRealized.Name).To(Equal("resource4"))
+				Expect(result.StampedObjects).To(HaveLen(1))
+
+				Expect(err).To(BeAssignableToTypeOf(realizer.ResourceErrors{}))
+				Expect(err.(realizer.ResourceErrors).Blocked).To(Equal([]string{"resource2", "resource3"}))
+			})
+		})
+
+		Context("and a resource consumes the output of one that has not produced it yet", func() {
+			var pendingOutput realizer.RetrieveOutputError
+
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].Images = []v1alpha1.ResourceReference{
+					{Name: "image", Resource: "resource1"},
+				}
+				supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, v1alpha1.SupplyChainResource{
+					Name:    "resource3",
+					Configs: []v1alpha1.ResourceReference{{Name: "config", Resource: "resource2"}},
+				})
+
+				stampedObject := &unstructured.Unstructured{}
+				pendingOutput = realizer.RetrieveOutputError{
+					Err:           templates.NewJsonPathError("status.latestImage", errors.New("not found")),
+					Resource:      &supplyChain.Spec.Resources[0],
+					StampedObject: stampedObject,
+				}
+				resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+					if resource.Name == "resource1" {
+						return stampedObject, nil, pendingOutput
+					}
+					return &unstructured.Unstructured{}, &templates.Output{}, nil
+				})
+			})
+
+			It("reports the downstream resources as waiting on the upstream resource", func() {
+				result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+
+				Expect(resourceRealizer.DoCallCount()).To(Equal(1))
+				Expect(result.StampedObjects).To(HaveLen(1))
+
+				Expect(err).To(BeAssignableToTypeOf(realizer.ResourceErrors{}))
+				resourceErrors := err.(realizer.ResourceErrors)
+				Expect(resourceErrors.Errors).To(Equal([]realizer.ResourceError{{Err: pendingOutput, ResourceName: "resource1"}}))
+				Expect(resourceErrors.Blocked).To(BeEmpty())
+				Expect(resourceErrors.Waiting).To(HaveLen(2))
+				Expect(resourceErrors.Waiting[0].Resource.Name).To(Equal("resource2"))
+				Expect(resourceErrors.Waiting[0].UpstreamResource).To(Equal("resource1"))
+				Expect(resourceErrors.Waiting[0]).To(MatchError("resource [resource2] is waiting on output from upstream resource [resource1]"))
+				Expect(resourceErrors.Waiting[1].Resource.Name).To(Equal("resource3"))
+				Expect(resourceErrors.Waiting[1].UpstreamResource).To(Equal("resource2"))
+			})
+
+			Context("and also the output of a resource that failed", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.Resources = append([]v1alpha1.SupplyChainResource{{Name: "resource0"}}, supplyChain.Spec.Resources...)
+					supplyChain.Spec.Resources[2].Sources = []v1alpha1.ResourceReference{{Name: "source", Resource: "resource0"}}
+
+					resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+						switch resource.Name {
+						case "resource0":
+							return nil, nil, errors.New("realizing is hard")
+						case "resource1":
+							return &unstructured.Unstructured{}, nil, pendingOutput
+						}
+						return &unstructured.Unstructured{}, &templates.Output{}, nil
+					})
+				})
+
+				It("reports the resource as blocked by the failure", func() {
+					_, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+
+					resourceErrors := err.(realizer.ResourceErrors)
+					Expect(resourceErrors.Blocked).To(Equal([]string{"resource2", "resource3"}))
+					Expect(resourceErrors.Waiting).To(BeEmpty())
+				})
+			})
+
+			Context("and the supply chain stops at the first error", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.RealizeStrategy = ""
+				})
+
+				It("reports the first downstream resource as waiting on the upstream resource without realizing it", func() {
+					result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+					Expect(resourceRealizer.DoCallCount()).To(Equal(1))
+					Expect(result.StampedObjects).To(HaveLen(1))
+
+					Expect(err).To(BeAssignableToTypeOf(realizer.UpstreamOutputNotAvailableError{}))
+					waitingErr := err.(realizer.UpstreamOutputNotAvailableError)
+					Expect(waitingErr.Resource.Name).To(Equal("resource2"))
+					Expect(waitingErr.UpstreamResource).To(Equal("resource1"))
+					Expect(waitingErr.Err).To(Equal(pendingOutput))
+					Expect(err).To(MatchError(HavePrefix("resource [resource2] is waiting on output from upstream resource [resource1]: ")))
+				})
+
+				Context("and the condition of the downstream resource is not met", func() {
+					BeforeEach(func() {
+						resourceRealizer.ConditionMetCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource) bool {
+							return resource.Name != "resource2"
+						})
+					})
+
+					It("returns the error of the upstream resource", func() {
+						_, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+						Expect(err).To(Equal(pendingOutput))
+						Expect(resourceRealizer.DoCallCount()).To(Equal(1))
+					})
+				})
+			})
+		})
+
+		Context("and no resource fails", func() {
+			BeforeEach(func() {
+				resourceRealizer.DoReturns(&unstructured.Unstructured{}, &templates.Output{}, nil)
+				resourceRealizer.DoCalls(nil)
+			})
+
+			It("does not return an error", func() {
+				result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.StampedObjects).To(HaveLen(2))
+			})
+		})
+	})
+
+	Context("when a resource's condition is not met", func() {
+		var resource3 v1alpha1.SupplyChainResource
+
+		BeforeEach(func() {
+			resource3 = v1alpha1.SupplyChainResource{
+				Name: "resource3",
+			}
+			supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, resource3)
+
+			resourceRealizer.ConditionMetCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource) bool {
+				return resource.Name != "resource1"
+			})
+			resourceRealizer.DoReturns(&unstructured.Unstructured{}, &templates.Output{}, nil)
+		})
+
+		It("skips the resource and realizes the included ones", func() {
+			result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(result.Skipped).To(Equal([]string{"resource1"}))
+			Expect(result.StampedObjects).To(HaveLen(2))
+
+			Expect(resourceRealizer.DoCallCount()).To(Equal(2))
+			_, firstRealized, _, _ := resourceRealizer.DoArgsForCall(0)
+			Expect(firstRealized.Name).To(Equal("resource2"))
+			_, secondRealized, _, _ := resourceRealizer.DoArgsForCall(1)
+			Expect(secondRealized.Name).To(Equal("resource3"))
+		})
+
+		Context("and another resource consumes its output", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].Images = []v1alpha1.ResourceReference{
+					{Name: "image", Resource: "resource1"},
+				}
+				supplyChain.Spec.Resources[2].Configs = []v1alpha1.ResourceReference{
+					{Name: "config", Resource: "resource2"},
+				}
+			})
+
+			It("skips every resource downstream of the skipped resource", func() {
+				result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(result.Skipped).To(Equal([]string{"resource1", "resource2", "resource3"}))
+				Expect(result.StampedObjects).To(BeEmpty())
+				Expect(resourceRealizer.DoCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when a resource's template does not exist", func() {
+		var resource3 v1alpha1.SupplyChainResource
+
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[0].TemplateRef = v1alpha1.ClusterTemplateReference{Kind: "ClusterImageTemplate", Name: "new-template"}
+			resource3 = v1alpha1.SupplyChainResource{
+				Name: "resource3",
+			}
+			supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, resource3)
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource *v1alpha1.SupplyChainResource, supplyChainName string, outputs realizer.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
+				if resource.Name == "resource1" {
+					return nil, nil, realizer.GetClusterTemplateError{
+						Err:         repository.TemplateNotFoundError{Kind: "ClusterImageTemplate", Name: "new-template"},
+						TemplateRef: resource.TemplateRef,
+					}
+				}
+				return &unstructured.Unstructured{}, &templates.Output{}, nil
+			})
+		})
+
+		It("returns the error when the resource is not optional", func() {
+			_, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+			Expect(err).To(BeAssignableToTypeOf(realizer.GetClusterTemplateError{}))
+			Expect(resourceRealizer.DoCallCount()).To(Equal(1))
+		})
+
+		Context("and the resource is optional", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[0].Optional = true
+			})
+
+			It("skips the resource and realizes the others", func() {
+				result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Skipped).To(Equal([]string{"resource1"}))
+				Expect(result.StampedObjects).To(HaveLen(2))
+				Expect(resourceRealizer.DoCallCount()).To(Equal(3))
+			})
+
+			Context("and another resource consumes its output", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.Resources[1].Images = []v1alpha1.ResourceReference{
+						{Name: "image", Resource: "resource1"},
+					}
+				})
+
+				It("skips the resources downstream of the optional resource", func() {
+					result, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(result.Skipped).To(Equal([]string{"resource1", "resource2"}))
+					Expect(result.StampedObjects).To(HaveLen(1))
+					_, lastRealized, _, _ := resourceRealizer.DoArgsForCall(1)
+					Expect(lastRealized.Name).To(Equal("resource3"))
+				})
+			})
+
+			Context("and getting the template fails for another reason", func() {
+				BeforeEach(func() {
+					resourceRealizer.DoCalls(nil)
+					resourceRealizer.DoReturns(nil, nil, realizer.GetClusterTemplateError{
+						Err:         errors.New("api server unavailable"),
+						TemplateRef: supplyChain.Spec.Resources[0].TemplateRef,
+					})
+				})
+
+				It("returns the error", func() {
+					_, err := rlzr.Realize(context.TODO(), resourceRealizer, supplyChain)
+					Expect(err).To(MatchError(ContainSubstring("api server unavailable")))
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,63 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+// stampedObjectSchemaErrors validates the stamped object against the schema its CustomResourceDefinition
+// declares for the object's version. Built-in kinds and versions without a schema are not validated.
+func stampedObjectSchemaErrors(ctx context.Context, repo repository.Repository, stampedObject *unstructured.Unstructured) (field.ErrorList, error) {
+	gvk := stampedObject.GroupVersionKind()
+
+	crd, err := repo.GetCustomResourceDefinition(ctx, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("get custom resource definition: %w", err)
+	}
+	if crd == nil {
+		return nil, nil
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Name != gvk.Version || version.Schema == nil {
+			continue
+		}
+
+		internalValidation := &apiextensions.CustomResourceValidation{}
+		err = apiextensionsv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(version.Schema, internalValidation, nil)
+		if err != nil {
+			return nil, fmt.Errorf("convert schema of [%s]: %w", crd.Name, err)
+		}
+
+		validator, _, err := validation.NewSchemaValidator(internalValidation)
+		if err != nil {
+			return nil, fmt.Errorf("build schema validator for [%s]: %w", crd.Name, err)
+		}
+
+		return validation.ValidateCustomResource(nil, stampedObject.UnstructuredContent(), validator), nil
+	}
+
+	return nil, nil
+}
@@ -7,11 +7,10 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type FakeRealizer struct {
-	RealizeStub        func(context.Context, workload.ResourceRealizer, *v1alpha1.ClusterSupplyChain) ([]*unstructured.Unstructured, error)
+	RealizeStub        func(context.Context, workload.ResourceRealizer, *v1alpha1.ClusterSupplyChain) (workload.Result, error)
 	realizeMutex       sync.RWMutex
 	realizeArgsForCall []struct {
 		arg1 context.Context
@@ -19,18 +18,18 @@ type FakeRealizer struct {
 		arg3 *v1alpha1.ClusterSupplyChain
 	}
 	realizeReturns struct {
-		result1 []*unstructured.Unstructured
+		result1 workload.Result
 		result2 error
 	}
 	realizeReturnsOnCall map[int]struct {
-		result1 []*unstructured.Unstructured
+		result1 workload.Result
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 workload.ResourceRealizer, arg3 *v1alpha1.ClusterSupplyChain) ([]*unstructured.Unstructured, error) {
+func (fake *FakeRealizer) Realize(arg1 context.Context, arg2 workload.ResourceRealizer, arg3 *v1alpha1.ClusterSupplyChain) (workload.Result, error) {
 	fake.realizeMutex.Lock()
 	ret, specificReturn := fake.realizeReturnsOnCall[len(fake.realizeArgsForCall)]
 	fake.realizeArgsForCall = append(fake.realizeArgsForCall, struct {
@@ -57,7 +56,7 @@ func (fake *FakeRealizer) RealizeCallCount() int {
 	return len(fake.realizeArgsForCall)
 }
 
-func (fake *FakeRealizer) RealizeCalls(stub func(context.Context, workload.ResourceRealizer, *v1alpha1.ClusterSupplyChain) ([]*unstructured.Unstructured, error)) {
+func (fake *FakeRealizer) RealizeCalls(stub func(context.Context, workload.ResourceRealizer, *v1alpha1.ClusterSupplyChain) (workload.Result, error)) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = stub
@@ -70,28 +69,28 @@ func (fake *FakeRealizer) RealizeArgsForCall(i int) (context.Context, workload.R
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRealizer) RealizeReturns(result1 []*unstructured.Unstructured, result2 error) {
+func (fake *FakeRealizer) RealizeReturns(result1 workload.Result, result2 error) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = nil
 	fake.realizeReturns = struct {
-		result1 []*unstructured.Unstructured
+		result1 workload.Result
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 []*unstructured.Unstructured, result2 error) {
+func (fake *FakeRealizer) RealizeReturnsOnCall(i int, result1 workload.Result, result2 error) {
 	fake.realizeMutex.Lock()
 	defer fake.realizeMutex.Unlock()
 	fake.RealizeStub = nil
 	if fake.realizeReturnsOnCall == nil {
 		fake.realizeReturnsOnCall = make(map[int]struct {
-			result1 []*unstructured.Unstructured
+			result1 workload.Result
 			result2 error
 		})
 	}
 	fake.realizeReturnsOnCall[i] = struct {
-		result1 []*unstructured.Unstructured
+		result1 workload.Result
 		result2 error
 	}{result1, result2}
 }
@@ -12,6 +12,18 @@ import (
 )
 
 type FakeResourceRealizer struct {
+	ConditionMetStub        func(context.Context, *v1alpha1.SupplyChainResource) bool
+	conditionMetMutex       sync.RWMutex
+	conditionMetArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.SupplyChainResource
+	}
+	conditionMetReturns struct {
+		result1 bool
+	}
+	conditionMetReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	DoStub        func(context.Context, *v1alpha1.SupplyChainResource, string, workload.Outputs) (*unstructured.Unstructured, *templates.Output, error)
 	doMutex       sync.RWMutex
 	doArgsForCall []struct {
@@ -30,10 +42,82 @@ type FakeResourceRealizer struct {
 		result2 *templates.Output
 		result3 error
 	}
+	ParamResolutionsStub        func() []v1alpha1.ParamResolution
+	paramResolutionsMutex       sync.RWMutex
+	paramResolutionsArgsForCall []struct {
+	}
+	paramResolutionsReturns struct {
+		result1 []v1alpha1.ParamResolution
+	}
+	paramResolutionsReturnsOnCall map[int]struct {
+		result1 []v1alpha1.ParamResolution
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeResourceRealizer) ConditionMet(arg1 context.Context, arg2 *v1alpha1.SupplyChainResource) bool {
+	fake.conditionMetMutex.Lock()
+	ret, specificReturn := fake.conditionMetReturnsOnCall[len(fake.conditionMetArgsForCall)]
+	fake.conditionMetArgsForCall = append(fake.conditionMetArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.SupplyChainResource
+	}{arg1, arg2})
+	stub := fake.ConditionMetStub
+	fakeReturns := fake.conditionMetReturns
+	fake.recordInvocation("ConditionMet", []interface{}{arg1, arg2})
+	fake.conditionMetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceRealizer) ConditionMetCallCount() int {
+	fake.conditionMetMutex.RLock()
+	defer fake.conditionMetMutex.RUnlock()
+	return len(fake.conditionMetArgsForCall)
+}
+
+func (fake *FakeResourceRealizer) ConditionMetCalls(stub func(context.Context, *v1alpha1.SupplyChainResource) bool) {
+	fake.conditionMetMutex.Lock()
+	defer fake.conditionMetMutex.Unlock()
+	fake.ConditionMetStub = stub
+}
+
+func (fake *FakeResourceRealizer) ConditionMetArgsForCall(i int) (context.Context, *v1alpha1.SupplyChainResource) {
+	fake.conditionMetMutex.RLock()
+	defer fake.conditionMetMutex.RUnlock()
+	argsForCall := fake.conditionMetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceRealizer) ConditionMetReturns(result1 bool) {
+	fake.conditionMetMutex.Lock()
+	defer fake.conditionMetMutex.Unlock()
+	fake.ConditionMetStub = nil
+	fake.conditionMetReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceRealizer) ConditionMetReturnsOnCall(i int, result1 bool) {
+	fake.conditionMetMutex.Lock()
+	defer fake.conditionMetMutex.Unlock()
+	fake.ConditionMetStub = nil
+	if fake.conditionMetReturnsOnCall == nil {
+		fake.conditionMetReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.conditionMetReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeResourceRealizer) Do(arg1 context.Context, arg2 *v1alpha1.SupplyChainResource, arg3 string, arg4 workload.Outputs) (*unstructured.Unstructured, *templates.Output, error) {
 	fake.doMutex.Lock()
 	ret, specificReturn := fake.doReturnsOnCall[len(fake.doArgsForCall)]
@@ -104,11 +188,68 @@ func (fake *FakeResourceRealizer) DoReturnsOnCall(i int, result1 *unstructured.U
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceRealizer) ParamResolutions() []v1alpha1.ParamResolution {
+	fake.paramResolutionsMutex.Lock()
+	ret, specificReturn := fake.paramResolutionsReturnsOnCall[len(fake.paramResolutionsArgsForCall)]
+	fake.paramResolutionsArgsForCall = append(fake.paramResolutionsArgsForCall, struct {
+	}{})
+	stub := fake.ParamResolutionsStub
+	fakeReturns := fake.paramResolutionsReturns
+	fake.recordInvocation("ParamResolutions", []interface{}{})
+	fake.paramResolutionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceRealizer) ParamResolutionsCallCount() int {
+	fake.paramResolutionsMutex.RLock()
+	defer fake.paramResolutionsMutex.RUnlock()
+	return len(fake.paramResolutionsArgsForCall)
+}
+
+func (fake *FakeResourceRealizer) ParamResolutionsCalls(stub func() []v1alpha1.ParamResolution) {
+	fake.paramResolutionsMutex.Lock()
+	defer fake.paramResolutionsMutex.Unlock()
+	fake.ParamResolutionsStub = stub
+}
+
+func (fake *FakeResourceRealizer) ParamResolutionsReturns(result1 []v1alpha1.ParamResolution) {
+	fake.paramResolutionsMutex.Lock()
+	defer fake.paramResolutionsMutex.Unlock()
+	fake.ParamResolutionsStub = nil
+	fake.paramResolutionsReturns = struct {
+		result1 []v1alpha1.ParamResolution
+	}{result1}
+}
+
+func (fake *FakeResourceRealizer) ParamResolutionsReturnsOnCall(i int, result1 []v1alpha1.ParamResolution) {
+	fake.paramResolutionsMutex.Lock()
+	defer fake.paramResolutionsMutex.Unlock()
+	fake.ParamResolutionsStub = nil
+	if fake.paramResolutionsReturnsOnCall == nil {
+		fake.paramResolutionsReturnsOnCall = make(map[int]struct {
+			result1 []v1alpha1.ParamResolution
+		})
+	}
+	fake.paramResolutionsReturnsOnCall[i] = struct {
+		result1 []v1alpha1.ParamResolution
+	}{result1}
+}
+
 func (fake *FakeResourceRealizer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.conditionMetMutex.RLock()
+	defer fake.conditionMetMutex.RUnlock()
 	fake.doMutex.RLock()
 	defer fake.doMutex.RUnlock()
+	fake.paramResolutionsMutex.RLock()
+	defer fake.paramResolutionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,60 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterRoleCache keeps the set of cluster roles Compute returns until it is invalidated, so
+// that a cluster role change does not list every workload, supply chain and binding again.
+// Invalidate it on changes to any object the set is computed from.
+type ClusterRoleCache struct {
+	Compute func() (map[string]bool, error)
+
+	mu    sync.Mutex
+	roles map[string]bool
+}
+
+// ClusterRoles returns the cached set, computing it first when there is none. A failure to
+// compute the set is not cached.
+func (c *ClusterRoleCache) ClusterRoles() (map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.roles != nil {
+		return c.roles, nil
+	}
+
+	roles, err := c.Compute()
+	if err != nil {
+		return nil, err
+	}
+	c.roles = roles
+	return roles, nil
+}
+
+// Invalidate drops the cached set. It is a map function that enqueues no requests, to watch
+// the objects the set is computed from with.
+func (c *ClusterRoleCache) Invalidate(_ client.Object) []reconcile.Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.roles = nil
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+)
+
+var _ = Describe("ClusterRoleCache", func() {
+	var (
+		cache        *registrar.ClusterRoleCache
+		computeCalls int
+		computeErr   error
+	)
+
+	BeforeEach(func() {
+		computeCalls = 0
+		computeErr = nil
+		cache = &registrar.ClusterRoleCache{
+			Compute: func() (map[string]bool, error) {
+				computeCalls++
+				if computeErr != nil {
+					return nil, computeErr
+				}
+				return map[string]bool{"some-role": true}, nil
+			},
+		}
+	})
+
+	It("computes the cluster roles once", func() {
+		roles, err := cache.ClusterRoles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roles).To(Equal(map[string]bool{"some-role": true}))
+
+		roles, err = cache.ClusterRoles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roles).To(Equal(map[string]bool{"some-role": true}))
+		Expect(computeCalls).To(Equal(1))
+	})
+
+	It("computes the cluster roles again once invalidated, without enqueueing requests", func() {
+		_, _ = cache.ClusterRoles()
+
+		Expect(cache.Invalidate(&rbacv1.RoleBinding{})).To(BeEmpty())
+
+		_, _ = cache.ClusterRoles()
+		Expect(computeCalls).To(Equal(2))
+	})
+
+	It("caches an empty set", func() {
+		cache.Compute = func() (map[string]bool, error) {
+			computeCalls++
+			return map[string]bool{}, nil
+		}
+
+		_, _ = cache.ClusterRoles()
+		_, _ = cache.ClusterRoles()
+		Expect(computeCalls).To(Equal(1))
+	})
+
+	Context("the cluster roles cannot be computed", func() {
+		BeforeEach(func() {
+			computeErr = fmt.Errorf("some error")
+		})
+
+		It("returns the error without caching it", func() {
+			_, err := cache.ClusterRoles()
+			Expect(err).To(MatchError("some error"))
+
+			computeErr = nil
+			roles, err := cache.ClusterRoles()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(Equal(map[string]bool{"some-role": true}))
+			Expect(computeCalls).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,247 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+const redactedValue = "<redacted>"
+
+// WorkloadExplanation is a read-only report of how a workload's supply chain was selected
+// and how far its resources have been realized.
+type WorkloadExplanation struct {
+	Workload types.NamespacedName `json:"workload"`
+	// SupplyChain is the name of the selected supply chain, empty when none was selected.
+	SupplyChain string `json:"supplyChain,omitempty"`
+	// Pinned reports that the supply chain was selected by the carto.run/supply-chain annotation.
+	Pinned bool `json:"pinned,omitempty"`
+	// Candidates are the supply chains whose selectors best match the workload's labels.
+	Candidates []string `json:"candidates,omitempty"`
+	// NotSelectedReason says why no supply chain was selected.
+	NotSelectedReason string                `json:"notSelectedReason,omitempty"`
+	Conditions        []metav1.Condition    `json:"conditions,omitempty"`
+	Resources         []ResourceExplanation `json:"resources,omitempty"`
+}
+
+type ResourceExplanation struct {
+	Name        string                            `json:"name"`
+	TemplateRef v1alpha1.ClusterTemplateReference `json:"templateRef"`
+	// Phase and Condition are read from the workload's status summary.
+	Phase     v1alpha1.ResourcePhase `json:"phase,omitempty"`
+	Condition *metav1.Condition      `json:"condition,omitempty"`
+	// StampedObjects are the objects on the cluster labelled as stamped for the resource.
+	StampedObjects []v1alpha1.ObjectReference `json:"stampedObjects,omitempty"`
+	// Output is read from the stamped object the way the realizer reads it.
+	Output      *templates.Output `json:"output,omitempty"`
+	OutputError string            `json:"outputError,omitempty"`
+}
+
+// ExplainWorkload reports the supply chain selected for the workload, using the same rules
+// as the workload reconciler, and the stamped objects and current outputs of each of its
+// resources. It only reads from the api server.
+func ExplainWorkload(ctx context.Context, c client.Client, nn types.NamespacedName) (*WorkloadExplanation, error) {
+	repo := repository.NewRepository(c, repository.NewCache(logr.FromContextOrDiscard(ctx)))
+
+	workload, err := repo.GetWorkload(ctx, nn.Name, nn.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("get workload: %w", err)
+	}
+	if workload == nil {
+		return nil, fmt.Errorf("workload [%s] not found", nn)
+	}
+
+	explanation := &WorkloadExplanation{
+		Workload:   nn,
+		Conditions: workload.Status.Conditions,
+	}
+
+	supplyChain, err := explainSelection(ctx, repo, workload, explanation)
+	if err != nil {
+		return nil, err
+	}
+	if supplyChain == nil {
+		return explanation, nil
+	}
+
+	explanation.SupplyChain = supplyChain.Name
+	for i := range supplyChain.Spec.Resources {
+		resource, err := explainResource(ctx, repo, workload, &supplyChain.Spec.Resources[i])
+		if err != nil {
+			return nil, err
+		}
+		explanation.Resources = append(explanation.Resources, resource)
+	}
+
+	return explanation, nil
+}
+
+func explainSelection(ctx context.Context, repo repository.Repository, workload *v1alpha1.Workload, explanation *WorkloadExplanation) (*v1alpha1.ClusterSupplyChain, error) {
+	if len(workload.Labels) == 0 {
+		explanation.NotSelectedReason = "workload is missing required labels"
+		return nil, nil
+	}
+
+	if pinnedName, ok := workload.Annotations[v1alpha1.WorkloadSupplyChainAnnotation]; ok {
+		explanation.Pinned = true
+		explanation.Candidates = []string{pinnedName}
+
+		supplyChain, err := repo.GetSupplyChain(ctx, pinnedName)
+		if err != nil {
+			return nil, fmt.Errorf("get pinned supply chain [%s]: %w", pinnedName, err)
+		}
+		if supplyChain == nil {
+			explanation.NotSelectedReason = fmt.Sprintf("pinned supply chain [%s] not found", pinnedName)
+			return nil, nil
+		}
+		if !repository.SelectorSatisfied(workload, supplyChain) {
+			explanation.NotSelectedReason = fmt.Sprintf("selector of pinned supply chain [%s] is not satisfied by labels: %v", pinnedName, workload.Labels)
+			return nil, nil
+		}
+		return supplyChain, nil
+	}
+
+	supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+	if err != nil {
+		return nil, fmt.Errorf("get supply chains for workload: %w", err)
+	}
+
+	for _, supplyChain := range supplyChains {
+		explanation.Candidates = append(explanation.Candidates, supplyChain.Name)
+	}
+	sort.Strings(explanation.Candidates)
+
+	switch len(supplyChains) {
+	case 0:
+		explanation.NotSelectedReason = fmt.Sprintf("no supply chain found where full selector is satisfied by labels: %v", workload.Labels)
+		return nil, nil
+	case 1:
+		return supplyChains[0], nil
+	default:
+		explanation.NotSelectedReason = fmt.Sprintf("more than one supply chain selected for workload: %v", explanation.Candidates)
+		return nil, nil
+	}
+}
+
+func explainResource(ctx context.Context, repo repository.Repository, workload *v1alpha1.Workload, resource *v1alpha1.SupplyChainResource) (ResourceExplanation, error) {
+	explanation := ResourceExplanation{
+		Name:        resource.Name,
+		TemplateRef: resource.TemplateRef,
+	}
+
+	if workload.Status.Summary != nil {
+		for _, summary := range workload.Status.Summary.Resources {
+			if summary.Name == resource.Name {
+				explanation.Phase = summary.Phase
+				explanation.Condition = summary.Condition
+			}
+		}
+	}
+
+	apiTemplate, err := repo.GetClusterTemplate(ctx, resource.TemplateRef)
+	if err != nil {
+		return explanation, fmt.Errorf("get template for resource [%s]: %w", resource.Name, err)
+	}
+
+	template, err := templates.NewModelFromAPI(apiTemplate, repo)
+	if err != nil {
+		return explanation, fmt.Errorf("get template for resource [%s]: %w", resource.Name, err)
+	}
+
+	query := stampedObjectQuery(template.GetResourceTemplate())
+	if query == nil {
+		// ytt templates do not declare the kind they stamp until they are evaluated
+		return explanation, nil
+	}
+	query.SetNamespace(workload.Namespace)
+	query.SetLabels(map[string]string{
+		"carto.run/workload-name":      workload.Name,
+		"carto.run/workload-namespace": workload.Namespace,
+		"carto.run/resource-name":      resource.Name,
+	})
+
+	stampedObjects, err := repo.ListUnstructured(ctx, query)
+	if err != nil {
+		return explanation, fmt.Errorf("list stamped objects for resource [%s]: %w", resource.Name, err)
+	}
+
+	for _, stampedObject := range stampedObjects {
+		explanation.StampedObjects = append(explanation.StampedObjects, v1alpha1.ObjectReference{
+			Kind:       stampedObject.GetKind(),
+			Namespace:  stampedObject.GetNamespace(),
+			Name:       stampedObject.GetName(),
+			APIVersion: stampedObject.GetAPIVersion(),
+			UID:        stampedObject.GetUID(),
+		})
+	}
+
+	if len(stampedObjects) == 1 {
+		template.SetStampedObject(stampedObjects[0])
+		explanation.Output, err = template.GetOutput(ctx)
+		if err != nil {
+			explanation.OutputError = err.Error()
+		}
+		if explanation.Output != nil && explanation.Output.Sensitive {
+			explanation.Output = redactedOutput(explanation.Output)
+		}
+	}
+
+	return explanation, nil
+}
+
+// redactedOutput replaces each value of a sensitive output, so that the explanation does not
+// reveal what was read from a Secret.
+func redactedOutput(output *templates.Output) *templates.Output {
+	redacted := &templates.Output{Sensitive: true}
+	if output.Source != nil {
+		redacted.Source = &templates.Source{URL: redactedValue, Revision: redactedValue}
+	}
+	if output.Image != nil {
+		redacted.Image = redactedValue
+	}
+	if output.Config != nil {
+		redacted.Config = redactedValue
+	}
+	return redacted
+}
+
+func stampedObjectQuery(spec v1alpha1.TemplateSpec) *unstructured.Unstructured {
+	if spec.Template == nil {
+		return nil
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(spec.Template.Raw, &typeMeta); err != nil || typeMeta.Kind == "" {
+		return nil
+	}
+
+	query := &unstructured.Unstructured{}
+	query.SetAPIVersion(typeMeta.APIVersion)
+	query.SetKind(typeMeta.Kind)
+	return query
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+)
+
+var _ = Describe("ExplainWorkload", func() {
+	var (
+		ctx           context.Context
+		fakeClient    client.Client
+		clientObjects []client.Object
+		workload      *v1alpha1.Workload
+		nn            types.NamespacedName
+		explanation   *registrar.WorkloadExplanation
+		err           error
+	)
+
+	supplyChain := func(name string, selector map[string]string) *v1alpha1.ClusterSupplyChain {
+		return &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.SupplyChainSpec{
+				Selector: selector,
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name:        "image-builder",
+						TemplateRef: v1alpha1.ClusterTemplateReference{Kind: "ClusterImageTemplate", Name: "image-template"},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		nn = types.NamespacedName{Namespace: "my-ns", Name: "my-workload"}
+
+		workload = &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+				Labels:    map[string]string{"app": "web"},
+			},
+			Status: v1alpha1.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1alpha1.WorkloadReady, Status: metav1.ConditionTrue, Reason: "Ready"},
+				},
+				Summary: &v1alpha1.WorkloadSummary{
+					Ready: metav1.ConditionTrue,
+					Resources: []v1alpha1.ResourceSummary{
+						{Name: "image-builder", Phase: v1alpha1.ResourcePhaseOutputAvailable},
+					},
+				},
+			},
+		}
+
+		imageTemplate := &v1alpha1.ClusterImageTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "image-template"},
+			Spec: v1alpha1.ImageTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"$(workload.metadata.name)$"}}`)},
+				},
+				ImagePath: ".data.image",
+			},
+		}
+
+		stampedObject := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-workload",
+				Namespace: "my-ns",
+				Labels: map[string]string{
+					"carto.run/workload-name":      "my-workload",
+					"carto.run/workload-namespace": "my-ns",
+					"carto.run/resource-name":      "image-builder",
+				},
+			},
+			Data: map[string]string{"image": "my-registry/my-image@sha256:abc"},
+		}
+
+		clientObjects = []client.Object{
+			imageTemplate,
+			stampedObject,
+			supplyChain("my-supply-chain", map[string]string{"app": "web"}),
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(append(clientObjects, workload)...).
+			Build()
+
+		explanation, err = registrar.ExplainWorkload(ctx, fakeClient, nn)
+	})
+
+	Context("a fully realized workload", func() {
+		It("reports the selected supply chain", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.Workload).To(Equal(nn))
+			Expect(explanation.SupplyChain).To(Equal("my-supply-chain"))
+			Expect(explanation.Candidates).To(Equal([]string{"my-supply-chain"}))
+			Expect(explanation.NotSelectedReason).To(BeEmpty())
+			Expect(explanation.Conditions).To(HaveLen(1))
+		})
+
+		It("reports the stamped objects, phase and output of each resource", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.Resources).To(HaveLen(1))
+
+			resource := explanation.Resources[0]
+			Expect(resource.Name).To(Equal("image-builder"))
+			Expect(resource.TemplateRef.Name).To(Equal("image-template"))
+			Expect(resource.Phase).To(Equal(v1alpha1.ResourcePhaseOutputAvailable))
+			Expect(resource.StampedObjects).To(Equal([]v1alpha1.ObjectReference{
+				{Kind: "ConfigMap", Namespace: "my-ns", Name: "my-workload", APIVersion: "v1"},
+			}))
+			Expect(resource.Output.Image).To(Equal("my-registry/my-image@sha256:abc"))
+			Expect(resource.OutputError).To(BeEmpty())
+		})
+
+		It("does not modify the workload", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			current := &v1alpha1.Workload{}
+			Expect(fakeClient.Get(ctx, nn, current)).To(Succeed())
+			Expect(current.ResourceVersion).To(Equal(workload.ResourceVersion))
+			Expect(current.Status).To(Equal(workload.Status))
+		})
+	})
+
+	Context("a resource whose output is read from a Secret", func() {
+		BeforeEach(func() {
+			imageTemplate := clientObjects[0].(*v1alpha1.ClusterImageTemplate)
+			imageTemplate.Spec.ImagePath = ""
+			imageTemplate.Spec.OutputFrom = &v1alpha1.OutputFrom{
+				Kind:         v1alpha1.OutputFromSecret,
+				NameFromPath: ".data.results",
+				Key:          "image",
+			}
+
+			stampedObject := clientObjects[1].(*corev1.ConfigMap)
+			stampedObject.Data = map[string]string{"results": "my-results"}
+
+			clientObjects = append(clientObjects, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-results", Namespace: "my-ns"},
+				Data:       map[string][]byte{"image": []byte("my-registry/my-image@sha256:abc")},
+			})
+		})
+
+		It("redacts the output", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.Resources).To(HaveLen(1))
+			Expect(explanation.Resources[0].OutputError).To(BeEmpty())
+			Expect(explanation.Resources[0].Output.Sensitive).To(BeTrue())
+			Expect(explanation.Resources[0].Output.Image).To(Equal("<redacted>"))
+		})
+	})
+
+	Context("a workload no supply chain selects", func() {
+		BeforeEach(func() {
+			workload.Labels = map[string]string{"app": "worker"}
+		})
+
+		It("reports why no supply chain was selected", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.SupplyChain).To(BeEmpty())
+			Expect(explanation.Candidates).To(BeEmpty())
+			Expect(explanation.NotSelectedReason).To(Equal("no supply chain found where full selector is satisfied by labels: map[app:worker]"))
+			Expect(explanation.Resources).To(BeEmpty())
+		})
+	})
+
+	Context("a workload more than one supply chain selects", func() {
+		BeforeEach(func() {
+			clientObjects = append(clientObjects, supplyChain("other-supply-chain", map[string]string{"app": "web"}))
+		})
+
+		It("reports every candidate", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.SupplyChain).To(BeEmpty())
+			Expect(explanation.Candidates).To(Equal([]string{"my-supply-chain", "other-supply-chain"}))
+			Expect(explanation.NotSelectedReason).To(ContainSubstring("more than one supply chain selected"))
+		})
+	})
+
+	Context("a workload pinned to a supply chain that does not exist", func() {
+		BeforeEach(func() {
+			workload.Annotations = map[string]string{v1alpha1.WorkloadSupplyChainAnnotation: "missing-supply-chain"}
+		})
+
+		It("reports the pinned supply chain was not found", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.Pinned).To(BeTrue())
+			Expect(explanation.SupplyChain).To(BeEmpty())
+			Expect(explanation.NotSelectedReason).To(Equal("pinned supply chain [missing-supply-chain] not found"))
+		})
+	})
+
+	Context("the workload does not exist", func() {
+		JustBeforeEach(func() {
+			explanation, err = registrar.ExplainWorkload(ctx, fakeClient, types.NamespacedName{Namespace: "my-ns", Name: "missing"})
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("workload [my-ns/missing] not found"))
+			Expect(explanation).To(BeNil())
+		})
+	})
+})
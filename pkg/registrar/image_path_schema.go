@@ -0,0 +1,155 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+const ImagePathSchemaWebhookPath = "/warn-carto-run-v1alpha1-clusterimagetemplate"
+
+// ImagePathSchemaWarner admits every image template, warning when the template's imagePath
+// reads a field the schema of the stamped kind does not declare. Such a path can never match,
+// so the supply chain would only fail once a workload stamps the template.
+type ImagePathSchemaWarner struct {
+	Repo    repository.Repository
+	decoder *admission.Decoder
+}
+
+func (w *ImagePathSchemaWarner) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+func (w *ImagePathSchemaWarner) Handle(ctx context.Context, req admission.Request) admission.Response {
+	template := &v1alpha1.ClusterImageTemplate{}
+	if err := w.decoder.Decode(req, template); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	warnings, err := ImagePathSchemaWarnings(ctx, w.Repo, template)
+	if err != nil {
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("unable to check imagePath against the schema of the stamped kind: %s", err))
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// ImagePathSchemaWarnings returns a warning when the imagePath of the template reads a field
+// that is absent from the CustomResourceDefinition schema of the kind the template stamps.
+// Templates of built-in kinds, ytt templates, templates whose outputs are not jsonpaths and
+// paths the schema leaves open, such as those below preserved unknown fields, are not checked.
+func ImagePathSchemaWarnings(ctx context.Context, repo repository.Repository, template *v1alpha1.ClusterImageTemplate) ([]string, error) {
+	spec := template.Spec
+	if spec.Template == nil || spec.ImagePath == "" || spec.OutputFrom != nil || spec.OutputSubresource == "scale" {
+		return nil, nil
+	}
+	if spec.OutputLanguage != "" && spec.OutputLanguage != v1alpha1.OutputLanguageJsonPath {
+		return nil, nil
+	}
+
+	stamped := &unstructured.Unstructured{}
+	if err := json.Unmarshal(spec.Template.Raw, &stamped.Object); err != nil {
+		return nil, fmt.Errorf("unmarshal template: %w", err)
+	}
+	gvk := stamped.GroupVersionKind()
+	if gvk.Kind == "" || gvk.Version == "" {
+		return nil, nil
+	}
+
+	crd, err := repo.GetCustomResourceDefinition(ctx, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("get custom resource definition: %w", err)
+	}
+	if crd == nil {
+		return nil, nil
+	}
+
+	var schema *apiextensionsv1.JSONSchemaProps
+	for _, version := range crd.Spec.Versions {
+		if version.Name == gvk.Version && version.Schema != nil {
+			schema = version.Schema.OpenAPIV3Schema
+		}
+	}
+	if schema == nil {
+		return nil, nil
+	}
+
+	parser, err := eval.ParseJsonPath(spec.ImagePath)
+	if err != nil {
+		return nil, fmt.Errorf("parse imagePath [%s]: %w", spec.ImagePath, err)
+	}
+
+	missing := missingSchemaField(parser.Root.Nodes, schema, nil)
+	if missing == nil {
+		return nil, nil
+	}
+
+	return []string{
+		fmt.Sprintf("imagePath [%s] of image template [%s] reads field [%s], which the schema of [%s] does not declare", spec.ImagePath, template.Name, strings.Join(missing, "."), gvk),
+	}, nil
+}
+
+// missingSchemaField walks the fields of the path through the schema and returns the path up
+// to the first field the schema does not declare, nil when every field is declared or the
+// schema cannot tell.
+func missingSchemaField(nodes []jsonpath.Node, schema *apiextensionsv1.JSONSchemaProps, seen []string) []string {
+	for _, node := range nodes {
+		if schema == nil || schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields {
+			return nil
+		}
+
+		switch n := node.(type) {
+		case *jsonpath.ListNode:
+			return missingSchemaField(n.Nodes, schema, seen)
+		case *jsonpath.FieldNode:
+			if n.Value == "" {
+				continue
+			}
+			seen = append(seen, n.Value)
+			if len(schema.Properties) == 0 {
+				return nil
+			}
+			property, ok := schema.Properties[n.Value]
+			if !ok {
+				return seen
+			}
+			schema = &property
+		case *jsonpath.ArrayNode, *jsonpath.FilterNode:
+			if schema.Items == nil {
+				return nil
+			}
+			schema = schema.Items.Schema
+		default:
+			// wildcards, recursive descent and unions may read any field
+			return nil
+		}
+	}
+
+	return nil
+}
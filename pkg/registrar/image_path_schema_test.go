@@ -0,0 +1,201 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("ImagePathSchemaWarner", func() {
+	var (
+		repo      *repositoryfakes.FakeRepository
+		template  *v1alpha1.ClusterImageTemplate
+		imagePath string
+		response  admission.Response
+	)
+
+	imageSchema := func(statusProperties map[string]apiextensionsv1.JSONSchemaProps) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "images.kpack.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{
+						Name: "v1alpha2",
+						Schema: &apiextensionsv1.CustomResourceValidation{
+							OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"metadata": {Type: "object"},
+									"status":   {Type: "object", Properties: statusProperties},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		repo = &repositoryfakes.FakeRepository{}
+		imagePath = ".status.latestImage"
+	})
+
+	JustBeforeEach(func() {
+		template = &v1alpha1.ClusterImageTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "carto.run/v1alpha1", Kind: "ClusterImageTemplate"},
+			ObjectMeta: metav1.ObjectMeta{Name: "image-template"},
+			Spec: v1alpha1.ImageTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "kpack.io/v1alpha2", "kind": "Image", "metadata": {"name": "$(workload.metadata.name)$"}}`)},
+				},
+				ImagePath: imagePath,
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		decoder, err := admission.NewDecoder(scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		warner := &registrar.ImagePathSchemaWarner{Repo: repo}
+		Expect(warner.InjectDecoder(decoder)).To(Succeed())
+
+		raw, err := json.Marshal(template)
+		Expect(err).NotTo(HaveOccurred())
+
+		response = warner.Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	})
+
+	Context("the schema of the stamped kind declares the field", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(imageSchema(map[string]apiextensionsv1.JSONSchemaProps{
+				"latestImage": {Type: "string"},
+			}), nil)
+		})
+
+		It("admits the template without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+
+		It("looks up the schema of the stamped kind", func() {
+			Expect(repo.GetCustomResourceDefinitionCallCount()).To(Equal(1))
+			_, gvk := repo.GetCustomResourceDefinitionArgsForCall(0)
+			Expect(gvk).To(Equal(schema.GroupVersionKind{Group: "kpack.io", Version: "v1alpha2", Kind: "Image"}))
+		})
+	})
+
+	Context("the schema of the stamped kind does not declare the field", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(imageSchema(map[string]apiextensionsv1.JSONSchemaProps{
+				"conditions": {Type: "array"},
+			}), nil)
+		})
+
+		It("admits the template with a warning", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				"imagePath [.status.latestImage] of image template [image-template] reads field [status.latestImage], which the schema of [kpack.io/v1alpha2, Kind=Image] does not declare",
+			))
+		})
+	})
+
+	Context("the path indexes into a list of the schema", func() {
+		BeforeEach(func() {
+			imagePath = `.status.images[?(@.name=="app")].digest`
+			repo.GetCustomResourceDefinitionReturns(imageSchema(map[string]apiextensionsv1.JSONSchemaProps{
+				"images": {
+					Type: "array",
+					Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"name":  {Type: "string"},
+							"image": {Type: "string"},
+						},
+					}},
+				},
+			}), nil)
+		})
+
+		It("checks the fields of the list items", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				`imagePath [.status.images[?(@.name=="app")].digest] of image template [image-template] reads field [status.images.digest], which the schema of [kpack.io/v1alpha2, Kind=Image] does not declare`,
+			))
+		})
+	})
+
+	Context("the schema preserves unknown fields", func() {
+		BeforeEach(func() {
+			preserve := true
+			crd := imageSchema(nil)
+			status := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["status"]
+			status.XPreserveUnknownFields = &preserve
+			status.Properties = map[string]apiextensionsv1.JSONSchemaProps{"conditions": {Type: "array"}}
+			crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["status"] = status
+			repo.GetCustomResourceDefinitionReturns(crd, nil)
+		})
+
+		It("admits the template without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+	})
+
+	Context("the stamped kind is built in", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(nil, nil)
+		})
+
+		It("admits the template without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+	})
+
+	Context("the schema cannot be read", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(nil, errors.New("no mapping"))
+		})
+
+		It("admits the template, warning that it could not be checked", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				"unable to check imagePath against the schema of the stamped kind: get custom resource definition: no mapping",
+			))
+		})
+	})
+})
@@ -28,6 +28,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+	"github.com/vmware-tanzu/cartographer/pkg/controller/workload"
+	realizerrunnable "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 )
 
@@ -35,13 +38,49 @@ import (
 
 //counterfeiter:generate . Logger
 type Logger interface {
+	Info(msg string, keysAndValues ...interface{})
 	Error(err error, msg string, keysAndValues ...interface{})
 }
 
 type Mapper struct {
 	Client client.Client
-	// fixme We should accept the context, not the logger - then we get the right logger and so does the client
+	// Context is passed to the client, and stops mapping once it is cancelled. Defaults to a
+	// context that is never cancelled.
+	Context context.Context
+	// fixme We should accept the context, not the logger - then we get the right logger
 	Logger Logger
+	// PolicyObjects are the admission policy objects whose changes reconcile every owner again
+	PolicyObjects []PolicyObjectReference
+	// Namespace, when set, is the only namespace whose workloads, deliverables and runnables are mapped
+	Namespace string
+	// ClusterRoles, when set, returns the only cluster roles whose changes are mapped to workloads.
+	// It is called on each cluster role change and must follow the current bindings, either by
+	// computing the set each time or by caching it in a ClusterRoleCache that is invalidated.
+	ClusterRoles func() (map[string]bool, error)
+	// Pause is the pause config map whose changes reconcile every owner again
+	Pause controller.Pause
+}
+
+// ctx is the mapper's Context, or a context that is never cancelled when it has none
+func (mapper *Mapper) ctx() context.Context {
+	if mapper.Context == nil {
+		return context.TODO()
+	}
+	return mapper.Context
+}
+
+// cancelled reports whether the mapper's context is cancelled, as it is while the manager
+// shuts down. Mapping stops then, as the requests would not be reconciled.
+func (mapper *Mapper) cancelled() bool {
+	return mapper.ctx().Err() != nil
+}
+
+// inScope restricts a list of workloads, deliverables or runnables to the mapper's namespace
+func (mapper *Mapper) inScope(opts ...client.ListOption) []client.ListOption {
+	if mapper.Namespace == "" {
+		return opts
+	}
+	return append(opts, client.InNamespace(mapper.Namespace))
 }
 
 func (mapper *Mapper) TemplateToDeliverableRequests(template client.Object) []reconcile.Request {
@@ -49,6 +88,9 @@ func (mapper *Mapper) TemplateToDeliverableRequests(template client.Object) []re
 
 	var requests []reconcile.Request
 	for _, delivery := range deliveries {
+		if mapper.cancelled() {
+			return nil
+		}
 		reqs := mapper.ClusterDeliveryToDeliverableRequests(&delivery)
 		requests = append(requests, reqs...)
 	}
@@ -61,6 +103,9 @@ func (mapper *Mapper) TemplateToWorkloadRequests(template client.Object) []recon
 
 	var requests []reconcile.Request
 	for _, supplyChain := range supplyChains {
+		if mapper.cancelled() {
+			return nil
+		}
 		reqs := mapper.ClusterSupplyChainToWorkloadRequests(&supplyChain)
 		requests = append(requests, reqs...)
 	}
@@ -80,7 +125,7 @@ func (mapper *Mapper) templateToSupplyChains(template client.Object) []v1alpha1.
 	list := &v1alpha1.ClusterSupplyChainList{}
 
 	err = mapper.Client.List(
-		context.TODO(),
+		mapper.ctx(),
 		list,
 	)
 
@@ -94,7 +139,7 @@ func (mapper *Mapper) templateToSupplyChains(template client.Object) []v1alpha1.
 	var supplyChains []v1alpha1.ClusterSupplyChain
 	for _, sc := range list.Items {
 		for _, res := range sc.Spec.Resources {
-			if res.TemplateRef.Kind == templateKind && res.TemplateRef.Name == templateName {
+			if referencesTemplate(res, templateKind, templateName) {
 				supplyChains = append(supplyChains, sc)
 			}
 		}
@@ -102,6 +147,10 @@ func (mapper *Mapper) templateToSupplyChains(template client.Object) []v1alpha1.
 	return supplyChains
 }
 
+func referencesTemplate(resource v1alpha1.SupplyChainResource, kind, name string) bool {
+	return resource.TemplateRef.Kind == kind && resource.TemplateRef.Name == name
+}
+
 func (mapper *Mapper) ClusterSupplyChainToWorkloadRequests(object client.Object) []reconcile.Request {
 	supplyChain, ok := object.(*v1alpha1.ClusterSupplyChain)
 	if !ok {
@@ -136,22 +185,28 @@ func (mapper *Mapper) clusterSupplyChainToWorkloads(sc v1alpha1.ClusterSupplyCha
 	}
 
 	scList := &v1alpha1.ClusterSupplyChainList{}
-	err = mapper.Client.List(context.TODO(), scList)
+	err = mapper.Client.List(mapper.ctx(), scList)
 	if err != nil {
 		mapper.Logger.Error(err, "cluster supply chain to workloads: client list supply chains")
 		return nil, err
 	}
 
 	var selectorGetters []repository.SelectorGetter
+	listed := false
 	for _, item := range scList.Items {
 		item := item
 		selectorGetters = append(selectorGetters, &item)
+		listed = listed || item.Name == sc.Name
+	}
+	if !listed {
+		// the supply chain was deleted, its workloads are enqueued to find another or report it is gone
+		selectorGetters = append(selectorGetters, &sc)
 	}
 
 	workloadList := &v1alpha1.WorkloadList{}
-	err = mapper.Client.List(context.TODO(), workloadList,
+	err = mapper.Client.List(mapper.ctx(), workloadList, mapper.inScope(
 		client.InNamespace(sc.Namespace),
-		client.MatchingLabels(sc.Spec.Selector))
+		client.MatchingLabels(sc.Spec.Selector))...)
 	if err != nil {
 		mapper.Logger.Error(err, "cluster supply chain to workloads: client list workloads")
 		return nil, err
@@ -159,9 +214,18 @@ func (mapper *Mapper) clusterSupplyChainToWorkloads(sc v1alpha1.ClusterSupplyCha
 
 	var matchingWorkloads []v1alpha1.Workload
 	for _, wl := range workloadList.Items {
+		if pinnedName, ok := wl.Annotations[v1alpha1.WorkloadSupplyChainAnnotation]; ok {
+			if pinnedName == sc.Name {
+				matchingWorkloads = append(matchingWorkloads, wl)
+			}
+			continue
+		}
+
 		for _, matchingObject := range repository.BestLabelMatches(&wl, selectorGetters) {
+			// matched by name, the event's copy of the supply chain, such as the old one of an
+			// update toggling its pause annotation, need not be the copy that was listed
 			matchingSC := matchingObject.(*v1alpha1.ClusterSupplyChain)
-			if reflect.DeepEqual(matchingSC, &sc) {
+			if matchingSC.Name == sc.Name {
 				matchingWorkloads = append(matchingWorkloads, wl)
 			}
 		}
@@ -206,7 +270,7 @@ func (mapper *Mapper) clusterDeliveryToDeliverables(d v1alpha1.ClusterDelivery)
 	}
 
 	deliveryList := &v1alpha1.ClusterDeliveryList{}
-	err = mapper.Client.List(context.TODO(), deliveryList)
+	err = mapper.Client.List(mapper.ctx(), deliveryList)
 	if err != nil {
 		mapper.Logger.Error(err, "cluster delivery to deliverables: client list deliveries")
 		return nil, err
@@ -219,9 +283,9 @@ func (mapper *Mapper) clusterDeliveryToDeliverables(d v1alpha1.ClusterDelivery)
 	}
 
 	deliverableList := &v1alpha1.DeliverableList{}
-	err = mapper.Client.List(context.TODO(), deliverableList,
+	err = mapper.Client.List(mapper.ctx(), deliverableList, mapper.inScope(
 		client.InNamespace(d.Namespace),
-		client.MatchingLabels(d.Spec.Selector))
+		client.MatchingLabels(d.Spec.Selector))...)
 	if err != nil {
 		mapper.Logger.Error(err, "cluster delivery to deliverables: client list deliverables")
 		return nil, err
@@ -240,37 +304,73 @@ func (mapper *Mapper) clusterDeliveryToDeliverables(d v1alpha1.ClusterDelivery)
 	return matchingDeliverables, nil
 }
 
-func (mapper *Mapper) RunTemplateToRunnableRequests(object client.Object) []reconcile.Request {
-	var err error
+// WorkloadToDeliverableRequests maps a workload to the deliverables of its namespace that link
+// it with spec.source.workloadRef.
+func (mapper *Mapper) WorkloadToDeliverableRequests(object client.Object) []reconcile.Request {
+	list := &v1alpha1.DeliverableList{}
+	err := mapper.Client.List(mapper.ctx(), list, mapper.inScope(client.InNamespace(object.GetNamespace()))...)
+	if err != nil {
+		mapper.Logger.Error(err, "workload to deliverable requests: client list")
+		return nil
+	}
 
+	var requests []reconcile.Request
+	for _, deliverable := range list.Items {
+		source := deliverable.Spec.Source
+		if source != nil && source.WorkloadRef != nil && source.WorkloadRef.Name == object.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: deliverable.Namespace, Name: deliverable.Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+func (mapper *Mapper) RunTemplateToRunnableRequests(object client.Object) []reconcile.Request {
 	runTemplate, ok := object.(*v1alpha1.ClusterRunTemplate)
 	if !ok {
 		mapper.Logger.Error(nil, "run template to runnable requests: cast to run template failed")
 		return nil
 	}
 
-	list := &v1alpha1.RunnableList{}
-
-	err = mapper.Client.List(context.TODO(), list)
+	runnables, err := RunnablesUsingTemplate(mapper.ctx(), mapper.Client, runTemplate.Name, mapper.inScope()...)
 	if err != nil {
-		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "run template to runnable requests: client list")
+		mapper.Logger.Error(err, "run template to runnable requests: client list")
 		return nil
 	}
 
 	var requests []reconcile.Request
-	for _, runnable := range list.Items {
+	for _, runnable := range runnables {
+		requests = append(requests, reconcile.Request{NamespacedName: runnable})
+	}
 
-		if runTemplateRefMatch(runnable.Spec.RunTemplateRef, runTemplate) {
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      runnable.Name,
-					Namespace: runnable.Namespace,
-				},
+	return requests
+}
+
+// RunnablesUsingTemplate returns every runnable whose runTemplateRef resolves to the
+// ClusterRunTemplate with the given name, using the same matching rule as the mapper.
+// The options narrow the runnables listed, e.g. to a namespace.
+func RunnablesUsingTemplate(ctx context.Context, c client.Client, templateName string, opts ...client.ListOption) ([]types.NamespacedName, error) {
+	list := &v1alpha1.RunnableList{}
+
+	err := c.List(ctx, list, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("client list: %w", err)
+	}
+
+	var runnables []types.NamespacedName
+	for i := range list.Items {
+		runnable := &list.Items[i]
+		if runnableReferencesRunTemplate(runnable, templateName) {
+			runnables = append(runnables, types.NamespacedName{
+				Name:      runnable.Name,
+				Namespace: runnable.Namespace,
 			})
 		}
 	}
 
-	return requests
+	return runnables, nil
 }
 
 // addGVK fulfills the 'GVK of an object returned from the APIServer
@@ -335,7 +435,7 @@ func (mapper *Mapper) templateToDeliveries(template client.Object) []v1alpha1.Cl
 	list := &v1alpha1.ClusterDeliveryList{}
 
 	err = mapper.Client.List(
-		context.TODO(),
+		mapper.ctx(),
 		list,
 	)
 
@@ -357,18 +457,26 @@ func (mapper *Mapper) templateToDeliveries(template client.Object) []v1alpha1.Cl
 	return deliveries
 }
 
-func runTemplateRefMatch(ref v1alpha1.TemplateReference, runTemplate *v1alpha1.ClusterRunTemplate) bool {
-	if ref.Name != runTemplate.Name {
+func runnableReferencesRunTemplate(runnable *v1alpha1.Runnable, templateName string) bool {
+	ref := runnable.Spec.RunTemplateRef
+	if ref.Kind != "ClusterRunTemplate" {
+		return false
+	}
+
+	// the name expression takes precedence over the name, resolution errors are
+	// reported by the runnable's own reconcile
+	name, err := realizerrunnable.RunTemplateName(runnable)
+	if err != nil {
 		return false
 	}
 
-	return ref.Kind == "ClusterRunTemplate" || ref.Kind == ""
+	return name == templateName
 }
 
 func (mapper *Mapper) ServiceAccountToWorkloadRequests(serviceAccountObject client.Object) []reconcile.Request {
 	list := &v1alpha1.WorkloadList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "service account to workload requests: list workloads")
 		return nil
@@ -423,7 +531,7 @@ func (mapper *Mapper) ServiceAccountToWorkloadRequests(serviceAccountObject clie
 func (mapper *Mapper) serviceAccountToSupplyChains(serviceAccountObject client.Object) []v1alpha1.ClusterSupplyChain {
 	list := &v1alpha1.ClusterSupplyChainList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list)
 	if err != nil {
 		mapper.Logger.Error(err, "service account to supply chains: list supply chains")
 		return nil
@@ -431,9 +539,16 @@ func (mapper *Mapper) serviceAccountToSupplyChains(serviceAccountObject client.O
 
 	var supplyChains []v1alpha1.ClusterSupplyChain
 	for _, sc := range list.Items {
-		if sc.Spec.ServiceAccountRef.Name == serviceAccountObject.GetName() {
-			supplyChains = append(supplyChains, sc)
+		ref := sc.Spec.ServiceAccountRef
+		if ref.Name != serviceAccountObject.GetName() {
+			continue
 		}
+		// without a namespace the service account is read from each workload's namespace,
+		// which is checked against the workloads of the supply chain
+		if ref.Namespace != "" && ref.Namespace != serviceAccountObject.GetNamespace() {
+			continue
+		}
+		supplyChains = append(supplyChains, sc)
 	}
 
 	return supplyChains
@@ -453,7 +568,7 @@ func (mapper *Mapper) RoleBindingToWorkloadRequests(roleBindingObject client.Obj
 				Namespace: subject.Namespace,
 				Name:      subject.Name,
 			}
-			err := mapper.Client.Get(context.TODO(), serviceAccountKey, serviceAccountObject)
+			err := mapper.Client.Get(mapper.ctx(), serviceAccountKey, serviceAccountObject)
 			if err != nil {
 				mapper.Logger.Error(fmt.Errorf("client get: %w", err), "role binding to workload requests: get service account")
 			}
@@ -478,7 +593,7 @@ func (mapper *Mapper) ClusterRoleBindingToWorkloadRequests(clusterRoleBindingObj
 				Namespace: subject.Namespace,
 				Name:      subject.Name,
 			}
-			err := mapper.Client.Get(context.TODO(), serviceAccountKey, serviceAccountObject)
+			err := mapper.Client.Get(mapper.ctx(), serviceAccountKey, serviceAccountObject)
 			if err != nil {
 				mapper.Logger.Error(fmt.Errorf("client get: %w", err), "cluster role binding to workload requests: get service account")
 				return []reconcile.Request{}
@@ -499,7 +614,7 @@ func (mapper *Mapper) RoleToWorkloadRequests(roleObject client.Object) []reconci
 
 	list := &rbacv1.RoleBindingList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "role to workload requests: list role bindings")
 		return nil
@@ -507,6 +622,9 @@ func (mapper *Mapper) RoleToWorkloadRequests(roleObject client.Object) []reconci
 
 	var requests []reconcile.Request
 	for _, roleBinding := range list.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if roleBinding.RoleRef.APIGroup == "" && roleBinding.RoleRef.Kind == "Role" && roleBinding.RoleRef.Name == role.Name && roleBinding.Namespace == role.Namespace {
 			requests = append(requests, mapper.RoleBindingToWorkloadRequests(&roleBinding)...)
 		}
@@ -522,9 +640,20 @@ func (mapper *Mapper) ClusterRoleToWorkloadRequests(clusterRoleObject client.Obj
 		return nil
 	}
 
+	if mapper.ClusterRoles != nil {
+		clusterRoles, err := mapper.ClusterRoles()
+		if err != nil {
+			mapper.Logger.Error(err, "cluster role to workload requests: get cluster roles of interest")
+			return nil
+		}
+		if !clusterRoles[clusterRole.Name] {
+			return nil
+		}
+	}
+
 	clusterRoleBindingList := &rbacv1.ClusterRoleBindingList{}
 
-	err := mapper.Client.List(context.TODO(), clusterRoleBindingList)
+	err := mapper.Client.List(mapper.ctx(), clusterRoleBindingList)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "cluster role to workload requests: list cluster role bindings")
 		return nil
@@ -533,6 +662,9 @@ func (mapper *Mapper) ClusterRoleToWorkloadRequests(clusterRoleObject client.Obj
 	var requests []reconcile.Request
 
 	for _, clusterRoleBinding := range clusterRoleBindingList.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if clusterRoleBinding.RoleRef.APIGroup == "" && clusterRoleBinding.RoleRef.Kind == "ClusterRole" && clusterRoleBinding.RoleRef.Name == clusterRole.Name {
 			requests = append(requests, mapper.ClusterRoleBindingToWorkloadRequests(&clusterRoleBinding)...)
 		}
@@ -540,13 +672,16 @@ func (mapper *Mapper) ClusterRoleToWorkloadRequests(clusterRoleObject client.Obj
 
 	roleBindingList := &rbacv1.RoleBindingList{}
 
-	err = mapper.Client.List(context.TODO(), roleBindingList)
+	err = mapper.Client.List(mapper.ctx(), roleBindingList)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "cluster role role to workload requests: list role bindings")
 		return nil
 	}
 
 	for _, roleBinding := range roleBindingList.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if roleBinding.RoleRef.APIGroup == "" && roleBinding.RoleRef.Kind == "ClusterRole" && roleBinding.RoleRef.Name == clusterRole.Name {
 			requests = append(requests, mapper.RoleBindingToWorkloadRequests(&roleBinding)...)
 		}
@@ -555,10 +690,81 @@ func (mapper *Mapper) ClusterRoleToWorkloadRequests(clusterRoleObject client.Obj
 	return requests
 }
 
+// WorkloadClusterRoles are the cluster roles bound, by a cluster role binding or a role binding,
+// to a service account that some workload's objects are stamped as.
+func (mapper *Mapper) WorkloadClusterRoles() (map[string]bool, error) {
+	workloadList := &v1alpha1.WorkloadList{}
+	if err := mapper.Client.List(mapper.ctx(), workloadList, mapper.inScope()...); err != nil {
+		return nil, fmt.Errorf("list workloads: %w", err)
+	}
+	if len(workloadList.Items) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	supplyChainList := &v1alpha1.ClusterSupplyChainList{}
+	if err := mapper.Client.List(mapper.ctx(), supplyChainList); err != nil {
+		return nil, fmt.Errorf("list supply chains: %w", err)
+	}
+	supplyChains := make(map[string]*v1alpha1.ClusterSupplyChain, len(supplyChainList.Items))
+	for i := range supplyChainList.Items {
+		supplyChains[supplyChainList.Items[i].Name] = &supplyChainList.Items[i]
+	}
+
+	serviceAccounts := make(map[types.NamespacedName]bool)
+	for i := range workloadList.Items {
+		supplyChain, ok := supplyChains[workloadList.Items[i].Status.SupplyChainRef.Name]
+		if !ok {
+			supplyChain = &v1alpha1.ClusterSupplyChain{}
+		}
+		name, namespace := workload.ServiceAccountNameAndNamespace(&workloadList.Items[i], supplyChain)
+		serviceAccounts[types.NamespacedName{Namespace: namespace, Name: name}] = true
+	}
+
+	boundToWorkloads := func(subjects []rbacv1.Subject, bindingNamespace string) bool {
+		for _, subject := range subjects {
+			if subject.APIGroup != "" || subject.Kind != "ServiceAccount" {
+				continue
+			}
+			namespace := subject.Namespace
+			if namespace == "" {
+				namespace = bindingNamespace
+			}
+			if serviceAccounts[types.NamespacedName{Namespace: namespace, Name: subject.Name}] {
+				return true
+			}
+		}
+		return false
+	}
+
+	clusterRoles := make(map[string]bool)
+
+	clusterRoleBindingList := &rbacv1.ClusterRoleBindingList{}
+	if err := mapper.Client.List(mapper.ctx(), clusterRoleBindingList); err != nil {
+		return nil, fmt.Errorf("list cluster role bindings: %w", err)
+	}
+	for _, clusterRoleBinding := range clusterRoleBindingList.Items {
+		if clusterRoleBinding.RoleRef.Kind == "ClusterRole" && boundToWorkloads(clusterRoleBinding.Subjects, "") {
+			clusterRoles[clusterRoleBinding.RoleRef.Name] = true
+		}
+	}
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := mapper.Client.List(mapper.ctx(), roleBindingList); err != nil {
+		return nil, fmt.Errorf("list role bindings: %w", err)
+	}
+	for _, roleBinding := range roleBindingList.Items {
+		if roleBinding.RoleRef.Kind == "ClusterRole" && boundToWorkloads(roleBinding.Subjects, roleBinding.Namespace) {
+			clusterRoles[roleBinding.RoleRef.Name] = true
+		}
+	}
+
+	return clusterRoles, nil
+}
+
 func (mapper *Mapper) ServiceAccountToDeliverableRequests(serviceAccountObject client.Object) []reconcile.Request {
 	list := &v1alpha1.DeliverableList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "service account to deliverable requests: list deliverables")
 		return nil
@@ -612,7 +818,7 @@ func (mapper *Mapper) ServiceAccountToDeliverableRequests(serviceAccountObject c
 func (mapper *Mapper) serviceAccountToDeliveries(serviceAccountObject client.Object) []v1alpha1.ClusterDelivery {
 	list := &v1alpha1.ClusterDeliveryList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "service account to deliveries: list deliveries")
 		return nil
@@ -642,7 +848,7 @@ func (mapper *Mapper) RoleBindingToDeliverableRequests(roleBindingObject client.
 				Namespace: subject.Namespace,
 				Name:      subject.Name,
 			}
-			err := mapper.Client.Get(context.TODO(), serviceAccountKey, serviceAccountObject)
+			err := mapper.Client.Get(mapper.ctx(), serviceAccountKey, serviceAccountObject)
 			if err != nil {
 				mapper.Logger.Error(fmt.Errorf("client get: %w", err), "role binding to deliverable requests: get service account")
 			}
@@ -667,7 +873,7 @@ func (mapper *Mapper) ClusterRoleBindingToDeliverableRequests(clusterRoleBinding
 				Namespace: subject.Namespace,
 				Name:      subject.Name,
 			}
-			err := mapper.Client.Get(context.TODO(), serviceAccountKey, serviceAccountObject)
+			err := mapper.Client.Get(mapper.ctx(), serviceAccountKey, serviceAccountObject)
 			if err != nil {
 				mapper.Logger.Error(fmt.Errorf("client get: %w", err), "cluster role binding to deliverable requests: get service account")
 				return []reconcile.Request{}
@@ -688,7 +894,7 @@ func (mapper *Mapper) RoleToDeliverableRequests(roleObject client.Object) []reco
 
 	list := &rbacv1.RoleBindingList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "role to deliverable requests: list role bindings")
 		return nil
@@ -696,6 +902,9 @@ func (mapper *Mapper) RoleToDeliverableRequests(roleObject client.Object) []reco
 
 	var requests []reconcile.Request
 	for _, roleBinding := range list.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if roleBinding.RoleRef.APIGroup == "" && roleBinding.RoleRef.Kind == "Role" && roleBinding.RoleRef.Name == role.Name && roleBinding.Namespace == role.Namespace {
 			requests = append(requests, mapper.RoleBindingToDeliverableRequests(&roleBinding)...)
 		}
@@ -713,7 +922,7 @@ func (mapper *Mapper) ClusterRoleToDeliverableRequests(clusterRoleObject client.
 
 	clusterRoleBindingList := &rbacv1.ClusterRoleBindingList{}
 
-	err := mapper.Client.List(context.TODO(), clusterRoleBindingList)
+	err := mapper.Client.List(mapper.ctx(), clusterRoleBindingList)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "cluster role to deliverable requests: list cluster role bindings")
 		return nil
@@ -722,6 +931,9 @@ func (mapper *Mapper) ClusterRoleToDeliverableRequests(clusterRoleObject client.
 	var requests []reconcile.Request
 
 	for _, clusterRoleBinding := range clusterRoleBindingList.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if clusterRoleBinding.RoleRef.APIGroup == "" && clusterRoleBinding.RoleRef.Kind == "ClusterRole" && clusterRoleBinding.RoleRef.Name == clusterRole.Name {
 			requests = append(requests, mapper.ClusterRoleBindingToDeliverableRequests(&clusterRoleBinding)...)
 		}
@@ -729,13 +941,16 @@ func (mapper *Mapper) ClusterRoleToDeliverableRequests(clusterRoleObject client.
 
 	roleBindingList := &rbacv1.RoleBindingList{}
 
-	err = mapper.Client.List(context.TODO(), roleBindingList)
+	err = mapper.Client.List(mapper.ctx(), roleBindingList)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "cluster role role to deliverable requests: list role bindings")
 		return nil
 	}
 
 	for _, roleBinding := range roleBindingList.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if roleBinding.RoleRef.APIGroup == "" && roleBinding.RoleRef.Kind == "ClusterRole" && roleBinding.RoleRef.Name == clusterRole.Name {
 			requests = append(requests, mapper.RoleBindingToDeliverableRequests(&roleBinding)...)
 		}
@@ -747,7 +962,7 @@ func (mapper *Mapper) ClusterRoleToDeliverableRequests(clusterRoleObject client.
 func (mapper *Mapper) ServiceAccountToRunnableRequests(serviceAccountObject client.Object) []reconcile.Request {
 	list := &v1alpha1.RunnableList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "service account to runnable requests: list runnables")
 		return nil
@@ -783,7 +998,7 @@ func (mapper *Mapper) RoleBindingToRunnableRequests(roleBindingObject client.Obj
 				Namespace: subject.Namespace,
 				Name:      subject.Name,
 			}
-			err := mapper.Client.Get(context.TODO(), serviceAccountKey, serviceAccountObject)
+			err := mapper.Client.Get(mapper.ctx(), serviceAccountKey, serviceAccountObject)
 			if err != nil {
 				mapper.Logger.Error(fmt.Errorf("client get: %w", err), "role binding to runnable requests: get service account")
 			}
@@ -808,7 +1023,7 @@ func (mapper *Mapper) ClusterRoleBindingToRunnableRequests(clusterRoleBindingObj
 				Namespace: subject.Namespace,
 				Name:      subject.Name,
 			}
-			err := mapper.Client.Get(context.TODO(), serviceAccountKey, serviceAccountObject)
+			err := mapper.Client.Get(mapper.ctx(), serviceAccountKey, serviceAccountObject)
 			if err != nil {
 				mapper.Logger.Error(fmt.Errorf("client get: %w", err), "cluster role binding to runnable requests: get service account")
 				return []reconcile.Request{}
@@ -829,7 +1044,7 @@ func (mapper *Mapper) RoleToRunnableRequests(roleObject client.Object) []reconci
 
 	list := &rbacv1.RoleBindingList{}
 
-	err := mapper.Client.List(context.TODO(), list)
+	err := mapper.Client.List(mapper.ctx(), list)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "role to runnable requests: list role bindings")
 		return nil
@@ -837,6 +1052,9 @@ func (mapper *Mapper) RoleToRunnableRequests(roleObject client.Object) []reconci
 
 	var requests []reconcile.Request
 	for _, roleBinding := range list.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if roleBinding.RoleRef.APIGroup == "" && roleBinding.RoleRef.Kind == "Role" && roleBinding.RoleRef.Name == role.Name && roleBinding.Namespace == role.Namespace {
 			requests = append(requests, mapper.RoleBindingToRunnableRequests(&roleBinding)...)
 		}
@@ -854,7 +1072,7 @@ func (mapper *Mapper) ClusterRoleToRunnableRequests(clusterRoleObject client.Obj
 
 	clusterRoleBindingList := &rbacv1.ClusterRoleBindingList{}
 
-	err := mapper.Client.List(context.TODO(), clusterRoleBindingList)
+	err := mapper.Client.List(mapper.ctx(), clusterRoleBindingList)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "cluster role to runnable requests: list cluster role bindings")
 		return nil
@@ -863,6 +1081,9 @@ func (mapper *Mapper) ClusterRoleToRunnableRequests(clusterRoleObject client.Obj
 	var requests []reconcile.Request
 
 	for _, clusterRoleBinding := range clusterRoleBindingList.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if clusterRoleBinding.RoleRef.APIGroup == "" && clusterRoleBinding.RoleRef.Kind == "ClusterRole" && clusterRoleBinding.RoleRef.Name == clusterRole.Name {
 			requests = append(requests, mapper.ClusterRoleBindingToRunnableRequests(&clusterRoleBinding)...)
 		}
@@ -870,13 +1091,16 @@ func (mapper *Mapper) ClusterRoleToRunnableRequests(clusterRoleObject client.Obj
 
 	roleBindingList := &rbacv1.RoleBindingList{}
 
-	err = mapper.Client.List(context.TODO(), roleBindingList)
+	err = mapper.Client.List(mapper.ctx(), roleBindingList)
 	if err != nil {
 		mapper.Logger.Error(fmt.Errorf("client list: %w", err), "cluster role role to runnable requests: list role bindings")
 		return nil
 	}
 
 	for _, roleBinding := range roleBindingList.Items {
+		if mapper.cancelled() {
+			return nil
+		}
 		if roleBinding.RoleRef.APIGroup == "" && roleBinding.RoleRef.Kind == "ClusterRole" && roleBinding.RoleRef.Name == clusterRole.Name {
 			requests = append(requests, mapper.RoleBindingToRunnableRequests(&roleBinding)...)
 		}
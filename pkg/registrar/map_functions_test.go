@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -346,6 +347,31 @@ var _ = Describe("MapFunctions", func() {
 						})
 					})
 
+					Describe("The mapper's context is cancelled", func() {
+						var ctx context.Context
+
+						BeforeEach(func() {
+							var cancel context.CancelFunc
+							ctx, cancel = context.WithCancel(context.Background())
+							cancel()
+							m.Context = ctx
+						})
+
+						It("lists with the mapper's context and returns no requests", func() {
+							t := &v1alpha1.ClusterTemplate{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "my-template",
+								},
+							}
+							reqs := m.TemplateToWorkloadRequests(t)
+
+							Expect(reqs).To(BeEmpty())
+							Expect(fakeClient.ListCallCount()).To(Equal(1))
+							listCtx, _, _ := fakeClient.ListArgsForCall(0)
+							Expect(listCtx).To(Equal(ctx))
+						})
+					})
+
 					Describe("The template does not reference a supply chain", func() {
 						It("returns an empty request list", func() {
 							t := &v1alpha1.ClusterTemplate{
@@ -497,7 +523,7 @@ var _ = Describe("MapFunctions", func() {
 					It("returns a list of requests that includes the workload", func() {
 						expected := []reconcile.Request{
 							{
-								types.NamespacedName{
+								NamespacedName: types.NamespacedName{
 									Namespace: "first-namespace",
 									Name:      "first-workload",
 								},
@@ -525,8 +551,100 @@ var _ = Describe("MapFunctions", func() {
 						It("returns an empty list of requests", func() {
 							Expect(result).To(BeEmpty())
 						})
+
+						Context("but the workload pins the supply chain by annotation", func() {
+							BeforeEach(func() {
+								workload.Annotations = map[string]string{
+									v1alpha1.WorkloadSupplyChainAnnotation: "mySupplyChain",
+								}
+							})
+							It("returns a list of requests that includes the workload", func() {
+								Expect(result).To(ConsistOf(reconcile.Request{
+									NamespacedName: types.NamespacedName{
+										Namespace: "first-namespace",
+										Name:      "first-workload",
+									},
+								}))
+							})
+						})
+					})
+
+					Context("the supply chain selects workloads by owner", func() {
+						BeforeEach(func() {
+							clusterSupplyChain.(*v1alpha1.ClusterSupplyChain).Spec.OwnerSelector = &v1alpha1.OwnerSelector{
+								OwnerKind:     "App",
+								OwnerAPIGroup: "apps.example.com",
+							}
+						})
+
+						Context("and the workload is owned by an object of that kind", func() {
+							BeforeEach(func() {
+								workload.OwnerReferences = []metav1.OwnerReference{
+									{APIVersion: "v1", Kind: "ConfigMap", Name: "my-config", UID: "uid-1"},
+									{APIVersion: "apps.example.com/v1", Kind: "App", Name: "my-app", UID: "uid-2"},
+								}
+							})
+
+							It("returns a list of requests that includes the workload", func() {
+								Expect(result).To(ConsistOf(reconcile.Request{
+									NamespacedName: types.NamespacedName{
+										Namespace: "first-namespace",
+										Name:      "first-workload",
+									},
+								}))
+							})
+						})
+
+						Context("and the workload is owned by an object of another group", func() {
+							BeforeEach(func() {
+								workload.OwnerReferences = []metav1.OwnerReference{
+									{APIVersion: "other.example.com/v1", Kind: "App", Name: "my-app", UID: "uid-2"},
+								}
+							})
+
+							It("returns an empty list of requests", func() {
+								Expect(result).To(BeEmpty())
+							})
+						})
+
+						Context("and the workload has no owner", func() {
+							It("returns an empty list of requests", func() {
+								Expect(result).To(BeEmpty())
+							})
+						})
+					})
+
+					Context("workload pins a different supply chain by annotation", func() {
+						BeforeEach(func() {
+							workload.Annotations = map[string]string{
+								v1alpha1.WorkloadSupplyChainAnnotation: "some-other-supply-chain",
+							}
+						})
+						It("returns an empty list of requests", func() {
+							Expect(result).To(BeEmpty())
+						})
 					})
 				})
+				Context("supply chain was deleted", func() {
+					BeforeEach(func() {
+						workload.Labels = map[string]string{
+							"myLabel": "myLabelsValue",
+						}
+						clientObjects = []client.Object{workload}
+					})
+
+					It("returns a list of requests that includes the workload it selected", func() {
+						Expect(result).To(Equal([]reconcile.Request{
+							{
+								NamespacedName: types.NamespacedName{
+									Namespace: "first-namespace",
+									Name:      "first-workload",
+								},
+							},
+						}))
+					})
+				})
+
 				Context("supply chain without matching workload", func() {
 					BeforeEach(func() {
 						workload.Labels = map[string]string{
@@ -665,7 +783,7 @@ var _ = Describe("MapFunctions", func() {
 					It("returns a list of requests that includes the deliverable", func() {
 						expected := []reconcile.Request{
 							{
-								types.NamespacedName{
+								NamespacedName: types.NamespacedName{
 									Namespace: "first-namespace",
 									Name:      "first-deliverable",
 								},
@@ -724,6 +842,80 @@ var _ = Describe("MapFunctions", func() {
 		})
 	})
 
+	Describe("WorkloadToDeliverableRequests", func() {
+		var (
+			clientObjects []client.Object
+			scheme        *runtime.Scheme
+			fakeLogger    *registrarfakes.FakeLogger
+			workload      *v1alpha1.Workload
+			result        []reconcile.Request
+		)
+
+		deliverable := func(name, namespace string, source *v1alpha1.DeliverableSource) *v1alpha1.Deliverable {
+			return &v1alpha1.Deliverable{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       v1alpha1.DeliverableSpec{Source: source},
+			}
+		}
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			fakeLogger = &registrarfakes.FakeLogger{}
+			clientObjects = nil
+
+			workload = &v1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-workload", Namespace: "my-namespace"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			mapper := &registrar.Mapper{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+				Logger: fakeLogger,
+			}
+
+			result = mapper.WorkloadToDeliverableRequests(workload)
+		})
+
+		Context("client.List returns an error", func() {
+			It("logs an error to the client", func() {
+				Expect(result).To(BeEmpty())
+
+				Expect(fakeLogger.ErrorCallCount()).To(Equal(1))
+				_, msg, _ := fakeLogger.ErrorArgsForCall(0)
+				Expect(msg).To(Equal("workload to deliverable requests: client list"))
+			})
+		})
+
+		Context("client does not return errors", func() {
+			BeforeEach(func() {
+				Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+				clientObjects = []client.Object{
+					deliverable("linked", "my-namespace", &v1alpha1.DeliverableSource{
+						WorkloadRef: &v1alpha1.WorkloadReference{Name: "my-workload"},
+					}),
+					deliverable("linked-to-another-workload", "my-namespace", &v1alpha1.DeliverableSource{
+						WorkloadRef: &v1alpha1.WorkloadReference{Name: "other-workload"},
+					}),
+					deliverable("linked-in-another-namespace", "other-namespace", &v1alpha1.DeliverableSource{
+						WorkloadRef: &v1alpha1.WorkloadReference{Name: "my-workload"},
+					}),
+					deliverable("not-linked", "my-namespace", &v1alpha1.DeliverableSource{
+						Source: v1alpha1.Source{Git: &v1alpha1.GitSource{}},
+					}),
+					deliverable("without-source", "my-namespace", nil),
+				}
+			})
+
+			It("returns requests for only the deliverables linking the workload", func() {
+				Expect(result).To(Equal([]reconcile.Request{
+					{NamespacedName: types.NamespacedName{Name: "linked", Namespace: "my-namespace"}},
+				}))
+			})
+		})
+	})
+
 	Describe("RunTemplateToRunnableRequests", func() {
 		var (
 			clientObjects     []client.Object
@@ -803,6 +995,7 @@ var _ = Describe("MapFunctions", func() {
 						BeforeEach(func() {
 							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
 								Name: "match",
+								Kind: "ClusterRunTemplate",
 							}
 							clientObjects = []client.Object{runnable}
 						})
@@ -810,7 +1003,7 @@ var _ = Describe("MapFunctions", func() {
 						It("returns a list of requests with the runnable present", func() {
 							expected := []reconcile.Request{
 								{
-									types.NamespacedName{
+									NamespacedName: types.NamespacedName{
 										Namespace: "my-namespace",
 										Name:      "my-runnable",
 									},
@@ -825,6 +1018,7 @@ var _ = Describe("MapFunctions", func() {
 						BeforeEach(func() {
 							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
 								Name: "match",
+								Kind: "ClusterRunTemplate",
 							}
 							runnable.Namespace = "match"
 							clientObjects = []client.Object{runnable}
@@ -833,7 +1027,7 @@ var _ = Describe("MapFunctions", func() {
 						It("returns a list of requests with the runnable present", func() {
 							expected := []reconcile.Request{
 								{
-									types.NamespacedName{
+									NamespacedName: types.NamespacedName{
 										Namespace: "match",
 										Name:      "my-runnable",
 									},
@@ -843,8 +1037,100 @@ var _ = Describe("MapFunctions", func() {
 							Expect(result).To(Equal(expected))
 						})
 					})
+
+					Context("with a templateRef name expression that resolves to the runTemplate name", func() {
+						BeforeEach(func() {
+							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+								NameExpression: "$(runnable.spec.inputs.flavor)$",
+								Kind:           "ClusterRunTemplate",
+							}
+							runnable.Spec.Inputs = map[string]apiextensionsv1.JSON{
+								"flavor": {Raw: []byte(`"match"`)},
+							}
+
+							exactNameRunnable := &v1alpha1.Runnable{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      "exact-name-runnable",
+									Namespace: "my-namespace",
+								},
+								Spec: v1alpha1.RunnableSpec{
+									RunTemplateRef: v1alpha1.TemplateReference{
+										Name: "match",
+										Kind: "ClusterRunTemplate",
+									},
+								},
+							}
+							clientObjects = []client.Object{runnable, exactNameRunnable}
+						})
+
+						It("returns requests for both the expression and the exact name runnables", func() {
+							Expect(result).To(ConsistOf(
+								reconcile.Request{
+									NamespacedName: types.NamespacedName{
+										Namespace: "my-namespace",
+										Name:      "my-runnable",
+									},
+								},
+								reconcile.Request{
+									NamespacedName: types.NamespacedName{
+										Namespace: "my-namespace",
+										Name:      "exact-name-runnable",
+									},
+								},
+							))
+						})
+					})
 				})
 				Context("no runnable matches the runTemplate", func() {
+					Context("because the templateRef name expression resolves to a different name", func() {
+						BeforeEach(func() {
+							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+								NameExpression: "$(runnable.spec.inputs.flavor)$",
+								Kind:           "ClusterRunTemplate",
+							}
+							runnable.Spec.Inputs = map[string]apiextensionsv1.JSON{
+								"flavor": {Raw: []byte(`"no-match"`)},
+							}
+							clientObjects = []client.Object{runnable}
+						})
+
+						It("returns an empty list of requests", func() {
+							Expect(result).To(BeEmpty())
+						})
+					})
+
+					Context("because the templateRef name expression resolves to a different name than its name", func() {
+						BeforeEach(func() {
+							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+								Name:           "match",
+								NameExpression: "$(runnable.spec.inputs.flavor)$",
+								Kind:           "ClusterRunTemplate",
+							}
+							runnable.Spec.Inputs = map[string]apiextensionsv1.JSON{
+								"flavor": {Raw: []byte(`"no-match"`)},
+							}
+							clientObjects = []client.Object{runnable}
+						})
+
+						It("returns an empty list of requests", func() {
+							Expect(result).To(BeEmpty())
+						})
+					})
+
+					Context("because the templateRef name expression cannot be resolved", func() {
+						BeforeEach(func() {
+							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+								NameExpression: "$(runnable.spec.inputs.missing)$",
+								Kind:           "ClusterRunTemplate",
+							}
+							clientObjects = []client.Object{runnable}
+						})
+
+						It("returns an empty list of requests", func() {
+							Expect(result).To(BeEmpty())
+						})
+					})
+
 					Context("because the name in the templateRef is different", func() {
 						BeforeEach(func() {
 							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
@@ -858,6 +1144,33 @@ var _ = Describe("MapFunctions", func() {
 						})
 					})
 
+					Context("because the templateRef has no Kind", func() {
+						BeforeEach(func() {
+							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+								Name: "match",
+							}
+							clientObjects = []client.Object{runnable}
+						})
+
+						It("returns an empty list of requests", func() {
+							Expect(result).To(BeEmpty())
+						})
+					})
+
+					Context("because the templateRef Kind is misspelled", func() {
+						BeforeEach(func() {
+							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
+								Name: "match",
+								Kind: "ClusterRuntemplate",
+							}
+							clientObjects = []client.Object{runnable}
+						})
+
+						It("returns an empty list of requests", func() {
+							Expect(result).To(BeEmpty())
+						})
+					})
+
 					Context("because the templateRef is the wrong Kind", func() {
 						BeforeEach(func() {
 							runnable.Spec.RunTemplateRef = v1alpha1.TemplateReference{
@@ -890,6 +1203,80 @@ var _ = Describe("MapFunctions", func() {
 		})
 	})
 
+	Describe("RunnablesUsingTemplate", func() {
+		var (
+			scheme        *runtime.Scheme
+			clientObjects []client.Object
+			runnables     []types.NamespacedName
+			err           error
+		)
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			clientObjects = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build()
+			runnables, err = registrar.RunnablesUsingTemplate(context.Background(), fakeClient, "my-template")
+		})
+
+		Context("listing runnables fails", func() {
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("client list")))
+				Expect(runnables).To(BeEmpty())
+			})
+		})
+
+		Context("there are matching and non-matching runnables", func() {
+			BeforeEach(func() {
+				Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+				newRunnable := func(name string, ref v1alpha1.TemplateReference) *v1alpha1.Runnable {
+					return &v1alpha1.Runnable{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      name,
+							Namespace: "some-namespace",
+						},
+						Spec: v1alpha1.RunnableSpec{
+							RunTemplateRef: ref,
+							Inputs: map[string]apiextensionsv1.JSON{
+								"template": {Raw: []byte(`"my-template"`)},
+							},
+						},
+					}
+				}
+
+				clientObjects = []client.Object{
+					newRunnable("by-name", v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-template"}),
+					newRunnable("by-expression", v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", NameExpression: "$(runnable.spec.inputs.template)$"}),
+					newRunnable("other-name", v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "other-template"}),
+					newRunnable("other-kind", v1alpha1.TemplateReference{Kind: "SomeKind", Name: "my-template"}),
+					newRunnable("no-kind", v1alpha1.TemplateReference{Name: "my-template"}),
+				}
+			})
+
+			It("returns only the runnables using the template", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(runnables).To(ConsistOf(
+					types.NamespacedName{Namespace: "some-namespace", Name: "by-name"},
+					types.NamespacedName{Namespace: "some-namespace", Name: "by-expression"},
+				))
+			})
+		})
+
+		Context("there are no matching runnables", func() {
+			BeforeEach(func() {
+				Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			})
+
+			It("returns an empty list", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(runnables).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("TemplateToSupplyChainRequests", func() {
 
 		var (
@@ -1405,6 +1792,16 @@ var _ = Describe("MapFunctions", func() {
 						}
 					})
 
+					It("does not return a request for a service account of the same name in another namespace", func() {
+						sa := &corev1.ServiceAccount{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "some-service-account",
+								Namespace: "some-other-namespace",
+							},
+						}
+						Expect(m.ServiceAccountToWorkloadRequests(sa)).To(BeEmpty())
+					})
+
 					It("uses the workload namespace to return a request for the matching workload", func() {
 						sa := &corev1.ServiceAccount{
 							ObjectMeta: metav1.ObjectMeta{
@@ -1442,6 +1839,20 @@ var _ = Describe("MapFunctions", func() {
 						Expect(reqs).To(HaveLen(1))
 						Expect(reqs[0].Name).To(Equal("some-workload"))
 					})
+
+					It("does not map a service account of the same name in another namespace to the supply chain", func() {
+						sa := &corev1.ServiceAccount{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "some-service-account",
+								Namespace: "some-namespace",
+							},
+						}
+						reqs := m.ServiceAccountToWorkloadRequests(sa)
+
+						Expect(reqs).To(BeEmpty())
+						By("not listing the workloads of the supply chain")
+						Expect(fakeClient.ListCallCount()).To(Equal(2))
+					})
 				})
 
 			})
@@ -2272,6 +2683,105 @@ var _ = Describe("MapFunctions", func() {
 					})
 				})
 
+				Context("the mapper only maps the cluster roles bound to workload service accounts", func() {
+					BeforeEach(func() {
+						m.ClusterRoles = m.WorkloadClusterRoles
+
+						listStub := fakeClient.ListStub
+						fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, options ...client.ListOption) error {
+							if err := listStub(ctx, list, options...); err != nil {
+								return err
+							}
+							if clusterRoleBindingList, ok := list.(*rbacv1.ClusterRoleBindingList); ok {
+								clusterRoleBindingList.Items = append(clusterRoleBindingList.Items, rbacv1.ClusterRoleBinding{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "system:controller:namespace-controller",
+									},
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:      "ServiceAccount",
+											Name:      "namespace-controller",
+											Namespace: "kube-system",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										Kind: "ClusterRole",
+										Name: "system:controller:namespace-controller",
+									},
+								})
+							}
+							return nil
+						}
+					})
+
+					It("still returns requests for a cluster role bound to a workload's service account", func() {
+						r := &rbacv1.ClusterRole{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "some-role",
+							},
+						}
+						reqs := m.ClusterRoleToWorkloadRequests(r)
+
+						Expect(reqs).To(HaveLen(1))
+						Expect(reqs[0].Name).To(Equal("some-workload"))
+					})
+
+					It("returns no requests for a system cluster role, without walking its bindings", func() {
+						r := &rbacv1.ClusterRole{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "system:controller:namespace-controller",
+							},
+						}
+						reqs := m.ClusterRoleToWorkloadRequests(r)
+
+						Expect(reqs).To(BeEmpty())
+						Expect(fakeClient.GetCallCount()).To(Equal(0))
+					})
+
+					It("returns no requests for a cluster role only bound to service accounts no workload uses", func() {
+						r := &rbacv1.ClusterRole{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "some-unused-role",
+							},
+						}
+						reqs := m.ClusterRoleToWorkloadRequests(r)
+
+						Expect(reqs).To(BeEmpty())
+						Expect(fakeClient.GetCallCount()).To(Equal(0))
+					})
+
+					It("computes the cluster roles from the workloads' service accounts", func() {
+						clusterRoles, err := m.WorkloadClusterRoles()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(clusterRoles).To(Equal(map[string]bool{"some-role": true}))
+					})
+				})
+
+			})
+		})
+
+		Context("the cluster roles of interest cannot be computed", func() {
+			BeforeEach(func() {
+				m.ClusterRoles = func() (map[string]bool, error) {
+					return nil, fmt.Errorf("some error")
+				}
+			})
+
+			It("logs the error and returns no requests", func() {
+				r := &rbacv1.ClusterRole{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-role",
+					},
+				}
+				reqs := m.ClusterRoleToWorkloadRequests(r)
+
+				Expect(reqs).To(BeEmpty())
+				Expect(fakeClient.ListCallCount()).To(Equal(0))
+				Expect(fakeLogger.ErrorCallCount()).To(Equal(1))
+
+				err, msg, _ := fakeLogger.ErrorArgsForCall(0)
+				Expect(err).To(MatchError("some error"))
+				Expect(msg).To(Equal("cluster role to workload requests: get cluster roles of interest"))
 			})
 		})
 
@@ -4289,4 +4799,114 @@ var _ = Describe("MapFunctions", func() {
 			})
 		})
 	})
+
+	Describe("a mapper scoped to a namespace", func() {
+		var (
+			m                 *registrar.Mapper
+			supplyChain       *v1alpha1.ClusterSupplyChain
+			workloads         []v1alpha1.Workload
+			runnables         []v1alpha1.Runnable
+			requests          []reconcile.Request
+			inNamespace       = types.NamespacedName{Namespace: "my-ns", Name: "my-workload"}
+			listedInNamespace = func(opts []client.ListOption, namespace string) bool {
+				listOptions := &client.ListOptions{}
+				listOptions.ApplyOptions(opts)
+				return listOptions.Namespace == "" || listOptions.Namespace == namespace
+			}
+		)
+
+		BeforeEach(func() {
+			labels := map[string]string{"app": "web"}
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ClusterSupplyChain",
+					APIVersion: "carto.run/v1alpha1",
+				},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Selector:          labels,
+					ServiceAccountRef: v1alpha1.ServiceAccountRef{Name: "my-sa"},
+				},
+			}
+
+			workloads = nil
+			runnables = nil
+			for _, namespace := range []string{"my-ns", "other-ns"} {
+				workloads = append(workloads, v1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-workload", Namespace: namespace, Labels: labels},
+				})
+				runnables = append(runnables, v1alpha1.Runnable{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: namespace},
+					Spec: v1alpha1.RunnableSpec{
+						RunTemplateRef: v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
+					},
+				})
+			}
+
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			fakeClient := &registrarfakes.FakeClient{}
+			fakeClient.SchemeReturns(scheme)
+			fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				switch typedList := list.(type) {
+				case *v1alpha1.ClusterSupplyChainList:
+					typedList.Items = []v1alpha1.ClusterSupplyChain{*supplyChain}
+				case *v1alpha1.WorkloadList:
+					for _, workload := range workloads {
+						if listedInNamespace(opts, workload.Namespace) {
+							typedList.Items = append(typedList.Items, workload)
+						}
+					}
+				case *v1alpha1.RunnableList:
+					for _, runnable := range runnables {
+						if listedInNamespace(opts, runnable.Namespace) {
+							typedList.Items = append(typedList.Items, runnable)
+						}
+					}
+				default:
+					panic("list type not stubbed")
+				}
+				return nil
+			}
+
+			m = &registrar.Mapper{
+				Client:    fakeClient,
+				Logger:    &registrarfakes.FakeLogger{},
+				Namespace: "my-ns",
+			}
+		})
+
+		It("ignores the workloads of other namespaces selected by a supply chain", func() {
+			requests = m.ClusterSupplyChainToWorkloadRequests(supplyChain)
+			Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: inNamespace}))
+		})
+
+		It("ignores the workloads of other namespaces using a service account", func() {
+			requests = m.ServiceAccountToWorkloadRequests(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "other-ns"},
+			})
+			Expect(requests).To(BeEmpty())
+
+			requests = m.ServiceAccountToWorkloadRequests(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "my-ns"},
+			})
+			Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: inNamespace}))
+		})
+
+		It("ignores the runnables of other namespaces using a run template", func() {
+			requests = m.RunTemplateToRunnableRequests(&v1alpha1.ClusterRunTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-run-template"},
+			})
+			Expect(requests).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "my-ns", Name: "my-runnable"},
+			}))
+		})
+
+		It("maps every namespace when it is not scoped", func() {
+			m.Namespace = ""
+			requests = m.ClusterSupplyChainToWorkloadRequests(supplyChain)
+			Expect(requests).To(HaveLen(2))
+		})
+	})
 })
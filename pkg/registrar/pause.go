@@ -0,0 +1,162 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	pkgcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+)
+
+// PauseConfigMapToWorkloadRequests enqueues every workload when the pause config map changes,
+// so that the workloads left alone while paused are reconciled once cartographer resumes.
+func (mapper *Mapper) PauseConfigMapToWorkloadRequests(object client.Object) []reconcile.Request {
+	if !mapper.Pause.Matches(object) {
+		return nil
+	}
+
+	list := &v1alpha1.WorkloadList{}
+	if err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...); err != nil {
+		mapper.Logger.Error(err, "pause config map to workload requests: client list workloads")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, workload := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name},
+		})
+	}
+
+	return requests
+}
+
+// PauseConfigMapToDeliverableRequests enqueues every deliverable when the pause config map
+// changes, so that the deliverables left alone while paused are reconciled once cartographer resumes.
+func (mapper *Mapper) PauseConfigMapToDeliverableRequests(object client.Object) []reconcile.Request {
+	if !mapper.Pause.Matches(object) {
+		return nil
+	}
+
+	list := &v1alpha1.DeliverableList{}
+	if err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...); err != nil {
+		mapper.Logger.Error(err, "pause config map to deliverable requests: client list deliverables")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, deliverable := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: deliverable.Namespace, Name: deliverable.Name},
+		})
+	}
+
+	return requests
+}
+
+// PauseConfigMapToRunnableRequests enqueues every runnable when the pause config map changes,
+// so that the runnables left alone while paused are reconciled once cartographer resumes.
+func (mapper *Mapper) PauseConfigMapToRunnableRequests(object client.Object) []reconcile.Request {
+	if !mapper.Pause.Matches(object) {
+		return nil
+	}
+
+	list := &v1alpha1.RunnableList{}
+	if err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...); err != nil {
+		mapper.Logger.Error(err, "pause config map to runnable requests: client list runnables")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, runnable := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: runnable.Namespace, Name: runnable.Name},
+		})
+	}
+
+	return requests
+}
+
+// pauseCache holds the pause config map alone. Its GetCache has the manager start it, and
+// wait for it to sync, along with its own cache, before any controller.
+type pauseCache struct {
+	cache.Cache
+}
+
+func (c pauseCache) GetCache() cache.Cache {
+	return c.Cache
+}
+
+// watchPauseConfigMap caches the pause config map, if one is configured, with a single
+// informer restricted to it whatever the namespace the manager is restricted to. The pause
+// reads the config map from it and the returned source, shared by the controllers, watches it.
+func watchPauseConfigMap(ctx context.Context, mgr manager.Manager, pause *controller.Pause) (source.Source, error) {
+	if !pause.Enabled() {
+		return nil, nil
+	}
+
+	configMapCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: pause.Namespace,
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", pause.Name)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("new cache for pause config map %s: %w", pause, err)
+	}
+
+	informer, err := configMapCache.GetInformer(ctx, &corev1.ConfigMap{})
+	if err != nil {
+		return nil, fmt.Errorf("informer for pause config map %s: %w", pause, err)
+	}
+
+	if err := mgr.Add(pauseCache{configMapCache}); err != nil {
+		return nil, fmt.Errorf("add pause config map cache to manager: %w", err)
+	}
+
+	pause.Reader = configMapCache
+	return &source.Informer{Informer: informer}, nil
+}
+
+// watchPause enqueues the requests of mapFunc when the pause config map changes, if one is configured
+func watchPause(ctrl pkgcontroller.Controller, pauseSource source.Source, mapFunc handler.MapFunc, spillover SpilloverOptions, logger Logger) error {
+	if pauseSource == nil {
+		return nil
+	}
+
+	if err := ctrl.Watch(
+		pauseSource,
+		EnqueueRequestsFromMapFuncWithSpillover(mapFunc, "ConfigMap", spillover, logger),
+	); err != nil {
+		return fmt.Errorf("watch pause config map: %w", err)
+	}
+
+	return nil
+}
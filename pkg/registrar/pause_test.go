@@ -0,0 +1,97 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar/registrarfakes"
+)
+
+var _ = Describe("PauseConfigMapTo*Requests", func() {
+	var (
+		mapper        *registrar.Mapper
+		clientObjects []client.Object
+		configMap     *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		clientObjects = []client.Object{
+			&v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "first-workload", Namespace: "first-ns"}},
+			&v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "second-workload", Namespace: "second-ns"}},
+			&v1alpha1.Deliverable{ObjectMeta: metav1.ObjectMeta{Name: "my-deliverable", Namespace: "first-ns"}},
+			&v1alpha1.Runnable{ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: "second-ns"}},
+		}
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cartographer-system", Name: "cartographer-pause"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		mapper = &registrar.Mapper{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+			Logger: &registrarfakes.FakeLogger{},
+			Pause:  controller.Pause{Namespace: "cartographer-system", Name: "cartographer-pause"},
+		}
+	})
+
+	Context("the pause config map changes", func() {
+		It("enqueues every workload", func() {
+			Expect(mapper.PauseConfigMapToWorkloadRequests(configMap)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "first-ns", Name: "first-workload"}},
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "second-ns", Name: "second-workload"}},
+			))
+		})
+
+		It("enqueues every deliverable", func() {
+			Expect(mapper.PauseConfigMapToDeliverableRequests(configMap)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "first-ns", Name: "my-deliverable"}},
+			))
+		})
+
+		It("enqueues every runnable", func() {
+			Expect(mapper.PauseConfigMapToRunnableRequests(configMap)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "second-ns", Name: "my-runnable"}},
+			))
+		})
+	})
+
+	Context("another config map changes", func() {
+		BeforeEach(func() {
+			configMap.Namespace = "first-ns"
+		})
+
+		It("enqueues nothing", func() {
+			Expect(mapper.PauseConfigMapToWorkloadRequests(configMap)).To(BeEmpty())
+			Expect(mapper.PauseConfigMapToDeliverableRequests(configMap)).To(BeEmpty())
+			Expect(mapper.PauseConfigMapToRunnableRequests(configMap)).To(BeEmpty())
+		})
+	})
+})
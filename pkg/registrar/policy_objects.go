@@ -0,0 +1,163 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// PolicyObjectReference names an admission policy object, such as a PodSecurityPolicy or a
+// ValidatingWebhookConfiguration, whose changes may change whether stamped objects are admitted.
+type PolicyObjectReference struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+}
+
+// ParsePolicyObjects parses a comma separated list of Kind.version.group/name references,
+// or Kind.version.group/namespace/name for namespaced objects, e.g.
+// ValidatingWebhookConfiguration.v1.admissionregistration.k8s.io/my-webhook,PodSecurityPolicy.v1beta1.policy/restricted
+func ParsePolicyObjects(list string) ([]PolicyObjectReference, error) {
+	var references []PolicyObjectReference
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.Split(item, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("policy object [%s] must be Kind.version.group/[namespace/]name", item)
+		}
+
+		gvk, _ := schema.ParseKindArg(parts[0])
+		if gvk == nil {
+			// the core group has no name, leaving Kind.version
+			if kindVersion := strings.SplitN(parts[0], ".", 2); len(kindVersion) == 2 {
+				gvk = &schema.GroupVersionKind{Kind: kindVersion[0], Version: kindVersion[1]}
+			}
+		}
+		if gvk == nil || gvk.Kind == "" || gvk.Version == "" {
+			return nil, fmt.Errorf("policy object [%s] must name its kind as Kind.version.group", item)
+		}
+
+		reference := PolicyObjectReference{GroupVersionKind: *gvk, Name: parts[len(parts)-1]}
+		if len(parts) == 3 {
+			reference.Namespace = parts[1]
+		}
+		if reference.Name == "" {
+			return nil, fmt.Errorf("policy object [%s] must name the object", item)
+		}
+
+		references = append(references, reference)
+	}
+
+	return references, nil
+}
+
+func (r PolicyObjectReference) matches(object client.Object) bool {
+	return object.GetObjectKind().GroupVersionKind().GroupKind() == r.GroupVersionKind.GroupKind() &&
+		object.GetNamespace() == r.Namespace &&
+		object.GetName() == r.Name
+}
+
+// PolicyObjectToWorkloadRequests enqueues every workload when one of the configured
+// policy objects changes, as any stamped object may now be admitted or rejected.
+func (mapper *Mapper) PolicyObjectToWorkloadRequests(object client.Object) []reconcile.Request {
+	if !mapper.isPolicyObject(object) {
+		return nil
+	}
+
+	list := &v1alpha1.WorkloadList{}
+	if err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...); err != nil {
+		mapper.Logger.Error(err, "policy object to workload requests: client list workloads")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, workload := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name},
+		})
+	}
+
+	return requests
+}
+
+// PolicyObjectToDeliverableRequests enqueues every deliverable when one of the configured
+// policy objects changes, as any stamped object may now be admitted or rejected.
+func (mapper *Mapper) PolicyObjectToDeliverableRequests(object client.Object) []reconcile.Request {
+	if !mapper.isPolicyObject(object) {
+		return nil
+	}
+
+	list := &v1alpha1.DeliverableList{}
+	if err := mapper.Client.List(mapper.ctx(), list, mapper.inScope()...); err != nil {
+		mapper.Logger.Error(err, "policy object to deliverable requests: client list deliverables")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, deliverable := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: deliverable.Namespace, Name: deliverable.Name},
+		})
+	}
+
+	return requests
+}
+
+func (mapper *Mapper) isPolicyObject(object client.Object) bool {
+	for _, reference := range mapper.PolicyObjects {
+		if reference.matches(object) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchPolicyObjects watches the kind of every configured policy object, once per kind
+func watchPolicyObjects(ctrl controller.Controller, policyObjects []PolicyObjectReference, mapFunc handler.MapFunc, spillover SpilloverOptions, logger Logger) error {
+	watched := map[schema.GroupVersionKind]bool{}
+	for _, reference := range policyObjects {
+		if watched[reference.GroupVersionKind] {
+			continue
+		}
+		watched[reference.GroupVersionKind] = true
+
+		kindType := &unstructured.Unstructured{}
+		kindType.SetGroupVersionKind(reference.GroupVersionKind)
+		if err := ctrl.Watch(
+			&source.Kind{Type: kindType},
+			EnqueueRequestsFromMapFuncWithSpillover(mapFunc, reference.GroupVersionKind.Kind, spillover, logger),
+		); err != nil {
+			return fmt.Errorf("watch policy object kind %s: %w", reference.GroupVersionKind, err)
+		}
+	}
+
+	return nil
+}
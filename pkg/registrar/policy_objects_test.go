@@ -0,0 +1,140 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar/registrarfakes"
+)
+
+var _ = Describe("PolicyObjects", func() {
+	webhookGVK := schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"}
+
+	Describe("ParsePolicyObjects", func() {
+		It("parses cluster scoped, namespaced and core group references", func() {
+			references, err := registrar.ParsePolicyObjects("ValidatingWebhookConfiguration.v1.admissionregistration.k8s.io/my-webhook, ConfigMap.v1/my-ns/policy")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(references).To(Equal([]registrar.PolicyObjectReference{
+				{GroupVersionKind: webhookGVK, Name: "my-webhook"},
+				{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, Namespace: "my-ns", Name: "policy"},
+			}))
+		})
+
+		It("parses an empty list", func() {
+			references, err := registrar.ParsePolicyObjects("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(references).To(BeEmpty())
+		})
+
+		It("rejects a reference without a name", func() {
+			_, err := registrar.ParsePolicyObjects("PodSecurityPolicy.v1beta1.policy")
+			Expect(err).To(MatchError("policy object [PodSecurityPolicy.v1beta1.policy] must be Kind.version.group/[namespace/]name"))
+		})
+
+		It("rejects a reference without a version", func() {
+			_, err := registrar.ParsePolicyObjects("PodSecurityPolicy/restricted")
+			Expect(err).To(MatchError("policy object [PodSecurityPolicy/restricted] must name its kind as Kind.version.group"))
+		})
+	})
+
+	Describe("PolicyObjectTo*Requests", func() {
+		var (
+			mapper        *registrar.Mapper
+			clientObjects []client.Object
+			policyObject  *unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			clientObjects = []client.Object{
+				&v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "first-workload", Namespace: "first-ns"}},
+				&v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "second-workload", Namespace: "second-ns"}},
+				&v1alpha1.Deliverable{ObjectMeta: metav1.ObjectMeta{Name: "my-deliverable", Namespace: "first-ns"}},
+			}
+
+			policyObject = &unstructured.Unstructured{}
+			policyObject.SetGroupVersionKind(webhookGVK)
+			policyObject.SetName("my-webhook")
+		})
+
+		JustBeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			mapper = &registrar.Mapper{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+				Logger: &registrarfakes.FakeLogger{},
+				PolicyObjects: []registrar.PolicyObjectReference{
+					{GroupVersionKind: webhookGVK, Name: "my-webhook"},
+				},
+			}
+		})
+
+		Context("a configured policy object changes", func() {
+			It("enqueues every workload", func() {
+				Expect(mapper.PolicyObjectToWorkloadRequests(policyObject)).To(ConsistOf(
+					reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "first-ns", Name: "first-workload"}},
+					reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "second-ns", Name: "second-workload"}},
+				))
+			})
+
+			It("enqueues every deliverable", func() {
+				Expect(mapper.PolicyObjectToDeliverableRequests(policyObject)).To(ConsistOf(
+					reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "first-ns", Name: "my-deliverable"}},
+				))
+			})
+		})
+
+		Context("another object of the policy kind changes", func() {
+			BeforeEach(func() {
+				policyObject.SetName("other-webhook")
+			})
+
+			It("enqueues nothing", func() {
+				Expect(mapper.PolicyObjectToWorkloadRequests(policyObject)).To(BeEmpty())
+				Expect(mapper.PolicyObjectToDeliverableRequests(policyObject)).To(BeEmpty())
+			})
+		})
+
+		Context("listing the workloads fails", func() {
+			It("logs the error and enqueues nothing", func() {
+				fakeClient := &registrarfakes.FakeClient{}
+				fakeClient.ListReturns(errors.New("no list"))
+				fakeLogger := &registrarfakes.FakeLogger{}
+				mapper.Client = fakeClient
+				mapper.Logger = fakeLogger
+
+				Expect(mapper.PolicyObjectToWorkloadRequests(policyObject)).To(BeEmpty())
+				Expect(fakeLogger.ErrorCallCount()).To(Equal(1))
+				err, msg, _ := fakeLogger.ErrorArgsForCall(0)
+				Expect(err).To(MatchError("no list"))
+				Expect(msg).To(Equal("policy object to workload requests: client list workloads"))
+			})
+		})
+	})
+})
@@ -19,12 +19,17 @@ package registrar
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/cluster-api/controllers/external"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	pkgcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -33,6 +38,7 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/deliverable"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/delivery"
 	"github.com/vmware-tanzu/cartographer/pkg/controller/runnable"
@@ -40,9 +46,12 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/controller/workload"
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	realizerdeliverable "github.com/vmware-tanzu/cartographer/pkg/realizer/deliverable"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/mirrors"
 	realizerrunnable "github.com/vmware-tanzu/cartographer/pkg/realizer/runnable"
 	realizerworkload "github.com/vmware-tanzu/cartographer/pkg/realizer/workload"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 )
 
 type Timer struct{}
@@ -64,44 +73,109 @@ func AddToScheme(scheme *runtime.Scheme) error {
 		return fmt.Errorf("rbac v1 add to scheme: %w", err)
 	}
 
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("apiextensions v1 add to scheme: %w", err)
+	}
+
 	return nil
 }
 
-func RegisterControllers(mgr manager.Manager) error {
-	if err := registerWorkloadController(mgr); err != nil {
+// Options configures the controllers registered by RegisterControllers. Fields prefixed
+// with Runnable only apply to the runnable controller.
+type Options struct {
+	Spillover         SpilloverOptions
+	StampedKindPolicy kindpolicy.Policy
+	ForbiddenRetry    controller.ForbiddenRetryOptions
+	// StatusFlushWindow coalesces the status writes of each object within the window,
+	// zero writes every status immediately.
+	StatusFlushWindow time.Duration
+	PolicyObjects     []PolicyObjectReference
+	WatchBackoff      tracker.WatchBackoff
+	// Namespace restricts the controllers to a single namespace, empty watches them all.
+	Namespace string
+	// MaxTrackedKinds bounds the kinds of stamped objects each controller watches, zero
+	// leaves them unbounded.
+	MaxTrackedKinds int
+	Pause           controller.Pause
+
+	AllowOutputOverrides          bool
+	CheckStampedObjectPermissions bool
+	RegistryMirrors               mirrors.Mirrors
+
+	TransientErrorBackoff      time.Duration
+	RunnableOutputLimits       runnable.OutputLimits
+	RunnableNamespaceFairQueue bool
+	RunnableDebounce           time.Duration
+	RunnableMaxFailedAttempts  int64
+
+	// pauseSource watches the pause config map for every controller, nil without one
+	pauseSource source.Source
+}
+
+func RegisterControllers(ctx context.Context, mgr manager.Manager, opts Options) error {
+	pauseSource, err := watchPauseConfigMap(ctx, mgr, &opts.Pause)
+	if err != nil {
+		return fmt.Errorf("watch pause config map: %w", err)
+	}
+	opts.pauseSource = pauseSource
+
+	if err := registerWorkloadController(ctx, mgr, opts); err != nil {
 		return fmt.Errorf("register workload controller: %w", err)
 	}
 
-	if err := registerSupplyChainController(mgr); err != nil {
+	if err := registerSupplyChainController(ctx, mgr); err != nil {
 		return fmt.Errorf("register supply-chain controller: %w", err)
 	}
 
-	if err := registerDeliveryController(mgr); err != nil {
+	if err := registerDeliveryController(ctx, mgr); err != nil {
 		return fmt.Errorf("register delivery controller: %w", err)
 	}
 
-	if err := registerDeliverableController(mgr); err != nil {
+	if err := registerDeliverableController(ctx, mgr, opts); err != nil {
 		return fmt.Errorf("register deliverable controller: %w", err)
 	}
 
-	if err := registerRunnableController(mgr); err != nil {
+	if err := registerRunnableController(ctx, mgr, opts); err != nil {
 		return fmt.Errorf("register runnable-service controller: %w", err)
 	}
 
 	return nil
 }
 
-func registerWorkloadController(mgr manager.Manager) error {
-	repo := repository.NewRepository(
+func registerWorkloadController(ctx context.Context, mgr manager.Manager, opts Options) error {
+	repo := repository.NewRepositoryWithAPIReader(
 		mgr.GetClient(),
+		mgr.GetAPIReader(),
 		repository.NewCache(mgr.GetLogger().WithName("workload-repo-cache")),
 	)
+	repo, err := requestServiceAccountTokens(mgr, repo)
+	if err != nil {
+		return fmt.Errorf("request service account tokens: %w", err)
+	}
+	repo, err = bufferStatusUpdates(mgr, repo, opts.StatusFlushWindow, "workload")
+	if err != nil {
+		return fmt.Errorf("buffer status updates: %w", err)
+	}
 
 	reconciler := &workload.Reconciler{
 		Repo:                    repo,
 		ConditionManagerBuilder: conditions.NewConditionManager,
-		ResourceRealizerBuilder: realizerworkload.NewResourceRealizerBuilder(repository.NewRepository, realizerclient.NewClientBuilder(mgr.GetConfig()), repository.NewCache(mgr.GetLogger().WithName("workload-stamping-repo-cache"))),
-		Realizer:                realizerworkload.NewRealizer(),
+		ResourceRealizerBuilder: realizerworkload.NewResourceRealizerBuilder(
+			repository.NewRepository,
+			realizerclient.NewClientBuilder(mgr.GetConfig(), repo.GetServiceAccountSecretLive),
+			repository.NewCache(mgr.GetLogger().WithName("workload-stamping-repo-cache")),
+			realizerworkload.ResourceRealizerOptions{
+				KindPolicy:           opts.StampedKindPolicy,
+				AllowOutputOverrides: opts.AllowOutputOverrides,
+				CheckPermissions:     opts.CheckStampedObjectPermissions,
+				RegistryMirrors:      opts.RegistryMirrors,
+			},
+		),
+		Realizer:             realizerworkload.NewRealizer(),
+		ForbiddenRetry:       opts.ForbiddenRetry,
+		EventRecorder:        mgr.GetEventRecorderFor("workload"),
+		AllowOutputOverrides: opts.AllowOutputOverrides,
+		Pause:                opts.Pause,
 	}
 
 	ctrl, err := pkgcontroller.New("workload", mgr, pkgcontroller.Options{
@@ -111,7 +185,11 @@ func registerWorkloadController(mgr manager.Manager) error {
 		return fmt.Errorf("controller new: %w", err)
 	}
 
-	reconciler.DynamicTracker = &external.ObjectTracker{Controller: ctrl}
+	objectTracker, err := newObjectTracker(mgr, ctrl, "workload", opts.WatchBackoff, opts.Namespace, opts.MaxTrackedKinds)
+	if err != nil {
+		return fmt.Errorf("new object tracker: %w", err)
+	}
+	reconciler.DynamicTracker = objectTracker
 
 	if err := ctrl.Watch(
 		&source.Kind{Type: &v1alpha1.Workload{}},
@@ -121,8 +199,25 @@ func registerWorkloadController(mgr manager.Manager) error {
 	}
 
 	mapper := Mapper{
-		Client: mgr.GetClient(),
-		Logger: mgr.GetLogger().WithName("workload"),
+		Client:        mgr.GetClient(),
+		Context:       ctx,
+		Logger:        mgr.GetLogger().WithName("workload"),
+		PolicyObjects: opts.PolicyObjects,
+		Namespace:     opts.Namespace,
+		Pause:         opts.Pause,
+	}
+	// changes to cluster roles no workload's service account is bound to, such as most of
+	// the system cluster roles, map to nothing rather than walking every binding of the role.
+	// The set of cluster roles is computed again after a change to any object it depends on.
+	clusterRoles := &ClusterRoleCache{Compute: mapper.WorkloadClusterRoles}
+	mapper.ClusterRoles = clusterRoles.ClusterRoles
+	for _, kindType := range []client.Object{&v1alpha1.Workload{}, &v1alpha1.ClusterSupplyChain{}, &rbacv1.RoleBinding{}, &rbacv1.ClusterRoleBinding{}} {
+		if err := ctrl.Watch(
+			&source.Kind{Type: kindType},
+			handler.EnqueueRequestsFromMapFunc(clusterRoles.Invalidate),
+		); err != nil {
+			return fmt.Errorf("watch %T: %w", kindType, err)
+		}
 	}
 
 	watches := map[client.Object]handler.MapFunc{
@@ -139,16 +234,50 @@ func registerWorkloadController(mgr manager.Manager) error {
 	for kindType, mapFunc := range watches {
 		if err := ctrl.Watch(
 			&source.Kind{Type: kindType},
-			handler.EnqueueRequestsFromMapFunc(mapFunc),
+			EnqueueRequestsFromMapFuncWithSpillover(mapFunc, reflect.TypeOf(kindType).Elem().Name(), opts.Spillover, mapper.Logger),
 		); err != nil {
 			return fmt.Errorf("watch %T: %w", kindType, err)
 		}
 	}
 
+	if err := watchPolicyObjects(ctrl, opts.PolicyObjects, mapper.PolicyObjectToWorkloadRequests, opts.Spillover, mapper.Logger); err != nil {
+		return err
+	}
+
+	if err := watchPause(ctrl, opts.pauseSource, mapper.PauseConfigMapToWorkloadRequests, opts.Spillover, mapper.Logger); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func registerSupplyChainController(mgr manager.Manager) error {
+// requestServiceAccountTokens reads the token of a service account through the TokenRequest
+// API, falling back to its token secret on clusters where no token can be requested.
+func requestServiceAccountTokens(mgr manager.Manager, repo repository.Repository) (repository.Repository, error) {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("clientset new: %w", err)
+	}
+
+	return repository.NewTokenRequestRepository(repo, mgr.GetClient(), clientset.CoreV1(), repository.DefaultTokenExpiration), nil
+}
+
+// bufferStatusUpdates coalesces the status writes of a reconciler when a flush window is
+// set, adding the buffer to the manager so pending statuses are written on shutdown.
+func bufferStatusUpdates(mgr manager.Manager, repo repository.Repository, flushWindow time.Duration, name string) (repository.Repository, error) {
+	if flushWindow <= 0 {
+		return repo, nil
+	}
+
+	buffered := repository.NewBufferedStatusRepository(repo, mgr.GetAPIReader(), flushWindow, mgr.GetLogger().WithName(name+"-status-buffer"))
+	if err := mgr.Add(buffered); err != nil {
+		return nil, fmt.Errorf("add status buffer to manager: %w", err)
+	}
+
+	return buffered, nil
+}
+
+func registerSupplyChainController(ctx context.Context, mgr manager.Manager) error {
 	repo := repository.NewRepository(
 		mgr.GetClient(),
 		repository.NewCache(mgr.GetLogger().WithName("supply-chain-repo-cache")),
@@ -173,8 +302,9 @@ func registerSupplyChainController(mgr manager.Manager) error {
 	}
 
 	mapper := Mapper{
-		Client: mgr.GetClient(),
-		Logger: mgr.GetLogger().WithName("supply-chain"),
+		Client:  mgr.GetClient(),
+		Context: ctx,
+		Logger:  mgr.GetLogger().WithName("supply-chain"),
 	}
 
 	for _, template := range v1alpha1.ValidSupplyChainTemplates {
@@ -189,7 +319,7 @@ func registerSupplyChainController(mgr manager.Manager) error {
 	return nil
 }
 
-func registerDeliveryController(mgr manager.Manager) error {
+func registerDeliveryController(ctx context.Context, mgr manager.Manager) error {
 	repo := repository.NewRepository(
 		mgr.GetClient(),
 		repository.NewCache(mgr.GetLogger().WithName("delivery-repo-cache")),
@@ -213,8 +343,9 @@ func registerDeliveryController(mgr manager.Manager) error {
 	}
 
 	mapper := Mapper{
-		Client: mgr.GetClient(),
-		Logger: mgr.GetLogger().WithName("delivery"),
+		Client:  mgr.GetClient(),
+		Context: ctx,
+		Logger:  mgr.GetLogger().WithName("delivery"),
 	}
 
 	for _, template := range v1alpha1.ValidDeliveryTemplates {
@@ -229,21 +360,33 @@ func registerDeliveryController(mgr manager.Manager) error {
 	return nil
 }
 
-func registerDeliverableController(mgr manager.Manager) error {
-	repo := repository.NewRepository(
+func registerDeliverableController(ctx context.Context, mgr manager.Manager, opts Options) error {
+	repo := repository.NewRepositoryWithAPIReader(
 		mgr.GetClient(),
+		mgr.GetAPIReader(),
 		repository.NewCache(mgr.GetLogger().WithName("deliverable-repo-cache")),
 	)
+	repo, err := requestServiceAccountTokens(mgr, repo)
+	if err != nil {
+		return fmt.Errorf("request service account tokens: %w", err)
+	}
+	repo, err = bufferStatusUpdates(mgr, repo, opts.StatusFlushWindow, "deliverable")
+	if err != nil {
+		return fmt.Errorf("buffer status updates: %w", err)
+	}
 
 	reconciler := &deliverable.Reconciler{
 		Repo:                    repo,
 		ConditionManagerBuilder: conditions.NewConditionManager,
 		ResourceRealizerBuilder: realizerdeliverable.NewResourceRealizerBuilder(
 			repository.NewRepository,
-			realizerclient.NewClientBuilder(mgr.GetConfig()),
+			realizerclient.NewClientBuilder(mgr.GetConfig(), repo.GetServiceAccountSecretLive),
 			repository.NewCache(mgr.GetLogger().WithName("deliverable-stamping-repo-cache")),
+			opts.StampedKindPolicy,
 		),
-		Realizer: realizerdeliverable.NewRealizer(),
+		Realizer:       realizerdeliverable.NewRealizer(),
+		ForbiddenRetry: opts.ForbiddenRetry,
+		Pause:          opts.Pause,
 	}
 
 	ctrl, err := pkgcontroller.New("deliverable", mgr, pkgcontroller.Options{
@@ -253,7 +396,11 @@ func registerDeliverableController(mgr manager.Manager) error {
 		return fmt.Errorf("controller new: %w", err)
 	}
 
-	reconciler.DynamicTracker = &external.ObjectTracker{Controller: ctrl}
+	objectTracker, err := newObjectTracker(mgr, ctrl, "deliverable", opts.WatchBackoff, opts.Namespace, opts.MaxTrackedKinds)
+	if err != nil {
+		return fmt.Errorf("new object tracker: %w", err)
+	}
+	reconciler.DynamicTracker = objectTracker
 
 	if err := ctrl.Watch(
 		&source.Kind{Type: &v1alpha1.Deliverable{}},
@@ -263,12 +410,17 @@ func registerDeliverableController(mgr manager.Manager) error {
 	}
 
 	mapper := Mapper{
-		Client: mgr.GetClient(),
-		Logger: mgr.GetLogger().WithName("deliverable"),
+		Client:        mgr.GetClient(),
+		Context:       ctx,
+		Logger:        mgr.GetLogger().WithName("deliverable"),
+		PolicyObjects: opts.PolicyObjects,
+		Namespace:     opts.Namespace,
+		Pause:         opts.Pause,
 	}
 
 	watches := map[client.Object]handler.MapFunc{
 		&v1alpha1.ClusterDelivery{}:  mapper.ClusterDeliveryToDeliverableRequests,
+		&v1alpha1.Workload{}:         mapper.WorkloadToDeliverableRequests,
 		&corev1.ServiceAccount{}:     mapper.ServiceAccountToDeliverableRequests,
 		&rbacv1.Role{}:               mapper.RoleToDeliverableRequests,
 		&rbacv1.RoleBinding{}:        mapper.RoleBindingToDeliverableRequests,
@@ -281,28 +433,50 @@ func registerDeliverableController(mgr manager.Manager) error {
 	for kindType, mapFunc := range watches {
 		if err := ctrl.Watch(
 			&source.Kind{Type: kindType},
-			handler.EnqueueRequestsFromMapFunc(mapFunc),
+			EnqueueRequestsFromMapFuncWithSpillover(mapFunc, reflect.TypeOf(kindType).Elem().Name(), opts.Spillover, mapper.Logger),
 		); err != nil {
 			return fmt.Errorf("watch %T: %w", kindType, err)
 		}
 	}
 
+	if err := watchPolicyObjects(ctrl, opts.PolicyObjects, mapper.PolicyObjectToDeliverableRequests, opts.Spillover, mapper.Logger); err != nil {
+		return err
+	}
+
+	if err := watchPause(ctrl, opts.pauseSource, mapper.PauseConfigMapToDeliverableRequests, opts.Spillover, mapper.Logger); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func registerRunnableController(mgr manager.Manager) error {
-	repo := repository.NewRepository(
+func registerRunnableController(ctx context.Context, mgr manager.Manager, opts Options) error {
+	repo := repository.NewRepositoryWithAPIReader(
 		mgr.GetClient(),
+		mgr.GetAPIReader(),
 		repository.NewCache(mgr.GetLogger().WithName("runnable-repo-cache")),
 	)
+	repo, err := requestServiceAccountTokens(mgr, repo)
+	if err != nil {
+		return fmt.Errorf("request service account tokens: %w", err)
+	}
+	repo, err = bufferStatusUpdates(mgr, repo, opts.StatusFlushWindow, "runnable")
+	if err != nil {
+		return fmt.Errorf("buffer status updates: %w", err)
+	}
 
 	reconciler := &runnable.Reconciler{
 		Repo:                    repo,
-		Realizer:                realizerrunnable.NewRealizer(),
+		Realizer:                realizerrunnable.NewRealizer(opts.StampedKindPolicy),
 		RunnableCache:           repository.NewCache(mgr.GetLogger().WithName("runnable-stamping-repo-cache")),
 		RepositoryBuilder:       repository.NewRepository,
-		ClientBuilder:           realizerclient.NewClientBuilder(mgr.GetConfig()),
+		ClientBuilder:           realizerclient.NewClientBuilder(mgr.GetConfig(), repo.GetServiceAccountSecretLive),
 		ConditionManagerBuilder: conditions.NewConditionManager,
+		ForbiddenRetry:          opts.ForbiddenRetry,
+		TransientErrorBackoff:   opts.TransientErrorBackoff,
+		OutputLimits:            opts.RunnableOutputLimits,
+		Pause:                   opts.Pause,
+		MaxFailedAttempts:       opts.RunnableMaxFailedAttempts,
 	}
 	ctrl, err := pkgcontroller.New("runnable-service", mgr, pkgcontroller.Options{
 		Reconciler: reconciler,
@@ -311,7 +485,21 @@ func registerRunnableController(mgr manager.Manager) error {
 		return fmt.Errorf("controller new runnable-service: %w", err)
 	}
 
-	reconciler.DynamicTracker = &external.ObjectTracker{Controller: ctrl}
+	if opts.RunnableNamespaceFairQueue {
+		err = controller.UseQueue(ctrl, func() workqueue.RateLimitingInterface {
+			return controller.NewNamespaceFairQueue(workqueue.DefaultControllerRateLimiter(), "runnable-service")
+		})
+		if err != nil {
+			return fmt.Errorf("namespace fair queue for runnable-service: %w", err)
+		}
+	}
+
+	objectTracker, err := newObjectTracker(mgr, ctrl, "runnable", opts.WatchBackoff, opts.Namespace, opts.MaxTrackedKinds)
+	if err != nil {
+		return fmt.Errorf("new object tracker: %w", err)
+	}
+	objectTracker.Debounce = opts.RunnableDebounce
+	reconciler.DynamicTracker = objectTracker
 
 	if err := ctrl.Watch(
 		&source.Kind{Type: &v1alpha1.Runnable{}},
@@ -321,8 +509,11 @@ func registerRunnableController(mgr manager.Manager) error {
 	}
 
 	mapper := Mapper{
-		Client: mgr.GetClient(),
-		Logger: mgr.GetLogger().WithName("runnable"),
+		Client:    mgr.GetClient(),
+		Context:   ctx,
+		Logger:    mgr.GetLogger().WithName("runnable"),
+		Namespace: opts.Namespace,
+		Pause:     opts.Pause,
 	}
 
 	watches := map[client.Object]handler.MapFunc{
@@ -334,18 +525,51 @@ func registerRunnableController(mgr manager.Manager) error {
 		&rbacv1.ClusterRoleBinding{}:   mapper.ClusterRoleBindingToRunnableRequests,
 	}
 
+	// no predicate filters these watches: the creation of a ClusterRunTemplate is mapped like
+	// any change to it, so a runnable created before its template reconciles once it appears
 	for kindType, mapFunc := range watches {
 		if err := ctrl.Watch(
 			&source.Kind{Type: kindType},
-			handler.EnqueueRequestsFromMapFunc(mapFunc),
+			EnqueueRequestsFromMapFuncWithSpillover(mapFunc, reflect.TypeOf(kindType).Elem().Name(), opts.Spillover, mapper.Logger),
 		); err != nil {
 			return fmt.Errorf("watch %T: %w", kindType, err)
 		}
 	}
 
+	if err := watchSecretData(ctrl, mapper.SecretToRunnableRequests, opts.Spillover, mapper.Logger); err != nil {
+		return err
+	}
+
+	if err := watchPause(ctrl, opts.pauseSource, mapper.PauseConfigMapToRunnableRequests, opts.Spillover, mapper.Logger); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// newObjectTracker watches the kinds of stamped objects with the manager's informers or, when
+// maxTrackedKinds bounds them, with informers of the tracker's own that it can stop again.
+func newObjectTracker(mgr manager.Manager, ctrl pkgcontroller.Controller, name string, watchBackoff tracker.WatchBackoff, namespace string, maxTrackedKinds int) (*tracker.ObjectTracker, error) {
+	objectTracker := &tracker.ObjectTracker{Controller: ctrl, Informers: mgr.GetCache(), Backoff: watchBackoff, Name: name}
+	if maxTrackedKinds <= 0 {
+		return objectTracker, nil
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("dynamic client: %w", err)
+	}
+
+	informers := &tracker.DynamicInformers{Client: dynamicClient, Mapper: mgr.GetRESTMapper(), Namespace: namespace}
+	if err := mgr.Add(informers); err != nil {
+		return nil, fmt.Errorf("add dynamic informers to manager: %w", err)
+	}
+
+	objectTracker.Informers = informers
+	objectTracker.MaxKinds = maxTrackedKinds
+	return objectTracker, nil
+}
+
 func IndexResources(ctx context.Context, mgr manager.Manager) error {
 	fieldIndexer := mgr.GetFieldIndexer()
 
@@ -0,0 +1,157 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+const RunTemplateStatusWebhookPath = "/warn-carto-run-v1alpha1-clusterruntemplate"
+
+// RunTemplateStatusWarner admits every run template, warning when an output path reads the
+// status of a stamped kind that has no status subresource. Nothing ever writes such a status,
+// so the runnables of the template would wait for their outputs forever.
+type RunTemplateStatusWarner struct {
+	Repo    repository.Repository
+	decoder *admission.Decoder
+}
+
+func (w *RunTemplateStatusWarner) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+func (w *RunTemplateStatusWarner) Handle(ctx context.Context, req admission.Request) admission.Response {
+	template := &v1alpha1.ClusterRunTemplate{}
+	if err := w.decoder.Decode(req, template); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	warnings, err := RunTemplateStatusWarnings(ctx, w.Repo, template)
+	if err != nil {
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("unable to check the stamped kind for a status subresource: %s", err))
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// RunTemplateStatusWarnings returns a warning for each output path, referenced output name path
+// and requeue hint path of the run template that reads .status, when the
+// CustomResourceDefinition of the stamped kind declares no status subresource. Templates of
+// built-in kinds and versions the definition does not serve are not checked.
+func RunTemplateStatusWarnings(ctx context.Context, repo repository.Repository, template *v1alpha1.ClusterRunTemplate) ([]string, error) {
+	spec := template.Spec
+
+	var paths []string
+	var names []string
+	for name := range spec.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		paths = append(paths, spec.Outputs[name])
+	}
+	names = nil
+	for name := range spec.ReferencedOutputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		paths = append(paths, spec.ReferencedOutputs[name].NamePath)
+	}
+	if spec.RequeueAfterPath != "" {
+		paths = append(paths, spec.RequeueAfterPath)
+	}
+
+	var statusPaths []string
+	for _, path := range paths {
+		parser, err := eval.ParseJsonPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("parse path [%s]: %w", path, err)
+		}
+		if jsonPathRoot(parser.Root.Nodes) == "status" {
+			statusPaths = append(statusPaths, path)
+		}
+	}
+	if len(statusPaths) == 0 || len(spec.Template.Raw) == 0 {
+		return nil, nil
+	}
+
+	stamped := &unstructured.Unstructured{}
+	if err := json.Unmarshal(spec.Template.Raw, &stamped.Object); err != nil {
+		return nil, fmt.Errorf("unmarshal template: %w", err)
+	}
+	gvk := stamped.GroupVersionKind()
+	if gvk.Kind == "" || gvk.Version == "" {
+		return nil, nil
+	}
+
+	crd, err := repo.GetCustomResourceDefinition(ctx, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("get custom resource definition: %w", err)
+	}
+	if crd == nil {
+		return nil, nil
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Name != gvk.Version {
+			continue
+		}
+		if version.Subresources != nil && version.Subresources.Status != nil {
+			return nil, nil
+		}
+
+		var warnings []string
+		for _, path := range statusPaths {
+			warnings = append(warnings, fmt.Sprintf("path [%s] of run template [%s] reads the status of [%s], which has no status subresource", path, template.Name, gvk))
+		}
+		return warnings, nil
+	}
+
+	return nil, nil
+}
+
+// jsonPathRoot is the first field the path reads, empty when the path does not start with a
+// field.
+func jsonPathRoot(nodes []jsonpath.Node) string {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *jsonpath.ListNode:
+			return jsonPathRoot(n.Nodes)
+		case *jsonpath.FieldNode:
+			if n.Value == "" {
+				continue
+			}
+			return n.Value
+		default:
+			return ""
+		}
+	}
+
+	return ""
+}
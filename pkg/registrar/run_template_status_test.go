@@ -0,0 +1,155 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("RunTemplateStatusWarner", func() {
+	var (
+		repo     *repositoryfakes.FakeRepository
+		outputs  map[string]string
+		response admission.Response
+	)
+
+	pipelineRunDefinition := func(subresources *apiextensionsv1.CustomResourceSubresources) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipelineruns.tekton.dev"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v1beta1", Subresources: subresources},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		repo = &repositoryfakes.FakeRepository{}
+		outputs = map[string]string{"url": ".status.results[0].value"}
+	})
+
+	JustBeforeEach(func() {
+		template := &v1alpha1.ClusterRunTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "carto.run/v1alpha1", Kind: "ClusterRunTemplate"},
+			ObjectMeta: metav1.ObjectMeta{Name: "run-template"},
+			Spec: v1alpha1.ClusterRunTemplateSpec{
+				Template: runtime.RawExtension{Raw: []byte(`{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "metadata": {"generateName": "run-"}}`)},
+				Outputs:  outputs,
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		decoder, err := admission.NewDecoder(scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		warner := &registrar.RunTemplateStatusWarner{Repo: repo}
+		Expect(warner.InjectDecoder(decoder)).To(Succeed())
+
+		raw, err := json.Marshal(template)
+		Expect(err).NotTo(HaveOccurred())
+
+		response = warner.Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	})
+
+	Context("the stamped kind has a status subresource", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(pipelineRunDefinition(&apiextensionsv1.CustomResourceSubresources{
+				Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+			}), nil)
+		})
+
+		It("admits the template without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+
+		It("looks up the definition of the stamped kind", func() {
+			Expect(repo.GetCustomResourceDefinitionCallCount()).To(Equal(1))
+			_, gvk := repo.GetCustomResourceDefinitionArgsForCall(0)
+			Expect(gvk).To(Equal(schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "PipelineRun"}))
+		})
+	})
+
+	Context("the stamped kind has no status subresource", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(pipelineRunDefinition(nil), nil)
+		})
+
+		It("admits the template with a warning for each path that reads the status", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				"path [.status.results[0].value] of run template [run-template] reads the status of [tekton.dev/v1beta1, Kind=PipelineRun], which has no status subresource",
+			))
+		})
+
+		Context("and no output path reads the status", func() {
+			BeforeEach(func() {
+				outputs = map[string]string{"name": "{.metadata.name}"}
+			})
+
+			It("admits the template without looking up the stamped kind", func() {
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Warnings).To(BeEmpty())
+				Expect(repo.GetCustomResourceDefinitionCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("the stamped kind is built in", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(nil, nil)
+		})
+
+		It("admits the template without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+	})
+
+	Context("the definition cannot be read", func() {
+		BeforeEach(func() {
+			repo.GetCustomResourceDefinitionReturns(nil, errors.New("no mapping"))
+		})
+
+		It("admits the template, warning that it could not be checked", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				"unable to check the stamped kind for a status subresource: get custom resource definition: no mapping",
+			))
+		})
+	})
+})
@@ -0,0 +1,135 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	pkgcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// SecretToRunnableRequests enqueues the runnables whose service account refers to the secret,
+// as one of its secrets or image pull secrets, so that they are reconciled with its new data.
+func (mapper *Mapper) SecretToRunnableRequests(secret client.Object) []reconcile.Request {
+	serviceAccountList := &corev1.ServiceAccountList{}
+	if err := mapper.Client.List(mapper.ctx(), serviceAccountList, client.InNamespace(secret.GetNamespace())); err != nil {
+		mapper.Logger.Error(err, "secret to runnable requests: client list service accounts")
+		return nil
+	}
+
+	serviceAccounts := map[string]bool{}
+	for _, serviceAccount := range serviceAccountList.Items {
+		if refersToSecret(serviceAccount, secret.GetName()) {
+			serviceAccounts[serviceAccount.Name] = true
+		}
+	}
+	if len(serviceAccounts) == 0 {
+		return nil
+	}
+
+	list := &v1alpha1.RunnableList{}
+	if err := mapper.Client.List(mapper.ctx(), list, mapper.inScope(client.InNamespace(secret.GetNamespace()))...); err != nil {
+		mapper.Logger.Error(err, "secret to runnable requests: client list runnables")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, runnable := range list.Items {
+		serviceAccountName := runnable.Spec.ServiceAccountName
+		if serviceAccountName == "" {
+			serviceAccountName = "default"
+		}
+		if runnable.Namespace == secret.GetNamespace() && serviceAccounts[serviceAccountName] {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: runnable.Namespace, Name: runnable.Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+func refersToSecret(serviceAccount corev1.ServiceAccount, name string) bool {
+	for _, ref := range serviceAccount.Secrets {
+		if ref.Name == name {
+			return true
+		}
+	}
+	for _, ref := range serviceAccount.ImagePullSecrets {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretDataChanged lets through the updates of a secret that change its type or data,
+// leaving out those that only touch its metadata, such as a refreshed annotation.
+func SecretDataChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return secretDataHash(e.ObjectOld) != secretDataHash(e.ObjectNew)
+		},
+	}
+}
+
+// secretDataHash is a hash of the type and data of the secret, empty for any other object.
+func secretDataHash(object client.Object) string {
+	secret, ok := object.(*corev1.Secret)
+	if !ok {
+		return ""
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\n", secret.Type)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(hash, "%s\n%d\n", key, len(secret.Data[key]))
+		_, _ = hash.Write(secret.Data[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// watchSecretData watches the secrets the service accounts of runnables refer to for changes
+// to their data.
+func watchSecretData(ctrl pkgcontroller.Controller, mapFunc handler.MapFunc, spillover SpilloverOptions, logger Logger) error {
+	if err := ctrl.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		EnqueueRequestsFromMapFuncWithSpillover(mapFunc, "Secret", spillover, logger),
+		SecretDataChanged(),
+	); err != nil {
+		return fmt.Errorf("watch secret data: %w", err)
+	}
+
+	return nil
+}
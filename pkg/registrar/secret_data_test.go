@@ -0,0 +1,167 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar/registrarfakes"
+)
+
+var _ = Describe("SecretToRunnableRequests", func() {
+	var (
+		mapper        *registrar.Mapper
+		clientObjects []client.Object
+		secret        *corev1.Secret
+	)
+
+	runnable := func(namespace, name, serviceAccountName string) *v1alpha1.Runnable {
+		return &v1alpha1.Runnable{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1alpha1.RunnableSpec{ServiceAccountName: serviceAccountName},
+		}
+	}
+
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	BeforeEach(func() {
+		clientObjects = []client.Object{
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "builder"},
+				Secrets:    []corev1.ObjectReference{{Name: "builder-token"}},
+			},
+			&corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Namespace: "my-ns", Name: "default"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+			},
+			&corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Namespace: "other-ns", Name: "default"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+			},
+			runnable("my-ns", "built", "builder"),
+			runnable("my-ns", "defaulted", ""),
+			runnable("my-ns", "pulled", "default"),
+			runnable("other-ns", "elsewhere", ""),
+		}
+
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "registry-credentials"}}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		mapper = &registrar.Mapper{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+			Logger: &registrarfakes.FakeLogger{},
+		}
+	})
+
+	It("enqueues the runnables whose service account pulls images with the secret", func() {
+		Expect(mapper.SecretToRunnableRequests(secret)).To(ConsistOf(
+			request("my-ns", "defaulted"),
+			request("my-ns", "pulled"),
+		))
+	})
+
+	Context("the secret is the token secret of a service account", func() {
+		BeforeEach(func() {
+			secret.Name = "builder-token"
+		})
+
+		It("enqueues the runnables of that service account", func() {
+			Expect(mapper.SecretToRunnableRequests(secret)).To(ConsistOf(request("my-ns", "built")))
+		})
+	})
+
+	Context("no service account refers to the secret", func() {
+		BeforeEach(func() {
+			secret.Name = "unrelated"
+		})
+
+		It("enqueues nothing", func() {
+			Expect(mapper.SecretToRunnableRequests(secret)).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("SecretDataChanged", func() {
+	var oldSecret, newSecret *corev1.Secret
+
+	BeforeEach(func() {
+		oldSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "registry-credentials", ResourceVersion: "1"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+		}
+		newSecret = oldSecret.DeepCopy()
+		newSecret.ResourceVersion = "2"
+	})
+
+	update := func() bool {
+		return registrar.SecretDataChanged().Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: newSecret})
+	}
+
+	Context("an update changes the data of the secret", func() {
+		BeforeEach(func() {
+			newSecret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"registry.example.com":{}}}`)
+		})
+
+		It("lets the update through", func() {
+			Expect(update()).To(BeTrue())
+		})
+	})
+
+	Context("an update only changes the metadata of the secret", func() {
+		BeforeEach(func() {
+			newSecret.Annotations = map[string]string{"refreshed-at": "2021-09-01T00:00:00Z"}
+			newSecret.Labels = map[string]string{"team": "a"}
+		})
+
+		It("filters the update out", func() {
+			Expect(update()).To(BeFalse())
+		})
+	})
+
+	Context("an update moves bytes from one key to another", func() {
+		BeforeEach(func() {
+			oldSecret.Data = map[string][]byte{"a": []byte("bc"), "ab": []byte("c")}
+			newSecret.Data = map[string][]byte{"a": []byte("b"), "ab": []byte("cc")}
+		})
+
+		It("lets the update through", func() {
+			Expect(update()).To(BeTrue())
+		})
+	})
+
+	It("lets the creation and deletion of a secret through", func() {
+		Expect(registrar.SecretDataChanged().Create(event.CreateEvent{Object: newSecret})).To(BeTrue())
+		Expect(registrar.SecretDataChanged().Delete(event.DeleteEvent{Object: oldSecret})).To(BeTrue())
+	})
+})
@@ -0,0 +1,103 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SpilloverOptions caps the number of requests a single mapped event enqueues at once.
+// Requests beyond the cap are enqueued in further batches of the same size, each batch
+// delayed by another Delay.
+type SpilloverOptions struct {
+	// MaxRequestsPerEvent of zero or less disables the cap
+	MaxRequestsPerEvent int
+	Delay               time.Duration
+}
+
+// EnqueueRequestsFromMapFuncWithSpillover behaves like handler.EnqueueRequestsFromMapFunc,
+// but spreads the requests of a single event over time when they exceed options.MaxRequestsPerEvent.
+func EnqueueRequestsFromMapFuncWithSpillover(mapFunc handler.MapFunc, sourceKind string, options SpilloverOptions, logger Logger) handler.EventHandler {
+	if options.MaxRequestsPerEvent <= 0 {
+		return handler.EnqueueRequestsFromMapFunc(mapFunc)
+	}
+
+	return &spilloverHandler{
+		mapFunc:    mapFunc,
+		sourceKind: sourceKind,
+		options:    options,
+		logger:     logger,
+	}
+}
+
+type spilloverHandler struct {
+	mapFunc    handler.MapFunc
+	sourceKind string
+	options    SpilloverOptions
+	logger     Logger
+}
+
+func (h *spilloverHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(q, evt.Object)
+}
+
+func (h *spilloverHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(q, evt.ObjectOld, evt.ObjectNew)
+}
+
+func (h *spilloverHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(q, evt.Object)
+}
+
+func (h *spilloverHandler) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(q, evt.Object)
+}
+
+func (h *spilloverHandler) enqueue(q workqueue.RateLimitingInterface, objects ...client.Object) {
+	seen := make(map[reconcile.Request]bool)
+	var requests []reconcile.Request
+	for _, obj := range objects {
+		for _, request := range h.mapFunc(obj) {
+			if !seen[request] {
+				seen[request] = true
+				requests = append(requests, request)
+			}
+		}
+	}
+
+	for i, request := range requests {
+		batch := i / h.options.MaxRequestsPerEvent
+		if batch == 0 {
+			q.Add(request)
+		} else {
+			q.AddAfter(request, time.Duration(batch)*h.options.Delay)
+		}
+	}
+
+	if len(requests) > h.options.MaxRequestsPerEvent {
+		h.logger.Info("mapped requests exceed the per event cap, delaying the remainder",
+			"source kind", h.sourceKind,
+			"requests", len(requests),
+			"max requests per event", h.options.MaxRequestsPerEvent,
+			"delayed", len(requests)-h.options.MaxRequestsPerEvent,
+		)
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar/registrarfakes"
+)
+
+type delayedRequest struct {
+	request reconcile.Request
+	delay   time.Duration
+}
+
+type recordingQueue struct {
+	workqueue.RateLimitingInterface
+	added   []reconcile.Request
+	delayed []delayedRequest
+}
+
+func (q *recordingQueue) Add(item interface{}) {
+	q.added = append(q.added, item.(reconcile.Request))
+}
+
+func (q *recordingQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.delayed = append(q.delayed, delayedRequest{request: item.(reconcile.Request), delay: duration})
+}
+
+var _ = Describe("EnqueueRequestsFromMapFuncWithSpillover", func() {
+	const serviceAccountCount = 10
+
+	var (
+		fakeLogger  *registrarfakes.FakeLogger
+		mapper      *registrar.Mapper
+		clusterRole *rbacv1.ClusterRole
+		options     registrar.SpilloverOptions
+		queue       *recordingQueue
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(registrar.AddToScheme(scheme)).To(Succeed())
+
+		clusterRole = &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shared-role",
+			},
+		}
+
+		clientObjects := []client.Object{clusterRole}
+		for i := 0; i < serviceAccountCount; i++ {
+			serviceAccountName := fmt.Sprintf("service-account-%d", i)
+			clientObjects = append(clientObjects,
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serviceAccountName,
+						Namespace: "some-namespace",
+					},
+				},
+				&rbacv1.ClusterRoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fmt.Sprintf("binding-%d", i),
+					},
+					RoleRef: rbacv1.RoleRef{
+						Kind: "ClusterRole",
+						Name: "shared-role",
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							Kind:      "ServiceAccount",
+							Name:      serviceAccountName,
+							Namespace: "some-namespace",
+						},
+					},
+				},
+				&v1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("workload-%d", i),
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.WorkloadSpec{
+						ServiceAccountName: serviceAccountName,
+					},
+				},
+			)
+		}
+
+		fakeLogger = &registrarfakes.FakeLogger{}
+		mapper = &registrar.Mapper{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+			Logger: fakeLogger,
+		}
+
+		queue = &recordingQueue{}
+	})
+
+	JustBeforeEach(func() {
+		eventHandler := registrar.EnqueueRequestsFromMapFuncWithSpillover(mapper.ClusterRoleToWorkloadRequests, "ClusterRole", options, fakeLogger)
+		eventHandler.Update(event.UpdateEvent{ObjectOld: clusterRole, ObjectNew: clusterRole}, queue)
+	})
+
+	allRequests := func() []reconcile.Request {
+		requests := append([]reconcile.Request{}, queue.added...)
+		for _, delayed := range queue.delayed {
+			requests = append(requests, delayed.request)
+		}
+		return requests
+	}
+
+	expectedRequests := func() []reconcile.Request {
+		var requests []reconcile.Request
+		for i := 0; i < serviceAccountCount; i++ {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "some-namespace", Name: fmt.Sprintf("workload-%d", i)},
+			})
+		}
+		return requests
+	}
+
+	Context("when a ClusterRole bound to many service accounts maps to more requests than the cap", func() {
+		BeforeEach(func() {
+			options = registrar.SpilloverOptions{
+				MaxRequestsPerEvent: 4,
+				Delay:               5 * time.Second,
+			}
+		})
+
+		It("enqueues only the cap immediately", func() {
+			Expect(queue.added).To(HaveLen(4))
+		})
+
+		It("delays the remainder in batches of the cap", func() {
+			Expect(queue.delayed).To(HaveLen(6))
+
+			var delays []time.Duration
+			for _, delayed := range queue.delayed {
+				delays = append(delays, delayed.delay)
+			}
+			Expect(delays).To(Equal([]time.Duration{
+				5 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second,
+				10 * time.Second, 10 * time.Second,
+			}))
+		})
+
+		It("enqueues every workload exactly once", func() {
+			Expect(allRequests()).To(ConsistOf(expectedRequests()))
+		})
+
+		It("logs the spillover", func() {
+			Expect(fakeLogger.InfoCallCount()).To(Equal(1))
+			msg, keysAndValues := fakeLogger.InfoArgsForCall(0)
+			Expect(msg).To(Equal("mapped requests exceed the per event cap, delaying the remainder"))
+			Expect(keysAndValues).To(Equal([]interface{}{
+				"source kind", "ClusterRole",
+				"requests", 10,
+				"max requests per event", 4,
+				"delayed", 6,
+			}))
+		})
+	})
+
+	Context("when the mapping produces no more requests than the cap", func() {
+		BeforeEach(func() {
+			options = registrar.SpilloverOptions{
+				MaxRequestsPerEvent: serviceAccountCount,
+				Delay:               5 * time.Second,
+			}
+		})
+
+		It("enqueues every request immediately", func() {
+			Expect(queue.added).To(ConsistOf(expectedRequests()))
+			Expect(queue.delayed).To(BeEmpty())
+		})
+
+		It("does not log", func() {
+			Expect(fakeLogger.InfoCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the cap is disabled", func() {
+		BeforeEach(func() {
+			options = registrar.SpilloverOptions{}
+		})
+
+		It("enqueues every request immediately", func() {
+			Expect(queue.added).To(ConsistOf(expectedRequests()))
+			Expect(queue.delayed).To(BeEmpty())
+		})
+
+		It("does not log", func() {
+			Expect(fakeLogger.InfoCallCount()).To(Equal(0))
+		})
+	})
+})
+
+var _ = Describe("Mapping the creation of a ClusterRunTemplate", func() {
+	var (
+		fakeLogger  *registrarfakes.FakeLogger
+		mapper      *registrar.Mapper
+		options     registrar.SpilloverOptions
+		queue       *recordingQueue
+		runTemplate *v1alpha1.ClusterRunTemplate
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(registrar.AddToScheme(scheme)).To(Succeed())
+
+		// the runnable exists before the template it references
+		runnable := &v1alpha1.Runnable{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-runnable",
+				Namespace: "some-namespace",
+			},
+			Spec: v1alpha1.RunnableSpec{
+				RunTemplateRef: v1alpha1.TemplateReference{
+					Kind: "ClusterRunTemplate",
+					Name: "my-run-template",
+				},
+			},
+		}
+
+		fakeLogger = &registrarfakes.FakeLogger{}
+		mapper = &registrar.Mapper{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(runnable).Build(),
+			Logger: fakeLogger,
+		}
+
+		runTemplate = &v1alpha1.ClusterRunTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-run-template"},
+		}
+
+		queue = &recordingQueue{}
+	})
+
+	JustBeforeEach(func() {
+		eventHandler := registrar.EnqueueRequestsFromMapFuncWithSpillover(mapper.RunTemplateToRunnableRequests, "ClusterRunTemplate", options, fakeLogger)
+		eventHandler.Create(event.CreateEvent{Object: runTemplate}, queue)
+	})
+
+	ItEnqueuesTheWaitingRunnable := func() {
+		It("enqueues the runnable waiting on the template", func() {
+			Expect(queue.added).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "some-namespace", Name: "my-runnable"},
+			}))
+			Expect(queue.delayed).To(BeEmpty())
+		})
+	}
+
+	Context("when the cap is disabled", func() {
+		BeforeEach(func() {
+			options = registrar.SpilloverOptions{}
+		})
+
+		ItEnqueuesTheWaitingRunnable()
+	})
+
+	Context("when the cap is enabled", func() {
+		BeforeEach(func() {
+			options = registrar.SpilloverOptions{
+				MaxRequestsPerEvent: 4,
+				Delay:               5 * time.Second,
+			}
+		})
+
+		ItEnqueuesTheWaitingRunnable()
+	})
+})
+
+var _ = Describe("Mapping a change to the pause annotation of a ClusterSupplyChain", func() {
+	var (
+		fakeLogger *registrarfakes.FakeLogger
+		mapper     *registrar.Mapper
+		queue      *recordingQueue
+		unpaused   *v1alpha1.ClusterSupplyChain
+		paused     *v1alpha1.ClusterSupplyChain
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(registrar.AddToScheme(scheme)).To(Succeed())
+
+		unpaused = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				Selector: map[string]string{"type": "web"},
+			},
+		}
+		paused = unpaused.DeepCopy()
+		paused.Annotations = map[string]string{v1alpha1.SupplyChainPauseAnnotation: "true"}
+
+		workload := func(name string, labels map[string]string) *v1alpha1.Workload {
+			return &v1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-namespace", Labels: labels},
+			}
+		}
+
+		fakeLogger = &registrarfakes.FakeLogger{}
+		mapper = &registrar.Mapper{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				unpaused.DeepCopy(),
+				workload("web-1", map[string]string{"type": "web"}),
+				workload("web-2", map[string]string{"type": "web"}),
+				workload("worker-1", map[string]string{"type": "worker"}),
+			).Build(),
+			Logger: fakeLogger,
+		}
+
+		queue = &recordingQueue{}
+	})
+
+	update := func(old, new *v1alpha1.ClusterSupplyChain) {
+		eventHandler := registrar.EnqueueRequestsFromMapFuncWithSpillover(mapper.ClusterSupplyChainToWorkloadRequests, "ClusterSupplyChain", registrar.SpilloverOptions{}, fakeLogger)
+		eventHandler.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: new}, queue)
+	}
+
+	expectedRequests := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "some-namespace", Name: "web-1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "some-namespace", Name: "web-2"}},
+	}
+
+	It("enqueues every selected workload when the supply chain is paused", func() {
+		update(unpaused, paused)
+		Expect(queue.added).To(ConsistOf(expectedRequests))
+	})
+
+	It("enqueues every selected workload when the supply chain is unpaused", func() {
+		update(paused, unpaused)
+		Expect(queue.added).To(ConsistOf(expectedRequests))
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+const SupplyChainOverlapWebhookPath = "/warn-carto-run-v1alpha1-clustersupplychain"
+
+// SupplyChainOverlapWarner admits every supply chain, warning when a new supply chain's
+// selector overlaps the selector of an existing supply chain that is equally specific.
+// A workload matching both would select neither, unless either supply chain has a canary
+// weight to split such workloads between them.
+type SupplyChainOverlapWarner struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+func (w *SupplyChainOverlapWarner) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+func (w *SupplyChainOverlapWarner) Handle(ctx context.Context, req admission.Request) admission.Response {
+	supplyChain := &v1alpha1.ClusterSupplyChain{}
+	if err := w.decoder.Decode(req, supplyChain); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	warnings, err := SupplyChainOverlapWarnings(ctx, w.Client, supplyChain)
+	if err != nil {
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("unable to check supply chain selectors for overlap: %s", err))
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// SupplyChainOverlapWarnings returns a warning for each existing supply chain whose selector
+// overlaps the selector of the supply chain and has as many labels and owner criteria.
+// Supply chains with a canary weight are meant to overlap and are not warned about.
+func SupplyChainOverlapWarnings(ctx context.Context, c client.Client, supplyChain *v1alpha1.ClusterSupplyChain) ([]string, error) {
+	list := &v1alpha1.ClusterSupplyChainList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("list supply chains: %w", err)
+	}
+
+	var overlapping []string
+	for i := range list.Items {
+		existing := &list.Items[i]
+		if existing.Name == supplyChain.Name || existing.Spec.CanaryWeight > 0 || supplyChain.Spec.CanaryWeight > 0 {
+			continue
+		}
+		if repository.SelectorSize(existing) == repository.SelectorSize(supplyChain) && repository.SelectorsOverlap(existing, supplyChain) {
+			overlapping = append(overlapping, existing.Name)
+		}
+	}
+	sort.Strings(overlapping)
+
+	var warnings []string
+	for _, name := range overlapping {
+		warnings = append(warnings, fmt.Sprintf("selector of supply chain [%s] overlaps the equally specific selector of supply chain [%s], workloads matching both will select neither", supplyChain.Name, name))
+	}
+	return warnings, nil
+}
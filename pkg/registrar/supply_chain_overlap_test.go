@@ -0,0 +1,125 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+)
+
+var _ = Describe("SupplyChainOverlapWarner", func() {
+	var (
+		scheme        *runtime.Scheme
+		clientObjects []client.Object
+		newChain      *v1alpha1.ClusterSupplyChain
+		response      admission.Response
+	)
+
+	supplyChain := func(name string, selector map[string]string) *v1alpha1.ClusterSupplyChain {
+		return &v1alpha1.ClusterSupplyChain{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "carto.run/v1alpha1", Kind: "ClusterSupplyChain"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.SupplyChainSpec{Selector: selector},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		newChain = supplyChain("new-chain", map[string]string{"type": "web"})
+		clientObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		warner := &registrar.SupplyChainOverlapWarner{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build(),
+		}
+		decoder, err := admission.NewDecoder(scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warner.InjectDecoder(decoder)).To(Succeed())
+
+		raw, err := json.Marshal(newChain)
+		Expect(err).NotTo(HaveOccurred())
+
+		response = warner.Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	})
+
+	Context("an existing supply chain has an equally specific, overlapping selector", func() {
+		BeforeEach(func() {
+			clientObjects = []client.Object{supplyChain("existing-chain", map[string]string{"type": "web"})}
+		})
+
+		It("admits the supply chain with a warning", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(ConsistOf(
+				"selector of supply chain [new-chain] overlaps the equally specific selector of supply chain [existing-chain], workloads matching both will select neither",
+			))
+		})
+
+		Context("and the supply chain is a canary of it", func() {
+			BeforeEach(func() {
+				newChain.Spec.CanaryWeight = 10
+			})
+
+			It("admits the supply chain without warnings", func() {
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Warnings).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("the existing supply chains are disjoint or more specific", func() {
+		BeforeEach(func() {
+			clientObjects = []client.Object{
+				supplyChain("disjoint-chain", map[string]string{"type": "worker"}),
+				supplyChain("more-specific-chain", map[string]string{"type": "web", "test": "tekton"}),
+			}
+		})
+
+		It("admits the supply chain without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+	})
+
+	Context("the only existing supply chain with the selector is the supply chain itself", func() {
+		BeforeEach(func() {
+			clientObjects = []client.Object{supplyChain("new-chain", map[string]string{"type": "web"})}
+		})
+
+		It("admits the supply chain without warnings", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,110 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+// ImpactReport lists what would be stamped again if a template were changed.
+type ImpactReport struct {
+	Template     v1alpha1.ClusterTemplateReference
+	SupplyChains []SupplyChainImpact
+}
+
+// SupplyChainImpact is the part of an ImpactReport for one supply chain that references
+// the template.
+type SupplyChainImpact struct {
+	SupplyChain string
+	// Resources are the names of the supply chain's resources that reference the template
+	Resources []string
+	// Workloads are the workloads selected for the supply chain, each of which stamps every
+	// one of Resources
+	Workloads []types.NamespacedName
+}
+
+// Workloads returns every workload in the report, across all of its supply chains.
+func (r *ImpactReport) Workloads() []types.NamespacedName {
+	var workloads []types.NamespacedName
+	for _, supplyChain := range r.SupplyChains {
+		workloads = append(workloads, supplyChain.Workloads...)
+	}
+	return workloads
+}
+
+// ImpactOfTemplateChange reports the supply chain resources that reference the template, and
+// the workloads that would stamp them again if it were changed. Workloads are matched to
+// supply chains using the same rules as the workload reconciler. Nothing is written.
+func ImpactOfTemplateChange(ctx context.Context, c client.Client, templateRef v1alpha1.ClusterTemplateReference) (*ImpactReport, error) {
+	supplyChainList := &v1alpha1.ClusterSupplyChainList{}
+	if err := c.List(ctx, supplyChainList); err != nil {
+		return nil, fmt.Errorf("list supply chains: %w", err)
+	}
+
+	report := &ImpactReport{Template: templateRef}
+
+	supplyChains := map[string]*v1alpha1.ClusterSupplyChain{}
+	var selectorGetters []repository.SelectorGetter
+	for i := range supplyChainList.Items {
+		supplyChain := &supplyChainList.Items[i]
+		supplyChains[supplyChain.Name] = supplyChain
+		selectorGetters = append(selectorGetters, supplyChain)
+
+		var resources []string
+		for _, resource := range supplyChain.Spec.Resources {
+			if referencesTemplate(resource, templateRef.Kind, templateRef.Name) {
+				resources = append(resources, resource.Name)
+			}
+		}
+		if len(resources) > 0 {
+			report.SupplyChains = append(report.SupplyChains, SupplyChainImpact{
+				SupplyChain: supplyChain.Name,
+				Resources:   resources,
+			})
+		}
+	}
+
+	if len(report.SupplyChains) == 0 {
+		return report, nil
+	}
+
+	impacts := map[string]*SupplyChainImpact{}
+	for i := range report.SupplyChains {
+		impacts[report.SupplyChains[i].SupplyChain] = &report.SupplyChains[i]
+	}
+
+	workloadList := &v1alpha1.WorkloadList{}
+	if err := c.List(ctx, workloadList); err != nil {
+		return nil, fmt.Errorf("list workloads: %w", err)
+	}
+
+	for i := range workloadList.Items {
+		workload := &workloadList.Items[i]
+		impact, ok := impacts[selectedSupplyChain(workload, supplyChains, selectorGetters)]
+		if !ok {
+			continue
+		}
+		impact.Workloads = append(impact.Workloads, types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name})
+	}
+
+	return report, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+)
+
+var _ = Describe("ImpactOfTemplateChange", func() {
+	var (
+		scheme        *runtime.Scheme
+		clientObjects []client.Object
+		templateRef   v1alpha1.ClusterTemplateReference
+		report        *registrar.ImpactReport
+		err           error
+	)
+
+	resource := func(name, kind, templateName string) v1alpha1.SupplyChainResource {
+		return v1alpha1.SupplyChainResource{
+			Name:        name,
+			TemplateRef: v1alpha1.ClusterTemplateReference{Kind: kind, Name: templateName},
+		}
+	}
+
+	supplyChain := func(name string, selector map[string]string, resources ...v1alpha1.SupplyChainResource) *v1alpha1.ClusterSupplyChain {
+		return &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.SupplyChainSpec{Selector: selector, Resources: resources},
+		}
+	}
+
+	workload := func(name string, labels map[string]string) *v1alpha1.Workload {
+		return &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", Labels: labels},
+		}
+	}
+
+	nn := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "my-ns", Name: name}
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		templateRef = v1alpha1.ClusterTemplateReference{Kind: "ClusterImageTemplate", Name: "kpack"}
+
+		clientObjects = []client.Object{
+			supplyChain("web-chain", map[string]string{"type": "web"},
+				resource("source", "ClusterSourceTemplate", "git"),
+				resource("image", "ClusterImageTemplate", "kpack"),
+			),
+			supplyChain("worker-chain", map[string]string{"type": "worker"},
+				resource("worker-image", "ClusterImageTemplate", "kpack"),
+				resource("config", "ClusterConfigTemplate", "kpack"),
+			),
+			supplyChain("batch-chain", map[string]string{"type": "batch"},
+				resource("image", "ClusterImageTemplate", "other"),
+			),
+			workload("web-1", map[string]string{"type": "web"}),
+			workload("web-2", map[string]string{"type": "web"}),
+			workload("worker-1", map[string]string{"type": "worker"}),
+			workload("batch-1", map[string]string{"type": "batch"}),
+			workload("unselected-1", map[string]string{"type": "unknown"}),
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build()
+		report, err = registrar.ImpactOfTemplateChange(context.Background(), fakeClient, templateRef)
+	})
+
+	It("reports each supply chain resource that references the template with the workloads that stamp it", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Template).To(Equal(templateRef))
+		Expect(report.SupplyChains).To(ConsistOf(
+			registrar.SupplyChainImpact{
+				SupplyChain: "web-chain",
+				Resources:   []string{"image"},
+				Workloads:   []types.NamespacedName{nn("web-1"), nn("web-2")},
+			},
+			registrar.SupplyChainImpact{
+				SupplyChain: "worker-chain",
+				Resources:   []string{"worker-image"},
+				Workloads:   []types.NamespacedName{nn("worker-1")},
+			},
+		))
+		Expect(report.Workloads()).To(ConsistOf(nn("web-1"), nn("web-2"), nn("worker-1")))
+	})
+
+	Context("a workload more than one referencing supply chain selects", func() {
+		BeforeEach(func() {
+			clientObjects = append(clientObjects,
+				supplyChain("other-web-chain", map[string]string{"type": "web"},
+					resource("image", "ClusterImageTemplate", "kpack"),
+				),
+			)
+		})
+
+		It("is not reported, as neither supply chain stamps it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.SupplyChains).To(HaveLen(3))
+			Expect(report.Workloads()).To(ConsistOf(nn("worker-1")))
+		})
+	})
+
+	Context("no supply chain references the template", func() {
+		BeforeEach(func() {
+			templateRef = v1alpha1.ClusterTemplateReference{Kind: "ClusterTemplate", Name: "kpack"}
+		})
+
+		It("reports nothing", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.SupplyChains).To(BeEmpty())
+			Expect(report.Workloads()).To(BeEmpty())
+		})
+	})
+
+	Context("the client cannot list supply chains", func() {
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			clientObjects = nil
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("list supply chains")))
+			Expect(report).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,30 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// ValidateSelector reports every problem with the selector of the supply chain, the same
+// checks the validating webhook admits supply chains with. The errors are *field.Error,
+// naming the offending field and value.
+func ValidateSelector(sc v1alpha1.ClusterSupplyChain) []error {
+	var errs []error
+	for _, err := range sc.ValidateSelector() {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+)
+
+var _ = Describe("ValidateSelector", func() {
+	var supplyChain v1alpha1.ClusterSupplyChain
+
+	BeforeEach(func() {
+		supplyChain = v1alpha1.ClusterSupplyChain{}
+	})
+
+	Context("the selector is valid", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Selector = map[string]string{
+				"apps.tanzu.vmware.com/workload-type": "web",
+				"team":                                "",
+			}
+		})
+
+		It("returns no errors", func() {
+			Expect(registrar.ValidateSelector(supplyChain)).To(BeEmpty())
+		})
+	})
+
+	Context("the selector is empty", func() {
+		It("returns a required error on the selector", func() {
+			errs := registrar.ValidateSelector(supplyChain)
+			Expect(errs).To(HaveLen(1))
+
+			fieldErr, ok := errs[0].(*field.Error)
+			Expect(ok).To(BeTrue())
+			Expect(fieldErr.Type).To(Equal(field.ErrorTypeRequired))
+			Expect(fieldErr.Field).To(Equal("spec.selector"))
+			Expect(fieldErr.Error()).To(ContainSubstring("an empty selector matches no workload"))
+		})
+	})
+
+	Context("the selector has invalid labels", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Selector = map[string]string{
+				"not a key": "web",
+				"team":      "not a value!",
+			}
+		})
+
+		It("returns an error for every invalid key and value", func() {
+			errs := registrar.ValidateSelector(supplyChain)
+			Expect(errs).To(HaveLen(2))
+
+			var badValues []interface{}
+			for _, err := range errs {
+				fieldErr, ok := err.(*field.Error)
+				Expect(ok).To(BeTrue())
+				Expect(fieldErr.Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(fieldErr.Field).To(Equal("spec.selector"))
+				badValues = append(badValues, fieldErr.BadValue)
+			}
+			Expect(badValues).To(ConsistOf("not a key", "not a value!"))
+		})
+	})
+})
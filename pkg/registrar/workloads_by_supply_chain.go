@@ -0,0 +1,83 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+// UnselectedWorkloads is the key WorkloadsBySupplyChain groups workloads under when no
+// single supply chain is selected for them.
+const UnselectedWorkloads = ""
+
+// WorkloadsBySupplyChain groups every workload by the name of the supply chain selected for it,
+// using the same rules as the workload reconciler. Workloads without a supply chain, or with
+// more than one candidate, are grouped under UnselectedWorkloads. Supply chains and workloads
+// are each listed once, however many there are.
+func WorkloadsBySupplyChain(ctx context.Context, c client.Client) (map[string][]types.NamespacedName, error) {
+	supplyChainList := &v1alpha1.ClusterSupplyChainList{}
+	if err := c.List(ctx, supplyChainList); err != nil {
+		return nil, fmt.Errorf("list supply chains: %w", err)
+	}
+
+	supplyChains := map[string]*v1alpha1.ClusterSupplyChain{}
+	var selectorGetters []repository.SelectorGetter
+	for i := range supplyChainList.Items {
+		supplyChain := &supplyChainList.Items[i]
+		supplyChains[supplyChain.Name] = supplyChain
+		selectorGetters = append(selectorGetters, supplyChain)
+	}
+
+	workloadList := &v1alpha1.WorkloadList{}
+	if err := c.List(ctx, workloadList); err != nil {
+		return nil, fmt.Errorf("list workloads: %w", err)
+	}
+
+	grouped := map[string][]types.NamespacedName{}
+	for i := range workloadList.Items {
+		workload := &workloadList.Items[i]
+		name := selectedSupplyChain(workload, supplyChains, selectorGetters)
+		grouped[name] = append(grouped[name], types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name})
+	}
+
+	return grouped, nil
+}
+
+func selectedSupplyChain(workload *v1alpha1.Workload, supplyChains map[string]*v1alpha1.ClusterSupplyChain, selectorGetters []repository.SelectorGetter) string {
+	if len(workload.Labels) == 0 {
+		return UnselectedWorkloads
+	}
+
+	if pinnedName, ok := workload.Annotations[v1alpha1.WorkloadSupplyChainAnnotation]; ok {
+		supplyChain, found := supplyChains[pinnedName]
+		if !found || !repository.SelectorSatisfied(workload, supplyChain) {
+			return UnselectedWorkloads
+		}
+		return pinnedName
+	}
+
+	matches := repository.CanaryMatches(workload, repository.BestLabelMatches(workload, selectorGetters))
+	if len(matches) != 1 {
+		return UnselectedWorkloads
+	}
+	return matches[0].(*v1alpha1.ClusterSupplyChain).Name
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrar_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+)
+
+var _ = Describe("WorkloadsBySupplyChain", func() {
+	var (
+		scheme        *runtime.Scheme
+		clientObjects []client.Object
+		grouped       map[string][]types.NamespacedName
+		err           error
+	)
+
+	supplyChain := func(name string, selector map[string]string) *v1alpha1.ClusterSupplyChain {
+		return &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.SupplyChainSpec{Selector: selector},
+		}
+	}
+
+	workload := func(name string, labels map[string]string) *v1alpha1.Workload {
+		return &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", Labels: labels},
+		}
+	}
+
+	nn := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "my-ns", Name: name}
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		clientObjects = []client.Object{
+			supplyChain("web-chain", map[string]string{"type": "web"}),
+			supplyChain("worker-chain", map[string]string{"type": "worker"}),
+			workload("web-1", map[string]string{"type": "web"}),
+			workload("web-2", map[string]string{"type": "web", "team": "a"}),
+			workload("worker-1", map[string]string{"type": "worker"}),
+			workload("batch-1", map[string]string{"type": "batch"}),
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clientObjects...).Build()
+		grouped, err = registrar.WorkloadsBySupplyChain(context.Background(), fakeClient)
+	})
+
+	It("groups the workloads by their selected supply chain", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grouped).To(HaveLen(3))
+		Expect(grouped["web-chain"]).To(ConsistOf(nn("web-1"), nn("web-2")))
+		Expect(grouped["worker-chain"]).To(ConsistOf(nn("worker-1")))
+	})
+
+	It("groups workloads no supply chain selects under the unselected key", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(grouped[registrar.UnselectedWorkloads]).To(ConsistOf(nn("batch-1")))
+	})
+
+	Context("a workload more than one supply chain selects", func() {
+		BeforeEach(func() {
+			clientObjects = append(clientObjects, supplyChain("other-web-chain", map[string]string{"type": "web"}))
+		})
+
+		It("is not selected", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(grouped).NotTo(HaveKey("web-chain"))
+			Expect(grouped).NotTo(HaveKey("other-web-chain"))
+			Expect(grouped[registrar.UnselectedWorkloads]).To(ConsistOf(nn("web-1"), nn("web-2"), nn("batch-1")))
+		})
+
+		Context("and one of them is a canary of the other", func() {
+			BeforeEach(func() {
+				canary := supplyChain("canary-web-chain", map[string]string{"type": "web"})
+				canary.Spec.CanaryWeight = 100
+				clientObjects = append(clientObjects[:len(clientObjects)-1], canary)
+			})
+
+			It("is grouped under the supply chain its canary weight routes it to", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(grouped).NotTo(HaveKey("web-chain"))
+				Expect(grouped["canary-web-chain"]).To(ConsistOf(nn("web-1"), nn("web-2")))
+			})
+		})
+	})
+
+	Context("a workload pinned to a supply chain", func() {
+		BeforeEach(func() {
+			pinned := workload("web-3", map[string]string{"type": "web", "stage": "worker"})
+			pinned.Annotations = map[string]string{v1alpha1.WorkloadSupplyChainAnnotation: "worker-chain"}
+			missing := workload("web-4", map[string]string{"type": "web"})
+			missing.Annotations = map[string]string{v1alpha1.WorkloadSupplyChainAnnotation: "missing-chain"}
+			satisfied := workload("worker-2", map[string]string{"type": "worker"})
+			satisfied.Annotations = map[string]string{v1alpha1.WorkloadSupplyChainAnnotation: "worker-chain"}
+			clientObjects = append(clientObjects, pinned, missing, satisfied)
+		})
+
+		It("is grouped under the pinned supply chain only when it exists and its selector is satisfied", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(grouped["worker-chain"]).To(ConsistOf(nn("worker-1"), nn("worker-2")))
+			Expect(grouped[registrar.UnselectedWorkloads]).To(ConsistOf(nn("batch-1"), nn("web-3"), nn("web-4")))
+		})
+	})
+
+	Context("the client cannot list supply chains", func() {
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			clientObjects = nil
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("list supply chains")))
+			Expect(grouped).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,67 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+)
+
+// ReviewAccess asks the api server, through a SelfSubjectAccessReview, whether the identity
+// of the repository's client may perform the verb on the resource of the object, in the
+// object's namespace. It returns an AccessDeniedError when it may not.
+func (r *repository) ReviewAccess(ctx context.Context, obj *unstructured.Unstructured, verb string) error {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("ReviewAccess", "verb", verb)
+
+	gvk := obj.GroupVersionKind()
+	mapping, err := r.cl.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		log.Error(err, "failed to get rest mapping", "gvk", gvk)
+		return fmt.Errorf("failed to get rest mapping for [%s]: %w", gvk, err)
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: obj.GetNamespace(),
+				Verb:      verb,
+				Group:     mapping.Resource.Group,
+				Version:   mapping.Resource.Version,
+				Resource:  mapping.Resource.Resource,
+			},
+		},
+	}
+	if err := r.cl.Create(ctx, review); err != nil {
+		log.Error(err, "failed to create self subject access review")
+		return fmt.Errorf("failed to review access to [%s]: %w", mapping.Resource.GroupResource(), err)
+	}
+
+	if !review.Status.Allowed {
+		return AccessDeniedError{
+			Verb:      verb,
+			Resource:  mapping.Resource.GroupResource().String(),
+			Namespace: obj.GetNamespace(),
+			Reason:    review.Status.Reason,
+		}
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ type Logger interface {
 type RepoCache interface {
 	Set(submitted, persisted *unstructured.Unstructured)
 	UnchangedSinceCached(local *unstructured.Unstructured, remote []*unstructured.Unstructured) *unstructured.Unstructured
+	Forget(submitted *unstructured.Unstructured)
 }
 
 func NewCache(l Logger) RepoCache {
@@ -52,6 +53,13 @@ func (c *cache) Set(submitted, persisted *unstructured.Unstructured) {
 	c.persistedCache[key] = *persisted
 }
 
+// Forget drops the object from the cache, so the next submission of it is applied.
+func (c *cache) Forget(submitted *unstructured.Unstructured) {
+	key := getKey(submitted)
+	delete(c.submittedCache, key)
+	delete(c.persistedCache, key)
+}
+
 func (c *cache) UnchangedSinceCached(submitted *unstructured.Unstructured, existingList []*unstructured.Unstructured) *unstructured.Unstructured {
 	key := getKey(submitted)
 	c.logger.Info("checking for changes since cached", "key", key)
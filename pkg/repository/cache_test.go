@@ -111,6 +111,11 @@ var _ = Describe("Cache", func() {
 							It("is true", func() {
 								Expect(cache.UnchangedSinceCached(submitted, existingObjsOnAPIServer)).ToNot(BeNil())
 							})
+
+							It("is false once the submitted object is forgotten", func() {
+								cache.Forget(submitted)
+								Expect(cache.UnchangedSinceCached(submitted, existingObjsOnAPIServer)).To(BeNil())
+							})
 						})
 
 						Context("when the existing object spec differs from the cached submitted object spec", func() {
@@ -0,0 +1,111 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CanaryWeightGetter is implemented by targets that take a percentage of the
+// sources they tie with, such as a new version of a supply chain rolled out
+// to some of the workloads of the version it replaces.
+type CanaryWeightGetter interface {
+	GetCanaryWeight() int
+}
+
+// UIDGetter is implemented by sources whose UID places them among the
+// weighted targets they tie with.
+type UIDGetter interface {
+	GetUID() types.UID
+}
+
+// CanaryMatches narrows matches, the equally specific targets found by
+// BestLabelMatches, down to the one the source is routed to by their canary
+// weights. Each weighted target takes its weight out of a hundred buckets and
+// the one target without a weight takes the remaining buckets; the source
+// lands in the bucket of a stable hash of its UID. Weighted targets are given
+// the first buckets, ordered by name, so that raising a weight only moves
+// sources over from the target without one.
+//
+// Matches are returned unchanged when none of them is weighted, or when more
+// than one of them is not.
+func CanaryMatches(source UIDGetter, matches []SelectorGetter) []SelectorGetter {
+	if len(matches) < 2 {
+		return matches
+	}
+
+	var weighted []SelectorGetter
+	var unweighted SelectorGetter
+	total := 0
+	for _, match := range matches {
+		weight := canaryWeightOf(match)
+		if weight > 0 {
+			weighted = append(weighted, match)
+			total += weight
+			continue
+		}
+		if unweighted != nil {
+			return matches
+		}
+		unweighted = match
+	}
+
+	if len(weighted) == 0 {
+		return matches
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return nameOf(weighted[i]) < nameOf(weighted[j])
+	})
+
+	buckets := total
+	if unweighted != nil && buckets < 100 {
+		buckets = 100
+	}
+
+	bucket := canaryBucket(source.GetUID(), buckets)
+	for _, match := range weighted {
+		if bucket < canaryWeightOf(match) {
+			return []SelectorGetter{match}
+		}
+		bucket -= canaryWeightOf(match)
+	}
+
+	return []SelectorGetter{unweighted}
+}
+
+func canaryWeightOf(target SelectorGetter) int {
+	if getter, ok := target.(CanaryWeightGetter); ok {
+		return getter.GetCanaryWeight()
+	}
+	return 0
+}
+
+func nameOf(target SelectorGetter) string {
+	if getter, ok := target.(interface{ GetName() string }); ok {
+		return getter.GetName()
+	}
+	return ""
+}
+
+// canaryBucket is the bucket, out of buckets, a stable hash of uid falls in.
+func canaryBucket(uid types.UID, buckets int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(uid))
+	return int(hash.Sum32() % uint32(buckets))
+}
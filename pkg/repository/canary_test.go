@@ -0,0 +1,126 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+var _ = Describe("CanaryMatches", func() {
+	var stable, canary *v1alpha1.ClusterSupplyChain
+
+	supplyChain := func(name string, canaryWeight int) *v1alpha1.ClusterSupplyChain {
+		return &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.SupplyChainSpec{
+				Selector:     map[string]string{"type": "web"},
+				CanaryWeight: canaryWeight,
+			},
+		}
+	}
+
+	workload := func(idx int) *v1alpha1.Workload {
+		return &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("workload-%d", idx),
+				UID:  types.UID(fmt.Sprintf("7c1f0e52-6f2a-4d6b-9a51-%012d", idx)),
+			},
+		}
+	}
+
+	selectedFor := func(count int, matches ...repository.SelectorGetter) map[string]int {
+		selected := map[string]int{}
+		for idx := 0; idx < count; idx++ {
+			res := repository.CanaryMatches(workload(idx), matches)
+			Expect(res).To(HaveLen(1))
+			selected[res[0].(*v1alpha1.ClusterSupplyChain).Name]++
+		}
+		return selected
+	}
+
+	BeforeEach(func() {
+		stable = supplyChain("stable", 0)
+		canary = supplyChain("canary", 20)
+	})
+
+	It("routes the weighted percentage of workloads to the canary", func() {
+		selected := selectedFor(2000, stable, canary)
+		Expect(selected["canary"]).To(BeNumerically("~", 400, 80))
+		Expect(selected["stable"]).To(BeNumerically("~", 1600, 80))
+	})
+
+	It("routes a workload to the same supply chain every time", func() {
+		for idx := 0; idx < 100; idx++ {
+			first := repository.CanaryMatches(workload(idx), []repository.SelectorGetter{stable, canary})
+			Expect(repository.CanaryMatches(workload(idx), []repository.SelectorGetter{canary, stable})).To(Equal(first))
+		}
+	})
+
+	It("only moves workloads over from the stable supply chain when the weight is raised", func() {
+		before := map[int]string{}
+		for idx := 0; idx < 500; idx++ {
+			before[idx] = repository.CanaryMatches(workload(idx), []repository.SelectorGetter{stable, canary})[0].(*v1alpha1.ClusterSupplyChain).Name
+		}
+
+		canary.Spec.CanaryWeight = 50
+		for idx := 0; idx < 500; idx++ {
+			if before[idx] == "canary" {
+				Expect(repository.CanaryMatches(workload(idx), []repository.SelectorGetter{stable, canary})).To(ConsistOf(canary))
+			}
+		}
+	})
+
+	Context("every match is weighted", func() {
+		It("distributes the workloads in proportion to the weights", func() {
+			selected := selectedFor(2000, supplyChain("a", 30), supplyChain("b", 10))
+			Expect(selected["a"]).To(BeNumerically("~", 1500, 100))
+			Expect(selected["b"]).To(BeNumerically("~", 500, 100))
+		})
+	})
+
+	Context("the weights add up to a hundred", func() {
+		It("routes every workload to the weighted supply chains", func() {
+			Expect(selectedFor(200, stable, supplyChain("canary", 100))).To(Equal(map[string]int{"canary": 200}))
+		})
+	})
+
+	Context("no match is weighted", func() {
+		It("returns the matches unchanged", func() {
+			other := supplyChain("other", 0)
+			Expect(repository.CanaryMatches(workload(0), []repository.SelectorGetter{stable, other})).To(Equal([]repository.SelectorGetter{stable, other}))
+		})
+	})
+
+	Context("more than one match is not weighted", func() {
+		It("returns the matches unchanged", func() {
+			other := supplyChain("other", 0)
+			Expect(repository.CanaryMatches(workload(0), []repository.SelectorGetter{stable, other, canary})).To(Equal([]repository.SelectorGetter{stable, other, canary}))
+		})
+	})
+
+	Context("there is a single match", func() {
+		It("returns it, whatever its weight", func() {
+			Expect(repository.CanaryMatches(workload(0), []repository.SelectorGetter{canary})).To(Equal([]repository.SelectorGetter{canary}))
+		})
+	})
+})
@@ -0,0 +1,113 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IsTransientError reports whether an error returned by the repository is likely to pass on
+// its own: the API server timed out, was unavailable or throttled the request, or the request
+// timed out on its way there.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if kerrors.IsTimeout(err) || kerrors.IsServerTimeout(err) || kerrors.IsServiceUnavailable(err) || kerrors.IsTooManyRequests(err) {
+		return true
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// TemplateNotFoundError is returned for a template reference whose kind is known but that
+// names a template that does not exist.
+type TemplateNotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e TemplateNotFoundError) Error() string {
+	return fmt.Sprintf("template [%s] of kind [%s] not found", e.Name, e.Kind)
+}
+
+// IsTemplateNotFound reports whether an error returned by the repository is, or wraps, a
+// TemplateNotFoundError.
+func IsTemplateNotFound(err error) bool {
+	var notFound TemplateNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// AccessDeniedError is returned when the api server reports that the client may not perform
+// the verb on the resource.
+type AccessDeniedError struct {
+	Verb      string
+	Resource  string
+	Namespace string
+	Reason    string
+}
+
+func (e AccessDeniedError) Error() string {
+	msg := fmt.Sprintf("not permitted to [%s] resource [%s] in namespace [%s]", e.Verb, e.Resource, e.Namespace)
+	if e.Reason != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Reason)
+	}
+	return msg
+}
+
+// StampedObjectOwnerLabels are the labels cartographer stamps objects with that name the
+// workload, deliverable or runnable the object belongs to. Cartographer does not overwrite an
+// object carrying one of them with a different value than its stamped object.
+var StampedObjectOwnerLabels = []string{
+	"carto.run/workload-namespace",
+	"carto.run/workload-name",
+	"carto.run/deliverable-namespace",
+	"carto.run/deliverable-name",
+	RunnableNameLabel,
+}
+
+// StampedObjectConflictError is returned when an object of the stamped object's name already
+// exists and is controlled by something else. The existing object is left alone.
+type StampedObjectConflictError struct {
+	Object *unstructured.Unstructured
+	// Owner describes what the existing object belongs to, empty when it has no controller.
+	Owner string
+}
+
+func (e StampedObjectConflictError) Error() string {
+	if e.Owner == "" {
+		return fmt.Sprintf("object [%s/%s] of kind [%s] already exists without a controller", e.Object.GetNamespace(), e.Object.GetName(), e.Object.GetKind())
+	}
+	return fmt.Sprintf("object [%s/%s] of kind [%s] already exists and belongs to %s", e.Object.GetNamespace(), e.Object.GetName(), e.Object.GetKind(), e.Owner)
+}
+
+// IsStampedObjectConflict reports whether an error returned by the repository is, or wraps, a
+// StampedObjectConflictError.
+func IsStampedObjectConflict(err error) bool {
+	var conflict StampedObjectConflictError
+	return errors.As(err, &conflict)
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+)
+
+var _ = Describe("IsTransientError", func() {
+	wrapped := func(err error) error {
+		return fmt.Errorf("failed to get runnable object from api server [ns/name]: %w", err)
+	}
+
+	DescribeTable("classifying errors",
+		func(err error, transient bool) {
+			Expect(repository.IsTransientError(err)).To(Equal(transient))
+		},
+		Entry("no error", nil, false),
+		Entry("a server timeout", wrapped(kerrors.NewServerTimeout(schema.GroupResource{Resource: "runnables"}, "get", 1)), true),
+		Entry("a timeout", wrapped(kerrors.NewTimeoutError("timed out", 1)), true),
+		Entry("an unavailable server", wrapped(kerrors.NewServiceUnavailable("unavailable")), true),
+		Entry("a throttled request", wrapped(kerrors.NewTooManyRequests("slow down", 1)), true),
+		Entry("a deadline exceeded", wrapped(context.DeadlineExceeded), true),
+		Entry("a forbidden request", wrapped(kerrors.NewForbidden(schema.GroupResource{Resource: "runnables"}, "name", errors.New("no"))), false),
+		Entry("an unexpected error", wrapped(errors.New("very bad runnable")), false),
+	)
+})
@@ -0,0 +1,32 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import "context"
+
+type fieldManagerKey struct{}
+
+// WithFieldManager returns a context in which EnsureObjectExistsOnCluster creates and patches
+// objects as the given field manager.
+func WithFieldManager(ctx context.Context, fieldManager string) context.Context {
+	return context.WithValue(ctx, fieldManagerKey{}, fieldManager)
+}
+
+// FieldManagerFromContext returns the field manager set with WithFieldManager, empty when
+// none was set and the client's default field manager is used.
+func FieldManagerFromContext(ctx context.Context) string {
+	fieldManager, _ := ctx.Value(fieldManagerKey{}).(string)
+	return fieldManager
+}
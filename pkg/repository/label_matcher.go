@@ -14,6 +14,12 @@
 
 package repository
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
 type SelectorGetter interface {
 	GetSelector() map[string]string
 }
@@ -22,6 +28,31 @@ type LabelsGetter interface {
 	GetLabels() map[string]string
 }
 
+// OwnerSelectorGetter is implemented by targets that, on top of their labels,
+// select sources by their owner references.
+//
+type OwnerSelectorGetter interface {
+	GetOwnerSelector() *v1alpha1.OwnerSelector
+}
+
+// OwnerReferencesGetter is implemented by sources whose owner references can
+// be matched by an OwnerSelectorGetter.
+//
+type OwnerReferencesGetter interface {
+	GetOwnerReferences() []metav1.OwnerReference
+}
+
+// StaticSelectorGetter adapts a plain label set to a SelectorGetter, so
+// that arbitrary selectors can be scored by BestLabelMatches.
+//
+type StaticSelectorGetter struct {
+	Labels map[string]string
+}
+
+func (s StaticSelectorGetter) GetSelector() map[string]string {
+	return s.Labels
+}
+
 // BestLabelMatches attempts at finding the targets that best match the label set
 // of the source.
 //
@@ -33,10 +64,14 @@ func BestLabelMatches(source LabelsGetter, targets []SelectorGetter) []SelectorG
 	// count the number of matches
 	matchCounter := make([]int, len(targets))
 	for idx, target := range targets {
-		if !subsetOf(source.GetLabels(), target.GetSelector()) {
+		if !subsetOf(source.GetLabels(), target.GetSelector()) || !ownersMatch(source, target) {
 			continue
 		}
 
+		if ownerSelector := ownerSelectorOf(target); ownerSelector != nil {
+			matchCounter[idx] += ownerSelector.Size()
+		}
+
 		for key, value := range target.GetSelector() {
 			srcValue, found := source.GetLabels()[key]
 			if !found || srcValue != value {
@@ -60,13 +95,13 @@ func BestLabelMatches(source LabelsGetter, targets []SelectorGetter) []SelectorG
 	// filter down to the most specific set
 	selectorsCount := make([]int, len(selectors))
 	for idx, selector := range selectors {
-		selectorsCount[idx] = len(selector.GetSelector())
+		selectorsCount[idx] = SelectorSize(selector)
 	}
 
 	var res []SelectorGetter
 	minSelectorCount := minSlice(selectorsCount)
 	for _, selector := range selectors {
-		if len(selector.GetSelector()) == minSelectorCount {
+		if SelectorSize(selector) == minSelectorCount {
 			res = append(res, selector)
 		}
 	}
@@ -74,6 +109,73 @@ func BestLabelMatches(source LabelsGetter, targets []SelectorGetter) []SelectorG
 	return res
 }
 
+// SelectorSatisfied verifies whether the label set of the source satisfies the
+// full, non-empty, selector of the target, and its owner references the owner
+// selector of the target, if any.
+//
+func SelectorSatisfied(source LabelsGetter, target SelectorGetter) bool {
+	return len(target.GetSelector()) > 0 && subsetOf(source.GetLabels(), target.GetSelector()) && ownersMatch(source, target)
+}
+
+// SelectorSize is the number of criteria of the selector of the target: its
+// labels and those of its owner selector, if any.
+//
+func SelectorSize(target SelectorGetter) int {
+	size := len(target.GetSelector())
+	if ownerSelector := ownerSelectorOf(target); ownerSelector != nil {
+		size += ownerSelector.Size()
+	}
+	return size
+}
+
+// SelectorsOverlap verifies whether some label set can satisfy the full,
+// non-empty, selectors of both a and b, that is, whether the selectors do not
+// require different values for a shared key nor owners of different kinds.
+//
+func SelectorsOverlap(a, b SelectorGetter) bool {
+	if len(a.GetSelector()) == 0 || len(b.GetSelector()) == 0 {
+		return false
+	}
+
+	for key, value := range a.GetSelector() {
+		if other, found := b.GetSelector()[key]; found && other != value {
+			return false
+		}
+	}
+
+	// a source is seldom owned by objects of two kinds, so owner selectors of
+	// different kinds are not taken to overlap
+	ownerA, ownerB := ownerSelectorOf(a), ownerSelectorOf(b)
+	if ownerA != nil && ownerB != nil && ownerA.OwnerKind != ownerB.OwnerKind {
+		return false
+	}
+
+	return true
+}
+
+func ownerSelectorOf(target SelectorGetter) *v1alpha1.OwnerSelector {
+	if getter, ok := target.(OwnerSelectorGetter); ok {
+		return getter.GetOwnerSelector()
+	}
+	return nil
+}
+
+// ownersMatch verifies whether the owner references of the source satisfy the
+// owner selector of the target, if it has one.
+//
+func ownersMatch(source LabelsGetter, target SelectorGetter) bool {
+	ownerSelector := ownerSelectorOf(target)
+	if ownerSelector == nil {
+		return true
+	}
+
+	getter, ok := source.(OwnerReferencesGetter)
+	if !ok {
+		return false
+	}
+	return ownerSelector.Matches(getter.GetOwnerReferences())
+}
+
 // minSlice gets the minimum value in a given slice (or 999, otherwise)
 //
 func minSlice(slice []int) int {
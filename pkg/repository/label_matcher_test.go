@@ -50,6 +50,24 @@ var _ = Describe("BestLabelMatches", func() {
 		}
 	}
 
+	var owned = func(labelset labels, owners ...metav1.OwnerReference) repository.LabelsGetter {
+		return &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:          labelset,
+				OwnerReferences: owners,
+			},
+		}
+	}
+
+	var osg = func(labelset labels, ownerSelector *v1alpha1.OwnerSelector) repository.SelectorGetter {
+		return &v1alpha1.ClusterSupplyChain{
+			Spec: v1alpha1.SupplyChainSpec{
+				Selector:      labelset,
+				OwnerSelector: ownerSelector,
+			},
+		}
+	}
+
 	DescribeTable("cases",
 		func(tc testcase) {
 			actual := repository.BestLabelMatches(
@@ -208,5 +226,180 @@ var _ = Describe("BestLabelMatches", func() {
 				}),
 			},
 		}),
+
+		Entry("owner selector; source owned by the selected kind", testcase{
+			source: owned(labels{"type": "web"}, metav1.OwnerReference{APIVersion: "apps.example.com/v1", Kind: "App"}),
+			targets: []repository.SelectorGetter{
+				sg(labels{"type": "web"}),
+				osg(labels{"type": "web"}, &v1alpha1.OwnerSelector{OwnerKind: "App", OwnerAPIGroup: "apps.example.com"}),
+			},
+			expected: []repository.SelectorGetter{
+				osg(labels{"type": "web"}, &v1alpha1.OwnerSelector{OwnerKind: "App", OwnerAPIGroup: "apps.example.com"}),
+			},
+		}),
+
+		Entry("owner selector; source with several owners, one of the selected kind", testcase{
+			source: owned(labels{"type": "web"},
+				metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap"},
+				metav1.OwnerReference{APIVersion: "apps.example.com/v1", Kind: "App"},
+			),
+			targets: []repository.SelectorGetter{
+				osg(labels{"type": "web"}, &v1alpha1.OwnerSelector{OwnerKind: "App"}),
+			},
+			expected: []repository.SelectorGetter{
+				osg(labels{"type": "web"}, &v1alpha1.OwnerSelector{OwnerKind: "App"}),
+			},
+		}),
+
+		Entry("owner selector; source owned by another group", testcase{
+			source: owned(labels{"type": "web"}, metav1.OwnerReference{APIVersion: "other.example.com/v1", Kind: "App"}),
+			targets: []repository.SelectorGetter{
+				osg(labels{"type": "web"}, &v1alpha1.OwnerSelector{OwnerKind: "App", OwnerAPIGroup: "apps.example.com"}),
+			},
+			expected: nil,
+		}),
+
+		Entry("owner selector; unowned source", testcase{
+			source: lg(labels{"type": "web"}),
+			targets: []repository.SelectorGetter{
+				sg(labels{"type": "web"}),
+				osg(labels{"type": "web"}, &v1alpha1.OwnerSelector{OwnerKind: "App"}),
+			},
+			expected: []repository.SelectorGetter{
+				sg(labels{"type": "web"}),
+			},
+		}),
 	)
 })
+
+var _ = Describe("SelectorSatisfied", func() {
+	type labels map[string]string
+
+	var lg = func(labelset labels) repository.LabelsGetter {
+		return &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labelset,
+			},
+		}
+	}
+
+	var sg = func(labelset labels) repository.SelectorGetter {
+		return &v1alpha1.ClusterSupplyChain{
+			Spec: v1alpha1.SupplyChainSpec{
+				Selector: labelset,
+			},
+		}
+	}
+
+	DescribeTable("cases",
+		func(source repository.LabelsGetter, target repository.SelectorGetter, expected bool) {
+			Expect(repository.SelectorSatisfied(source, target)).To(Equal(expected))
+		},
+
+		Entry("labels are a superset of the selector",
+			lg(labels{"type": "web", "test": "tekton"}), sg(labels{"type": "web"}), true),
+		Entry("labels equal the selector",
+			lg(labels{"type": "web"}), sg(labels{"type": "web"}), true),
+		Entry("labels miss part of the selector",
+			lg(labels{"type": "web"}), sg(labels{"type": "web", "test": "tekton"}), false),
+		Entry("labels mismatch the selector value",
+			lg(labels{"type": "web"}), sg(labels{"type": "worker"}), false),
+		Entry("empty selector",
+			lg(labels{"type": "web"}), sg(labels{}), false),
+		Entry("labels and an owner satisfy the owner selector",
+			&v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{
+				Labels:          labels{"type": "web"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps.example.com/v1", Kind: "App"}},
+			}},
+			&v1alpha1.ClusterSupplyChain{Spec: v1alpha1.SupplyChainSpec{
+				Selector:      labels{"type": "web"},
+				OwnerSelector: &v1alpha1.OwnerSelector{OwnerKind: "App"},
+			}}, true),
+		Entry("labels satisfy the selector but no owner the owner selector",
+			lg(labels{"type": "web"}),
+			&v1alpha1.ClusterSupplyChain{Spec: v1alpha1.SupplyChainSpec{
+				Selector:      labels{"type": "web"},
+				OwnerSelector: &v1alpha1.OwnerSelector{OwnerKind: "App"},
+			}}, false),
+	)
+})
+
+var _ = Describe("SelectorsOverlap", func() {
+	type labels map[string]string
+
+	var sg = func(labelset labels) repository.SelectorGetter {
+		return repository.StaticSelectorGetter{Labels: labelset}
+	}
+
+	DescribeTable("cases",
+		func(a, b repository.SelectorGetter, expected bool) {
+			Expect(repository.SelectorsOverlap(a, b)).To(Equal(expected))
+			Expect(repository.SelectorsOverlap(b, a)).To(Equal(expected))
+		},
+
+		Entry("equal selectors",
+			sg(labels{"type": "web"}), sg(labels{"type": "web"}), true),
+		Entry("selectors on different keys",
+			sg(labels{"type": "web"}), sg(labels{"test": "tekton"}), true),
+		Entry("selectors agreeing on a shared key",
+			sg(labels{"type": "web", "test": "tekton"}), sg(labels{"type": "web", "scan": "grype"}), true),
+		Entry("a selector that is a subset of the other",
+			sg(labels{"type": "web"}), sg(labels{"type": "web", "test": "tekton"}), true),
+		Entry("selectors disagreeing on a shared key",
+			sg(labels{"type": "web"}), sg(labels{"type": "worker"}), false),
+		Entry("selectors disagreeing on one of their shared keys",
+			sg(labels{"type": "web", "test": "tekton"}), sg(labels{"type": "web", "test": "none"}), false),
+		Entry("an empty selector",
+			sg(labels{}), sg(labels{"type": "web"}), false),
+		Entry("equal selectors with owner selectors of different kinds",
+			&v1alpha1.ClusterSupplyChain{Spec: v1alpha1.SupplyChainSpec{
+				Selector: labels{"type": "web"}, OwnerSelector: &v1alpha1.OwnerSelector{OwnerKind: "App"},
+			}},
+			&v1alpha1.ClusterSupplyChain{Spec: v1alpha1.SupplyChainSpec{
+				Selector: labels{"type": "web"}, OwnerSelector: &v1alpha1.OwnerSelector{OwnerKind: "Service"},
+			}}, false),
+		Entry("equal selectors with an owner selector on one of them",
+			&v1alpha1.ClusterSupplyChain{Spec: v1alpha1.SupplyChainSpec{
+				Selector: labels{"type": "web"}, OwnerSelector: &v1alpha1.OwnerSelector{OwnerKind: "App"},
+			}},
+			sg(labels{"type": "web"}), true),
+	)
+})
+
+var _ = Describe("StaticSelectorGetter", func() {
+	var source repository.LabelsGetter
+
+	BeforeEach(func() {
+		source = &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"type": "web",
+					"test": "tekton",
+				},
+			},
+		}
+	})
+
+	It("returns its labels as the selector", func() {
+		getter := repository.StaticSelectorGetter{Labels: map[string]string{"type": "web"}}
+		Expect(getter.GetSelector()).To(Equal(map[string]string{"type": "web"}))
+	})
+
+	It("is scored by BestLabelMatches", func() {
+		partial := repository.StaticSelectorGetter{Labels: map[string]string{"type": "web"}}
+		full := repository.StaticSelectorGetter{Labels: map[string]string{"type": "web", "test": "tekton"}}
+		mismatched := repository.StaticSelectorGetter{Labels: map[string]string{"type": "worker"}}
+		extra := repository.StaticSelectorGetter{Labels: map[string]string{"type": "web", "test": "tekton", "scan": "security"}}
+
+		matches := repository.BestLabelMatches(source, []repository.SelectorGetter{partial, full, mismatched, extra})
+		Expect(matches).To(Equal([]repository.SelectorGetter{full}))
+	})
+
+	It("returns every getter tied for the best match", func() {
+		first := repository.StaticSelectorGetter{Labels: map[string]string{"type": "web"}}
+		second := repository.StaticSelectorGetter{Labels: map[string]string{"test": "tekton"}}
+
+		matches := repository.BestLabelMatches(source, []repository.SelectorGetter{first, second})
+		Expect(matches).To(Equal([]repository.SelectorGetter{first, second}))
+	})
+})
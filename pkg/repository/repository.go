@@ -20,10 +20,13 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -47,33 +50,72 @@ type Repository interface {
 	GetSupplyChain(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error)
 	StatusUpdate(ctx context.Context, object client.Object) error
 	GetRunnable(ctx context.Context, name string, namespace string) (*v1alpha1.Runnable, error)
+	DefaultRunnable(ctx context.Context, runnable *v1alpha1.Runnable) error
 	ListUnstructured(ctx context.Context, obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error)
+	ListStampedObjects(ctx context.Context, owner client.Object) ([]*unstructured.Unstructured, error)
+	DeleteUnstructured(ctx context.Context, obj *unstructured.Unstructured) error
+	GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	GetUnstructuredLive(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	GetDelivery(ctx context.Context, name string) (*v1alpha1.ClusterDelivery, error)
 	GetScheme() *runtime.Scheme
 	GetServiceAccountSecret(ctx context.Context, serviceAccountName, ns string) (*corev1.Secret, error)
+	GetServiceAccountSecretLive(ctx context.Context, serviceAccountName, ns string) (*corev1.Secret, error)
+	GetCustomResourceDefinition(ctx context.Context, gvk schema.GroupVersionKind) (*apiextensionsv1.CustomResourceDefinition, error)
+	GetSubresource(ctx context.Context, obj *unstructured.Unstructured, subresource string) (map[string]interface{}, error)
+	ReviewAccess(ctx context.Context, obj *unstructured.Unstructured, verb string) error
 }
 
 type RepositoryBuilder func(client client.Client, repoCache RepoCache) Repository
 
 type repository struct {
-	rc RepoCache
-	cl client.Client
+	rc        RepoCache
+	cl        client.Client
+	apiReader client.Reader
 }
 
 func NewRepository(client client.Client, repoCache RepoCache) Repository {
 	return &repository{
-		rc: repoCache,
-		cl: client,
+		rc:        repoCache,
+		cl:        client,
+		apiReader: client,
 	}
 }
 
+// NewRepositoryWithAPIReader returns a Repository whose reads go through the client, usually
+// backed by the manager's informer cache and so possibly stale, except for the reads of the
+// *Live methods, which go through the apiReader.
+func NewRepositoryWithAPIReader(client client.Client, apiReader client.Reader, repoCache RepoCache) Repository {
+	return &repository{
+		rc:        repoCache,
+		cl:        client,
+		apiReader: apiReader,
+	}
+}
+
+// GetServiceAccountSecret reads the token secret of the service account through the client,
+// which may return a stale secret shortly after it was rotated.
 func (r *repository) GetServiceAccountSecret(ctx context.Context, name, namespace string) (*corev1.Secret, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("service account", fmt.Sprintf("%s/%s", namespace, name))
 	ctx = logr.NewContext(ctx, log)
 	log.V(logger.DEBUG).Info("GetServiceAccountSecret")
 
+	return r.getServiceAccountSecret(ctx, r.cl, name, namespace)
+}
+
+// GetServiceAccountSecretLive reads the token secret of the service account from the api server.
+func (r *repository) GetServiceAccountSecretLive(ctx context.Context, name, namespace string) (*corev1.Secret, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("service account", fmt.Sprintf("%s/%s", namespace, name))
+	ctx = logr.NewContext(ctx, log)
+	log.V(logger.DEBUG).Info("GetServiceAccountSecretLive")
+
+	return r.getServiceAccountSecret(ctx, r.apiReader, name, namespace)
+}
+
+func (r *repository) getServiceAccountSecret(ctx context.Context, reader client.Reader, name, namespace string) (*corev1.Secret, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
 	serviceAccount := corev1.ServiceAccount{}
-	err := r.getObject(ctx, name, namespace, &serviceAccount)
+	err := getObject(ctx, reader, name, namespace, &serviceAccount)
 	if err != nil {
 		log.Error(err, "failed to get service account object from api server")
 		return nil, fmt.Errorf("failed to get service account object from api server [%s/%s]: %w", namespace, name, err)
@@ -86,7 +128,7 @@ func (r *repository) GetServiceAccountSecret(ctx context.Context, name, namespac
 
 	for _, secretRef := range serviceAccount.Secrets {
 		secret := corev1.Secret{}
-		err := r.getObject(ctx, secretRef.Name, namespace, &secret)
+		err := getObject(ctx, reader, secretRef.Name, namespace, &secret)
 		if err != nil {
 			log.Error(err, "failed to get secret object from api server",
 				"secret", fmt.Sprintf("%s/%s", namespace, secretRef.Name))
@@ -152,12 +194,57 @@ func (r *repository) EnsureObjectExistsOnCluster(ctx context.Context, obj *unstr
 	}
 
 	if outdatedObject != nil {
+		if err := checkOwnership(obj, outdatedObject); err != nil {
+			return err
+		}
 		log.Info("patching object", "object", obj)
 		return r.patchUnstructured(ctx, outdatedObject, obj)
 	} else {
 		log.Info("creating object", "object", obj)
-		return r.createUnstructured(ctx, obj)
+		err := r.createUnstructured(ctx, obj)
+		if kerrors.IsAlreadyExists(err) {
+			return r.checkExistingOwnership(ctx, obj, err)
+		}
+		return err
+	}
+}
+
+// checkExistingOwnership reads the object whose name the stamped object collided with, returning
+// a StampedObjectConflictError when it belongs to something else and the create error otherwise.
+func (r *repository) checkExistingOwnership(ctx context.Context, obj *unstructured.Unstructured, createErr error) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := r.apiReader.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return createErr
+	}
+	if err := checkOwnership(obj, existing); err != nil {
+		return err
 	}
+	return createErr
+}
+
+// checkOwnership refuses to overwrite an existing object that one of the
+// StampedObjectOwnerLabels gives another owner than the stamped object, or whose controller
+// is not the controller of the stamped object.
+func checkOwnership(stamped, existing *unstructured.Unstructured) error {
+	for _, label := range StampedObjectOwnerLabels {
+		if owner := existing.GetLabels()[label]; owner != "" && owner != stamped.GetLabels()[label] {
+			return StampedObjectConflictError{Object: existing, Owner: fmt.Sprintf("%s label [%s]", label, owner)}
+		}
+	}
+
+	ours := metav1.GetControllerOfNoCopy(stamped)
+	if ours == nil {
+		return nil
+	}
+	theirs := metav1.GetControllerOfNoCopy(existing)
+	if theirs == nil {
+		return StampedObjectConflictError{Object: existing}
+	}
+	if theirs.UID != ours.UID {
+		return StampedObjectConflictError{Object: existing, Owner: fmt.Sprintf("%s [%s]", theirs.Kind, theirs.Name)}
+	}
+	return nil
 }
 
 func getOutdatedUnstructuredByName(target *unstructured.Unstructured, candidates []*unstructured.Unstructured) *unstructured.Unstructured {
@@ -199,6 +286,52 @@ func (r *repository) ListUnstructured(ctx context.Context, obj *unstructured.Uns
 	return pointersToUnstructureds, nil
 }
 
+func (r *repository) DeleteUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	log.Info("deleting object", "object", obj)
+	err := r.cl.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("GetUnstructured")
+
+	return r.getUnstructured(ctx, r.cl, obj)
+}
+
+// GetUnstructuredLive reads the object from the api server, bypassing any cache of the client.
+func (r *repository) GetUnstructuredLive(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("GetUnstructuredLive")
+
+	return r.getUnstructured(ctx, r.apiReader, obj)
+}
+
+func (r *repository) getUnstructured(ctx context.Context, reader client.Reader, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	found := &unstructured.Unstructured{}
+	found.SetGroupVersionKind(obj.GroupVersionKind())
+
+	err := getObject(ctx, reader, obj.GetName(), obj.GetNamespace(), found)
+	if kerrors.IsNotFound(err) {
+		log.V(logger.DEBUG).Info("object is not found on api server", "object", obj)
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err, "failed to get object from api server", "object", obj)
+		return nil, fmt.Errorf("failed to get object from api server [%s/%s]: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	return found, nil
+}
+
 func (r *repository) GetClusterTemplate(ctx context.Context, ref v1alpha1.ClusterTemplateReference) (client.Object, error) {
 	return r.getTemplate(ctx, ref.Name, ref.Kind)
 }
@@ -221,10 +354,9 @@ func (r *repository) getTemplate(ctx context.Context, name string, kind string)
 	}
 
 	err = r.getObject(ctx, name, "", apiTemplate)
-	//TODO: Remove IsNotFound check, this should just be an error, breaks kuttl test
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("template is not found on api server")
-		return nil, nil
+		return nil, TemplateNotFoundError{Kind: kind, Name: name}
 	}
 	if err != nil {
 		log.Error(err, "failed to get template object from api server")
@@ -251,9 +383,37 @@ func (r *repository) GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateRe
 	return runTemplate, nil
 }
 
+func (r *repository) GetCustomResourceDefinition(ctx context.Context, gvk schema.GroupVersionKind) (*apiextensionsv1.CustomResourceDefinition, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("GetCustomResourceDefinition")
+
+	mapping, err := r.cl.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		log.Error(err, "failed to get rest mapping", "gvk", gvk)
+		return nil, fmt.Errorf("failed to get rest mapping for [%s]: %w", gvk, err)
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	err = r.getObject(ctx, fmt.Sprintf("%s.%s", mapping.Resource.Resource, mapping.Resource.Group), "", crd)
+	if kerrors.IsNotFound(err) {
+		log.V(logger.DEBUG).Info("no custom resource definition for kind, it is built in", "gvk", gvk)
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err, "failed to get custom resource definition from api server")
+		return nil, fmt.Errorf("failed to get custom resource definition from api server for [%s]: %w", gvk, err)
+	}
+
+	return crd, nil
+}
+
 func (r *repository) createUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
 	submitted := obj.DeepCopy()
-	if err := r.cl.Create(ctx, obj); err != nil {
+	var opts []client.CreateOption
+	if fieldManager := FieldManagerFromContext(ctx); fieldManager != "" {
+		opts = append(opts, client.FieldOwner(fieldManager))
+	}
+	if err := r.cl.Create(ctx, obj, opts...); err != nil {
 		return fmt.Errorf("create: %w", err)
 	}
 
@@ -265,7 +425,11 @@ func (r *repository) patchUnstructured(ctx context.Context, existingObj *unstruc
 	submitted := obj.DeepCopy()
 
 	obj.SetResourceVersion(existingObj.GetResourceVersion())
-	if err := r.cl.Patch(ctx, obj, client.MergeFrom(existingObj)); err != nil {
+	var opts []client.PatchOption
+	if fieldManager := FieldManagerFromContext(ctx); fieldManager != "" {
+		opts = append(opts, client.FieldOwner(fieldManager))
+	}
+	if err := r.cl.Patch(ctx, obj, client.MergeFrom(existingObj), opts...); err != nil {
 		return fmt.Errorf("patch: %w", err)
 	}
 
@@ -290,7 +454,7 @@ func (r *repository) GetSupplyChainsForWorkload(ctx context.Context, workload *v
 	}
 
 	var supplyChains []*v1alpha1.ClusterSupplyChain
-	for _, matchingObject := range BestLabelMatches(workload, selectorGetters) {
+	for _, matchingObject := range CanaryMatches(workload, BestLabelMatches(workload, selectorGetters)) {
 		log.V(logger.DEBUG).Info("supply chain matched workload",
 			"supply chain", matchingObject)
 		supplyChains = append(supplyChains, matchingObject.(*v1alpha1.ClusterSupplyChain))
@@ -337,10 +501,14 @@ func getNamespacedName(name string, namespace string) string {
 }
 
 func (r *repository) getObject(ctx context.Context, name string, namespace string, obj client.Object) error {
+	return getObject(ctx, r.cl, name, namespace, obj)
+}
+
+func getObject(ctx context.Context, reader client.Reader, name string, namespace string, obj client.Object) error {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("getObject")
 
-	err := r.cl.Get(ctx,
+	err := reader.Get(ctx,
 		client.ObjectKey{
 			Name:      name,
 			Namespace: namespace,
@@ -411,6 +579,24 @@ func (r *repository) GetRunnable(ctx context.Context, name string, namespace str
 	return runnable, nil
 }
 
+// DefaultRunnable stores the runnable with its defaults applied, for runnables created before
+// the defaulting webhook applied them at admission.
+func (r *repository) DefaultRunnable(ctx context.Context, runnable *v1alpha1.Runnable) error {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("DefaultRunnable")
+
+	defaulted := runnable.DeepCopy()
+	defaulted.Default()
+
+	err := r.cl.Patch(ctx, defaulted, client.MergeFrom(runnable))
+	if err != nil {
+		log.Error(err, "failed to patch runnable object on api server")
+		return fmt.Errorf("failed to patch runnable object on api server [%s/%s]: %w", runnable.Namespace, runnable.Name, err)
+	}
+
+	return nil
+}
+
 func (r *repository) GetSupplyChain(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetSupplyChain")
@@ -23,13 +23,17 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -137,6 +141,61 @@ spec:
 					Expect(createCallObj).To(Equal(stampedObj))
 				})
 
+				It("creates the object as the field manager of the context", func() {
+					Expect(repo.EnsureObjectExistsOnCluster(repository.WithFieldManager(ctx, "cartographer-staging"), stampedObj, true)).To(Succeed())
+
+					_, _, opts := cl.CreateArgsForCall(0)
+					createOpts := &client.CreateOptions{}
+					createOpts.ApplyOptions(opts)
+					Expect(createOpts.FieldManager).To(Equal("cartographer-staging"))
+				})
+
+				It("leaves the field manager to the client when the context sets none", func() {
+					Expect(repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)).To(Succeed())
+
+					_, _, opts := cl.CreateArgsForCall(0)
+					Expect(opts).To(BeEmpty())
+				})
+
+				Context("and an object of the same name that is not labeled for the stamped object exists", func() {
+					var existingObj *unstructured.Unstructured
+
+					BeforeEach(func() {
+						isController := true
+						stampedObj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "Workload", Name: "my-workload", UID: "my-uid", Controller: &isController}})
+
+						existingObj = stampedObj.DeepCopy()
+						existingObj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "Application", Name: "foreign", UID: "foreign-uid", Controller: &isController}})
+
+						cl.CreateReturns(kerrors.NewAlreadyExists(schema.GroupResource{Group: "batch", Resource: "jobs"}, "hello"))
+						cl.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+							existingObj.DeepCopyInto(obj.(*unstructured.Unstructured))
+							return nil
+						}
+					})
+
+					It("reports the conflict with the foreign object", func() {
+						err := repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)
+						Expect(err).To(MatchError("object [default/hello] of kind [Job] already exists and belongs to Application [foreign]"))
+
+						Expect(cl.GetCallCount()).To(Equal(1))
+						_, key, _ := cl.GetArgsForCall(0)
+						Expect(key).To(Equal(client.ObjectKey{Namespace: "default", Name: "hello"}))
+					})
+
+					Context("and the object is controlled by the stamped object's controller", func() {
+						BeforeEach(func() {
+							existingObj.SetOwnerReferences(stampedObj.GetOwnerReferences())
+						})
+
+						It("returns the create error", func() {
+							err := repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)
+							Expect(kerrors.IsAlreadyExists(err)).To(BeTrue())
+							Expect(repository.IsStampedObjectConflict(err)).To(BeFalse())
+						})
+					})
+				})
+
 				Context("and the apiServer errors when creating the object", func() {
 					BeforeEach(func() {
 						cl.CreateReturns(errors.New("some-error"))
@@ -254,6 +313,15 @@ spec:
 								Expect(cl.PatchCallCount()).To(Equal(1))
 							})
 
+							It("patches the object as the field manager of the context", func() {
+								Expect(repo.EnsureObjectExistsOnCluster(repository.WithFieldManager(ctx, "cartographer-staging"), stampedObj, true)).To(Succeed())
+
+								_, _, _, opts := cl.PatchArgsForCall(0)
+								patchOpts := &client.PatchOptions{}
+								patchOpts.ApplyOptions(opts)
+								Expect(patchOpts.FieldManager).To(Equal("cartographer-staging"))
+							})
+
 							Context("and the patch succeeds", func() {
 								var returnedPatchedObj *unstructured.Unstructured
 
@@ -298,6 +366,74 @@ spec:
 									Expect(cache.SetCallCount()).To(Equal(0))
 								})
 							})
+
+							Context("and the stamped object has a controller", func() {
+								controllerRef := func(kind, name, uid string) []metav1.OwnerReference {
+									isController := true
+									return []metav1.OwnerReference{{APIVersion: "carto.run/v1alpha1", Kind: kind, Name: name, UID: types.UID(uid), Controller: &isController}}
+								}
+
+								BeforeEach(func() {
+									stampedObj.SetOwnerReferences(controllerRef("Workload", "my-workload", "my-uid"))
+								})
+
+								Context("that also controls the existing object", func() {
+									BeforeEach(func() {
+										existingObjList.Items[0].SetOwnerReferences(controllerRef("Workload", "my-workload", "my-uid"))
+									})
+
+									It("patches the object", func() {
+										Expect(repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)).To(Succeed())
+										Expect(cl.PatchCallCount()).To(Equal(1))
+									})
+								})
+
+								Context("and another controller controls the existing object", func() {
+									BeforeEach(func() {
+										existingObjList.Items[0].SetOwnerReferences(controllerRef("Workload", "other-workload", "other-uid"))
+									})
+
+									It("refuses to patch the object", func() {
+										err := repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)
+										Expect(repository.IsStampedObjectConflict(err)).To(BeTrue())
+										Expect(err).To(MatchError(ContainSubstring("[default/hello]")))
+										Expect(err).To(MatchError(ContainSubstring("already exists and belongs to Workload [other-workload]")))
+										Expect(cl.PatchCallCount()).To(Equal(0))
+									})
+								})
+
+								Context("and the existing object has no controller", func() {
+									It("refuses to patch the object", func() {
+										err := repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)
+										Expect(repository.IsStampedObjectConflict(err)).To(BeTrue())
+										Expect(cl.PatchCallCount()).To(Equal(0))
+									})
+								})
+							})
+
+							Context("and the existing object is labeled as stamped for the same workload", func() {
+								BeforeEach(func() {
+									existingObjList.Items[0].SetLabels(map[string]string{"carto.run/workload-name": "my-workload"})
+									stampedObj.SetLabels(map[string]string{"carto.run/workload-name": "my-workload"})
+								})
+
+								It("patches the object", func() {
+									Expect(repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)).To(Succeed())
+									Expect(cl.PatchCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("and the existing object is labeled as stamped for another workload", func() {
+								BeforeEach(func() {
+									existingObjList.Items[0].SetLabels(map[string]string{"carto.run/workload-name": "other-workload"})
+								})
+
+								It("refuses to patch the object", func() {
+									err := repo.EnsureObjectExistsOnCluster(ctx, stampedObj, true)
+									Expect(err).To(MatchError(ContainSubstring("already exists and belongs to carto.run/workload-name label [other-workload]")))
+									Expect(cl.PatchCallCount()).To(Equal(0))
+								})
+							})
 						})
 
 						Context("list has more than one object", func() {
@@ -390,6 +526,104 @@ spec:
 			})
 		})
 
+		Context("DeleteUnstructured", func() {
+			var obj *unstructured.Unstructured
+
+			BeforeEach(func() {
+				obj = &unstructured.Unstructured{}
+				obj.SetAPIVersion("batch/v1")
+				obj.SetKind("Job")
+				obj.SetName("hello")
+				obj.SetNamespace("default")
+			})
+
+			It("deletes the object in the background", func() {
+				Expect(repo.DeleteUnstructured(ctx, obj)).To(Succeed())
+
+				Expect(cl.DeleteCallCount()).To(Equal(1))
+				_, deletedObj, options := cl.DeleteArgsForCall(0)
+				Expect(deletedObj).To(Equal(obj))
+				Expect(options).To(ConsistOf(client.PropagationPolicy(metav1.DeletePropagationBackground)))
+			})
+
+			Context("when the object no longer exists", func() {
+				BeforeEach(func() {
+					cl.DeleteReturns(kerrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, "hello"))
+				})
+
+				It("does not return an error", func() {
+					Expect(repo.DeleteUnstructured(ctx, obj)).To(Succeed())
+				})
+			})
+
+			Context("when the delete fails", func() {
+				BeforeEach(func() {
+					cl.DeleteReturns(errors.New("some-error"))
+				})
+
+				It("returns a helpful error", func() {
+					err := repo.DeleteUnstructured(ctx, obj)
+					Expect(err).To(MatchError(ContainSubstring("delete: some-error")))
+				})
+			})
+		})
+
+		Context("GetUnstructured", func() {
+			var obj *unstructured.Unstructured
+
+			BeforeEach(func() {
+				obj = &unstructured.Unstructured{}
+				obj.SetAPIVersion("batch/v1")
+				obj.SetKind("Job")
+				obj.SetName("hello")
+				obj.SetNamespace("default")
+
+				cl.GetStub = func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+					o.SetName(key.Name)
+					o.SetNamespace(key.Namespace)
+					o.SetLabels(map[string]string{"found": "true"})
+					return nil
+				}
+			})
+
+			It("gets the object of the requested type by name", func() {
+				found, err := repo.GetUnstructured(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found.GetLabels()).To(Equal(map[string]string{"found": "true"}))
+
+				Expect(cl.GetCallCount()).To(Equal(1))
+				_, key, requested := cl.GetArgsForCall(0)
+				Expect(key).To(Equal(client.ObjectKey{Name: "hello", Namespace: "default"}))
+				Expect(requested.GetObjectKind().GroupVersionKind()).To(Equal(obj.GroupVersionKind()))
+			})
+
+			Context("when the object does not exist", func() {
+				BeforeEach(func() {
+					cl.GetStub = nil
+					cl.GetReturns(kerrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, "hello"))
+				})
+
+				It("returns nil", func() {
+					found, err := repo.GetUnstructured(ctx, obj)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeNil())
+				})
+			})
+
+			Context("when the get fails", func() {
+				BeforeEach(func() {
+					cl.GetStub = nil
+					cl.GetReturns(errors.New("some-error"))
+				})
+
+				It("returns a helpful error", func() {
+					_, err := repo.GetUnstructured(ctx, obj)
+					Expect(err).To(MatchError(ContainSubstring("failed to get object from api server [default/hello]")))
+					Expect(err).To(MatchError(ContainSubstring("some-error")))
+				})
+			})
+		})
+
 		Context("GetSupplyChainsForWorkload", func() {
 			BeforeEach(func() {
 				cl.ListReturns(errors.New("some list error"))
@@ -751,6 +985,82 @@ spec:
 			})
 		})
 
+		Context("ReviewAccess", func() {
+			var (
+				obj     *unstructured.Unstructured
+				allowed bool
+			)
+
+			BeforeEach(func() {
+				restMapper := meta.NewDefaultRESTMapper(nil)
+				restMapper.Add(schema.GroupVersionKind{Group: "kpack.io", Version: "v1alpha2", Kind: "Image"}, meta.RESTScopeNamespace)
+				cl.RESTMapperReturns(restMapper)
+
+				obj = &unstructured.Unstructured{}
+				obj.SetAPIVersion("kpack.io/v1alpha2")
+				obj.SetKind("Image")
+				obj.SetNamespace("some-namespace")
+				obj.SetName("some-image")
+
+				cl.CreateStub = func(_ context.Context, o client.Object, _ ...client.CreateOption) error {
+					review := o.(*authorizationv1.SelfSubjectAccessReview)
+					review.Status.Allowed = allowed
+					if !allowed {
+						review.Status.Reason = "no RBAC policy matched"
+					}
+					return nil
+				}
+			})
+
+			Context("when the access review allows the verb", func() {
+				BeforeEach(func() {
+					allowed = true
+				})
+
+				It("reviews the verb on the resource of the object in its namespace", func() {
+					Expect(repo.ReviewAccess(ctx, obj, "create")).To(Succeed())
+
+					Expect(cl.CreateCallCount()).To(Equal(1))
+					_, created, _ := cl.CreateArgsForCall(0)
+					Expect(created.(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes).To(Equal(&authorizationv1.ResourceAttributes{
+						Namespace: "some-namespace",
+						Verb:      "create",
+						Group:     "kpack.io",
+						Version:   "v1alpha2",
+						Resource:  "images",
+					}))
+				})
+			})
+
+			Context("when the access review denies the verb", func() {
+				BeforeEach(func() {
+					allowed = false
+				})
+
+				It("returns an AccessDeniedError naming the verb and resource", func() {
+					err := repo.ReviewAccess(ctx, obj, "patch")
+					Expect(err).To(Equal(repository.AccessDeniedError{
+						Verb:      "patch",
+						Resource:  "images.kpack.io",
+						Namespace: "some-namespace",
+						Reason:    "no RBAC policy matched",
+					}))
+					Expect(err).To(MatchError("not permitted to [patch] resource [images.kpack.io] in namespace [some-namespace]: no RBAC policy matched"))
+				})
+			})
+
+			Context("when the access review cannot be created", func() {
+				BeforeEach(func() {
+					cl.CreateStub = nil
+					cl.CreateReturns(errors.New("some create error"))
+				})
+
+				It("returns a helpful error", func() {
+					err := repo.ReviewAccess(ctx, obj, "create")
+					Expect(err).To(MatchError("failed to review access to [images.kpack.io]: some create error"))
+				})
+			})
+		})
 	})
 
 	Describe("tests using apiMachinery fake client", func() {
@@ -774,6 +1084,203 @@ spec:
 			repo = repository.NewRepository(cl, cache)
 		})
 
+		Context("GetServiceAccountSecret and GetServiceAccountSecretLive", func() {
+			var apiReader client.Reader
+
+			serviceAccountObjects := func(token string) []client.Object {
+				return []client.Object{
+					&v1.ServiceAccount{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "my-ns"},
+						Secrets:    []v1.ObjectReference{{Name: "my-sa-token"}},
+					},
+					&v1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-sa-token", Namespace: "my-ns"},
+						Type:       v1.SecretTypeServiceAccountToken,
+						Data:       map[string][]byte{"token": []byte(token)},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				clientObjects = serviceAccountObjects("cached-token")
+				apiReader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(serviceAccountObjects("rotated-token")...).Build()
+			})
+
+			JustBeforeEach(func() {
+				repo = repository.NewRepositoryWithAPIReader(cl, apiReader, cache)
+			})
+
+			It("reads the secret through the client", func() {
+				secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("token", []byte("cached-token")))
+			})
+
+			It("reads the secret through the api reader when read live", func() {
+				secret, err := repo.GetServiceAccountSecretLive(ctx, "my-sa", "my-ns")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("token", []byte("rotated-token")))
+			})
+
+			Context("the repository has no api reader", func() {
+				JustBeforeEach(func() {
+					repo = repository.NewRepository(cl, cache)
+				})
+
+				It("reads the secret live through the client", func() {
+					secret, err := repo.GetServiceAccountSecretLive(ctx, "my-sa", "my-ns")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(secret.Data).To(HaveKeyWithValue("token", []byte("cached-token")))
+				})
+			})
+		})
+
+		Context("ListStampedObjects", func() {
+			var runnable *v1alpha1.Runnable
+
+			stampedConfigMap := func(name, namespace, runnableName string, ownerUID types.UID) *v1.ConfigMap {
+				return &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       namespace,
+						Labels:          map[string]string{repository.RunnableNameLabel: runnableName},
+						OwnerReferences: []metav1.OwnerReference{{APIVersion: "carto.run/v1alpha1", Kind: "Runnable", Name: runnableName, UID: ownerUID}},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				runnable = &v1alpha1.Runnable{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "carto.run/v1alpha1", Kind: "Runnable"},
+					ObjectMeta: metav1.ObjectMeta{Name: "my-runnable", Namespace: "my-ns", UID: "runnable-uid"},
+					Spec: v1alpha1.RunnableSpec{
+						RunTemplateRef: v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-run-template"},
+					},
+				}
+
+				runTemplate := &v1alpha1.ClusterRunTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-run-template"},
+					Spec: v1alpha1.ClusterRunTemplateSpec{
+						Template: runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"generateName": "my-run-"}}`)},
+					},
+				}
+
+				clientObjects = []client.Object{
+					runTemplate,
+					stampedConfigMap("stamped-1", "my-ns", "my-runnable", "runnable-uid"),
+					stampedConfigMap("stamped-2", "my-ns", "my-runnable", "runnable-uid"),
+					stampedConfigMap("other-runnable", "my-ns", "other-runnable", "other-uid"),
+					stampedConfigMap("previous-owner", "my-ns", "my-runnable", "previous-uid"),
+					stampedConfigMap("other-namespace", "other-ns", "my-runnable", "runnable-uid"),
+					&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "my-ns"}},
+				}
+			})
+
+			names := func(objects []*unstructured.Unstructured) []string {
+				var result []string
+				for _, obj := range objects {
+					result = append(result, obj.GetName())
+				}
+				return result
+			}
+
+			It("lists the objects of the run template's kind stamped for the runnable", func() {
+				objects, err := repo.ListStampedObjects(ctx, runnable)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names(objects)).To(ConsistOf("stamped-1", "stamped-2"))
+			})
+
+			Context("the runnable last stamped another kind", func() {
+				BeforeEach(func() {
+					runnable.Status.StampedRef = &v1alpha1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "my-ns", Name: "stamped-secret"}
+
+					clientObjects = append(clientObjects,
+						&v1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "stamped-secret",
+								Namespace:       "my-ns",
+								Labels:          map[string]string{repository.RunnableNameLabel: "my-runnable"},
+								OwnerReferences: []metav1.OwnerReference{{APIVersion: "carto.run/v1alpha1", Kind: "Runnable", Name: "my-runnable", UID: "runnable-uid"}},
+							},
+						},
+					)
+				})
+
+				It("lists the objects of both kinds", func() {
+					objects, err := repo.ListStampedObjects(ctx, runnable)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(names(objects)).To(ConsistOf("stamped-1", "stamped-2", "stamped-secret"))
+				})
+			})
+
+			Context("the run template does not exist", func() {
+				BeforeEach(func() {
+					runnable.Spec.RunTemplateRef.Name = "missing-run-template"
+				})
+
+				It("returns an error", func() {
+					_, err := repo.ListStampedObjects(ctx, runnable)
+					Expect(err).To(MatchError(ContainSubstring("failed to get run template [missing-run-template]")))
+				})
+			})
+
+			Context("the owner is not a runnable", func() {
+				It("returns an error", func() {
+					_, err := repo.ListStampedObjects(ctx, &v1alpha1.Workload{})
+					Expect(err).To(MatchError("cannot list stamped objects of owner of type [*v1alpha1.Workload], only runnables are supported"))
+				})
+			})
+		})
+
+		Context("GetUnstructured and GetUnstructuredLive", func() {
+			var (
+				apiReader client.Reader
+				query     *unstructured.Unstructured
+			)
+
+			configMap := func(value string) *v1.ConfigMap {
+				return &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "my-ns"},
+					Data:       map[string]string{"value": value},
+				}
+			}
+
+			BeforeEach(func() {
+				clientObjects = []client.Object{configMap("cached")}
+				apiReader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap("live")).Build()
+
+				query = &unstructured.Unstructured{}
+				query.SetAPIVersion("v1")
+				query.SetKind("ConfigMap")
+				query.SetNamespace("my-ns")
+				query.SetName("my-config")
+			})
+
+			JustBeforeEach(func() {
+				repo = repository.NewRepositoryWithAPIReader(cl, apiReader, cache)
+			})
+
+			It("reads the object through the client", func() {
+				obj, err := repo.GetUnstructured(ctx, query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(obj.Object["data"]).To(HaveKeyWithValue("value", "cached"))
+			})
+
+			It("reads the object through the api reader when read live", func() {
+				obj, err := repo.GetUnstructuredLive(ctx, query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(obj.Object["data"]).To(HaveKeyWithValue("value", "live"))
+			})
+
+			It("returns nil when the object does not exist on the api server", func() {
+				query.SetName("other-config")
+
+				obj, err := repo.GetUnstructuredLive(ctx, query)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(obj).To(BeNil())
+			})
+		})
+
 		Context("GetClusterTemplate", func() {
 			BeforeEach(func() {
 				template := &v1alpha1.ClusterSourceTemplate{
@@ -793,6 +1300,17 @@ spec:
 				Expect(err).ToNot(HaveOccurred())
 				Expect(template.GetName()).To(Equal("some-name"))
 			})
+
+			It("returns a TemplateNotFoundError when no template of the kind has the name", func() {
+				templateRef := v1alpha1.ClusterTemplateReference{
+					Kind: "ClusterSourceTemplate",
+					Name: "some-other-name",
+				}
+				template, err := repo.GetClusterTemplate(ctx, templateRef)
+				Expect(template).To(BeNil())
+				Expect(err).To(Equal(repository.TemplateNotFoundError{Kind: "ClusterSourceTemplate", Name: "some-other-name"}))
+				Expect(repository.IsTemplateNotFound(err)).To(BeTrue())
+			})
 		})
 
 		Context("GetDeliveryClusterTemplate", func() {
@@ -842,6 +1360,133 @@ spec:
 			})
 		})
 
+		Context("GetCustomResourceDefinition", func() {
+			var restMapper *meta.DefaultRESTMapper
+
+			BeforeEach(func() {
+				Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+				restMapper = meta.NewDefaultRESTMapper(nil)
+				restMapper.Add(schema.GroupVersionKind{Group: "test.run", Version: "v1alpha1", Kind: "TestObj"}, meta.RESTScopeNamespace)
+				restMapper.Add(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+				clientObjects = []client.Object{
+					&apiextensionsv1.CustomResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "testobjs.test.run",
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				cl = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(clientObjects...).Build()
+				repo = repository.NewRepository(cl, cache)
+			})
+
+			It("gets the definition of the kind's resource", func() {
+				crd, err := repo.GetCustomResourceDefinition(ctx, schema.GroupVersionKind{Group: "test.run", Version: "v1alpha1", Kind: "TestObj"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(crd.Name).To(Equal("testobjs.test.run"))
+			})
+
+			Context("the kind is built in", func() {
+				It("returns a nil definition", func() {
+					crd, err := repo.GetCustomResourceDefinition(ctx, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(crd).To(BeNil())
+				})
+			})
+
+			Context("the kind is not known to the rest mapper", func() {
+				It("returns an error", func() {
+					_, err := repo.GetCustomResourceDefinition(ctx, schema.GroupVersionKind{Group: "unknown.run", Version: "v1", Kind: "Unknown"})
+					Expect(err).To(MatchError(ContainSubstring("failed to get rest mapping for [unknown.run/v1, Kind=Unknown]")))
+				})
+			})
+		})
+
+		Context("GetSubresource", func() {
+			var (
+				restMapper *meta.DefaultRESTMapper
+				testObj    *unstructured.Unstructured
+			)
+
+			BeforeEach(func() {
+				Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+				restMapper = meta.NewDefaultRESTMapper(nil)
+				restMapper.Add(schema.GroupVersionKind{Group: "test.run", Version: "v1alpha1", Kind: "TestObj"}, meta.RESTScopeNamespace)
+
+				selectorPath := ".status.selector"
+				testObj = &unstructured.Unstructured{}
+				testObj.SetAPIVersion("test.run/v1alpha1")
+				testObj.SetKind("TestObj")
+				testObj.SetName("my-obj")
+				testObj.SetNamespace("my-ns")
+				Expect(unstructured.SetNestedField(testObj.Object, int64(3), "spec", "replicas")).To(Succeed())
+				Expect(unstructured.SetNestedField(testObj.Object, int64(2), "status", "replicas")).To(Succeed())
+				Expect(unstructured.SetNestedField(testObj.Object, "app=my-app", "status", "selector")).To(Succeed())
+
+				clientObjects = []client.Object{
+					&apiextensionsv1.CustomResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "testobjs.test.run",
+						},
+						Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+							Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+								{
+									Name: "v1alpha1",
+									Subresources: &apiextensionsv1.CustomResourceSubresources{
+										Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+										Scale: &apiextensionsv1.CustomResourceSubresourceScale{
+											SpecReplicasPath:   ".spec.replicas",
+											StatusReplicasPath: ".status.replicas",
+											LabelSelectorPath:  &selectorPath,
+										},
+									},
+								},
+							},
+						},
+					},
+					testObj.DeepCopy(),
+				}
+			})
+
+			JustBeforeEach(func() {
+				cl = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(clientObjects...).Build()
+				repo = repository.NewRepository(cl, cache)
+			})
+
+			It("returns the scale view of the object", func() {
+				scale, err := repo.GetSubresource(ctx, testObj, repository.ScaleSubresource)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scale["kind"]).To(Equal("Scale"))
+				Expect(scale["spec"]).To(HaveKeyWithValue("replicas", BeNumerically("==", 3)))
+				Expect(scale["status"]).To(HaveKeyWithValue("replicas", BeNumerically("==", 2)))
+				Expect(scale["status"]).To(HaveKeyWithValue("selector", "app=my-app"))
+			})
+
+			It("returns the status view of the object", func() {
+				status, err := repo.GetSubresource(ctx, testObj, repository.StatusSubresource)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status["status"]).To(HaveKeyWithValue("replicas", BeNumerically("==", 2)))
+			})
+
+			Context("the kind does not declare the subresource", func() {
+				BeforeEach(func() {
+					crd := clientObjects[0].(*apiextensionsv1.CustomResourceDefinition)
+					crd.Spec.Versions[0].Subresources.Scale = nil
+				})
+
+				It("returns a SubresourceNotAvailableError", func() {
+					_, err := repo.GetSubresource(ctx, testObj, repository.ScaleSubresource)
+					Expect(err).To(BeAssignableToTypeOf(repository.SubresourceNotAvailableError{}))
+					Expect(err).To(MatchError("kind [testobj.test.run] does not have a [scale] subresource"))
+				})
+			})
+		})
+
 		Context("GetWorkload", func() {
 			BeforeEach(func() {
 				workload := &v1alpha1.Workload{
@@ -920,6 +1565,32 @@ spec:
 			})
 		})
 
+		Context("DefaultRunnable", func() {
+			BeforeEach(func() {
+				runnable := &v1alpha1.Runnable{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "runnable-name",
+						Namespace: "runnable-namespace",
+					},
+					Spec: v1alpha1.RunnableSpec{
+						RunTemplateRef: v1alpha1.TemplateReference{Name: "my-template"},
+					},
+				}
+				clientObjects = []client.Object{runnable}
+			})
+
+			It("stores the runnable with its defaults applied", func() {
+				runnable, err := repo.GetRunnable(ctx, "runnable-name", "runnable-namespace")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repo.DefaultRunnable(ctx, runnable)).To(Succeed())
+
+				stored, err := repo.GetRunnable(ctx, "runnable-name", "runnable-namespace")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Spec.RunTemplateRef).To(Equal(v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: "my-template"}))
+			})
+		})
+
 		Context("GetSupplyChain", func() {
 			BeforeEach(func() {
 				supplyChain := &v1alpha1.ClusterSupplyChain{
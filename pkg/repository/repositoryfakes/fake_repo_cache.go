@@ -9,6 +9,11 @@ import (
 )
 
 type FakeRepoCache struct {
+	ForgetStub        func(*unstructured.Unstructured)
+	forgetMutex       sync.RWMutex
+	forgetArgsForCall []struct {
+		arg1 *unstructured.Unstructured
+	}
 	SetStub        func(*unstructured.Unstructured, *unstructured.Unstructured)
 	setMutex       sync.RWMutex
 	setArgsForCall []struct {
@@ -31,6 +36,38 @@ type FakeRepoCache struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeRepoCache) Forget(arg1 *unstructured.Unstructured) {
+	fake.forgetMutex.Lock()
+	fake.forgetArgsForCall = append(fake.forgetArgsForCall, struct {
+		arg1 *unstructured.Unstructured
+	}{arg1})
+	stub := fake.ForgetStub
+	fake.recordInvocation("Forget", []interface{}{arg1})
+	fake.forgetMutex.Unlock()
+	if stub != nil {
+		fake.ForgetStub(arg1)
+	}
+}
+
+func (fake *FakeRepoCache) ForgetCallCount() int {
+	fake.forgetMutex.RLock()
+	defer fake.forgetMutex.RUnlock()
+	return len(fake.forgetArgsForCall)
+}
+
+func (fake *FakeRepoCache) ForgetCalls(stub func(*unstructured.Unstructured)) {
+	fake.forgetMutex.Lock()
+	defer fake.forgetMutex.Unlock()
+	fake.ForgetStub = stub
+}
+
+func (fake *FakeRepoCache) ForgetArgsForCall(i int) *unstructured.Unstructured {
+	fake.forgetMutex.RLock()
+	defer fake.forgetMutex.RUnlock()
+	argsForCall := fake.forgetArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeRepoCache) Set(arg1 *unstructured.Unstructured, arg2 *unstructured.Unstructured) {
 	fake.setMutex.Lock()
 	fake.setArgsForCall = append(fake.setArgsForCall, struct {
@@ -134,6 +171,8 @@ func (fake *FakeRepoCache) UnchangedSinceCachedReturnsOnCall(i int, result1 *uns
 func (fake *FakeRepoCache) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.forgetMutex.RLock()
+	defer fake.forgetMutex.RUnlock()
 	fake.setMutex.RLock()
 	defer fake.setMutex.RUnlock()
 	fake.unchangedSinceCachedMutex.RLock()
@@ -7,13 +7,39 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
-	v1 "k8s.io/api/core/v1"
+	v1a "k8s.io/api/core/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type FakeRepository struct {
+	DefaultRunnableStub        func(context.Context, *v1alpha1.Runnable) error
+	defaultRunnableMutex       sync.RWMutex
+	defaultRunnableArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.Runnable
+	}
+	defaultRunnableReturns struct {
+		result1 error
+	}
+	defaultRunnableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteUnstructuredStub        func(context.Context, *unstructured.Unstructured) error
+	deleteUnstructuredMutex       sync.RWMutex
+	deleteUnstructuredArgsForCall []struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+	}
+	deleteUnstructuredReturns struct {
+		result1 error
+	}
+	deleteUnstructuredReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EnsureObjectExistsOnClusterStub        func(context.Context, *unstructured.Unstructured, bool) error
 	ensureObjectExistsOnClusterMutex       sync.RWMutex
 	ensureObjectExistsOnClusterArgsForCall []struct {
@@ -41,6 +67,20 @@ type FakeRepository struct {
 		result1 client.Object
 		result2 error
 	}
+	GetCustomResourceDefinitionStub        func(context.Context, schema.GroupVersionKind) (*v1.CustomResourceDefinition, error)
+	getCustomResourceDefinitionMutex       sync.RWMutex
+	getCustomResourceDefinitionArgsForCall []struct {
+		arg1 context.Context
+		arg2 schema.GroupVersionKind
+	}
+	getCustomResourceDefinitionReturns struct {
+		result1 *v1.CustomResourceDefinition
+		result2 error
+	}
+	getCustomResourceDefinitionReturnsOnCall map[int]struct {
+		result1 *v1.CustomResourceDefinition
+		result2 error
+	}
 	GetDeliverableStub        func(context.Context, string, string) (*v1alpha1.Deliverable, error)
 	getDeliverableMutex       sync.RWMutex
 	getDeliverableArgsForCall []struct {
@@ -137,7 +177,7 @@ type FakeRepository struct {
 	getSchemeReturnsOnCall map[int]struct {
 		result1 *runtime.Scheme
 	}
-	GetServiceAccountSecretStub        func(context.Context, string, string) (*v1.Secret, error)
+	GetServiceAccountSecretStub        func(context.Context, string, string) (*v1a.Secret, error)
 	getServiceAccountSecretMutex       sync.RWMutex
 	getServiceAccountSecretArgsForCall []struct {
 		arg1 context.Context
@@ -145,11 +185,41 @@ type FakeRepository struct {
 		arg3 string
 	}
 	getServiceAccountSecretReturns struct {
-		result1 *v1.Secret
+		result1 *v1a.Secret
 		result2 error
 	}
 	getServiceAccountSecretReturnsOnCall map[int]struct {
-		result1 *v1.Secret
+		result1 *v1a.Secret
+		result2 error
+	}
+	GetServiceAccountSecretLiveStub        func(context.Context, string, string) (*v1a.Secret, error)
+	getServiceAccountSecretLiveMutex       sync.RWMutex
+	getServiceAccountSecretLiveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getServiceAccountSecretLiveReturns struct {
+		result1 *v1a.Secret
+		result2 error
+	}
+	getServiceAccountSecretLiveReturnsOnCall map[int]struct {
+		result1 *v1a.Secret
+		result2 error
+	}
+	GetSubresourceStub        func(context.Context, *unstructured.Unstructured, string) (map[string]interface{}, error)
+	getSubresourceMutex       sync.RWMutex
+	getSubresourceArgsForCall []struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+		arg3 string
+	}
+	getSubresourceReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	getSubresourceReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
 		result2 error
 	}
 	GetSupplyChainStub        func(context.Context, string) (*v1alpha1.ClusterSupplyChain, error)
@@ -180,6 +250,34 @@ type FakeRepository struct {
 		result1 []*v1alpha1.ClusterSupplyChain
 		result2 error
 	}
+	GetUnstructuredStub        func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	getUnstructuredMutex       sync.RWMutex
+	getUnstructuredArgsForCall []struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+	}
+	getUnstructuredReturns struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}
+	getUnstructuredReturnsOnCall map[int]struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}
+	GetUnstructuredLiveStub        func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	getUnstructuredLiveMutex       sync.RWMutex
+	getUnstructuredLiveArgsForCall []struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+	}
+	getUnstructuredLiveReturns struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}
+	getUnstructuredLiveReturnsOnCall map[int]struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}
 	GetWorkloadStub        func(context.Context, string, string) (*v1alpha1.Workload, error)
 	getWorkloadMutex       sync.RWMutex
 	getWorkloadArgsForCall []struct {
@@ -195,6 +293,20 @@ type FakeRepository struct {
 		result1 *v1alpha1.Workload
 		result2 error
 	}
+	ListStampedObjectsStub        func(context.Context, client.Object) ([]*unstructured.Unstructured, error)
+	listStampedObjectsMutex       sync.RWMutex
+	listStampedObjectsArgsForCall []struct {
+		arg1 context.Context
+		arg2 client.Object
+	}
+	listStampedObjectsReturns struct {
+		result1 []*unstructured.Unstructured
+		result2 error
+	}
+	listStampedObjectsReturnsOnCall map[int]struct {
+		result1 []*unstructured.Unstructured
+		result2 error
+	}
 	ListUnstructuredStub        func(context.Context, *unstructured.Unstructured) ([]*unstructured.Unstructured, error)
 	listUnstructuredMutex       sync.RWMutex
 	listUnstructuredArgsForCall []struct {
@@ -209,6 +321,19 @@ type FakeRepository struct {
 		result1 []*unstructured.Unstructured
 		result2 error
 	}
+	ReviewAccessStub        func(context.Context, *unstructured.Unstructured, string) error
+	reviewAccessMutex       sync.RWMutex
+	reviewAccessArgsForCall []struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+		arg3 string
+	}
+	reviewAccessReturns struct {
+		result1 error
+	}
+	reviewAccessReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StatusUpdateStub        func(context.Context, client.Object) error
 	statusUpdateMutex       sync.RWMutex
 	statusUpdateArgsForCall []struct {
@@ -225,6 +350,130 @@ type FakeRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeRepository) DefaultRunnable(arg1 context.Context, arg2 *v1alpha1.Runnable) error {
+	fake.defaultRunnableMutex.Lock()
+	ret, specificReturn := fake.defaultRunnableReturnsOnCall[len(fake.defaultRunnableArgsForCall)]
+	fake.defaultRunnableArgsForCall = append(fake.defaultRunnableArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.Runnable
+	}{arg1, arg2})
+	stub := fake.DefaultRunnableStub
+	fakeReturns := fake.defaultRunnableReturns
+	fake.recordInvocation("DefaultRunnable", []interface{}{arg1, arg2})
+	fake.defaultRunnableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) DefaultRunnableCallCount() int {
+	fake.defaultRunnableMutex.RLock()
+	defer fake.defaultRunnableMutex.RUnlock()
+	return len(fake.defaultRunnableArgsForCall)
+}
+
+func (fake *FakeRepository) DefaultRunnableCalls(stub func(context.Context, *v1alpha1.Runnable) error) {
+	fake.defaultRunnableMutex.Lock()
+	defer fake.defaultRunnableMutex.Unlock()
+	fake.DefaultRunnableStub = stub
+}
+
+func (fake *FakeRepository) DefaultRunnableArgsForCall(i int) (context.Context, *v1alpha1.Runnable) {
+	fake.defaultRunnableMutex.RLock()
+	defer fake.defaultRunnableMutex.RUnlock()
+	argsForCall := fake.defaultRunnableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) DefaultRunnableReturns(result1 error) {
+	fake.defaultRunnableMutex.Lock()
+	defer fake.defaultRunnableMutex.Unlock()
+	fake.DefaultRunnableStub = nil
+	fake.defaultRunnableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DefaultRunnableReturnsOnCall(i int, result1 error) {
+	fake.defaultRunnableMutex.Lock()
+	defer fake.defaultRunnableMutex.Unlock()
+	fake.DefaultRunnableStub = nil
+	if fake.defaultRunnableReturnsOnCall == nil {
+		fake.defaultRunnableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.defaultRunnableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeleteUnstructured(arg1 context.Context, arg2 *unstructured.Unstructured) error {
+	fake.deleteUnstructuredMutex.Lock()
+	ret, specificReturn := fake.deleteUnstructuredReturnsOnCall[len(fake.deleteUnstructuredArgsForCall)]
+	fake.deleteUnstructuredArgsForCall = append(fake.deleteUnstructuredArgsForCall, struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+	}{arg1, arg2})
+	stub := fake.DeleteUnstructuredStub
+	fakeReturns := fake.deleteUnstructuredReturns
+	fake.recordInvocation("DeleteUnstructured", []interface{}{arg1, arg2})
+	fake.deleteUnstructuredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) DeleteUnstructuredCallCount() int {
+	fake.deleteUnstructuredMutex.RLock()
+	defer fake.deleteUnstructuredMutex.RUnlock()
+	return len(fake.deleteUnstructuredArgsForCall)
+}
+
+func (fake *FakeRepository) DeleteUnstructuredCalls(stub func(context.Context, *unstructured.Unstructured) error) {
+	fake.deleteUnstructuredMutex.Lock()
+	defer fake.deleteUnstructuredMutex.Unlock()
+	fake.DeleteUnstructuredStub = stub
+}
+
+func (fake *FakeRepository) DeleteUnstructuredArgsForCall(i int) (context.Context, *unstructured.Unstructured) {
+	fake.deleteUnstructuredMutex.RLock()
+	defer fake.deleteUnstructuredMutex.RUnlock()
+	argsForCall := fake.deleteUnstructuredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) DeleteUnstructuredReturns(result1 error) {
+	fake.deleteUnstructuredMutex.Lock()
+	defer fake.deleteUnstructuredMutex.Unlock()
+	fake.DeleteUnstructuredStub = nil
+	fake.deleteUnstructuredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeleteUnstructuredReturnsOnCall(i int, result1 error) {
+	fake.deleteUnstructuredMutex.Lock()
+	defer fake.deleteUnstructuredMutex.Unlock()
+	fake.DeleteUnstructuredStub = nil
+	if fake.deleteUnstructuredReturnsOnCall == nil {
+		fake.deleteUnstructuredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteUnstructuredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) EnsureObjectExistsOnCluster(arg1 context.Context, arg2 *unstructured.Unstructured, arg3 bool) error {
 	fake.ensureObjectExistsOnClusterMutex.Lock()
 	ret, specificReturn := fake.ensureObjectExistsOnClusterReturnsOnCall[len(fake.ensureObjectExistsOnClusterArgsForCall)]
@@ -353,6 +602,71 @@ func (fake *FakeRepository) GetClusterTemplateReturnsOnCall(i int, result1 clien
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetCustomResourceDefinition(arg1 context.Context, arg2 schema.GroupVersionKind) (*v1.CustomResourceDefinition, error) {
+	fake.getCustomResourceDefinitionMutex.Lock()
+	ret, specificReturn := fake.getCustomResourceDefinitionReturnsOnCall[len(fake.getCustomResourceDefinitionArgsForCall)]
+	fake.getCustomResourceDefinitionArgsForCall = append(fake.getCustomResourceDefinitionArgsForCall, struct {
+		arg1 context.Context
+		arg2 schema.GroupVersionKind
+	}{arg1, arg2})
+	stub := fake.GetCustomResourceDefinitionStub
+	fakeReturns := fake.getCustomResourceDefinitionReturns
+	fake.recordInvocation("GetCustomResourceDefinition", []interface{}{arg1, arg2})
+	fake.getCustomResourceDefinitionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetCustomResourceDefinitionCallCount() int {
+	fake.getCustomResourceDefinitionMutex.RLock()
+	defer fake.getCustomResourceDefinitionMutex.RUnlock()
+	return len(fake.getCustomResourceDefinitionArgsForCall)
+}
+
+func (fake *FakeRepository) GetCustomResourceDefinitionCalls(stub func(context.Context, schema.GroupVersionKind) (*v1.CustomResourceDefinition, error)) {
+	fake.getCustomResourceDefinitionMutex.Lock()
+	defer fake.getCustomResourceDefinitionMutex.Unlock()
+	fake.GetCustomResourceDefinitionStub = stub
+}
+
+func (fake *FakeRepository) GetCustomResourceDefinitionArgsForCall(i int) (context.Context, schema.GroupVersionKind) {
+	fake.getCustomResourceDefinitionMutex.RLock()
+	defer fake.getCustomResourceDefinitionMutex.RUnlock()
+	argsForCall := fake.getCustomResourceDefinitionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetCustomResourceDefinitionReturns(result1 *v1.CustomResourceDefinition, result2 error) {
+	fake.getCustomResourceDefinitionMutex.Lock()
+	defer fake.getCustomResourceDefinitionMutex.Unlock()
+	fake.GetCustomResourceDefinitionStub = nil
+	fake.getCustomResourceDefinitionReturns = struct {
+		result1 *v1.CustomResourceDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetCustomResourceDefinitionReturnsOnCall(i int, result1 *v1.CustomResourceDefinition, result2 error) {
+	fake.getCustomResourceDefinitionMutex.Lock()
+	defer fake.getCustomResourceDefinitionMutex.Unlock()
+	fake.GetCustomResourceDefinitionStub = nil
+	if fake.getCustomResourceDefinitionReturnsOnCall == nil {
+		fake.getCustomResourceDefinitionReturnsOnCall = make(map[int]struct {
+			result1 *v1.CustomResourceDefinition
+			result2 error
+		})
+	}
+	fake.getCustomResourceDefinitionReturnsOnCall[i] = struct {
+		result1 *v1.CustomResourceDefinition
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) GetDeliverable(arg1 context.Context, arg2 string, arg3 string) (*v1alpha1.Deliverable, error) {
 	fake.getDeliverableMutex.Lock()
 	ret, specificReturn := fake.getDeliverableReturnsOnCall[len(fake.getDeliverableArgsForCall)]
@@ -798,7 +1112,7 @@ func (fake *FakeRepository) GetSchemeReturnsOnCall(i int, result1 *runtime.Schem
 	}{result1}
 }
 
-func (fake *FakeRepository) GetServiceAccountSecret(arg1 context.Context, arg2 string, arg3 string) (*v1.Secret, error) {
+func (fake *FakeRepository) GetServiceAccountSecret(arg1 context.Context, arg2 string, arg3 string) (*v1a.Secret, error) {
 	fake.getServiceAccountSecretMutex.Lock()
 	ret, specificReturn := fake.getServiceAccountSecretReturnsOnCall[len(fake.getServiceAccountSecretArgsForCall)]
 	fake.getServiceAccountSecretArgsForCall = append(fake.getServiceAccountSecretArgsForCall, struct {
@@ -825,7 +1139,7 @@ func (fake *FakeRepository) GetServiceAccountSecretCallCount() int {
 	return len(fake.getServiceAccountSecretArgsForCall)
 }
 
-func (fake *FakeRepository) GetServiceAccountSecretCalls(stub func(context.Context, string, string) (*v1.Secret, error)) {
+func (fake *FakeRepository) GetServiceAccountSecretCalls(stub func(context.Context, string, string) (*v1a.Secret, error)) {
 	fake.getServiceAccountSecretMutex.Lock()
 	defer fake.getServiceAccountSecretMutex.Unlock()
 	fake.GetServiceAccountSecretStub = stub
@@ -838,28 +1152,160 @@ func (fake *FakeRepository) GetServiceAccountSecretArgsForCall(i int) (context.C
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRepository) GetServiceAccountSecretReturns(result1 *v1.Secret, result2 error) {
+func (fake *FakeRepository) GetServiceAccountSecretReturns(result1 *v1a.Secret, result2 error) {
 	fake.getServiceAccountSecretMutex.Lock()
 	defer fake.getServiceAccountSecretMutex.Unlock()
 	fake.GetServiceAccountSecretStub = nil
 	fake.getServiceAccountSecretReturns = struct {
-		result1 *v1.Secret
+		result1 *v1a.Secret
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetServiceAccountSecretReturnsOnCall(i int, result1 *v1.Secret, result2 error) {
+func (fake *FakeRepository) GetServiceAccountSecretReturnsOnCall(i int, result1 *v1a.Secret, result2 error) {
 	fake.getServiceAccountSecretMutex.Lock()
 	defer fake.getServiceAccountSecretMutex.Unlock()
 	fake.GetServiceAccountSecretStub = nil
 	if fake.getServiceAccountSecretReturnsOnCall == nil {
 		fake.getServiceAccountSecretReturnsOnCall = make(map[int]struct {
-			result1 *v1.Secret
+			result1 *v1a.Secret
 			result2 error
 		})
 	}
 	fake.getServiceAccountSecretReturnsOnCall[i] = struct {
-		result1 *v1.Secret
+		result1 *v1a.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetServiceAccountSecretLive(arg1 context.Context, arg2 string, arg3 string) (*v1a.Secret, error) {
+	fake.getServiceAccountSecretLiveMutex.Lock()
+	ret, specificReturn := fake.getServiceAccountSecretLiveReturnsOnCall[len(fake.getServiceAccountSecretLiveArgsForCall)]
+	fake.getServiceAccountSecretLiveArgsForCall = append(fake.getServiceAccountSecretLiveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceAccountSecretLiveStub
+	fakeReturns := fake.getServiceAccountSecretLiveReturns
+	fake.recordInvocation("GetServiceAccountSecretLive", []interface{}{arg1, arg2, arg3})
+	fake.getServiceAccountSecretLiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetServiceAccountSecretLiveCallCount() int {
+	fake.getServiceAccountSecretLiveMutex.RLock()
+	defer fake.getServiceAccountSecretLiveMutex.RUnlock()
+	return len(fake.getServiceAccountSecretLiveArgsForCall)
+}
+
+func (fake *FakeRepository) GetServiceAccountSecretLiveCalls(stub func(context.Context, string, string) (*v1a.Secret, error)) {
+	fake.getServiceAccountSecretLiveMutex.Lock()
+	defer fake.getServiceAccountSecretLiveMutex.Unlock()
+	fake.GetServiceAccountSecretLiveStub = stub
+}
+
+func (fake *FakeRepository) GetServiceAccountSecretLiveArgsForCall(i int) (context.Context, string, string) {
+	fake.getServiceAccountSecretLiveMutex.RLock()
+	defer fake.getServiceAccountSecretLiveMutex.RUnlock()
+	argsForCall := fake.getServiceAccountSecretLiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetServiceAccountSecretLiveReturns(result1 *v1a.Secret, result2 error) {
+	fake.getServiceAccountSecretLiveMutex.Lock()
+	defer fake.getServiceAccountSecretLiveMutex.Unlock()
+	fake.GetServiceAccountSecretLiveStub = nil
+	fake.getServiceAccountSecretLiveReturns = struct {
+		result1 *v1a.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetServiceAccountSecretLiveReturnsOnCall(i int, result1 *v1a.Secret, result2 error) {
+	fake.getServiceAccountSecretLiveMutex.Lock()
+	defer fake.getServiceAccountSecretLiveMutex.Unlock()
+	fake.GetServiceAccountSecretLiveStub = nil
+	if fake.getServiceAccountSecretLiveReturnsOnCall == nil {
+		fake.getServiceAccountSecretLiveReturnsOnCall = make(map[int]struct {
+			result1 *v1a.Secret
+			result2 error
+		})
+	}
+	fake.getServiceAccountSecretLiveReturnsOnCall[i] = struct {
+		result1 *v1a.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSubresource(arg1 context.Context, arg2 *unstructured.Unstructured, arg3 string) (map[string]interface{}, error) {
+	fake.getSubresourceMutex.Lock()
+	ret, specificReturn := fake.getSubresourceReturnsOnCall[len(fake.getSubresourceArgsForCall)]
+	fake.getSubresourceArgsForCall = append(fake.getSubresourceArgsForCall, struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSubresourceStub
+	fakeReturns := fake.getSubresourceReturns
+	fake.recordInvocation("GetSubresource", []interface{}{arg1, arg2, arg3})
+	fake.getSubresourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetSubresourceCallCount() int {
+	fake.getSubresourceMutex.RLock()
+	defer fake.getSubresourceMutex.RUnlock()
+	return len(fake.getSubresourceArgsForCall)
+}
+
+func (fake *FakeRepository) GetSubresourceCalls(stub func(context.Context, *unstructured.Unstructured, string) (map[string]interface{}, error)) {
+	fake.getSubresourceMutex.Lock()
+	defer fake.getSubresourceMutex.Unlock()
+	fake.GetSubresourceStub = stub
+}
+
+func (fake *FakeRepository) GetSubresourceArgsForCall(i int) (context.Context, *unstructured.Unstructured, string) {
+	fake.getSubresourceMutex.RLock()
+	defer fake.getSubresourceMutex.RUnlock()
+	argsForCall := fake.getSubresourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) GetSubresourceReturns(result1 map[string]interface{}, result2 error) {
+	fake.getSubresourceMutex.Lock()
+	defer fake.getSubresourceMutex.Unlock()
+	fake.GetSubresourceStub = nil
+	fake.getSubresourceReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSubresourceReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.getSubresourceMutex.Lock()
+	defer fake.getSubresourceMutex.Unlock()
+	fake.GetSubresourceStub = nil
+	if fake.getSubresourceReturnsOnCall == nil {
+		fake.getSubresourceReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.getSubresourceReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
 		result2 error
 	}{result1, result2}
 }
@@ -994,6 +1440,136 @@ func (fake *FakeRepository) GetSupplyChainsForWorkloadReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetUnstructured(arg1 context.Context, arg2 *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	fake.getUnstructuredMutex.Lock()
+	ret, specificReturn := fake.getUnstructuredReturnsOnCall[len(fake.getUnstructuredArgsForCall)]
+	fake.getUnstructuredArgsForCall = append(fake.getUnstructuredArgsForCall, struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+	}{arg1, arg2})
+	stub := fake.GetUnstructuredStub
+	fakeReturns := fake.getUnstructuredReturns
+	fake.recordInvocation("GetUnstructured", []interface{}{arg1, arg2})
+	fake.getUnstructuredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetUnstructuredCallCount() int {
+	fake.getUnstructuredMutex.RLock()
+	defer fake.getUnstructuredMutex.RUnlock()
+	return len(fake.getUnstructuredArgsForCall)
+}
+
+func (fake *FakeRepository) GetUnstructuredCalls(stub func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)) {
+	fake.getUnstructuredMutex.Lock()
+	defer fake.getUnstructuredMutex.Unlock()
+	fake.GetUnstructuredStub = stub
+}
+
+func (fake *FakeRepository) GetUnstructuredArgsForCall(i int) (context.Context, *unstructured.Unstructured) {
+	fake.getUnstructuredMutex.RLock()
+	defer fake.getUnstructuredMutex.RUnlock()
+	argsForCall := fake.getUnstructuredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetUnstructuredReturns(result1 *unstructured.Unstructured, result2 error) {
+	fake.getUnstructuredMutex.Lock()
+	defer fake.getUnstructuredMutex.Unlock()
+	fake.GetUnstructuredStub = nil
+	fake.getUnstructuredReturns = struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetUnstructuredReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 error) {
+	fake.getUnstructuredMutex.Lock()
+	defer fake.getUnstructuredMutex.Unlock()
+	fake.GetUnstructuredStub = nil
+	if fake.getUnstructuredReturnsOnCall == nil {
+		fake.getUnstructuredReturnsOnCall = make(map[int]struct {
+			result1 *unstructured.Unstructured
+			result2 error
+		})
+	}
+	fake.getUnstructuredReturnsOnCall[i] = struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetUnstructuredLive(arg1 context.Context, arg2 *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	fake.getUnstructuredLiveMutex.Lock()
+	ret, specificReturn := fake.getUnstructuredLiveReturnsOnCall[len(fake.getUnstructuredLiveArgsForCall)]
+	fake.getUnstructuredLiveArgsForCall = append(fake.getUnstructuredLiveArgsForCall, struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+	}{arg1, arg2})
+	stub := fake.GetUnstructuredLiveStub
+	fakeReturns := fake.getUnstructuredLiveReturns
+	fake.recordInvocation("GetUnstructuredLive", []interface{}{arg1, arg2})
+	fake.getUnstructuredLiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetUnstructuredLiveCallCount() int {
+	fake.getUnstructuredLiveMutex.RLock()
+	defer fake.getUnstructuredLiveMutex.RUnlock()
+	return len(fake.getUnstructuredLiveArgsForCall)
+}
+
+func (fake *FakeRepository) GetUnstructuredLiveCalls(stub func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)) {
+	fake.getUnstructuredLiveMutex.Lock()
+	defer fake.getUnstructuredLiveMutex.Unlock()
+	fake.GetUnstructuredLiveStub = stub
+}
+
+func (fake *FakeRepository) GetUnstructuredLiveArgsForCall(i int) (context.Context, *unstructured.Unstructured) {
+	fake.getUnstructuredLiveMutex.RLock()
+	defer fake.getUnstructuredLiveMutex.RUnlock()
+	argsForCall := fake.getUnstructuredLiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetUnstructuredLiveReturns(result1 *unstructured.Unstructured, result2 error) {
+	fake.getUnstructuredLiveMutex.Lock()
+	defer fake.getUnstructuredLiveMutex.Unlock()
+	fake.GetUnstructuredLiveStub = nil
+	fake.getUnstructuredLiveReturns = struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetUnstructuredLiveReturnsOnCall(i int, result1 *unstructured.Unstructured, result2 error) {
+	fake.getUnstructuredLiveMutex.Lock()
+	defer fake.getUnstructuredLiveMutex.Unlock()
+	fake.GetUnstructuredLiveStub = nil
+	if fake.getUnstructuredLiveReturnsOnCall == nil {
+		fake.getUnstructuredLiveReturnsOnCall = make(map[int]struct {
+			result1 *unstructured.Unstructured
+			result2 error
+		})
+	}
+	fake.getUnstructuredLiveReturnsOnCall[i] = struct {
+		result1 *unstructured.Unstructured
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) GetWorkload(arg1 context.Context, arg2 string, arg3 string) (*v1alpha1.Workload, error) {
 	fake.getWorkloadMutex.Lock()
 	ret, specificReturn := fake.getWorkloadReturnsOnCall[len(fake.getWorkloadArgsForCall)]
@@ -1060,6 +1636,71 @@ func (fake *FakeRepository) GetWorkloadReturnsOnCall(i int, result1 *v1alpha1.Wo
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) ListStampedObjects(arg1 context.Context, arg2 client.Object) ([]*unstructured.Unstructured, error) {
+	fake.listStampedObjectsMutex.Lock()
+	ret, specificReturn := fake.listStampedObjectsReturnsOnCall[len(fake.listStampedObjectsArgsForCall)]
+	fake.listStampedObjectsArgsForCall = append(fake.listStampedObjectsArgsForCall, struct {
+		arg1 context.Context
+		arg2 client.Object
+	}{arg1, arg2})
+	stub := fake.ListStampedObjectsStub
+	fakeReturns := fake.listStampedObjectsReturns
+	fake.recordInvocation("ListStampedObjects", []interface{}{arg1, arg2})
+	fake.listStampedObjectsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) ListStampedObjectsCallCount() int {
+	fake.listStampedObjectsMutex.RLock()
+	defer fake.listStampedObjectsMutex.RUnlock()
+	return len(fake.listStampedObjectsArgsForCall)
+}
+
+func (fake *FakeRepository) ListStampedObjectsCalls(stub func(context.Context, client.Object) ([]*unstructured.Unstructured, error)) {
+	fake.listStampedObjectsMutex.Lock()
+	defer fake.listStampedObjectsMutex.Unlock()
+	fake.ListStampedObjectsStub = stub
+}
+
+func (fake *FakeRepository) ListStampedObjectsArgsForCall(i int) (context.Context, client.Object) {
+	fake.listStampedObjectsMutex.RLock()
+	defer fake.listStampedObjectsMutex.RUnlock()
+	argsForCall := fake.listStampedObjectsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) ListStampedObjectsReturns(result1 []*unstructured.Unstructured, result2 error) {
+	fake.listStampedObjectsMutex.Lock()
+	defer fake.listStampedObjectsMutex.Unlock()
+	fake.ListStampedObjectsStub = nil
+	fake.listStampedObjectsReturns = struct {
+		result1 []*unstructured.Unstructured
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListStampedObjectsReturnsOnCall(i int, result1 []*unstructured.Unstructured, result2 error) {
+	fake.listStampedObjectsMutex.Lock()
+	defer fake.listStampedObjectsMutex.Unlock()
+	fake.ListStampedObjectsStub = nil
+	if fake.listStampedObjectsReturnsOnCall == nil {
+		fake.listStampedObjectsReturnsOnCall = make(map[int]struct {
+			result1 []*unstructured.Unstructured
+			result2 error
+		})
+	}
+	fake.listStampedObjectsReturnsOnCall[i] = struct {
+		result1 []*unstructured.Unstructured
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) ListUnstructured(arg1 context.Context, arg2 *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
 	fake.listUnstructuredMutex.Lock()
 	ret, specificReturn := fake.listUnstructuredReturnsOnCall[len(fake.listUnstructuredArgsForCall)]
@@ -1125,6 +1766,69 @@ func (fake *FakeRepository) ListUnstructuredReturnsOnCall(i int, result1 []*unst
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) ReviewAccess(arg1 context.Context, arg2 *unstructured.Unstructured, arg3 string) error {
+	fake.reviewAccessMutex.Lock()
+	ret, specificReturn := fake.reviewAccessReturnsOnCall[len(fake.reviewAccessArgsForCall)]
+	fake.reviewAccessArgsForCall = append(fake.reviewAccessArgsForCall, struct {
+		arg1 context.Context
+		arg2 *unstructured.Unstructured
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ReviewAccessStub
+	fakeReturns := fake.reviewAccessReturns
+	fake.recordInvocation("ReviewAccess", []interface{}{arg1, arg2, arg3})
+	fake.reviewAccessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRepository) ReviewAccessCallCount() int {
+	fake.reviewAccessMutex.RLock()
+	defer fake.reviewAccessMutex.RUnlock()
+	return len(fake.reviewAccessArgsForCall)
+}
+
+func (fake *FakeRepository) ReviewAccessCalls(stub func(context.Context, *unstructured.Unstructured, string) error) {
+	fake.reviewAccessMutex.Lock()
+	defer fake.reviewAccessMutex.Unlock()
+	fake.ReviewAccessStub = stub
+}
+
+func (fake *FakeRepository) ReviewAccessArgsForCall(i int) (context.Context, *unstructured.Unstructured, string) {
+	fake.reviewAccessMutex.RLock()
+	defer fake.reviewAccessMutex.RUnlock()
+	argsForCall := fake.reviewAccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRepository) ReviewAccessReturns(result1 error) {
+	fake.reviewAccessMutex.Lock()
+	defer fake.reviewAccessMutex.Unlock()
+	fake.ReviewAccessStub = nil
+	fake.reviewAccessReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ReviewAccessReturnsOnCall(i int, result1 error) {
+	fake.reviewAccessMutex.Lock()
+	defer fake.reviewAccessMutex.Unlock()
+	fake.ReviewAccessStub = nil
+	if fake.reviewAccessReturnsOnCall == nil {
+		fake.reviewAccessReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reviewAccessReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) StatusUpdate(arg1 context.Context, arg2 client.Object) error {
 	fake.statusUpdateMutex.Lock()
 	ret, specificReturn := fake.statusUpdateReturnsOnCall[len(fake.statusUpdateArgsForCall)]
@@ -1190,10 +1894,16 @@ func (fake *FakeRepository) StatusUpdateReturnsOnCall(i int, result1 error) {
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.defaultRunnableMutex.RLock()
+	defer fake.defaultRunnableMutex.RUnlock()
+	fake.deleteUnstructuredMutex.RLock()
+	defer fake.deleteUnstructuredMutex.RUnlock()
 	fake.ensureObjectExistsOnClusterMutex.RLock()
 	defer fake.ensureObjectExistsOnClusterMutex.RUnlock()
 	fake.getClusterTemplateMutex.RLock()
 	defer fake.getClusterTemplateMutex.RUnlock()
+	fake.getCustomResourceDefinitionMutex.RLock()
+	defer fake.getCustomResourceDefinitionMutex.RUnlock()
 	fake.getDeliverableMutex.RLock()
 	defer fake.getDeliverableMutex.RUnlock()
 	fake.getDeliveriesForDeliverableMutex.RLock()
@@ -1210,14 +1920,26 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getSchemeMutex.RUnlock()
 	fake.getServiceAccountSecretMutex.RLock()
 	defer fake.getServiceAccountSecretMutex.RUnlock()
+	fake.getServiceAccountSecretLiveMutex.RLock()
+	defer fake.getServiceAccountSecretLiveMutex.RUnlock()
+	fake.getSubresourceMutex.RLock()
+	defer fake.getSubresourceMutex.RUnlock()
 	fake.getSupplyChainMutex.RLock()
 	defer fake.getSupplyChainMutex.RUnlock()
 	fake.getSupplyChainsForWorkloadMutex.RLock()
 	defer fake.getSupplyChainsForWorkloadMutex.RUnlock()
+	fake.getUnstructuredMutex.RLock()
+	defer fake.getUnstructuredMutex.RUnlock()
+	fake.getUnstructuredLiveMutex.RLock()
+	defer fake.getUnstructuredLiveMutex.RUnlock()
 	fake.getWorkloadMutex.RLock()
 	defer fake.getWorkloadMutex.RUnlock()
+	fake.listStampedObjectsMutex.RLock()
+	defer fake.listStampedObjectsMutex.RUnlock()
 	fake.listUnstructuredMutex.RLock()
 	defer fake.listUnstructuredMutex.RUnlock()
+	fake.reviewAccessMutex.RLock()
+	defer fake.reviewAccessMutex.RUnlock()
 	fake.statusUpdateMutex.RLock()
 	defer fake.statusUpdateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,117 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+)
+
+// RunnableNameLabel is set on every object stamped for a runnable to the runnable's name.
+const RunnableNameLabel = "carto.run/runnable-name"
+
+// ListStampedObjects lists the objects stamped for the owner: those labelled as stamped for it
+// that it is an owner of, so objects left behind by a deleted owner of the same name are not
+// listed. Only runnables are supported. The kinds listed are the kind the runnable's run
+// template stamps and the kind of the object most recently stamped for the runnable, which
+// differ when the run template was changed to stamp another kind.
+func (r *repository) ListStampedObjects(ctx context.Context, owner client.Object) ([]*unstructured.Unstructured, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("ListStampedObjects")
+
+	runnable, ok := owner.(*v1alpha1.Runnable)
+	if !ok {
+		return nil, fmt.Errorf("cannot list stamped objects of owner of type [%T], only runnables are supported", owner)
+	}
+
+	gvks, err := r.runnableStampedKinds(ctx, runnable)
+	if err != nil {
+		return nil, err
+	}
+
+	var stampedObjects []*unstructured.Unstructured
+	for _, gvk := range gvks {
+		query := &unstructured.Unstructured{}
+		query.SetGroupVersionKind(gvk)
+		query.SetNamespace(runnable.Namespace)
+		query.SetLabels(map[string]string{RunnableNameLabel: runnable.Name})
+
+		objects, err := r.ListUnstructured(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stamped objects of kind [%s]: %w", gvk, err)
+		}
+
+		for _, obj := range objects {
+			if ownedBy(obj, owner) {
+				stampedObjects = append(stampedObjects, obj)
+			}
+		}
+	}
+
+	return stampedObjects, nil
+}
+
+func (r *repository) runnableStampedKinds(ctx context.Context, runnable *v1alpha1.Runnable) ([]schema.GroupVersionKind, error) {
+	var gvks []schema.GroupVersionKind
+	add := func(gvk schema.GroupVersionKind) {
+		if gvk.Kind == "" {
+			return
+		}
+		for _, existing := range gvks {
+			if existing == gvk {
+				return
+			}
+		}
+		gvks = append(gvks, gvk)
+	}
+
+	// a name expression is resolved by the realizer, the object it last stamped records the kind
+	if ref := runnable.Spec.RunTemplateRef; ref.Name != "" && ref.NameExpression == "" {
+		runTemplate, err := r.GetRunTemplate(ctx, v1alpha1.TemplateReference{Kind: "ClusterRunTemplate", Name: ref.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get run template [%s]: %w", ref.Name, err)
+		}
+
+		stamped := &unstructured.Unstructured{}
+		if err := json.Unmarshal(runTemplate.Spec.Template.Raw, &stamped.Object); err != nil {
+			return nil, fmt.Errorf("failed to read the kind stamped by run template [%s]: %w", ref.Name, err)
+		}
+		add(stamped.GroupVersionKind())
+	}
+
+	if stampedRef := runnable.Status.StampedRef; stampedRef != nil {
+		add(schema.FromAPIVersionAndKind(stampedRef.APIVersion, stampedRef.Kind))
+	}
+
+	return gvks, nil
+}
+
+func ownedBy(obj *unstructured.Unstructured, owner client.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BufferedStatusRepository is a Repository whose StatusUpdate does not write immediately.
+// The latest status for each object is held for FlushWindow and written once, so several
+// reconciles of the same object within the window cost a single write to the api server.
+// Every other method is served by the wrapped Repository.
+//
+// A write that conflicts with a newer version of the object is retried on the version read
+// from Reader, keeping the buffered status, and a write that fails transiently is buffered
+// again. Any other failure, such as a status the api server rejects, drops the status, as
+// does the object having been deleted.
+//
+// Start flushes whatever is still pending when its context is cancelled; add the
+// repository to the manager so the final state of every object is written on shutdown.
+type BufferedStatusRepository struct {
+	Repository
+
+	reader      client.Reader
+	flushWindow time.Duration
+	logger      logr.Logger
+
+	mu      sync.Mutex
+	pending map[string]pendingStatus
+}
+
+type pendingStatus struct {
+	object client.Object
+	timer  *time.Timer
+}
+
+func NewBufferedStatusRepository(repo Repository, reader client.Reader, flushWindow time.Duration, logger logr.Logger) *BufferedStatusRepository {
+	return &BufferedStatusRepository{
+		Repository:  repo,
+		reader:      reader,
+		flushWindow: flushWindow,
+		logger:      logger,
+		pending:     make(map[string]pendingStatus),
+	}
+}
+
+// StatusUpdate buffers a copy of the object, replacing any status still pending for it.
+// Errors writing the status are logged when the buffer is flushed.
+func (r *BufferedStatusRepository) StatusUpdate(_ context.Context, object client.Object) error {
+	r.buffer(object.DeepCopyObject().(client.Object))
+	return nil
+}
+
+func (r *BufferedStatusRepository) buffer(object client.Object) {
+	key := statusKey(object)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.pending[key]
+	entry.object = object
+	if !ok {
+		entry.timer = time.AfterFunc(r.flushWindow, func() {
+			r.flush(key)
+		})
+	}
+	r.pending[key] = entry
+}
+
+func (r *BufferedStatusRepository) flush(key string) {
+	r.mu.Lock()
+	entry, ok := r.pending[key]
+	delete(r.pending, key)
+	r.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	r.write(entry.object)
+}
+
+func (r *BufferedStatusRepository) write(object client.Object) {
+	log := r.logger.WithValues("object", statusKey(object))
+	err := r.update(logr.NewContext(context.Background(), log), object)
+	if err == nil {
+		return
+	}
+
+	if kerrors.IsNotFound(err) {
+		// the object went away since it was reconciled, there is no status to keep
+		log.Info("dropping buffered status", "reason", err.Error())
+		return
+	}
+
+	if !kerrors.IsConflict(err) && !IsTransientError(err) {
+		// writing the same status again would fail the same way
+		log.Error(err, "failed to flush buffered status, dropping it")
+		return
+	}
+
+	log.Error(err, "failed to flush buffered status, retrying")
+	r.retry(object)
+}
+
+// update writes the status of the object, writing it again on the latest version of the
+// object for as long as the write conflicts with a newer one.
+func (r *BufferedStatusRepository) update(ctx context.Context, object client.Object) error {
+	attempt := object
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Repository.StatusUpdate(ctx, attempt)
+		if !kerrors.IsConflict(err) {
+			return err
+		}
+
+		latest, readErr := r.withLatestVersion(ctx, object)
+		if readErr != nil {
+			return readErr
+		}
+		attempt = latest
+		return err
+	})
+}
+
+// withLatestVersion reads the object from the api server and returns it with the status of
+// the given object.
+func (r *BufferedStatusRepository) withLatestVersion(ctx context.Context, object client.Object) (client.Object, error) {
+	latest := object.DeepCopyObject().(client.Object)
+	if err := r.reader.Get(ctx, client.ObjectKeyFromObject(object), latest); err != nil {
+		return nil, err
+	}
+
+	buffered, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("convert buffered object: %w", err)
+	}
+	fresh, err := runtime.DefaultUnstructuredConverter.ToUnstructured(latest)
+	if err != nil {
+		return nil, fmt.Errorf("convert latest object: %w", err)
+	}
+	fresh["status"] = buffered["status"]
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(fresh, latest); err != nil {
+		return nil, fmt.Errorf("convert latest object: %w", err)
+	}
+
+	return latest, nil
+}
+
+// retry buffers the object again unless a newer status was buffered while it was written
+func (r *BufferedStatusRepository) retry(object client.Object) {
+	r.mu.Lock()
+	_, newer := r.pending[statusKey(object)]
+	r.mu.Unlock()
+
+	if !newer {
+		r.buffer(object)
+	}
+}
+
+// Start blocks until the context is cancelled and then writes every pending status.
+func (r *BufferedStatusRepository) Start(ctx context.Context) error {
+	<-ctx.Done()
+	r.Flush()
+	return nil
+}
+
+// Flush writes every pending status immediately.
+func (r *BufferedStatusRepository) Flush() {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]pendingStatus)
+	r.mu.Unlock()
+
+	for _, entry := range pending {
+		entry.timer.Stop()
+		if err := r.update(context.Background(), entry.object); err != nil {
+			r.logger.Error(err, "failed to flush buffered status", "object", statusKey(entry.object))
+		}
+	}
+}
+
+func statusKey(object client.Object) string {
+	return fmt.Sprintf("%T/%s/%s", object, object.GetNamespace(), object.GetName())
+}
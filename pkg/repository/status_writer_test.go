@@ -0,0 +1,229 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("BufferedStatusRepository", func() {
+	var (
+		ctx         context.Context
+		wrapped     *repositoryfakes.FakeRepository
+		reader      *repositoryfakes.FakeClient
+		repo        *repository.BufferedStatusRepository
+		flushWindow time.Duration
+	)
+
+	workloadWithCondition := func(name, reason string) *v1alpha1.Workload {
+		return &v1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "my-ns",
+			},
+			Status: v1alpha1.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   v1alpha1.WorkloadReady,
+						Reason: reason,
+					},
+				},
+			},
+		}
+	}
+
+	writtenReasons := func() []string {
+		var reasons []string
+		for i := 0; i < wrapped.StatusUpdateCallCount(); i++ {
+			_, obj := wrapped.StatusUpdateArgsForCall(i)
+			reasons = append(reasons, obj.(*v1alpha1.Workload).Status.Conditions[0].Reason)
+		}
+		return reasons
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		wrapped = &repositoryfakes.FakeRepository{}
+		reader = &repositoryfakes.FakeClient{}
+		flushWindow = 50 * time.Millisecond
+	})
+
+	JustBeforeEach(func() {
+		repo = repository.NewBufferedStatusRepository(wrapped, reader, flushWindow, logr.Discard())
+	})
+
+	It("coalesces updates of the same object within the window, the last write winning", func() {
+		workload := workloadWithCondition("my-workload", "First")
+		Expect(repo.StatusUpdate(ctx, workload)).To(Succeed())
+
+		workload.Status.Conditions[0].Reason = "Second"
+		Expect(repo.StatusUpdate(ctx, workload)).To(Succeed())
+		Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Last"))).To(Succeed())
+
+		Expect(wrapped.StatusUpdateCallCount()).To(Equal(0))
+
+		Eventually(wrapped.StatusUpdateCallCount).Should(Equal(1))
+		Consistently(wrapped.StatusUpdateCallCount, 2*flushWindow).Should(Equal(1))
+		Expect(writtenReasons()).To(Equal([]string{"Last"}))
+	})
+
+	It("writes updates of different objects separately", func() {
+		Expect(repo.StatusUpdate(ctx, workloadWithCondition("first-workload", "First"))).To(Succeed())
+		Expect(repo.StatusUpdate(ctx, workloadWithCondition("second-workload", "Second"))).To(Succeed())
+
+		Eventually(wrapped.StatusUpdateCallCount).Should(Equal(2))
+		Expect(writtenReasons()).To(ConsistOf("First", "Second"))
+	})
+
+	It("buffers a copy of the object", func() {
+		workload := workloadWithCondition("my-workload", "Buffered")
+		Expect(repo.StatusUpdate(ctx, workload)).To(Succeed())
+		workload.Status.Conditions[0].Reason = "MutatedAfterwards"
+
+		Eventually(wrapped.StatusUpdateCallCount).Should(Equal(1))
+		Expect(writtenReasons()).To(Equal([]string{"Buffered"}))
+	})
+
+	It("serves every other method from the wrapped repository", func() {
+		wrapped.GetWorkloadReturns(workloadWithCondition("my-workload", "Read"), nil)
+
+		workload, err := repo.GetWorkload(ctx, "my-workload", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workload.Name).To(Equal("my-workload"))
+		Expect(wrapped.GetWorkloadCallCount()).To(Equal(1))
+	})
+
+	Context("when the write fails", func() {
+		Context("with a transient error", func() {
+			BeforeEach(func() {
+				wrapped.StatusUpdateReturnsOnCall(0, kerrors.NewServiceUnavailable("etcd leader changed"))
+			})
+
+			It("retries the write", func() {
+				Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Retried"))).To(Succeed())
+
+				Eventually(wrapped.StatusUpdateCallCount).Should(Equal(2))
+				Expect(writtenReasons()).To(Equal([]string{"Retried", "Retried"}))
+			})
+		})
+
+		Context("with a conflict", func() {
+			BeforeEach(func() {
+				wrapped.StatusUpdateReturnsOnCall(0, kerrors.NewConflict(schema.GroupResource{}, "my-workload", errors.New("stale")))
+				reader.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					latest := workloadWithCondition(key.Name, "FromServer")
+					latest.ResourceVersion = "2"
+					latest.Spec.ServiceAccountName = "updated"
+					latest.DeepCopyInto(obj.(*v1alpha1.Workload))
+					return nil
+				}
+			})
+
+			It("writes the buffered status on the latest version of the object", func() {
+				Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Buffered"))).To(Succeed())
+
+				Eventually(wrapped.StatusUpdateCallCount).Should(Equal(2))
+				Expect(reader.GetCallCount()).To(Equal(1))
+				_, key, _ := reader.GetArgsForCall(0)
+				Expect(key).To(Equal(client.ObjectKey{Namespace: "my-ns", Name: "my-workload"}))
+
+				_, obj := wrapped.StatusUpdateArgsForCall(1)
+				written := obj.(*v1alpha1.Workload)
+				Expect(written.ResourceVersion).To(Equal("2"))
+				Expect(written.Spec.ServiceAccountName).To(Equal("updated"))
+				Expect(written.Status.Conditions[0].Reason).To(Equal("Buffered"))
+				Consistently(wrapped.StatusUpdateCallCount, 2*flushWindow).Should(Equal(2))
+			})
+
+			Context("and the object was deleted since", func() {
+				BeforeEach(func() {
+					reader.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "my-workload"))
+				})
+
+				It("drops the status", func() {
+					Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Stale"))).To(Succeed())
+
+					Eventually(reader.GetCallCount).Should(Equal(1))
+					Consistently(wrapped.StatusUpdateCallCount, 2*flushWindow).Should(Equal(1))
+				})
+			})
+		})
+
+		Context("with an error writing the status again would not fix", func() {
+			BeforeEach(func() {
+				wrapped.StatusUpdateReturns(kerrors.NewForbidden(schema.GroupResource{Resource: "workloads"}, "my-workload", errors.New("denied by webhook")))
+			})
+
+			It("drops the status", func() {
+				Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Rejected"))).To(Succeed())
+
+				Eventually(wrapped.StatusUpdateCallCount).Should(Equal(1))
+				Consistently(wrapped.StatusUpdateCallCount, 2*flushWindow).Should(Equal(1))
+				Expect(reader.GetCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("because the object was deleted", func() {
+			BeforeEach(func() {
+				wrapped.StatusUpdateReturns(kerrors.NewNotFound(schema.GroupResource{}, "my-workload"))
+			})
+
+			It("drops the status", func() {
+				Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Stale"))).To(Succeed())
+
+				Eventually(wrapped.StatusUpdateCallCount).Should(Equal(1))
+				Consistently(wrapped.StatusUpdateCallCount, 2*flushWindow).Should(Equal(1))
+				Expect(reader.GetCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the manager stops before the window elapses", func() {
+		BeforeEach(func() {
+			flushWindow = time.Hour
+		})
+
+		It("writes the pending statuses", func() {
+			startCtx, cancel := context.WithCancel(ctx)
+			done := make(chan error)
+			go func() {
+				done <- repo.Start(startCtx)
+			}()
+
+			Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "First"))).To(Succeed())
+			Expect(repo.StatusUpdate(ctx, workloadWithCondition("my-workload", "Last"))).To(Succeed())
+			Expect(wrapped.StatusUpdateCallCount()).To(Equal(0))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+
+			Expect(writtenReasons()).To(Equal([]string{"Last"}))
+		})
+	})
+})
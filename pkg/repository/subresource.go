@@ -0,0 +1,125 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+const (
+	StatusSubresource = "status"
+	ScaleSubresource  = "scale"
+)
+
+type SubresourceNotAvailableError struct {
+	Subresource string
+	Object      *unstructured.Unstructured
+}
+
+func (e SubresourceNotAvailableError) Error() string {
+	return fmt.Sprintf("kind [%s] does not have a [%s] subresource", utils.GetFullyQualifiedType(e.Object), e.Subresource)
+}
+
+// GetSubresource reads the object from the api server and returns its view through the named
+// subresource, as declared by the CustomResourceDefinition of its kind. The scale view is
+// assembled from the replica and selector paths the definition declares, the way the api
+// server serves it.
+func (r *repository) GetSubresource(ctx context.Context, obj *unstructured.Unstructured, subresource string) (map[string]interface{}, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("subresource", subresource)
+	ctx = logr.NewContext(ctx, log)
+	log.V(logger.DEBUG).Info("GetSubresource")
+
+	crd, err := r.GetCustomResourceDefinition(ctx, obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+
+	subresources := crdSubresources(crd, obj.GroupVersionKind().Version)
+	if subresources == nil ||
+		(subresource == StatusSubresource && subresources.Status == nil) ||
+		(subresource == ScaleSubresource && subresources.Scale == nil) ||
+		(subresource != StatusSubresource && subresource != ScaleSubresource) {
+		log.V(logger.DEBUG).Info("kind does not have the subresource", "object", obj)
+		return nil, SubresourceNotAvailableError{Subresource: subresource, Object: obj}
+	}
+
+	current, err := r.GetUnstructured(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("object [%s/%s] of type [%s] not found", obj.GetNamespace(), obj.GetName(), utils.GetFullyQualifiedType(obj))
+	}
+
+	if subresource == StatusSubresource {
+		return current.UnstructuredContent(), nil
+	}
+
+	return scaleView(current, subresources.Scale), nil
+}
+
+func crdSubresources(crd *apiextensionsv1.CustomResourceDefinition, version string) *apiextensionsv1.CustomResourceSubresources {
+	if crd == nil {
+		return nil
+	}
+
+	for _, crdVersion := range crd.Spec.Versions {
+		if crdVersion.Name == version {
+			return crdVersion.Subresources
+		}
+	}
+
+	return nil
+}
+
+func scaleView(obj *unstructured.Unstructured, scale *apiextensionsv1.CustomResourceSubresourceScale) map[string]interface{} {
+	evaluator := eval.EvaluatorBuilder()
+	valueAt := func(path string) interface{} {
+		value, err := evaluator.EvaluateJsonPath(path, obj.UnstructuredContent())
+		if err != nil {
+			return nil
+		}
+		return value
+	}
+
+	status := map[string]interface{}{
+		"replicas": valueAt(scale.StatusReplicasPath),
+	}
+	if scale.LabelSelectorPath != nil {
+		status["selector"] = valueAt(*scale.LabelSelectorPath)
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "autoscaling/v1",
+		"kind":       "Scale",
+		"metadata": map[string]interface{}{
+			"name":      obj.GetName(),
+			"namespace": obj.GetNamespace(),
+		},
+		"spec": map[string]interface{}{
+			"replicas": valueAt(scale.SpecReplicasPath),
+		},
+		"status": status,
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+)
+
+// DefaultTokenExpiration is the lifetime requested of service account tokens
+const DefaultTokenExpiration = time.Hour
+
+// TokenRequestRepository is a Repository whose GetServiceAccountSecret requests a short-lived
+// token for the service account through the TokenRequest API, as clusters from Kubernetes 1.24
+// no longer create token secrets for service accounts. A token is reused until less than a
+// fifth of its lifetime is left. When no token can be requested, such as on clusters without
+// the API, the token secret of the service account is read instead.
+//
+// Tokens are kept by the UID of the service account, so a service account that is deleted and
+// created again under the same name gets a new token rather than one the api server no longer
+// accepts. Tokens of deleted service accounts, and tokens past their refresh time, are dropped.
+//
+// The token is returned as a service account token secret that holds only the token.
+type TokenRequestRepository struct {
+	Repository
+
+	reader          client.Reader
+	serviceAccounts corev1client.ServiceAccountsGetter
+	expiration      time.Duration
+
+	mu     sync.Mutex
+	tokens map[types.UID]requestedToken
+	uids   map[string]types.UID
+}
+
+type requestedToken struct {
+	secret  *corev1.Secret
+	refresh time.Time
+}
+
+// NewTokenRequestRepository returns a TokenRequestRepository that reads service accounts
+// through the reader and requests their tokens through serviceAccounts.
+func NewTokenRequestRepository(repo Repository, reader client.Reader, serviceAccounts corev1client.ServiceAccountsGetter, expiration time.Duration) *TokenRequestRepository {
+	return &TokenRequestRepository{
+		Repository:      repo,
+		reader:          reader,
+		serviceAccounts: serviceAccounts,
+		expiration:      expiration,
+		tokens:          make(map[types.UID]requestedToken),
+		uids:            make(map[string]types.UID),
+	}
+}
+
+func (r *TokenRequestRepository) GetServiceAccountSecret(ctx context.Context, name, namespace string) (*corev1.Secret, error) {
+	uid := r.serviceAccountUID(ctx, name, namespace)
+
+	r.mu.Lock()
+	token, ok := r.tokens[uid]
+	r.mu.Unlock()
+	if uid != "" && ok && time.Now().Before(token.refresh) {
+		return token.secret, nil
+	}
+
+	return r.requestServiceAccountSecret(ctx, name, namespace, uid, r.Repository.GetServiceAccountSecret)
+}
+
+// GetServiceAccountSecretLive requests a new token for the service account rather than reusing
+// the last one, falling back to a live read of its token secret.
+func (r *TokenRequestRepository) GetServiceAccountSecretLive(ctx context.Context, name, namespace string) (*corev1.Secret, error) {
+	uid := r.serviceAccountUID(ctx, name, namespace)
+	return r.requestServiceAccountSecret(ctx, name, namespace, uid, r.Repository.GetServiceAccountSecretLive)
+}
+
+// serviceAccountUID reads the UID of the service account, dropping the token kept for an
+// earlier service account of the same name. It returns an empty UID, and keeps no token, when
+// the service account cannot be read.
+func (r *TokenRequestRepository) serviceAccountUID(ctx context.Context, name, namespace string) types.UID {
+	log := logr.FromContextOrDiscard(ctx).WithValues("service account", fmt.Sprintf("%s/%s", namespace, name))
+	key := fmt.Sprintf("%s/%s", namespace, name)
+
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, serviceAccount)
+	if err != nil && !kerrors.IsNotFound(err) {
+		log.V(logger.DEBUG).Info("failed to read service account", "error", err.Error())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous, ok := r.uids[key]; ok && (err != nil || previous != serviceAccount.UID) {
+		delete(r.tokens, previous)
+		delete(r.uids, key)
+	}
+	if err != nil {
+		return ""
+	}
+
+	r.uids[key] = serviceAccount.UID
+	return serviceAccount.UID
+}
+
+func (r *TokenRequestRepository) requestServiceAccountSecret(ctx context.Context, name, namespace string, uid types.UID, getSecret func(ctx context.Context, name, namespace string) (*corev1.Secret, error)) (*corev1.Secret, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("service account", fmt.Sprintf("%s/%s", namespace, name))
+
+	token, err := r.requestToken(ctx, name, namespace)
+	if err != nil {
+		log.V(logger.DEBUG).Info("failed to request token, reading token secret", "error", err.Error())
+		secret, secretErr := getSecret(ctx, name, namespace)
+		if secretErr != nil {
+			return nil, fmt.Errorf("failed to request token for service account [%s/%s]: %v, and %w", namespace, name, err, secretErr)
+		}
+		return secret, nil
+	}
+
+	if uid != "" {
+		r.storeToken(uid, token)
+	}
+
+	return token.secret, nil
+}
+
+// storeToken keeps the token of the service account, dropping the tokens that are due a
+// refresh so that those of service accounts no longer in use do not pile up.
+func (r *TokenRequestRepository) storeToken(uid types.UID, token requestedToken) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for keptUID, kept := range r.tokens {
+		if !now.Before(kept.refresh) {
+			delete(r.tokens, keptUID)
+		}
+	}
+	for key, keptUID := range r.uids {
+		if _, ok := r.tokens[keptUID]; !ok && keptUID != uid {
+			delete(r.uids, key)
+		}
+	}
+
+	r.tokens[uid] = token
+}
+
+func (r *TokenRequestRepository) requestToken(ctx context.Context, name, namespace string) (requestedToken, error) {
+	expirationSeconds := int64(r.expiration.Seconds())
+	requested := time.Now()
+
+	tokenRequest, err := r.serviceAccounts.ServiceAccounts(namespace).CreateToken(ctx, name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return requestedToken{}, err
+	}
+	if tokenRequest.Status.Token == "" {
+		return requestedToken{}, fmt.Errorf("token request returned no token")
+	}
+
+	expiry := tokenRequest.Status.ExpirationTimestamp.Time
+	lifetime := expiry.Sub(requested)
+
+	return requestedToken{
+		secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					corev1.ServiceAccountNameKey: name,
+				},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+			Data: map[string][]byte{
+				corev1.ServiceAccountTokenKey: []byte(tokenRequest.Status.Token),
+			},
+		},
+		refresh: expiry.Add(-lifetime / 5),
+	}, nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("TokenRequestRepository", func() {
+	var (
+		ctx       context.Context
+		wrapped   *repositoryfakes.FakeRepository
+		clientset *fake.Clientset
+		reader    client.Client
+		repo      *repository.TokenRequestRepository
+
+		tokenRequests []*authenticationv1.TokenRequest
+		tokenLifetime time.Duration
+		tokenErr      error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		wrapped = &repositoryfakes.FakeRepository{}
+		tokenRequests = nil
+		tokenLifetime = time.Hour
+		tokenErr = nil
+
+		reader = crfake.NewClientBuilder().WithObjects(
+			serviceAccount("my-sa", "my-ns", "my-sa-uid"),
+			serviceAccount("my-sa", "other-ns", "other-sa-uid"),
+		).Build()
+
+		clientset = fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			createAction := action.(k8stesting.CreateAction)
+			if createAction.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+			if tokenErr != nil {
+				return true, nil, tokenErr
+			}
+
+			tokenRequest := createAction.GetObject().(*authenticationv1.TokenRequest)
+			tokenRequests = append(tokenRequests, tokenRequest)
+			tokenRequest.Status = authenticationv1.TokenRequestStatus{
+				Token:               fmt.Sprintf("token-%d", len(tokenRequests)),
+				ExpirationTimestamp: metav1.NewTime(time.Now().Add(tokenLifetime)),
+			}
+			return true, tokenRequest, nil
+		})
+	})
+
+	JustBeforeEach(func() {
+		repo = repository.NewTokenRequestRepository(wrapped, reader, clientset.CoreV1(), time.Hour)
+	})
+
+	It("requests a token for the service account", func() {
+		secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(secret.Type).To(Equal(corev1.SecretTypeServiceAccountToken))
+		Expect(secret.Annotations).To(HaveKeyWithValue(corev1.ServiceAccountNameKey, "my-sa"))
+		Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-1")))
+
+		Expect(tokenRequests).To(HaveLen(1))
+		Expect(*tokenRequests[0].Spec.ExpirationSeconds).To(Equal(int64(3600)))
+
+		actions := clientset.Actions()
+		Expect(actions).To(HaveLen(1))
+		Expect(actions[0].GetNamespace()).To(Equal("my-ns"))
+		Expect(actions[0].(k8stesting.CreateAction).GetSubresource()).To(Equal("token"))
+
+		Expect(wrapped.GetServiceAccountSecretCallCount()).To(Equal(0))
+	})
+
+	It("reuses the token while it is far from expiring", func() {
+		_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-1")))
+		Expect(tokenRequests).To(HaveLen(1))
+	})
+
+	It("requests a new token for a live read", func() {
+		_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		secret, err := repo.GetServiceAccountSecretLive(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-2")))
+
+		secret, err = repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-2")))
+		Expect(tokenRequests).To(HaveLen(2))
+	})
+
+	It("requests a token per service account", func() {
+		_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+		Expect(err).NotTo(HaveOccurred())
+
+		secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "other-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-2")))
+	})
+
+	Context("the service account is created again under the same name", func() {
+		It("requests a new token", func() {
+			_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reader.Delete(ctx, serviceAccount("my-sa", "my-ns", "my-sa-uid"))).To(Succeed())
+			Expect(reader.Create(ctx, serviceAccount("my-sa", "my-ns", "new-sa-uid"))).To(Succeed())
+
+			secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-2")))
+			Expect(tokenRequests).To(HaveLen(2))
+		})
+	})
+
+	Context("the service account is deleted", func() {
+		It("does not reuse its token", func() {
+			_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reader.Delete(ctx, serviceAccount("my-sa", "my-ns", "my-sa-uid"))).To(Succeed())
+
+			_, err = repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenRequests).To(HaveLen(2))
+
+			Expect(reader.Create(ctx, serviceAccount("my-sa", "my-ns", "my-sa-uid"))).To(Succeed())
+
+			secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-3")))
+			Expect(tokenRequests).To(HaveLen(3))
+		})
+	})
+
+	Context("the token is close to expiring", func() {
+		BeforeEach(func() {
+			tokenLifetime = time.Millisecond
+		})
+
+		It("requests a new token", func() {
+			_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(2 * time.Millisecond)
+
+			secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(HaveKeyWithValue(corev1.ServiceAccountTokenKey, []byte("token-2")))
+			Expect(tokenRequests).To(HaveLen(2))
+		})
+	})
+
+	Context("a token cannot be requested", func() {
+		BeforeEach(func() {
+			tokenErr = errors.New("the server could not find the requested resource")
+		})
+
+		Context("the service account has a token secret", func() {
+			var staticSecret *corev1.Secret
+
+			BeforeEach(func() {
+				staticSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-sa-token-abcde", Namespace: "my-ns"},
+					Type:       corev1.SecretTypeServiceAccountToken,
+				}
+				wrapped.GetServiceAccountSecretReturns(staticSecret, nil)
+			})
+
+			It("returns the token secret", func() {
+				secret, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret).To(Equal(staticSecret))
+
+				Expect(wrapped.GetServiceAccountSecretCallCount()).To(Equal(1))
+				_, name, namespace := wrapped.GetServiceAccountSecretArgsForCall(0)
+				Expect(name).To(Equal("my-sa"))
+				Expect(namespace).To(Equal("my-ns"))
+			})
+		})
+
+		Context("and the token secret is read live", func() {
+			BeforeEach(func() {
+				wrapped.GetServiceAccountSecretLiveReturns(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-sa-token-fghij", Namespace: "my-ns"},
+					Type:       corev1.SecretTypeServiceAccountToken,
+				}, nil)
+			})
+
+			It("reads the token secret live", func() {
+				secret, err := repo.GetServiceAccountSecretLive(ctx, "my-sa", "my-ns")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Name).To(Equal("my-sa-token-fghij"))
+				Expect(wrapped.GetServiceAccountSecretLiveCallCount()).To(Equal(1))
+				Expect(wrapped.GetServiceAccountSecretCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("the service account has no token secret", func() {
+			BeforeEach(func() {
+				wrapped.GetServiceAccountSecretReturns(nil, errors.New("service account [my-ns/my-sa] does not have any secrets"))
+			})
+
+			It("returns both errors", func() {
+				_, err := repo.GetServiceAccountSecret(ctx, "my-sa", "my-ns")
+				Expect(err).To(MatchError("failed to request token for service account [my-ns/my-sa]: the server could not find the requested resource, and service account [my-ns/my-sa] does not have any secrets"))
+			})
+		})
+	})
+})
+
+func serviceAccount(name, namespace string, uid types.UID) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       uid,
+		},
+	}
+}
@@ -17,6 +17,7 @@ package root
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,15 +25,79 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controller"
+	"github.com/vmware-tanzu/cartographer/pkg/controller/runnable"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/kindpolicy"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/mirrors"
 	"github.com/vmware-tanzu/cartographer/pkg/registrar"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
 )
 
 type Command struct {
-	Port    int
-	CertDir string
-	Logger  logr.Logger
+	Port                         int
+	CertDir                      string
+	Logger                       logr.Logger
+	MaxMappedRequestsPerEvent    int
+	MappedRequestsSpilloverDelay time.Duration
+	StampedKindPolicy            kindpolicy.Policy
+	ForbiddenApplyMaxRetries     int64
+	ForbiddenApplyRetryBackoff   time.Duration
+	StatusFlushWindow            time.Duration
+	PolicyObjects                []registrar.PolicyObjectReference
+	WatchRetryBackoff            time.Duration
+	WatchRetryMaxBackoff         time.Duration
+	// Namespace, when set, restricts cartographer to the workloads, deliverables and runnables of
+	// that namespace. Cluster scoped resources such as supply chains are still read cluster wide.
+	Namespace string
+	// AllowOutputOverrides honors the carto.run/override-output annotations of workloads.
+	AllowOutputOverrides bool
+	// TransientErrorBackoff is how long to wait before requeueing a runnable that could not be
+	// read because of a transient API server error, zero leaving it to the rate limiter.
+	TransientErrorBackoff time.Duration
+	// RunnableMaxOutputBytes and RunnableMaxTotalOutputBytes cap the size of each output and of
+	// all outputs recorded in a runnable's status, zero being unlimited.
+	RunnableMaxOutputBytes      int
+	RunnableMaxTotalOutputBytes int
+	// RunnableNamespaceFairQueue hands out the queued runnables of each namespace in turn, so
+	// that a namespace with many queued runnables does not hold back the others.
+	RunnableNamespaceFairQueue bool
+	// WarnImagePathSchema serves the webhook warning of image templates whose imagePath reads a
+	// field the schema of the stamped kind does not declare.
+	WarnImagePathSchema bool
+	// WarnRunTemplateStatus serves the webhook warning of run templates whose output paths read
+	// the status of a stamped kind that has no status subresource.
+	WarnRunTemplateStatus bool
+	// CheckStampedObjectPermissions reviews whether the service account of a workload may apply
+	// each stamped object before applying it, reporting InsufficientPermissions when it may not.
+	CheckStampedObjectPermissions bool
+	// RunnableTrackedObjectDebounce coalesces the events of an object stamped for a runnable
+	// within the window into one reconcile of the runnable, zero reconciling on every event.
+	RunnableTrackedObjectDebounce time.Duration
+	// Pause is the config map whose paused key, set to "true", has every workload, deliverable
+	// and runnable reconciler return without action, for cluster maintenance.
+	Pause controller.Pause
+	// RunnableMaxFailedAttempts is how many times in a row a runnable may fail with the same
+	// class of retried error before it is no longer requeued, zero retrying forever.
+	RunnableMaxFailedAttempts int64
+	// MaxTrackedKinds bounds the kinds of stamped objects the workload, deliverable and runnable
+	// controllers each watch at once, zero watching every kind with the manager's informers.
+	MaxTrackedKinds int
+	// MetricsBindAddress is the address the metrics are served on, "0" disabling them.
+	MetricsBindAddress string
+	// RegistryMirrors rewrites the images read from image templates to the mirrors they are
+	// pulled from before they are passed to downstream resources.
+	RegistryMirrors mirrors.Mirrors
+}
+
+func (cmd *Command) metricsBindAddress() string {
+	if cmd.MetricsBindAddress == "" {
+		return "0"
+	}
+	return cmd.MetricsBindAddress
 }
 
 func (cmd *Command) Execute(ctx context.Context) error {
@@ -53,14 +118,49 @@ func (cmd *Command) Execute(ctx context.Context) error {
 		Port:               cmd.Port,
 		CertDir:            cmd.CertDir,
 		Scheme:             scheme,
-		MetricsBindAddress: "0",
+		MetricsBindAddress: cmd.metricsBindAddress(),
+		Namespace:          cmd.Namespace,
 	})
 
 	if err != nil {
 		return fmt.Errorf("manager new: %w", err)
 	}
 
-	if err := registrar.RegisterControllers(mgr); err != nil {
+	spillover := registrar.SpilloverOptions{
+		MaxRequestsPerEvent: cmd.MaxMappedRequestsPerEvent,
+		Delay:               cmd.MappedRequestsSpilloverDelay,
+	}
+	forbiddenRetry := controller.ForbiddenRetryOptions{
+		MaxRetries: cmd.ForbiddenApplyMaxRetries,
+		Backoff:    cmd.ForbiddenApplyRetryBackoff,
+	}
+	watchBackoff := tracker.WatchBackoff{
+		Initial: cmd.WatchRetryBackoff,
+		Max:     cmd.WatchRetryMaxBackoff,
+	}
+	runnableOutputLimits := runnable.OutputLimits{
+		MaxBytes:      cmd.RunnableMaxOutputBytes,
+		MaxTotalBytes: cmd.RunnableMaxTotalOutputBytes,
+	}
+	if err := registrar.RegisterControllers(ctx, mgr, registrar.Options{
+		Spillover:                     spillover,
+		StampedKindPolicy:             cmd.StampedKindPolicy,
+		ForbiddenRetry:                forbiddenRetry,
+		StatusFlushWindow:             cmd.StatusFlushWindow,
+		PolicyObjects:                 cmd.PolicyObjects,
+		WatchBackoff:                  watchBackoff,
+		Namespace:                     cmd.Namespace,
+		MaxTrackedKinds:               cmd.MaxTrackedKinds,
+		Pause:                         cmd.Pause,
+		AllowOutputOverrides:          cmd.AllowOutputOverrides,
+		CheckStampedObjectPermissions: cmd.CheckStampedObjectPermissions,
+		RegistryMirrors:               cmd.RegistryMirrors,
+		TransientErrorBackoff:         cmd.TransientErrorBackoff,
+		RunnableOutputLimits:          runnableOutputLimits,
+		RunnableNamespaceFairQueue:    cmd.RunnableNamespaceFairQueue,
+		RunnableDebounce:              cmd.RunnableTrackedObjectDebounce,
+		RunnableMaxFailedAttempts:     cmd.RunnableMaxFailedAttempts,
+	}); err != nil {
 		return fmt.Errorf("register controllers: %w", err)
 	}
 
@@ -76,6 +176,9 @@ func (cmd *Command) Execute(ctx context.Context) error {
 			Complete(); err != nil {
 			return fmt.Errorf("clustersupplychain webhook: %w", err)
 		}
+		mgr.GetWebhookServer().Register(registrar.SupplyChainOverlapWebhookPath, &webhook.Admission{
+			Handler: &registrar.SupplyChainOverlapWarner{Client: mgr.GetClient()},
+		})
 		if err := controllerruntime.NewWebhookManagedBy(mgr).
 			For(&v1alpha1.ClusterConfigTemplate{}).
 			Complete(); err != nil {
@@ -86,6 +189,12 @@ func (cmd *Command) Execute(ctx context.Context) error {
 			Complete(); err != nil {
 			return fmt.Errorf("clusterimagetemplate webhook: %w", err)
 		}
+		if cmd.WarnImagePathSchema {
+			repo := repository.NewRepository(mgr.GetClient(), repository.NewCache(mgr.GetLogger().WithName("image-path-schema-repo-cache")))
+			mgr.GetWebhookServer().Register(registrar.ImagePathSchemaWebhookPath, &webhook.Admission{
+				Handler: &registrar.ImagePathSchemaWarner{Repo: repo},
+			})
+		}
 		if err := controllerruntime.NewWebhookManagedBy(mgr).
 			For(&v1alpha1.ClusterSourceTemplate{}).
 			Complete(); err != nil {
@@ -106,6 +215,17 @@ func (cmd *Command) Execute(ctx context.Context) error {
 			Complete(); err != nil {
 			return fmt.Errorf("clusterdeploymenttemplate webhook: %w", err)
 		}
+		if err := controllerruntime.NewWebhookManagedBy(mgr).
+			For(&v1alpha1.Runnable{}).
+			Complete(); err != nil {
+			return fmt.Errorf("runnable webhook: %w", err)
+		}
+		if cmd.WarnRunTemplateStatus {
+			repo := repository.NewRepository(mgr.GetClient(), repository.NewCache(mgr.GetLogger().WithName("run-template-status-repo-cache")))
+			mgr.GetWebhookServer().Register(registrar.RunTemplateStatusWebhookPath, &webhook.Admission{
+				Handler: &registrar.RunTemplateStatusWarner{Repo: repo},
+			})
+		}
 	}
 
 	if err := mgr.Start(ctx); err != nil {
@@ -15,16 +15,19 @@
 package templates
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 )
 
 type clusterConfigTemplate struct {
 	template      *v1alpha1.ClusterConfigTemplate
 	evaluator     evaluator
+	repo          repository.Repository
 	stampedObject *unstructured.Unstructured
 }
 
@@ -32,8 +35,8 @@ func (t *clusterConfigTemplate) GetKind() string {
 	return t.template.Kind
 }
 
-func NewClusterConfigTemplateModel(template *v1alpha1.ClusterConfigTemplate, eval evaluator) *clusterConfigTemplate {
-	return &clusterConfigTemplate{template: template, evaluator: eval}
+func NewClusterConfigTemplateModel(template *v1alpha1.ClusterConfigTemplate, eval evaluator, repo repository.Repository) *clusterConfigTemplate {
+	return &clusterConfigTemplate{template: template, evaluator: eval, repo: repo}
 }
 
 func (t *clusterConfigTemplate) GetName() string {
@@ -46,8 +49,32 @@ func (t *clusterConfigTemplate) SetStampedObject(stampedObject *unstructured.Uns
 	t.stampedObject = stampedObject
 }
 
-func (t *clusterConfigTemplate) GetOutput() (*Output, error) {
-	config, err := t.evaluator.EvaluateJsonPath(t.template.Spec.ConfigPath, t.stampedObject.UnstructuredContent())
+func (t *clusterConfigTemplate) GetOutput(ctx context.Context) (*Output, error) {
+	if err := checkReadinessGates(t.stampedObject, t.template.Spec.ReadinessGates); err != nil {
+		return nil, err
+	}
+
+	content, err := outputContent(ctx, t.repo, t.stampedObject, t.template.Spec.OutputSubresource)
+	if err != nil {
+		return nil, err
+	}
+
+	if from := t.template.Spec.OutputFrom; from != nil {
+		config, err := outputFrom(ctx, t.repo, t.evaluator, t.stampedObject, content, from)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOutputValue(from.NameFromPath, config, t.template.Spec.OutputRequired, false); err != nil {
+			return nil, err
+		}
+		return &Output{
+			Config:    config,
+			Sensitive: from.Kind == v1alpha1.OutputFromSecret,
+			Warnings:  outputPathWarnings(from.NameFromPath),
+		}, nil
+	}
+
+	config, err := t.evaluator.EvaluateJsonPath(t.template.Spec.ConfigPath, content)
 	if err != nil {
 		return nil, JsonPathError{
 			Err: fmt.Errorf("failed to evaluate spec.configPath [%s]: %w",
@@ -56,8 +83,13 @@ func (t *clusterConfigTemplate) GetOutput() (*Output, error) {
 		}
 	}
 
+	if err := checkOutputValue(t.template.Spec.ConfigPath, config, t.template.Spec.OutputRequired, false); err != nil {
+		return nil, err
+	}
+
 	return &Output{
-		Config: config,
+		Config:   config,
+		Warnings: outputPathWarnings(t.template.Spec.ConfigPath),
 	}, nil
 }
 
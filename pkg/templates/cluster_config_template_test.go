@@ -15,6 +15,8 @@
 package templates_test
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
@@ -22,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 	"github.com/vmware-tanzu/cartographer/pkg/templates/templatesfakes"
 )
@@ -52,17 +55,19 @@ var _ = Describe("ClusterConfigTemplate", func() {
 			output        *templates.Output
 			stampedObject *unstructured.Unstructured
 			evaluator     *templatesfakes.FakeEvaluator
+			repo          *repositoryfakes.FakeRepository
 		)
 
 		BeforeEach(func() {
 			stampedObject = &unstructured.Unstructured{}
 			evaluator = &templatesfakes.FakeEvaluator{}
+			repo = &repositoryfakes.FakeRepository{}
 		})
 
 		JustBeforeEach(func() {
-			clusterConfigTemplateModel := templates.NewClusterConfigTemplateModel(configTemplate, evaluator)
+			clusterConfigTemplateModel := templates.NewClusterConfigTemplateModel(configTemplate, evaluator, repo)
 			clusterConfigTemplateModel.SetStampedObject(stampedObject)
-			output, err = clusterConfigTemplateModel.GetOutput()
+			output, err = clusterConfigTemplateModel.GetOutput(context.TODO())
 		})
 
 		When("passed a stamped object for which the evaluator can return a value at the configPath", func() {
@@ -92,5 +97,113 @@ var _ = Describe("ClusterConfigTemplate", func() {
 			})
 			ItReturnsAHelpfulError("some error")
 		})
+
+		When("the value at the configPath is empty", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathReturns("", nil)
+			})
+			It("returns the empty output", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.Config).To(Equal(""))
+			})
+
+			When("the template requires its output", func() {
+				BeforeEach(func() {
+					outputRequired := true
+					configTemplate.Spec.OutputRequired = &outputRequired
+				})
+				It("returns an OutputEmptyError", func() {
+					Expect(output).To(BeNil())
+					Expect(err).To(MatchError("value at json path 'some.path' is empty"))
+				})
+			})
+		})
+
+		When("the template reads its output from a ConfigMap named by the stamped object", func() {
+			var resultsObject *unstructured.Unstructured
+
+			BeforeEach(func() {
+				configTemplate.Spec.ConfigPath = ""
+				configTemplate.Spec.OutputFrom = &v1alpha1.OutputFrom{
+					Kind:         v1alpha1.OutputFromConfigMap,
+					NameFromPath: ".status.resultsConfigMap",
+					Key:          "result",
+				}
+				stampedObject.SetNamespace("some-namespace")
+				evaluator.EvaluateJsonPathReturns("some-results", nil)
+
+				resultsObject = &unstructured.Unstructured{}
+				resultsObject.SetUnstructuredContent(map[string]interface{}{
+					"data": map[string]interface{}{"result": "some value"},
+				})
+				repo.GetUnstructuredReturns(resultsObject, nil)
+			})
+
+			It("returns the value at the key of the ConfigMap", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.Config).To(Equal("some value"))
+				Expect(output.Sensitive).To(BeFalse())
+			})
+
+			It("reads the ConfigMap named at the nameFromPath in the namespace of the stamped object", func() {
+				path, _ := evaluator.EvaluateJsonPathArgsForCall(0)
+				Expect(path).To(Equal(".status.resultsConfigMap"))
+
+				Expect(repo.GetUnstructuredCallCount()).To(Equal(1))
+				_, query := repo.GetUnstructuredArgsForCall(0)
+				Expect(query.GetAPIVersion()).To(Equal("v1"))
+				Expect(query.GetKind()).To(Equal("ConfigMap"))
+				Expect(query.GetNamespace()).To(Equal("some-namespace"))
+				Expect(query.GetName()).To(Equal("some-results"))
+			})
+
+			When("the key is not in the ConfigMap", func() {
+				BeforeEach(func() {
+					configTemplate.Spec.OutputFrom.Key = "missing"
+				})
+				It("returns an OutputFromError", func() {
+					Expect(output).To(BeNil())
+					Expect(err).To(MatchError("failed to read the output from the ConfigMap named by the stamped object: key [missing] not found in [some-results]"))
+				})
+			})
+
+			When("the ConfigMap does not exist", func() {
+				BeforeEach(func() {
+					repo.GetUnstructuredReturns(nil, nil)
+				})
+				It("returns an OutputFromError", func() {
+					Expect(output).To(BeNil())
+					Expect(err).To(MatchError("failed to read the output from the ConfigMap named by the stamped object: [some-results] not found"))
+				})
+			})
+
+			When("the stamped object does not name a ConfigMap yet", func() {
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathReturns("", nil)
+				})
+				It("returns an OutputEmptyError", func() {
+					Expect(output).To(BeNil())
+					Expect(err).To(MatchError("value at json path '.status.resultsConfigMap' is empty"))
+					Expect(repo.GetUnstructuredCallCount()).To(Equal(0))
+				})
+			})
+
+			When("the output is read from a Secret", func() {
+				BeforeEach(func() {
+					configTemplate.Spec.OutputFrom.Kind = v1alpha1.OutputFromSecret
+					resultsObject.SetUnstructuredContent(map[string]interface{}{
+						"data": map[string]interface{}{"result": base64.StdEncoding.EncodeToString([]byte("some secret value"))},
+					})
+				})
+				It("returns the decoded value, marked sensitive", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output.Config).To(Equal("some secret value"))
+					Expect(output.Sensitive).To(BeTrue())
+
+					_, query := repo.GetUnstructuredArgsForCall(0)
+					Expect(query.GetKind()).To(Equal("Secret"))
+				})
+			})
+		})
 	})
 })
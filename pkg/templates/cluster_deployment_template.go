@@ -15,6 +15,7 @@
 package templates
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -49,7 +50,7 @@ func (t *clusterDeploymentTemplate) SetStampedObject(stampedObject *unstructured
 	t.stampedObject = stampedObject
 }
 
-func (t *clusterDeploymentTemplate) GetOutput() (*Output, error) {
+func (t *clusterDeploymentTemplate) GetOutput(_ context.Context) (*Output, error) {
 	if err := t.outputReady(t.stampedObject); err != nil {
 		return nil, err
 	}
@@ -62,6 +63,7 @@ func (t *clusterDeploymentTemplate) GetOutput() (*Output, error) {
 
 	output.Source.URL = t.inputs.Deployment.URL
 	output.Source.Revision = t.inputs.Deployment.Revision
+	output.Source.Digest = t.inputs.Deployment.Digest
 
 	return output, nil
 }
@@ -15,6 +15,7 @@
 package templates_test
 
 import (
+	"context"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
@@ -71,7 +72,7 @@ var _ = Describe("ClusterDeploymentTemplate", func() {
 			clusterDeploymentTemplateModel := templates.NewClusterDeploymentTemplateModel(deploymentTemplate, evaluator)
 			clusterDeploymentTemplateModel.SetStampedObject(stampedObject)
 			clusterDeploymentTemplateModel.SetInputs(inputs)
-			output, err = clusterDeploymentTemplateModel.GetOutput()
+			output, err = clusterDeploymentTemplateModel.GetOutput(context.TODO())
 		})
 
 		Context("observedCompletion", func() {
@@ -17,16 +17,21 @@ package templates
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 )
 
 type clusterImageTemplate struct {
 	template      *v1alpha1.ClusterImageTemplate
 	evaluator     evaluator
+	repo          repository.Repository
 	stampedObject *unstructured.Unstructured
 }
 
@@ -34,8 +39,8 @@ func (t *clusterImageTemplate) GetKind() string {
 	return t.template.Kind
 }
 
-func NewClusterImageTemplateModel(template *v1alpha1.ClusterImageTemplate, eval evaluator) *clusterImageTemplate {
-	return &clusterImageTemplate{template: template, evaluator: eval}
+func NewClusterImageTemplateModel(template *v1alpha1.ClusterImageTemplate, eval evaluator, repo repository.Repository) *clusterImageTemplate {
+	return &clusterImageTemplate{template: template, evaluator: eval, repo: repo}
 }
 
 func (t *clusterImageTemplate) GetName() string {
@@ -48,8 +53,38 @@ func (t *clusterImageTemplate) SetStampedObject(stampedObject *unstructured.Unst
 	t.stampedObject = stampedObject
 }
 
-func (t *clusterImageTemplate) GetOutput() (*Output, error) {
-	image, err := t.evaluator.EvaluateJsonPath(t.template.Spec.ImagePath, t.stampedObject.UnstructuredContent())
+func (t *clusterImageTemplate) GetOutput(ctx context.Context) (*Output, error) {
+	if err := checkReadinessGates(t.stampedObject, t.template.Spec.ReadinessGates); err != nil {
+		return nil, err
+	}
+
+	content, err := outputContent(ctx, t.repo, t.stampedObject, t.template.Spec.OutputSubresource)
+	if err != nil {
+		return nil, err
+	}
+
+	if from := t.template.Spec.OutputFrom; from != nil {
+		image, err := outputFrom(ctx, t.repo, t.evaluator, t.stampedObject, content, from)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOutputValue(from.NameFromPath, image, t.template.Spec.OutputRequired, true); err != nil {
+			return nil, err
+		}
+		if err := checkImagePinned(from.NameFromPath, image, t.template.Spec.RequireDigest); err != nil {
+			return nil, err
+		}
+		return &Output{
+			Image:     image,
+			Sensitive: from.Kind == v1alpha1.OutputFromSecret,
+			Warnings:  outputPathWarnings(from.NameFromPath),
+		}, nil
+	}
+
+	image, err := t.evaluateImage(content)
+	if ambiguousErr, ok := err.(eval.AmbiguousJsonPathError); ok {
+		return nil, ambiguousErr
+	}
 	if err != nil {
 		return nil, JsonPathError{
 			Err: fmt.Errorf("failed to evaluate the url path [%s]: %w",
@@ -58,11 +93,56 @@ func (t *clusterImageTemplate) GetOutput() (*Output, error) {
 		}
 	}
 
+	if err := checkOutputValue(t.template.Spec.ImagePath, image, t.template.Spec.OutputRequired, true); err != nil {
+		return nil, err
+	}
+	if err := checkImagePinned(t.template.Spec.ImagePath, image, t.template.Spec.RequireDigest); err != nil {
+		return nil, err
+	}
+
 	return &Output{
-		Image: image,
+		Image:    image,
+		Warnings: outputPathWarnings(t.template.Spec.ImagePath),
 	}, nil
 }
 
+// imageDigest matches the digest an image reference is pinned to, name@algorithm:hex
+var imageDigest = regexp.MustCompile(`@(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+
+// checkImagePinned fails an image that is not pinned to a digest, when the template requires it
+func checkImagePinned(expression string, image interface{}, requireDigest bool) error {
+	if !requireDigest {
+		return nil
+	}
+	if reference, ok := image.(string); ok && imageDigest.MatchString(reference) {
+		return nil
+	}
+	return NewImageNotPinnedError(expression, fmt.Sprint(image))
+}
+
+// evaluateImage reads the image at the imagePath, picking one of the values it matches
+// when the template sorts them
+func (t *clusterImageTemplate) evaluateImage(content map[string]interface{}) (interface{}, error) {
+	imageSort := t.template.Spec.ImageSort
+	if imageSort == nil {
+		return t.evaluator.EvaluateJsonPath(t.template.Spec.ImagePath, content)
+	}
+
+	picked, err := t.evaluator.EvaluateJsonPathSorted(t.template.Spec.ImagePath, imageSort.SortBy, imageSort.Pick, content)
+	if err != nil {
+		return nil, err
+	}
+	if imageSort.ValuePath == "" {
+		return picked, nil
+	}
+
+	image, err := t.evaluator.EvaluateJsonPath(imageSort.ValuePath, picked)
+	if err != nil {
+		return nil, fmt.Errorf("value path [%s] of the picked value: %w", imageSort.ValuePath, err)
+	}
+	return image, nil
+}
+
 func (t *clusterImageTemplate) GetResourceTemplate() v1alpha1.TemplateSpec {
 	return t.template.Spec.TemplateSpec
 }
@@ -15,13 +15,17 @@
 package templates_test
 
 import (
+	"context"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 	"github.com/vmware-tanzu/cartographer/pkg/templates/templatesfakes"
 )
@@ -52,17 +56,19 @@ var _ = Describe("ClusterImageTemplate", func() {
 			output        *templates.Output
 			stampedObject *unstructured.Unstructured
 			evaluator     *templatesfakes.FakeEvaluator
+			repo          *repositoryfakes.FakeRepository
 		)
 
 		BeforeEach(func() {
 			stampedObject = &unstructured.Unstructured{}
 			evaluator = &templatesfakes.FakeEvaluator{}
+			repo = &repositoryfakes.FakeRepository{}
 		})
 
 		JustBeforeEach(func() {
-			clusterImageTemplateModel := templates.NewClusterImageTemplateModel(imageTemplate, evaluator)
+			clusterImageTemplateModel := templates.NewClusterImageTemplateModel(imageTemplate, evaluator, repo)
 			clusterImageTemplateModel.SetStampedObject(stampedObject)
-			output, err = clusterImageTemplateModel.GetOutput()
+			output, err = clusterImageTemplateModel.GetOutput(context.TODO())
 		})
 
 		When("passed a stamped object for which the evaluator can return a value at the imagePath", func() {
@@ -79,6 +85,29 @@ var _ = Describe("ClusterImageTemplate", func() {
 			})
 		})
 
+		When("the imagePath is rooted at the spec of the stamped object", func() {
+			BeforeEach(func() {
+				imageTemplate.Spec.ImagePath = ".spec.image"
+				evaluator.EvaluateJsonPathReturns("some value", nil)
+			})
+			It("returns the output with a warning naming the path", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.Image).To(Equal("some value"))
+				Expect(output.Warnings).To(Equal([]string{"output path [.spec.image] reads .spec of the stamped object rather than .status"}))
+			})
+		})
+
+		When("the imagePath is rooted at the status of the stamped object", func() {
+			BeforeEach(func() {
+				imageTemplate.Spec.ImagePath = "{.status.latestImage}"
+				evaluator.EvaluateJsonPathReturns("some value", nil)
+			})
+			It("returns the output without warnings", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.Warnings).To(BeEmpty())
+			})
+		})
+
 		When("passed a stamped object for which the evaluator cannot return a value at the imagePath", func() {
 			BeforeEach(func() {
 				evaluator.EvaluateJsonPathReturns("", fmt.Errorf("some error"))
@@ -93,5 +122,228 @@ var _ = Describe("ClusterImageTemplate", func() {
 			})
 			ItReturnsAHelpfulError("some error")
 		})
+
+		When("the imagePath matches more than one value", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathReturns("", eval.AmbiguousJsonPathError{Path: "some.path", Count: 2})
+			})
+			It("returns an AmbiguousJsonPathError", func() {
+				Expect(output).To(BeNil())
+				Expect(err).To(Equal(eval.AmbiguousJsonPathError{Path: "some.path", Count: 2}))
+			})
+		})
+
+		When("the template sorts the values at the imagePath", func() {
+			BeforeEach(func() {
+				imageTemplate.Spec.ImageSort = &v1alpha1.OutputSort{SortBy: ".platform", Pick: v1alpha1.OutputPickLast}
+				evaluator.EvaluateJsonPathSortedReturns("some value", nil)
+			})
+
+			It("outputs the value the evaluator picks", func() {
+				Expect(evaluator.EvaluateJsonPathCallCount()).To(Equal(0))
+				Expect(evaluator.EvaluateJsonPathSortedCallCount()).To(Equal(1))
+				path, sortBy, pick, obj := evaluator.EvaluateJsonPathSortedArgsForCall(0)
+				Expect(path).To(Equal("some.path"))
+				Expect(sortBy).To(Equal(".platform"))
+				Expect(pick).To(Equal("last"))
+				Expect(obj).To(Equal(stampedObject.UnstructuredContent()))
+
+				Expect(output.Image).To(Equal("some value"))
+			})
+
+			When("the sort has a value path", func() {
+				var picked map[string]interface{}
+
+				BeforeEach(func() {
+					imageTemplate.Spec.ImageSort.ValuePath = ".image"
+					picked = map[string]interface{}{"platform": "linux/amd64", "image": "some-image"}
+					evaluator.EvaluateJsonPathSortedReturns(picked, nil)
+					evaluator.EvaluateJsonPathReturns("some-image", nil)
+				})
+
+				It("outputs the value at the value path of the picked value", func() {
+					Expect(evaluator.EvaluateJsonPathCallCount()).To(Equal(1))
+					path, obj := evaluator.EvaluateJsonPathArgsForCall(0)
+					Expect(path).To(Equal(".image"))
+					Expect(obj).To(Equal(picked))
+
+					Expect(output.Image).To(Equal("some-image"))
+				})
+			})
+
+			When("the evaluator cannot sort the values", func() {
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathSortedReturns(nil, fmt.Errorf("some sort error"))
+				})
+
+				It("returns an error which identifies the failing json path expression", func() {
+					Expect(output).To(BeNil())
+					jsonPathErr, ok := err.(templates.JsonPathError)
+					Expect(ok).To(BeTrue())
+					Expect(jsonPathErr.JsonPathExpression()).To(Equal("some.path"))
+				})
+				ItReturnsAHelpfulError("some sort error")
+			})
+		})
+
+		When("the template reads its output from a subresource", func() {
+			var scale map[string]interface{}
+
+			BeforeEach(func() {
+				imageTemplate.Spec.OutputSubresource = "scale"
+				scale = map[string]interface{}{"status": map[string]interface{}{"replicas": int64(2)}}
+				repo.GetSubresourceReturns(scale, nil)
+				evaluator.EvaluateJsonPathReturns("some value", nil)
+			})
+
+			It("evaluates the imagePath against the subresource", func() {
+				Expect(repo.GetSubresourceCallCount()).To(Equal(1))
+				_, obj, subresource := repo.GetSubresourceArgsForCall(0)
+				Expect(obj).To(Equal(stampedObject))
+				Expect(subresource).To(Equal("scale"))
+
+				_, evaluated := evaluator.EvaluateJsonPathArgsForCall(0)
+				Expect(evaluated).To(Equal(scale))
+
+				Expect(output.Image).To(Equal("some value"))
+			})
+
+			When("the subresource cannot be read", func() {
+				BeforeEach(func() {
+					repo.GetSubresourceReturns(nil, fmt.Errorf("no scale here"))
+				})
+
+				It("returns a SubresourceError", func() {
+					Expect(output).To(BeNil())
+					subresourceErr, ok := err.(templates.SubresourceError)
+					Expect(ok).To(BeTrue())
+					Expect(subresourceErr.Subresource).To(Equal("scale"))
+					Expect(evaluator.EvaluateJsonPathCallCount()).To(Equal(0))
+				})
+				ItReturnsAHelpfulError("failed to read the [scale] subresource of the stamped object: no scale here")
+			})
+		})
+
+		When("the value at the imagePath is empty", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathReturns("", nil)
+			})
+			It("returns an error which identifies the empty json path expression", func() {
+				Expect(output).To(BeNil())
+				emptyErr, ok := err.(templates.OutputEmptyError)
+				Expect(ok).To(BeTrue())
+				Expect(emptyErr.JsonPathExpression()).To(Equal("some.path"))
+			})
+			ItReturnsAHelpfulError("value at json path 'some.path' is empty")
+
+			When("the template does not require its output", func() {
+				BeforeEach(func() {
+					outputRequired := false
+					imageTemplate.Spec.OutputRequired = &outputRequired
+				})
+				It("returns the empty output", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output.Image).To(Equal(""))
+				})
+			})
+		})
+
+		When("the value at the imagePath is only whitespace", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathReturns(" \t\n", nil)
+			})
+			It("returns an OutputEmptyError", func() {
+				Expect(output).To(BeNil())
+				Expect(err).To(BeAssignableToTypeOf(templates.OutputEmptyError{}))
+			})
+		})
+
+		When("the template requires the image to be pinned to a digest", func() {
+			BeforeEach(func() {
+				imageTemplate.Spec.RequireDigest = true
+			})
+
+			When("the image is only tagged", func() {
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathReturns("registry.example.com/app:v1.2.3", nil)
+				})
+
+				It("returns an ImageNotPinnedError naming the image and path", func() {
+					Expect(output).To(BeNil())
+					Expect(err).To(Equal(templates.NewImageNotPinnedError("some.path", "registry.example.com/app:v1.2.3")))
+					Expect(err).To(MatchError("image [registry.example.com/app:v1.2.3] at json path 'some.path' is not pinned to a digest"))
+				})
+			})
+
+			When("the image is tagged and pinned to a digest", func() {
+				const pinned = "registry.example.com/app:v1.2.3@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathReturns(pinned, nil)
+				})
+
+				It("returns the output", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output.Image).To(Equal(pinned))
+				})
+			})
+
+			When("the digest is truncated", func() {
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathReturns("registry.example.com/app@sha256:0123456789abcdef", nil)
+				})
+
+				It("returns an ImageNotPinnedError", func() {
+					Expect(err).To(BeAssignableToTypeOf(templates.ImageNotPinnedError{}))
+				})
+			})
+		})
+
+		When("the template has readiness gates", func() {
+			BeforeEach(func() {
+				imageTemplate.Spec.ReadinessGates = []v1alpha1.ReadinessGate{
+					{ConditionType: "Succeeded"},
+					{ConditionType: "SBOMScanned", Status: metav1.ConditionTrue},
+				}
+				stampedObject.Object = map[string]interface{}{
+					"status": map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Succeeded", "status": "True"},
+							map[string]interface{}{"type": "SBOMScanned", "status": "False"},
+						},
+					},
+				}
+				evaluator.EvaluateJsonPathReturns("some value", nil)
+			})
+
+			It("returns a ReadinessGatesNotSatisfiedError naming the failing gate", func() {
+				Expect(output).To(BeNil())
+				Expect(err).To(Equal(templates.ReadinessGatesNotSatisfiedError{
+					Unsatisfied: []string{"[SBOMScanned] is [False], want [True]"},
+				}))
+				Expect(evaluator.EvaluateJsonPathCallCount()).To(Equal(0))
+			})
+
+			When("the stamped object does not report a gated condition", func() {
+				BeforeEach(func() {
+					imageTemplate.Spec.ReadinessGates = []v1alpha1.ReadinessGate{{ConditionType: "Signed"}}
+				})
+
+				It("treats the condition as Unknown", func() {
+					Expect(err).To(MatchError("readiness gates of the stamped object not satisfied: condition [Signed] is [Unknown], want [True]"))
+				})
+			})
+
+			When("every gate passes", func() {
+				BeforeEach(func() {
+					imageTemplate.Spec.ReadinessGates[1].Status = metav1.ConditionFalse
+				})
+
+				It("returns the output", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output.Image).To(Equal("some value"))
+				})
+			})
+		})
 	})
 })
@@ -15,15 +15,21 @@
 package templates
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/valyala/fasttemplate"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
 type Outputs map[string]apiextensionsv1.JSON
@@ -31,14 +37,16 @@ type Outputs map[string]apiextensionsv1.JSON
 type ClusterRunTemplate interface {
 	GetName() string
 	GetResourceTemplate() v1alpha1.TemplateSpec
-	GetOutput(stampedObjects []*unstructured.Unstructured) (Outputs, *unstructured.Unstructured, error)
+	GetOutput(ctx context.Context, stampedObjects []*unstructured.Unstructured) (Outputs, *unstructured.Unstructured, error)
+	TransformOutputs(outputs Outputs) (Outputs, error)
 }
 
 type runTemplate struct {
 	template *v1alpha1.ClusterRunTemplate
+	repo     repository.Repository
 }
 
-func (t runTemplate) GetOutput(stampedObjects []*unstructured.Unstructured) (Outputs, *unstructured.Unstructured, error) {
+func (t runTemplate) GetOutput(ctx context.Context, stampedObjects []*unstructured.Unstructured) (Outputs, *unstructured.Unstructured, error) {
 	var (
 		updateError                        error
 		everyObjectErrored                 bool
@@ -62,6 +70,10 @@ func (t runTemplate) GetOutput(stampedObjects []*unstructured.Unstructured) (Out
 			continue
 		}
 
+		if status == "True" && objectErr == nil {
+			objectErr = t.getReferencedOutputsOfSingleObject(ctx, evaluator, *stampedObject, provisionalOutputs)
+		}
+
 		if status == "True" && objectErr == nil {
 			objectCreationTimestamp, err := getCreationTimestamp(stampedObject, evaluator)
 			if err != nil {
@@ -133,8 +145,125 @@ func (t runTemplate) getOutputsOfSingleObject(evaluator eval.Evaluator, stampedO
 	return objectErr, provisionalOutputs
 }
 
-func NewRunTemplateModel(template *v1alpha1.ClusterRunTemplate) ClusterRunTemplate {
-	return &runTemplate{template: template}
+// getReferencedOutputsOfSingleObject adds the outputs read from the objects that stampedObject
+// refers to by name. It is only called for stamped objects that succeeded, as the objects
+// they refer to may not exist before then.
+func (t runTemplate) getReferencedOutputsOfSingleObject(ctx context.Context, evaluator eval.Evaluator, stampedObject unstructured.Unstructured, outputs Outputs) error {
+	for key, ref := range t.template.Spec.ReferencedOutputs {
+		output, err := t.getReferencedOutput(ctx, evaluator, stampedObject, ref)
+		if err != nil {
+			return fmt.Errorf("failed to read referenced output [%s]: %w", key, err)
+		}
+
+		result, err := json.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("failed to marshal output for key [%s]: %w", key, err)
+		}
+
+		outputs[key] = apiextensionsv1.JSON{Raw: result}
+	}
+	return nil
+}
+
+func (t runTemplate) getReferencedOutput(ctx context.Context, evaluator eval.Evaluator, stampedObject unstructured.Unstructured, ref v1alpha1.ReferencedOutput) (interface{}, error) {
+	name, err := evaluator.EvaluateJsonPath(ref.NamePath, stampedObject.UnstructuredContent())
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate name path [%s]: %w", ref.NamePath, err)
+	}
+
+	nameString, ok := name.(string)
+	if !ok || nameString == "" {
+		return nil, fmt.Errorf("name path [%s] did not resolve to a non-empty string: %v", ref.NamePath, name)
+	}
+
+	query := &unstructured.Unstructured{}
+	query.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.Resource.APIVersion, ref.Resource.Kind))
+	query.SetNamespace(stampedObject.GetNamespace())
+	query.SetName(nameString)
+
+	referencedObject, err := t.repo.GetUnstructured(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referenced object: %w", err)
+	}
+
+	if referencedObject == nil {
+		return nil, fmt.Errorf("referenced object [%s/%s] of type [%s] not found",
+			query.GetNamespace(), query.GetName(), utils.GetFullyQualifiedType(query))
+	}
+
+	output, err := evaluator.EvaluateJsonPath(ref.Path, referencedObject.UnstructuredContent())
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate path [%s] on referenced object [%s/%s]: %w",
+			ref.Path, query.GetNamespace(), query.GetName(), err)
+	}
+
+	return output, nil
+}
+
+type outputTransformContext struct {
+	Value   interface{}            `json:"value"`
+	Outputs map[string]interface{} `json:"outputs"`
+}
+
+// TransformOutputs applies the template's output transforms to outputs extracted from a
+// single stamped object. Every transform sees the extracted values, never the result of
+// another transform.
+func (t runTemplate) TransformOutputs(outputs Outputs) (Outputs, error) {
+	if len(t.template.Spec.OutputTransforms) == 0 {
+		return outputs, nil
+	}
+
+	extracted := map[string]interface{}{}
+	for key, output := range outputs {
+		var value interface{}
+		if err := json.Unmarshal(output.Raw, &value); err != nil {
+			return nil, NewOutputTransformError(key, fmt.Errorf("unmarshal extracted value: %w", err))
+		}
+		extracted[key] = value
+	}
+
+	var keys []string
+	for key := range t.template.Spec.OutputTransforms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	transformed := Outputs{}
+	for key, output := range outputs {
+		transformed[key] = output
+	}
+
+	for _, key := range keys {
+		value, ok := extracted[key]
+		if !ok {
+			return nil, NewOutputTransformError(key, fmt.Errorf("no value was extracted for the output"))
+		}
+
+		tagInterpolator := StandardTagInterpolator{
+			Context: outputTransformContext{
+				Value:   value,
+				Outputs: extracted,
+			},
+			Evaluator: eval.EvaluatorBuilder(),
+		}
+
+		result, err := InterpolateLeafNode(fasttemplate.ExecuteFuncStringWithErr, []byte(t.template.Spec.OutputTransforms[key]), tagInterpolator)
+		if err != nil {
+			return nil, NewOutputTransformError(key, err)
+		}
+
+		raw, err := json.Marshal(result)
+		if err != nil {
+			return nil, NewOutputTransformError(key, fmt.Errorf("marshal transformed value: %w", err))
+		}
+		transformed[key] = apiextensionsv1.JSON{Raw: raw}
+	}
+
+	return transformed, nil
+}
+
+func NewRunTemplateModel(template *v1alpha1.ClusterRunTemplate, repo repository.Repository) ClusterRunTemplate {
+	return &runTemplate{template: template, repo: repo}
 }
 
 func (t runTemplate) GetName() string {
@@ -146,3 +275,56 @@ func (t runTemplate) GetResourceTemplate() v1alpha1.TemplateSpec {
 		Template: &t.template.Spec.Template,
 	}
 }
+
+const (
+	maxEvaluatedOutputLength = 256
+	redactedOutput           = "<redacted>"
+)
+
+// EvaluateOutputPaths evaluates each output path of the run template against the stamped object,
+// so that template authors can see what the paths resolve to. Values are truncated, and the
+// values of the template's sensitive outputs are redacted.
+func EvaluateOutputPaths(template *v1alpha1.ClusterRunTemplate, stampedObject *unstructured.Unstructured) []v1alpha1.OutputPathEvaluation {
+	names := make([]string, 0, len(template.Spec.Outputs))
+	for name := range template.Spec.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sensitive := make(map[string]bool)
+	for _, name := range template.Spec.SensitiveOutputs {
+		sensitive[name] = true
+	}
+
+	evaluator := eval.EvaluatorBuilder()
+
+	var evaluations []v1alpha1.OutputPathEvaluation
+	for _, name := range names {
+		evaluation := v1alpha1.OutputPathEvaluation{
+			Name: name,
+			Path: template.Spec.Outputs[name],
+		}
+
+		output, err := evaluator.EvaluateJsonPath(evaluation.Path, stampedObject.UnstructuredContent())
+		if err != nil {
+			evaluation.Error = err.Error()
+		} else if sensitive[name] {
+			evaluation.Value = redactedOutput
+		} else if raw, err := json.Marshal(output); err != nil {
+			evaluation.Error = fmt.Sprintf("failed to marshal output: %s", err)
+		} else {
+			evaluation.Value = truncate(string(raw), maxEvaluatedOutputLength)
+		}
+
+		evaluations = append(evaluations, evaluation)
+	}
+
+	return evaluations
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length-3] + "..."
+}
@@ -15,12 +15,17 @@
 package templates_test
 
 import (
+	"context"
+	"errors"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -33,9 +38,13 @@ var _ = Describe("ClusterRunTemplate", func() {
 			apiTemplate                                                         *v1alpha1.ClusterRunTemplate
 			firstStampedObject, secondStampedObject, unconditionedStampedObject *unstructured.Unstructured
 			stampedObjects                                                      []*unstructured.Unstructured
+			ctx                                                                 context.Context
+			repo                                                                *repositoryfakes.FakeRepository
 		)
 
 		BeforeEach(func() {
+			ctx = context.Background()
+			repo = &repositoryfakes.FakeRepository{}
 			apiTemplate = &v1alpha1.ClusterRunTemplate{}
 
 			firstStampedObject = &unstructured.Unstructured{}
@@ -106,8 +115,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 
 			Context("with no outputs", func() {
 				It("returns an empty list", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(outputs).To(BeEmpty())
 					Expect(evaluatedStampedObject).To(Equal(firstStampedObject))
@@ -127,8 +136,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 						Expect(utils.AlterFieldOfNestedStringMaps(firstStampedObject.Object, "status.conditions.[0]status", "False")).To(Succeed()) // TODO: fix this notation or start using a jsonpath parser
 					})
 					It("returns empty outputs", func() {
-						template := templates.NewRunTemplateModel(apiTemplate)
-						outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(outputs).To(BeEmpty())
 						Expect(evaluatedStampedObject).To(BeNil())
@@ -136,8 +145,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 				})
 
 				It("returns the new outputs", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(outputs["simplistic"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}))
 					Expect(outputs["complexish"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`{"name":"complex object","type":"object"}`)}))
@@ -152,12 +161,123 @@ var _ = Describe("ClusterRunTemplate", func() {
 					}
 				})
 				It("returns an error", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					_, _, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					_, _, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(Equal("failed to evaluate path [spec.nonexistant]: evaluate: failed to find results: nonexistant is not found"))
 				})
 			})
+			Context("with referenced outputs defined", func() {
+				var referencedObject *unstructured.Unstructured
+
+				BeforeEach(func() {
+					Expect(unstructured.SetNestedField(firstStampedObject.Object, "my-task-run", "status", "taskRun", "name")).To(Succeed())
+
+					apiTemplate.Spec.Outputs = map[string]string{
+						"simplistic": "spec.simple",
+					}
+					apiTemplate.Spec.ReferencedOutputs = map[string]v1alpha1.ReferencedOutput{
+						"digest": {
+							NamePath: "status.taskRun.name",
+							Resource: v1alpha1.ResourceType{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun"},
+							Path:     "status.results[0].value",
+						},
+					}
+
+					referencedObject = &unstructured.Unstructured{}
+					referencedObject.SetName("my-task-run")
+					Expect(unstructured.SetNestedSlice(referencedObject.Object, []interface{}{
+						map[string]interface{}{"name": "digest", "value": "sha256:abc"},
+					}, "status", "results")).To(Succeed())
+					repo.GetUnstructuredReturns(referencedObject, nil)
+				})
+
+				It("reads the output from the referenced object", func() {
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(outputs["simplistic"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}))
+					Expect(outputs["digest"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"sha256:abc"`)}))
+					Expect(evaluatedStampedObject).To(Equal(firstStampedObject))
+				})
+
+				It("fetches the referenced object by the name in the stamped object", func() {
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					_, _, _ = template.GetOutput(ctx, stampedObjects)
+
+					Expect(repo.GetUnstructuredCallCount()).To(Equal(1))
+					_, query := repo.GetUnstructuredArgsForCall(0)
+					Expect(query.GetAPIVersion()).To(Equal("tekton.dev/v1beta1"))
+					Expect(query.GetKind()).To(Equal("TaskRun"))
+					Expect(query.GetNamespace()).To(Equal("somens"))
+					Expect(query.GetName()).To(Equal("my-task-run"))
+				})
+
+				Context("when the object has not succeeded", func() {
+					BeforeEach(func() {
+						Expect(utils.AlterFieldOfNestedStringMaps(firstStampedObject.Object, "status.conditions.[0]status", "False")).To(Succeed())
+					})
+
+					It("does not fetch the referenced object", func() {
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						outputs, _, err := template.GetOutput(ctx, stampedObjects)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(outputs).To(BeEmpty())
+						Expect(repo.GetUnstructuredCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the referenced object does not exist", func() {
+					BeforeEach(func() {
+						repo.GetUnstructuredReturns(nil, nil)
+					})
+
+					It("returns a helpful error", func() {
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						_, _, err := template.GetOutput(ctx, stampedObjects)
+						Expect(err).To(MatchError("failed to read referenced output [digest]: referenced object [somens/my-task-run] of type [taskrun.tekton.dev] not found"))
+					})
+				})
+
+				Context("when the referenced object cannot be fetched", func() {
+					BeforeEach(func() {
+						repo.GetUnstructuredReturns(nil, errors.New("some get error"))
+					})
+
+					It("returns the error", func() {
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						_, _, err := template.GetOutput(ctx, stampedObjects)
+						Expect(err).To(MatchError("failed to read referenced output [digest]: failed to get referenced object: some get error"))
+					})
+				})
+
+				Context("when the name path does not resolve", func() {
+					BeforeEach(func() {
+						unstructured.RemoveNestedField(firstStampedObject.Object, "status", "taskRun")
+					})
+
+					It("returns an error without fetching", func() {
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						_, _, err := template.GetOutput(ctx, stampedObjects)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("failed to read referenced output [digest]: failed to evaluate name path [status.taskRun.name]"))
+						Expect(repo.GetUnstructuredCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the path does not resolve on the referenced object", func() {
+					BeforeEach(func() {
+						unstructured.RemoveNestedField(referencedObject.Object, "status")
+					})
+
+					It("returns an error", func() {
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						_, _, err := template.GetOutput(ctx, stampedObjects)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("failed to read referenced output [digest]: failed to evaluate path [status.results[0].value] on referenced object [somens/my-task-run]"))
+					})
+				})
+			})
 		})
 
 		Context("when there are multiple objects", func() {
@@ -176,8 +296,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 					Expect(utils.AlterFieldOfNestedStringMaps(secondStampedObject.Object, "status.conditions.[0]status", "False")).To(Succeed())
 				})
 				It("returns empty outputs", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(outputs).To(BeEmpty())
 					Expect(evaluatedStampedObject).To(BeNil())
@@ -188,8 +308,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 					Expect(utils.AlterFieldOfNestedStringMaps(secondStampedObject.Object, "status.conditions.[0]status", "False")).To(Succeed())
 				})
 				It("returns the output of the earlier submitted and successful object", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(outputs["simplistic"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"is a string"`)}))
 					Expect(outputs["complexish"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`{"name":"complex object","type":"object"}`)}))
@@ -198,8 +318,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 			})
 			Context("when all have succeeded", func() {
 				It("returns the output of the most recently submitted and successful object", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(outputs["simplistic"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"2nd-simple"`)}))
 					Expect(outputs["complexish"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"2nd-complex"`)}))
@@ -214,8 +334,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 				})
 
 				It("returns the output of the most recently submitted, successful, non-error inducing object", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					outputs, evaluatedStampedObject, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					outputs, evaluatedStampedObject, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(outputs["simplistic"]).To(Equal(apiextensionsv1.JSON{Raw: []byte(`"populated"`)}))
 					Expect(evaluatedStampedObject).To(Equal(firstStampedObject))
@@ -228,8 +348,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 					}
 				})
 				It("returns a helpful error", func() {
-					template := templates.NewRunTemplateModel(apiTemplate)
-					_, _, err := template.GetOutput(stampedObjects)
+					template := templates.NewRunTemplateModel(apiTemplate, repo)
+					_, _, err := template.GetOutput(ctx, stampedObjects)
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(Equal("failed to evaluate path [spec.nonexistant]: evaluate: failed to find results: nonexistant is not found"))
 				})
@@ -239,8 +359,8 @@ var _ = Describe("ClusterRunTemplate", func() {
 						stampedObjects = []*unstructured.Unstructured{unconditionedStampedObject, firstStampedObject}
 					})
 					It("returns a helpful error", func() {
-						template := templates.NewRunTemplateModel(apiTemplate)
-						_, _, err := template.GetOutput(stampedObjects)
+						template := templates.NewRunTemplateModel(apiTemplate, repo)
+						_, _, err := template.GetOutput(ctx, stampedObjects)
 						Expect(err).To(HaveOccurred())
 						Expect(err.Error()).To(ContainSubstring("failed to evaluate path [spec.nonexistant]: evaluate: failed to find results: nonexistant is not found"))
 					})
@@ -248,4 +368,124 @@ var _ = Describe("ClusterRunTemplate", func() {
 			})
 		})
 	})
+
+	Describe("TransformOutputs", func() {
+		var (
+			apiTemplate *v1alpha1.ClusterRunTemplate
+			outputs     templates.Outputs
+		)
+
+		BeforeEach(func() {
+			apiTemplate = &v1alpha1.ClusterRunTemplate{}
+			outputs = templates.Outputs{
+				"registry":   apiextensionsv1.JSON{Raw: []byte(`"registry.example.com"`)},
+				"repository": apiextensionsv1.JSON{Raw: []byte(`"my-app"`)},
+				"digest":     apiextensionsv1.JSON{Raw: []byte(`"sha256:abc"`)},
+				"complex":    apiextensionsv1.JSON{Raw: []byte(`{"nested":{"value":"deep"}}`)},
+			}
+		})
+
+		Context("when the template declares no transforms", func() {
+			It("returns the outputs unchanged", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				transformed, err := template.TransformOutputs(outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transformed).To(Equal(outputs))
+			})
+		})
+
+		Context("when a transform combines multiple extracted fields", func() {
+			BeforeEach(func() {
+				apiTemplate.Spec.OutputTransforms = map[string]string{
+					"digest": "$(outputs.registry)$/$(outputs.repository)$@$(value)$",
+				}
+			})
+
+			It("replaces the output with the transformed value", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				transformed, err := template.TransformOutputs(outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transformed["digest"].Raw).To(MatchJSON(`"registry.example.com/my-app@sha256:abc"`))
+			})
+
+			It("leaves the other outputs unchanged", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				transformed, err := template.TransformOutputs(outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transformed["registry"]).To(Equal(outputs["registry"]))
+				Expect(transformed["repository"]).To(Equal(outputs["repository"]))
+				Expect(transformed["complex"]).To(Equal(outputs["complex"]))
+			})
+
+			It("does not modify the outputs passed in", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				_, err := template.TransformOutputs(outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs["digest"].Raw).To(MatchJSON(`"sha256:abc"`))
+			})
+		})
+
+		Context("when a transform is a single tag", func() {
+			BeforeEach(func() {
+				apiTemplate.Spec.OutputTransforms = map[string]string{
+					"complex": "$(value.nested)$",
+				}
+			})
+
+			It("keeps the type of the value the tag points at", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				transformed, err := template.TransformOutputs(outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transformed["complex"].Raw).To(MatchJSON(`{"value":"deep"}`))
+			})
+		})
+
+		Context("when a transform refers to a field that was not extracted", func() {
+			BeforeEach(func() {
+				apiTemplate.Spec.OutputTransforms = map[string]string{
+					"digest": "$(outputs.tag)$",
+				}
+			})
+
+			It("returns an OutputTransformError", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				_, err := template.TransformOutputs(outputs)
+				Expect(err).To(BeAssignableToTypeOf(templates.OutputTransformError{}))
+				Expect(err.Error()).To(ContainSubstring("failed to transform output [digest]"))
+				Expect(err.Error()).To(ContainSubstring("tag is not found"))
+			})
+		})
+
+		Context("when a transform is declared for an output that was not extracted", func() {
+			BeforeEach(func() {
+				apiTemplate.Spec.OutputTransforms = map[string]string{
+					"image": "$(outputs.registry)$",
+				}
+			})
+
+			It("returns an OutputTransformError", func() {
+				template := templates.NewRunTemplateModel(apiTemplate, nil)
+				_, err := template.TransformOutputs(outputs)
+				Expect(err).To(MatchError("failed to transform output [image]: no value was extracted for the output"))
+			})
+		})
+	})
+
+	Describe("EvaluateOutputPaths", func() {
+		It("truncates long values", func() {
+			apiTemplate := &v1alpha1.ClusterRunTemplate{
+				Spec: v1alpha1.ClusterRunTemplateSpec{
+					Outputs: map[string]string{"log": "status.log"},
+				},
+			}
+			stampedObject := &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{"log": strings.Repeat("a", 300)},
+			}}
+
+			evaluations := templates.EvaluateOutputPaths(apiTemplate, stampedObject)
+			Expect(evaluations).To(HaveLen(1))
+			Expect(evaluations[0].Value).To(HaveLen(256))
+			Expect(evaluations[0].Value).To(Equal(`"` + strings.Repeat("a", 252) + "..."))
+		})
+	})
 })
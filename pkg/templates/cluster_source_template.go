@@ -15,16 +15,20 @@
 package templates
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 )
 
 type clusterSourceTemplate struct {
 	template      *v1alpha1.ClusterSourceTemplate
 	evaluator     evaluator
+	repo          repository.Repository
 	stampedObject *unstructured.Unstructured
 }
 
@@ -32,8 +36,8 @@ func (t *clusterSourceTemplate) GetKind() string {
 	return t.template.Kind
 }
 
-func NewClusterSourceTemplateModel(template *v1alpha1.ClusterSourceTemplate, eval evaluator) *clusterSourceTemplate {
-	return &clusterSourceTemplate{template: template, evaluator: eval}
+func NewClusterSourceTemplateModel(template *v1alpha1.ClusterSourceTemplate, eval evaluator, repo repository.Repository) *clusterSourceTemplate {
+	return &clusterSourceTemplate{template: template, evaluator: eval, repo: repo}
 }
 
 func (t *clusterSourceTemplate) GetName() string {
@@ -46,8 +50,20 @@ func (t *clusterSourceTemplate) SetStampedObject(stampedObject *unstructured.Uns
 	t.stampedObject = stampedObject
 }
 
-func (t *clusterSourceTemplate) GetOutput() (*Output, error) {
-	url, err := t.evaluator.EvaluateJsonPath(t.template.Spec.URLPath, t.stampedObject.UnstructuredContent())
+func (t *clusterSourceTemplate) GetOutput(ctx context.Context) (*Output, error) {
+	if err := checkReadinessGates(t.stampedObject, t.template.Spec.ReadinessGates); err != nil {
+		return nil, err
+	}
+
+	content, err := outputContent(ctx, t.repo, t.stampedObject, t.template.Spec.OutputSubresource)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := t.evaluator.EvaluateJsonPath(t.template.Spec.URLPath, content)
+	if ambiguousErr, ok := err.(eval.AmbiguousJsonPathError); ok {
+		return nil, ambiguousErr
+	}
 	if err != nil {
 		return nil, JsonPathError{
 			Err: fmt.Errorf("failed to evaluate the url path [%s]: %w",
@@ -56,7 +72,10 @@ func (t *clusterSourceTemplate) GetOutput() (*Output, error) {
 		}
 	}
 
-	revision, err := t.evaluator.EvaluateJsonPath(t.template.Spec.RevisionPath, t.stampedObject.UnstructuredContent())
+	revision, err := t.evaluator.EvaluateJsonPath(t.template.Spec.RevisionPath, content)
+	if ambiguousErr, ok := err.(eval.AmbiguousJsonPathError); ok {
+		return nil, ambiguousErr
+	}
 	if err != nil {
 		return nil, JsonPathError{
 			Err: fmt.Errorf("failed to evaluate the revision path [%s]: %w",
@@ -64,11 +83,38 @@ func (t *clusterSourceTemplate) GetOutput() (*Output, error) {
 			expression: t.template.Spec.RevisionPath,
 		}
 	}
+	if err := checkOutputValue(t.template.Spec.URLPath, url, t.template.Spec.OutputRequired, true); err != nil {
+		return nil, err
+	}
+	if err := checkOutputValue(t.template.Spec.RevisionPath, revision, t.template.Spec.OutputRequired, true); err != nil {
+		return nil, err
+	}
+
+	var digest interface{}
+	if t.template.Spec.DigestPath != "" {
+		digest, err = t.evaluator.EvaluateJsonPath(t.template.Spec.DigestPath, content)
+		if ambiguousErr, ok := err.(eval.AmbiguousJsonPathError); ok {
+			return nil, ambiguousErr
+		}
+		if err != nil {
+			return nil, JsonPathError{
+				Err: fmt.Errorf("failed to evaluate the digest path [%s]: %w",
+					t.template.Spec.DigestPath, err),
+				expression: t.template.Spec.DigestPath,
+			}
+		}
+		if err := checkOutputValue(t.template.Spec.DigestPath, digest, t.template.Spec.OutputRequired, true); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Output{
 		Source: &Source{
 			URL:      url,
 			Revision: revision,
+			Digest:   digest,
 		},
+		Warnings: outputPathWarnings(t.template.Spec.URLPath, t.template.Spec.RevisionPath, t.template.Spec.DigestPath),
 	}, nil
 }
 
@@ -15,6 +15,7 @@
 package templates_test
 
 import (
+	"context"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
@@ -22,6 +23,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 	"github.com/vmware-tanzu/cartographer/pkg/templates/templatesfakes"
 )
@@ -57,17 +60,19 @@ var _ = Describe("ClusterSourceTemplate", func() {
 			output        *templates.Output
 			stampedObject *unstructured.Unstructured
 			evaluator     *templatesfakes.FakeEvaluator
+			repo          *repositoryfakes.FakeRepository
 		)
 
 		BeforeEach(func() {
 			stampedObject = &unstructured.Unstructured{}
 			evaluator = &templatesfakes.FakeEvaluator{}
+			repo = &repositoryfakes.FakeRepository{}
 		})
 
 		JustBeforeEach(func() {
-			clusterSourceTemplateModel := templates.NewClusterSourceTemplateModel(sourceTemplate, evaluator)
+			clusterSourceTemplateModel := templates.NewClusterSourceTemplateModel(sourceTemplate, evaluator, repo)
 			clusterSourceTemplateModel.SetStampedObject(stampedObject)
-			output, err = clusterSourceTemplateModel.GetOutput()
+			output, err = clusterSourceTemplateModel.GetOutput(context.TODO())
 		})
 
 		When("passed a stamped object for which the evaluator can return a value at the urlPath and revisionPath", func() {
@@ -100,6 +105,79 @@ var _ = Describe("ClusterSourceTemplate", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+		When("the template has a digestPath", func() {
+			BeforeEach(func() {
+				sourceTemplate.Spec.DigestPath = "some.digest.path"
+			})
+
+			Context("and the evaluator can return a value at all three paths", func() {
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathStub = func(path string, obj interface{}) (interface{}, error) {
+						switch path {
+						case urlPath:
+							return "some value", nil
+						case revisionPath:
+							return "some other value", nil
+						case "some.digest.path":
+							return "sha256:abc", nil
+						}
+						return "", fmt.Errorf("unexpected error")
+					}
+				})
+
+				It("returns the digest with the url and revision", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(evaluator.EvaluateJsonPathCallCount()).To(Equal(3))
+					path, _ := evaluator.EvaluateJsonPathArgsForCall(2)
+					Expect(path).To(Equal("some.digest.path"))
+
+					Expect(*output.Source).To(Equal(templates.Source{
+						URL:      "some value",
+						Revision: "some other value",
+						Digest:   "sha256:abc",
+					}))
+				})
+			})
+
+			Context("and the evaluator cannot return a value at the digestPath", func() {
+				BeforeEach(func() {
+					evaluator.EvaluateJsonPathStub = func(path string, obj interface{}) (interface{}, error) {
+						switch path {
+						case urlPath:
+							return "some value", nil
+						case revisionPath:
+							return "some other value", nil
+						}
+						return "", fmt.Errorf("some error")
+					}
+				})
+
+				It("returns an error which identifies the digestPath", func() {
+					Expect(output).To(BeNil())
+					jsonPathErr, ok := err.(templates.JsonPathError)
+					Expect(ok).To(BeTrue())
+					Expect(jsonPathErr.JsonPathExpression()).To(Equal("some.digest.path"))
+				})
+			})
+		})
+
+		When("the template has no digestPath", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathStub = func(path string, obj interface{}) (interface{}, error) {
+					if path == urlPath {
+						return "some value", nil
+					}
+					return "some other value", nil
+				}
+			})
+
+			It("returns the url and revision without a digest", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(evaluator.EvaluateJsonPathCallCount()).To(Equal(2))
+				Expect(output.Source.Digest).To(BeNil())
+			})
+		})
+
 		When("passed a stamped object for which the evaluator cannot return a value at the urlPath and revisionPath", func() {
 			BeforeEach(func() {
 				evaluator.EvaluateJsonPathReturns("", fmt.Errorf("some error"))
@@ -114,5 +192,37 @@ var _ = Describe("ClusterSourceTemplate", func() {
 			})
 			ItReturnsAHelpfulError("some error")
 		})
+
+		When("the revisionPath matches more than one value", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathStub = func(path string, obj interface{}) (interface{}, error) {
+					if path == urlPath {
+						return "some value", nil
+					}
+					return "", eval.AmbiguousJsonPathError{Path: path, Count: 3}
+				}
+			})
+			It("returns an AmbiguousJsonPathError", func() {
+				Expect(output).To(BeNil())
+				Expect(err).To(Equal(eval.AmbiguousJsonPathError{Path: revisionPath, Count: 3}))
+			})
+		})
+
+		When("the value at the revisionPath is only whitespace", func() {
+			BeforeEach(func() {
+				evaluator.EvaluateJsonPathStub = func(path string, obj interface{}) (interface{}, error) {
+					if path == urlPath {
+						return "some value", nil
+					}
+					return "  ", nil
+				}
+			})
+			It("returns an error which identifies the empty json path expression", func() {
+				Expect(output).To(BeNil())
+				emptyErr, ok := err.(templates.OutputEmptyError)
+				Expect(ok).To(BeTrue())
+				Expect(emptyErr.JsonPathExpression()).To(Equal(revisionPath))
+			})
+		})
 	})
 })
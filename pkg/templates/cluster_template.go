@@ -15,6 +15,8 @@
 package templates
 
 import (
+	"context"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -40,7 +42,7 @@ func (t *clusterTemplate) SetInputs(_ *Inputs) {}
 
 func (t *clusterTemplate) SetStampedObject(_ *unstructured.Unstructured) {}
 
-func (t *clusterTemplate) GetOutput() (*Output, error) {
+func (t *clusterTemplate) GetOutput(_ context.Context) (*Output, error) {
 	return &Output{}, nil
 }
 
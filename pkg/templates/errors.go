@@ -16,6 +16,9 @@ package templates
 
 import (
 	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type JsonPathError struct {
@@ -38,6 +41,86 @@ func (e JsonPathError) JsonPathExpression() string {
 	return e.expression
 }
 
+type OutputEmptyError struct {
+	expression string
+}
+
+func NewOutputEmptyError(expression string) OutputEmptyError {
+	return OutputEmptyError{
+		expression: expression,
+	}
+}
+
+func (e OutputEmptyError) Error() string {
+	return fmt.Sprintf("value at json path '%s' is empty", e.expression)
+}
+
+func (e OutputEmptyError) JsonPathExpression() string {
+	return e.expression
+}
+
+type SubresourceError struct {
+	Err         error
+	Subresource string
+}
+
+func (e SubresourceError) Error() string {
+	return fmt.Errorf("failed to read the [%s] subresource of the stamped object: %w", e.Subresource, e.Err).Error()
+}
+
+type OutputFromError struct {
+	Err  error
+	Kind string
+}
+
+func (e OutputFromError) Error() string {
+	return fmt.Errorf("failed to read the output from the %s named by the stamped object: %w", e.Kind, e.Err).Error()
+}
+
+type ReadinessGatesNotSatisfiedError struct {
+	Unsatisfied []string
+}
+
+func (e ReadinessGatesNotSatisfiedError) Error() string {
+	return fmt.Sprintf("readiness gates of the stamped object not satisfied: condition %s", strings.Join(e.Unsatisfied, ", condition "))
+}
+
+type ImageNotPinnedError struct {
+	Image      string
+	expression string
+}
+
+func NewImageNotPinnedError(expression string, image string) ImageNotPinnedError {
+	return ImageNotPinnedError{
+		Image:      image,
+		expression: expression,
+	}
+}
+
+func (e ImageNotPinnedError) Error() string {
+	return fmt.Sprintf("image [%s] at json path '%s' is not pinned to a digest", e.Image, e.expression)
+}
+
+func (e ImageNotPinnedError) JsonPathExpression() string {
+	return e.expression
+}
+
+type OutputTransformError struct {
+	Err    error
+	Output string
+}
+
+func NewOutputTransformError(output string, err error) OutputTransformError {
+	return OutputTransformError{
+		Err:    err,
+		Output: output,
+	}
+}
+
+func (e OutputTransformError) Error() string {
+	return fmt.Errorf("failed to transform output [%s]: %w", e.Output, e.Err).Error()
+}
+
 type ObservedGenerationError struct {
 	Err error
 }
@@ -79,3 +162,17 @@ func NewDeploymentFailedConditionMetError(err error) DeploymentFailedConditionMe
 func (e DeploymentFailedConditionMetError) Error() string {
 	return e.Err.Error()
 }
+
+type MissingOwnerReferenceError struct {
+	Owner           string
+	OwnerReferences []metav1.OwnerReference
+}
+
+func (e MissingOwnerReferenceError) Error() string {
+	var refs []string
+	for _, ref := range e.OwnerReferences {
+		refs = append(refs, fmt.Sprintf("%s/%s", ref.Kind, ref.Name))
+	}
+	return fmt.Sprintf("template sets metadata.ownerReferences [%s] without a controller reference to [%s], the stamped object would not be garbage collected with it",
+		strings.Join(refs, ", "), e.Owner)
+}
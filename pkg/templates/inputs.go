@@ -17,6 +17,7 @@ package templates
 type SourceInput struct {
 	URL      interface{} `json:"url"`
 	Revision interface{} `json:"revision"`
+	Digest   interface{} `json:"digest,omitempty"`
 	Name     string      `json:"name"`
 }
 
@@ -29,6 +29,7 @@ type TemplateExecutor func(template, startTag, endTag string, f fasttemplate.Tag
 //counterfeiter:generate . evaluator
 type evaluator interface {
 	EvaluateJsonPath(path string, obj interface{}) (interface{}, error)
+	EvaluateJsonPathSorted(path string, sortBy string, pick string, obj interface{}) (interface{}, error)
 }
 
 //counterfeiter:generate . tagInterpolator
@@ -0,0 +1,111 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/valyala/fasttemplate"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+)
+
+const inputHashLength = 8
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9.-]+`)
+var invalidNamespaceCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// InputHash returns a short, stable hash of the inputs a stamped object is built from.
+func InputHash(inputs interface{}) (string, error) {
+	inputsJSON, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("json marshal inputs: %w", err)
+	}
+
+	sum := sha256.Sum256(inputsJSON)
+	return hex.EncodeToString(sum[:])[:inputHashLength], nil
+}
+
+// RenderName interpolates the $(<<jsonPath>>)$ tags of the name template against the context
+// and turns the result into a valid object name: it is lowercased, runs of characters not
+// allowed in a DNS-1123 subdomain are replaced by a dash, and it is truncated to 253 characters.
+func RenderName(nameTemplate string, context JsonPathContext) (string, error) {
+	interpolator := StandardTagInterpolator{
+		Context:   context,
+		Evaluator: eval.EvaluatorBuilder(),
+	}
+
+	rendered, err := fasttemplate.ExecuteFuncStringWithErr(nameTemplate, `$(`, `)$`, interpolator.InterpolateTag)
+	if err != nil {
+		return "", fmt.Errorf("interpolate name template [%s]: %w", nameTemplate, err)
+	}
+
+	name := sanitizeName(rendered)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("name template [%s] rendered [%s], which is not a valid name: %s", nameTemplate, rendered, strings.Join(errs, ", "))
+	}
+
+	return name, nil
+}
+
+// RenderNamespace interpolates the $(<<jsonPath>>)$ tags of the namespace template against the
+// context and turns the result into a valid namespace name: it is lowercased, runs of characters
+// not allowed in a DNS-1123 label are replaced by a dash, and it is truncated to 63 characters.
+func RenderNamespace(namespaceTemplate string, context JsonPathContext) (string, error) {
+	interpolator := StandardTagInterpolator{
+		Context:   context,
+		Evaluator: eval.EvaluatorBuilder(),
+	}
+
+	rendered, err := fasttemplate.ExecuteFuncStringWithErr(namespaceTemplate, `$(`, `)$`, interpolator.InterpolateTag)
+	if err != nil {
+		return "", fmt.Errorf("interpolate namespace template [%s]: %w", namespaceTemplate, err)
+	}
+
+	namespace := strings.Trim(invalidNamespaceCharacters.ReplaceAllString(strings.ToLower(rendered), "-"), "-")
+	if len(namespace) > validation.DNS1123LabelMaxLength {
+		namespace = strings.TrimRight(namespace[:validation.DNS1123LabelMaxLength], "-")
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", fmt.Errorf("namespace template [%s] rendered [%s], which is not a valid namespace: %s", namespaceTemplate, rendered, strings.Join(errs, ", "))
+	}
+
+	return namespace, nil
+}
+
+func sanitizeName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-")
+
+	var labels []string
+	for _, label := range strings.Split(name, ".") {
+		label = strings.Trim(label, "-")
+		if label != "" {
+			labels = append(labels, label)
+		}
+	}
+	name = strings.Join(labels, ".")
+
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength], "-.")
+	}
+
+	return name
+}
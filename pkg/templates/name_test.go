@@ -0,0 +1,136 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+var _ = Describe("Name", func() {
+	Describe("RenderName", func() {
+		var context map[string]interface{}
+
+		BeforeEach(func() {
+			context = map[string]interface{}{
+				"workload": &v1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-workload"},
+				},
+				"resource":  map[string]interface{}{"name": "source-provider"},
+				"inputHash": "1a2b3c4d",
+			}
+		})
+
+		It("renders the workload name, resource name and input hash", func() {
+			name, err := templates.RenderName("$(workload.metadata.name)$-$(resource.name)$-$(inputHash)$", context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("my-workload-source-provider-1a2b3c4d"))
+		})
+
+		It("replaces characters that are not allowed in a name", func() {
+			context["resource"] = map[string]interface{}{"name": "Source_Provider!!"}
+
+			name, err := templates.RenderName("$(workload.metadata.name)$.$(resource.name)$", context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("my-workload.source-provider"))
+		})
+
+		It("drops dashes and dots that would start or end a segment of the name", func() {
+			name, err := templates.RenderName("-_$(workload.metadata.name)$-..-$(inputHash)$.", context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("my-workload.1a2b3c4d"))
+		})
+
+		It("truncates the name to 253 characters", func() {
+			context["resource"] = map[string]interface{}{"name": strings.Repeat("a", 252) + "-bbb"}
+
+			name, err := templates.RenderName("$(resource.name)$", context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal(strings.Repeat("a", 252)))
+		})
+
+		It("errors when the template references a value that is not in the context", func() {
+			_, err := templates.RenderName("$(workload.metadata.nope)$", context)
+			Expect(err).To(MatchError(ContainSubstring("interpolate name template [$(workload.metadata.nope)$]")))
+		})
+
+		It("errors when nothing valid is left of the rendered name", func() {
+			_, err := templates.RenderName("_!_", context)
+			Expect(err).To(MatchError(ContainSubstring("name template [_!_] rendered [_!_], which is not a valid name")))
+		})
+	})
+
+	Describe("RenderNamespace", func() {
+		var context map[string]interface{}
+
+		BeforeEach(func() {
+			context = map[string]interface{}{
+				"deliverable": &v1alpha1.Deliverable{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Labels:    map[string]string{"env": "Staging.EU"},
+					},
+				},
+			}
+		})
+
+		It("renders the namespace, replacing characters that are not allowed in a namespace", func() {
+			namespace, err := templates.RenderNamespace("$(deliverable.metadata.labels.env)$-apps", context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespace).To(Equal("staging-eu-apps"))
+		})
+
+		It("truncates the namespace to 63 characters", func() {
+			namespace, err := templates.RenderNamespace(strings.Repeat("a", 62)+"-bbb", context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespace).To(Equal(strings.Repeat("a", 62)))
+		})
+
+		It("errors when the template references a value that is not in the context", func() {
+			_, err := templates.RenderNamespace("$(deliverable.metadata.nope)$", context)
+			Expect(err).To(MatchError(ContainSubstring("interpolate namespace template [$(deliverable.metadata.nope)$]")))
+		})
+
+		It("errors when nothing valid is left of the rendered namespace", func() {
+			_, err := templates.RenderNamespace("_!_", context)
+			Expect(err).To(MatchError(ContainSubstring("namespace template [_!_] rendered [_!_], which is not a valid namespace")))
+		})
+	})
+
+	Describe("InputHash", func() {
+		It("is short and stable for the same inputs", func() {
+			first, err := templates.InputHash(map[string]interface{}{"sources": []string{"a"}})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := templates.InputHash(map[string]interface{}{"sources": []string{"a"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first).To(HaveLen(8))
+			Expect(first).To(Equal(second))
+		})
+
+		It("changes when the inputs change", func() {
+			first, _ := templates.InputHash(map[string]interface{}{"sources": []string{"a"}})
+			second, _ := templates.InputHash(map[string]interface{}{"sources": []string{"b"}})
+
+			Expect(first).NotTo(Equal(second))
+		})
+	})
+})
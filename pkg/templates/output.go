@@ -14,9 +14,15 @@
 
 package templates
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Source struct {
 	URL      interface{} `json:"url"`
 	Revision interface{} `json:"revision"`
+	Digest   interface{} `json:"digest,omitempty"`
 }
 
 type Image interface{}
@@ -27,4 +33,37 @@ type Output struct {
 	Source *Source
 	Image  Image
 	Config Config
+	// Sensitive outputs were read from a Secret, their values are redacted where reported.
+	// It is not part of the value, so it does not change the hash of the output.
+	Sensitive bool `json:"-"`
+	// Warnings describe output paths that read the spec or metadata of the stamped object
+	// rather than its status. They do not fail the resource and do not change the hash of
+	// the output.
+	Warnings []string `json:"-"`
+	// SourceRevision is the revision of the source upstream of the resource the output was
+	// read from. It is passed on to downstream resources and does not change the hash of the
+	// output.
+	SourceRevision string `json:"-"`
+}
+
+// outputPathWarnings warns of each output path rooted at .spec or .metadata. Those fields
+// echo what cartographer stamped, so the output never reflects the work the object did.
+func outputPathWarnings(paths ...string) []string {
+	var warnings []string
+	for _, path := range paths {
+		if root := outputPathRoot(path); root == "spec" || root == "metadata" {
+			warnings = append(warnings, fmt.Sprintf("output path [%s] reads .%s of the stamped object rather than .status", path, root))
+		}
+	}
+	return warnings
+}
+
+// outputPathRoot is the first field of a jsonpath expression, e.g. spec for .spec.image
+// or {.spec.image}.
+func outputPathRoot(path string) string {
+	root := strings.TrimLeft(strings.TrimSpace(path), "{$.")
+	if i := strings.IndexAny(root, ".[}"); i >= 0 {
+		root = root[:i]
+	}
+	return root
 }
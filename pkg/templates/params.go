@@ -15,6 +15,9 @@
 package templates
 
 import (
+	"bytes"
+	"sort"
+
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -28,9 +31,24 @@ func ParamsBuilder(
 	resourceParams []v1alpha1.DelegatableParam,
 	ownerParams []v1alpha1.Param,
 ) Params {
+	params, _ := ResolveParams(templateParams, blueprintParams, resourceParams, ownerParams)
+	return params
+}
+
+// ResolveParams builds the params like ParamsBuilder, also reporting, by name, each param that
+// more than one layer set to different values, the layer whose value won and the layers whose
+// values were overridden. The Resource of the resolutions is left to the caller.
+func ResolveParams(
+	templateParams []v1alpha1.TemplateParam,
+	blueprintParams []v1alpha1.DelegatableParam,
+	resourceParams []v1alpha1.DelegatableParam,
+	ownerParams []v1alpha1.Param,
+) (Params, []v1alpha1.ParamResolution) {
 	newParams := Params{}
+	layers := paramLayers{}
 	for _, param := range templateParams {
 		newParams[param.Name] = param.DefaultValue
+		layers.set(param.Name, v1alpha1.ParamLayerTemplate, param.DefaultValue)
 	}
 
 	protectedFromOwnerOverride := make(map[string]bool)
@@ -44,6 +62,7 @@ func ParamsBuilder(
 			newParams[key] = *blueprintOverride.DefaultValue
 			protectedFromOwnerOverride[key] = false
 		}
+		layers.set(key, v1alpha1.ParamLayerBlueprint, newParams[key])
 	}
 
 	for _, resourceOverride := range resourceParams {
@@ -55,16 +74,69 @@ func ParamsBuilder(
 			newParams[key] = *resourceOverride.DefaultValue
 			protectedFromOwnerOverride[key] = false
 		}
+		layers.set(key, v1alpha1.ParamLayerResource, newParams[key])
 	}
 
 	for _, ownerOverride := range ownerParams {
 		key := ownerOverride.Name
 		if ownerCanOverride(protectedFromOwnerOverride, key) {
 			newParams[key] = ownerOverride.Value
+			layers.set(key, v1alpha1.ParamLayerOwner, ownerOverride.Value)
+		} else {
+			layers.ignore(key, v1alpha1.ParamLayerOwner, ownerOverride.Value)
+		}
+	}
+
+	return newParams, layers.resolutions(newParams)
+}
+
+type layerValue struct {
+	layer v1alpha1.ParamLayer
+	value apiextensionsv1.JSON
+}
+
+// paramLayers records, by param name, the value each layer set, the winning layer last.
+type paramLayers map[string][]layerValue
+
+func (l paramLayers) set(name string, layer v1alpha1.ParamLayer, value apiextensionsv1.JSON) {
+	l[name] = append(l[name], layerValue{layer: layer, value: value})
+}
+
+// ignore records the value of a layer that was not allowed to override the winning layer,
+// keeping the winning layer last.
+func (l paramLayers) ignore(name string, layer v1alpha1.ParamLayer, value apiextensionsv1.JSON) {
+	values := l[name]
+	winner := values[len(values)-1]
+	l[name] = append(values[:len(values)-1:len(values)-1], layerValue{layer: layer, value: value}, winner)
+}
+
+func (l paramLayers) resolutions(params Params) []v1alpha1.ParamResolution {
+	var names []string
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var resolutions []v1alpha1.ParamResolution
+	for _, name := range names {
+		values := l[name]
+		winner := values[len(values)-1]
+		var overridden []v1alpha1.ParamLayer
+		for _, value := range values[:len(values)-1] {
+			if !bytes.Equal(value.value.Raw, params[name].Raw) {
+				overridden = append(overridden, value.layer)
+			}
+		}
+		if len(overridden) > 0 {
+			resolutions = append(resolutions, v1alpha1.ParamResolution{
+				Name:       name,
+				Layer:      winner.layer,
+				Overridden: overridden,
+			})
 		}
 	}
 
-	return newParams
+	return resolutions
 }
 
 func ownerCanOverride(isProtected map[string]bool, key string) bool {
@@ -180,4 +180,61 @@ var _ = Describe("Params", func() {
 			ownerParam,
 			"from the owner"),
 	)
+
+	It("keeps the structure of param values", func() {
+		params := templates.ParamsBuilder(
+			[]v1alpha1.TemplateParam{{Name: "args", DefaultValue: apiextensionsv1.JSON{Raw: []byte(`"--verbose"`)}}},
+			nil,
+			nil,
+			[]v1alpha1.Param{{Name: "args", Value: apiextensionsv1.JSON{Raw: []byte(`["--port", 8080]`)}}},
+		)
+
+		Expect(params).To(Equal(templates.Params{
+			"args": apiextensionsv1.JSON{Raw: []byte(`["--port", 8080]`)},
+		}))
+	})
+
+	Describe("ResolveParams", func() {
+		It("reports the winning layer of a param three layers set differently", func() {
+			params, resolutions := templates.ResolveParams(
+				[]v1alpha1.TemplateParam{*templateParam},
+				[]v1alpha1.DelegatableParam{*delegatingBlueprintParam},
+				[]v1alpha1.DelegatableParam{*delegatingResourceParam},
+				nil,
+			)
+
+			Expect(string(params["target-name"].Raw)).To(Equal("from the resource"))
+			Expect(resolutions).To(Equal([]v1alpha1.ParamResolution{{
+				Name:       "target-name",
+				Layer:      v1alpha1.ParamLayerResource,
+				Overridden: []v1alpha1.ParamLayer{v1alpha1.ParamLayerTemplate, v1alpha1.ParamLayerBlueprint},
+			}}))
+		})
+
+		It("reports an owner value that a protected layer kept from being used", func() {
+			_, resolutions := templates.ResolveParams(
+				[]v1alpha1.TemplateParam{*templateParam},
+				[]v1alpha1.DelegatableParam{*nonDelegatingBlueprintParam},
+				nil,
+				[]v1alpha1.Param{*ownerParam},
+			)
+
+			Expect(resolutions).To(Equal([]v1alpha1.ParamResolution{{
+				Name:       "target-name",
+				Layer:      v1alpha1.ParamLayerBlueprint,
+				Overridden: []v1alpha1.ParamLayer{v1alpha1.ParamLayerTemplate, v1alpha1.ParamLayerOwner},
+			}}))
+		})
+
+		It("does not report params whose layers agree or that one layer set", func() {
+			_, resolutions := templates.ResolveParams(
+				[]v1alpha1.TemplateParam{*templateParam, {Name: "other", DefaultValue: apiextensionsv1.JSON{Raw: []byte(`"x"`)}}},
+				nil,
+				nil,
+				[]v1alpha1.Param{{Name: "target-name", Value: templateParam.DefaultValue}},
+			)
+
+			Expect(resolutions).To(BeEmpty())
+		})
+	})
 })
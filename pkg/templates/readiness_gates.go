@@ -0,0 +1,70 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// checkReadinessGates fails when a condition in the status of the stamped object does not
+// have the status its readiness gate wants. A condition the object does not report is
+// Unknown.
+func checkReadinessGates(stampedObject *unstructured.Unstructured, gates []v1alpha1.ReadinessGate) error {
+	if len(gates) == 0 {
+		return nil
+	}
+
+	statuses := conditionStatuses(stampedObject)
+
+	var unsatisfied []string
+	for _, gate := range gates {
+		status, ok := statuses[gate.ConditionType]
+		if !ok {
+			status = metav1.ConditionUnknown
+		}
+		if status != gate.WantStatus() {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("[%s] is [%s], want [%s]", gate.ConditionType, status, gate.WantStatus()))
+		}
+	}
+
+	if len(unsatisfied) > 0 {
+		return ReadinessGatesNotSatisfiedError{Unsatisfied: unsatisfied}
+	}
+	return nil
+}
+
+func conditionStatuses(obj *unstructured.Unstructured) map[string]metav1.ConditionStatus {
+	statuses := map[string]metav1.ConditionStatus{}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.UnstructuredContent(), "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		status, _ := condition["status"].(string)
+		if conditionType != "" {
+			statuses[conditionType] = metav1.ConditionStatus(status)
+		}
+	}
+
+	return statuses
+}
@@ -48,6 +48,10 @@ type Stamper struct {
 	TemplatingContext JsonPathContext
 	Owner             client.Object
 	Labels            Labels
+	// TargetNamespace, when set, is the namespace the object is stamped into. The owner is
+	// not set as an owner reference of an object stamped outside of its own namespace, as
+	// owner references cannot cross namespaces; the object is only tracked by its labels.
+	TargetNamespace string
 }
 
 func StamperBuilder(owner client.Object, templatingContext JsonPathContext, labels Labels) Stamper {
@@ -149,27 +153,67 @@ func (s *Stamper) Stamp(ctx context.Context, resourceTemplate v1alpha1.TemplateS
 		return nil, err
 	}
 
-	if stampedObject.GetNamespace() == "" {
+	if s.TargetNamespace != "" {
+		stampedObject.SetNamespace(s.TargetNamespace)
+	} else if stampedObject.GetNamespace() == "" {
 		stampedObject.SetNamespace(s.Owner.GetNamespace())
 	}
 
 	apiVersion, kind := s.Owner.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
-	stampedObject.SetOwnerReferences([]metav1.OwnerReference{
-		{
-			APIVersion:         apiVersion,
-			Kind:               kind,
-			UID:                s.Owner.GetUID(),
-			Name:               s.Owner.GetName(),
-			BlockOwnerDeletion: pointer.BoolPtr(true),
-			Controller:         pointer.BoolPtr(true),
-		},
+	ownerReferences, err := s.ownerReferences(stampedObject, metav1.OwnerReference{
+		APIVersion:         apiVersion,
+		Kind:               kind,
+		UID:                s.Owner.GetUID(),
+		Name:               s.Owner.GetName(),
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+		Controller:         pointer.BoolPtr(true),
 	})
+	if err != nil {
+		return nil, err
+	}
+	stampedObject.SetOwnerReferences(ownerReferences)
 
 	s.mergeLabels(stampedObject)
 
 	return stampedObject, nil
 }
 
+// ownerReferences are the owner references of the stamped object: the owner as its controller,
+// along with any other references the template sets. A template that sets owner references on an
+// object in the owner's namespace must include the owner and no other controller, or the object
+// would not be garbage collected with the owner.
+func (s *Stamper) ownerReferences(stampedObject *unstructured.Unstructured, owner metav1.OwnerReference) ([]metav1.OwnerReference, error) {
+	templateReferences := stampedObject.GetOwnerReferences()
+	if s.TargetNamespace != "" && s.TargetNamespace != s.Owner.GetNamespace() {
+		return templateReferences, nil
+	}
+	if len(templateReferences) == 0 || stampedObject.GetNamespace() != s.Owner.GetNamespace() {
+		return []metav1.OwnerReference{owner}, nil
+	}
+
+	ownerReferences := []metav1.OwnerReference{owner}
+	ownerFound, otherController := false, false
+	for _, ref := range templateReferences {
+		switch {
+		case ref.Kind == owner.Kind && ref.Name == owner.Name:
+			ownerFound = true
+		case ref.Controller != nil && *ref.Controller:
+			otherController = true
+		default:
+			ownerReferences = append(ownerReferences, ref)
+		}
+	}
+
+	if !ownerFound || otherController {
+		return nil, MissingOwnerReferenceError{
+			Owner:           fmt.Sprintf("%s/%s", owner.Kind, owner.Name),
+			OwnerReferences: templateReferences,
+		}
+	}
+
+	return ownerReferences, nil
+}
+
 func (s *Stamper) applyTemplate(resourceTemplateJSON []byte) (*unstructured.Unstructured, error) {
 	var resourceTemplate interface{}
 	err := json.Unmarshal(resourceTemplateJSON, &resourceTemplate)
@@ -199,11 +243,7 @@ func (s *Stamper) applyYtt(ctx context.Context, template string) (*unstructured.
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
 
-	ytt := "ytt"
-	// ko copies the content of the kodata directory into the container at a path referenced by $KO_DATA_PATH
-	if kodata, ok := os.LookupEnv("KO_DATA_PATH"); ok {
-		ytt = path.Join(kodata, fmt.Sprintf("ytt-%s-%s", runtime.GOOS, runtime.GOARCH))
-	}
+	ytt := yttBinary()
 
 	args := []string{"-f", "-"}
 	stdin := bytes.NewReader([]byte(template))
@@ -248,6 +288,14 @@ func (s *Stamper) applyYtt(ctx context.Context, template string) (*unstructured.
 	return stampedObject, nil
 }
 
+func yttBinary() string {
+	// ko copies the content of the kodata directory into the container at a path referenced by $KO_DATA_PATH
+	if kodata, ok := os.LookupEnv("KO_DATA_PATH"); ok {
+		return path.Join(kodata, fmt.Sprintf("ytt-%s-%s", runtime.GOOS, runtime.GOARCH))
+	}
+	return "ytt"
+}
+
 func (s *Stamper) mergeLabels(obj *unstructured.Unstructured) {
 	labels := obj.GetLabels()
 	if labels == nil {
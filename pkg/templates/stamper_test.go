@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
@@ -120,6 +121,101 @@ var _ = Describe("Stamper", func() {
 
 				})
 			})
+
+			Context("the stamper has a target namespace", func() {
+				var template v1alpha1.TemplateSpec
+				BeforeEach(func() {
+					template = v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{
+							Raw: []byte(`{
+								"kind": "Silly",
+								"apiVersion": "silly.io/v1",
+								"metadata": { "namespace": "template-ns" }
+							}`),
+						},
+					}
+				})
+
+				It("stamps the object into the target namespace without the owner reference", func() {
+					stamper.TargetNamespace = "target-ns"
+					stamped, err := stamper.Stamp(context.TODO(), template)
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stamped.GetNamespace()).To(Equal("target-ns"))
+					Expect(stamped.GetOwnerReferences()).To(BeEmpty())
+				})
+
+				It("keeps the owner reference when the target is the owner's namespace", func() {
+					stamper.TargetNamespace = "owner-ns"
+					stamped, err := stamper.Stamp(context.TODO(), template)
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stamped.GetNamespace()).To(Equal("owner-ns"))
+					Expect(stamped.GetOwnerReferences()).To(HaveLen(1))
+				})
+			})
+
+			Context("template sets owner references", func() {
+				stampWithOwnerReferences := func(ownerReferences string) (*unstructured.Unstructured, error) {
+					return stamper.Stamp(context.TODO(), v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{
+							Raw: []byte(`{
+								"kind": "Silly",
+								"apiVersion": "silly.io/v1",
+								"metadata": { "ownerReferences": ` + ownerReferences + ` }
+							}`),
+						},
+					})
+				}
+
+				It("fails when the owner is not among them", func() {
+					_, err := stampWithOwnerReferences(`[{"apiVersion": "v1", "kind": "Secret", "name": "other", "uid": "abc"}]`)
+
+					Expect(err).To(BeAssignableToTypeOf(templates.MissingOwnerReferenceError{}))
+					Expect(err).To(MatchError("template sets metadata.ownerReferences [Secret/other] without a controller reference to [ConfigMap/my-config-map], the stamped object would not be garbage collected with it"))
+				})
+
+				It("fails when another object is set as the controller", func() {
+					_, err := stampWithOwnerReferences(`[
+						{"apiVersion": "v1", "kind": "ConfigMap", "name": "my-config-map", "uid": "1234567890abcdef"},
+						{"apiVersion": "v1", "kind": "Secret", "name": "other", "uid": "abc", "controller": true}
+					]`)
+
+					Expect(err).To(BeAssignableToTypeOf(templates.MissingOwnerReferenceError{}))
+				})
+
+				It("keeps the other references when the owner is among them", func() {
+					stamped, err := stampWithOwnerReferences(`[
+						{"apiVersion": "v1", "kind": "ConfigMap", "name": "my-config-map", "uid": "1234567890abcdef"},
+						{"apiVersion": "v1", "kind": "Secret", "name": "other", "uid": "abc"}
+					]`)
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stamped.GetOwnerReferences()).To(HaveLen(2))
+					Expect(stamped.GetOwnerReferences()[0].Name).To(Equal("my-config-map"))
+					Expect(stamped.GetOwnerReferences()[0].Controller).To(Equal(pointer.BoolPtr(true)))
+					Expect(stamped.GetOwnerReferences()[1].Name).To(Equal("other"))
+				})
+
+				It("replaces them when the object is in another namespace than the owner", func() {
+					stamped, err := stamper.Stamp(context.TODO(), v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{
+							Raw: []byte(`{
+								"kind": "Silly",
+								"apiVersion": "silly.io/v1",
+								"metadata": {
+									"namespace": "template-ns",
+									"ownerReferences": [{"apiVersion": "v1", "kind": "Secret", "name": "other", "uid": "abc"}]
+								}
+							}`),
+						},
+					})
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stamped.GetOwnerReferences()).To(HaveLen(1))
+					Expect(stamped.GetOwnerReferences()[0].Name).To(Equal("my-config-map"))
+				})
+			})
 		})
 
 		DescribeTable("tag evaluation of template",
@@ -15,34 +15,59 @@
 package templates
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
 )
 
 type Template interface {
 	GetResourceTemplate() v1alpha1.TemplateSpec
 	GetDefaultParams() v1alpha1.TemplateParams
-	GetOutput() (*Output, error)
+	GetOutput(ctx context.Context) (*Output, error)
 	SetInputs(*Inputs)
 	SetStampedObject(stampedObject *unstructured.Unstructured)
 	GetName() string
 	GetKind() string
 }
 
-func NewModelFromAPI(template client.Object) (Template, error) {
+// evaluators are the evaluators for each output language a template can declare
+var evaluators = newEvaluatorRegistry()
+
+func newEvaluatorRegistry() *eval.EvaluatorRegistry {
+	registry := eval.NewEvaluatorRegistry()
+	registry.Register(v1alpha1.OutputLanguageYtt, YttEvaluator{})
+	return registry
+}
+
+func NewModelFromAPI(template client.Object, repo repository.Repository) (Template, error) {
 	switch v := template.(type) {
 
 	case *v1alpha1.ClusterSourceTemplate:
-		return NewClusterSourceTemplateModel(v, eval.EvaluatorBuilder()), nil
+		evaluator, err := evaluators.For(v.Spec.OutputLanguage)
+		if err != nil {
+			return nil, err
+		}
+		return NewClusterSourceTemplateModel(v, evaluator, repo), nil
 	case *v1alpha1.ClusterImageTemplate:
-		return NewClusterImageTemplateModel(v, eval.EvaluatorBuilder()), nil
+		evaluator, err := evaluators.For(v.Spec.OutputLanguage)
+		if err != nil {
+			return nil, err
+		}
+		return NewClusterImageTemplateModel(v, evaluator, repo), nil
 	case *v1alpha1.ClusterConfigTemplate:
-		return NewClusterConfigTemplateModel(v, eval.EvaluatorBuilder()), nil
+		evaluator, err := evaluators.For(v.Spec.OutputLanguage)
+		if err != nil {
+			return nil, err
+		}
+		return NewClusterConfigTemplateModel(v, evaluator, repo), nil
 	case *v1alpha1.ClusterDeploymentTemplate:
 		return NewClusterDeploymentTemplateModel(v, eval.EvaluatorBuilder()), nil
 	case *v1alpha1.ClusterTemplate:
@@ -50,3 +75,92 @@ func NewModelFromAPI(template client.Object) (Template, error) {
 	}
 	return nil, fmt.Errorf("resource does not match a known template")
 }
+
+// outputContent returns the content that output paths are evaluated against: the stamped
+// object itself, or its view through the subresource named by the template.
+func outputContent(ctx context.Context, repo repository.Repository, stampedObject *unstructured.Unstructured, subresource string) (map[string]interface{}, error) {
+	if subresource == "" {
+		return stampedObject.UnstructuredContent(), nil
+	}
+
+	content, err := repo.GetSubresource(ctx, stampedObject, subresource)
+	if err != nil {
+		return nil, SubresourceError{
+			Err:         err,
+			Subresource: subresource,
+		}
+	}
+
+	return content, nil
+}
+
+// outputFrom reads the output from the key of the ConfigMap or Secret, in the namespace of
+// the stamped object, whose name is at the nameFromPath of the content. Secret values are
+// decoded.
+func outputFrom(ctx context.Context, repo repository.Repository, evaluator evaluator, stampedObject *unstructured.Unstructured, content map[string]interface{}, from *v1alpha1.OutputFrom) (interface{}, error) {
+	name, err := evaluator.EvaluateJsonPath(from.NameFromPath, content)
+	if err != nil {
+		return nil, JsonPathError{
+			Err:        fmt.Errorf("failed to evaluate outputFrom.nameFromPath [%s]: %w", from.NameFromPath, err),
+			expression: from.NameFromPath,
+		}
+	}
+	nameString, ok := name.(string)
+	if !ok || nameString == "" {
+		return nil, NewOutputEmptyError(from.NameFromPath)
+	}
+
+	query := &unstructured.Unstructured{}
+	query.SetAPIVersion("v1")
+	query.SetKind(from.Kind)
+	query.SetNamespace(stampedObject.GetNamespace())
+	query.SetName(nameString)
+
+	obj, err := repo.GetUnstructured(ctx, query)
+	if err != nil {
+		return nil, OutputFromError{Err: err, Kind: from.Kind}
+	}
+	if obj == nil {
+		return nil, OutputFromError{Err: fmt.Errorf("[%s] not found", nameString), Kind: from.Kind}
+	}
+
+	value, found, err := unstructured.NestedString(obj.Object, "data", from.Key)
+	if err != nil || !found {
+		return nil, OutputFromError{Err: fmt.Errorf("key [%s] not found in [%s]", from.Key, nameString), Kind: from.Kind}
+	}
+
+	if from.Kind != v1alpha1.OutputFromSecret {
+		return value, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, OutputFromError{Err: fmt.Errorf("decode key [%s] of [%s]: %w", from.Key, nameString, err), Kind: from.Kind}
+	}
+	return string(decoded), nil
+}
+
+// checkOutputValue fails a value read at an output path that is empty or only whitespace,
+// when the template requires its outputs.
+func checkOutputValue(expression string, value interface{}, required *bool, requiredByDefault bool) error {
+	if required != nil {
+		requiredByDefault = *required
+	}
+	if requiredByDefault && isEmptyOutput(value) {
+		return NewOutputEmptyError(expression)
+	}
+	return nil
+}
+
+func isEmptyOutput(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -44,7 +44,7 @@ var _ = Describe("Templates", func() {
 	}
 
 	JustBeforeEach(func() {
-		templateModel, err = templates.NewModelFromAPI(apiTemplate)
+		templateModel, err = templates.NewModelFromAPI(apiTemplate, nil)
 	})
 
 	Describe("NewModelFromAPI", func() {
@@ -94,6 +94,30 @@ var _ = Describe("Templates", func() {
 			})
 		})
 
+		Context("when passed a template whose outputs are ytt expressions", func() {
+			BeforeEach(func() {
+				apiTemplate = &v1alpha1.ClusterImageTemplate{
+					Spec: v1alpha1.ImageTemplateSpec{OutputLanguage: v1alpha1.OutputLanguageYtt},
+				}
+			})
+
+			ItDoesNotReturnAnError()
+
+			It("returns a template", func() {
+				Expect(templateModel).NotTo(BeNil())
+			})
+		})
+
+		Context("when passed a template with an unknown output language", func() {
+			BeforeEach(func() {
+				apiTemplate = &v1alpha1.ClusterSourceTemplate{
+					Spec: v1alpha1.SourceTemplateSpec{OutputLanguage: "cel"},
+				}
+			})
+
+			ItReturnsAHelpfulError("unknown output language [cel], expected one of [jsonpath, ytt]")
+		})
+
 		Context("when passed an unsupported object", func() {
 			BeforeEach(func() {
 				apiTemplate = &v1alpha1.Workload{}
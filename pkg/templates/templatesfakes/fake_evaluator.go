@@ -20,6 +20,22 @@ type FakeEvaluator struct {
 		result1 interface{}
 		result2 error
 	}
+	EvaluateJsonPathSortedStub        func(string, string, string, interface{}) (interface{}, error)
+	evaluateJsonPathSortedMutex       sync.RWMutex
+	evaluateJsonPathSortedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 interface{}
+	}
+	evaluateJsonPathSortedReturns struct {
+		result1 interface{}
+		result2 error
+	}
+	evaluateJsonPathSortedReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -89,11 +105,80 @@ func (fake *FakeEvaluator) EvaluateJsonPathReturnsOnCall(i int, result1 interfac
 	}{result1, result2}
 }
 
+func (fake *FakeEvaluator) EvaluateJsonPathSorted(arg1 string, arg2 string, arg3 string, arg4 interface{}) (interface{}, error) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	ret, specificReturn := fake.evaluateJsonPathSortedReturnsOnCall[len(fake.evaluateJsonPathSortedArgsForCall)]
+	fake.evaluateJsonPathSortedArgsForCall = append(fake.evaluateJsonPathSortedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.EvaluateJsonPathSortedStub
+	fakeReturns := fake.evaluateJsonPathSortedReturns
+	fake.recordInvocation("EvaluateJsonPathSorted", []interface{}{arg1, arg2, arg3, arg4})
+	fake.evaluateJsonPathSortedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEvaluator) EvaluateJsonPathSortedCallCount() int {
+	fake.evaluateJsonPathSortedMutex.RLock()
+	defer fake.evaluateJsonPathSortedMutex.RUnlock()
+	return len(fake.evaluateJsonPathSortedArgsForCall)
+}
+
+func (fake *FakeEvaluator) EvaluateJsonPathSortedCalls(stub func(string, string, string, interface{}) (interface{}, error)) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	defer fake.evaluateJsonPathSortedMutex.Unlock()
+	fake.EvaluateJsonPathSortedStub = stub
+}
+
+func (fake *FakeEvaluator) EvaluateJsonPathSortedArgsForCall(i int) (string, string, string, interface{}) {
+	fake.evaluateJsonPathSortedMutex.RLock()
+	defer fake.evaluateJsonPathSortedMutex.RUnlock()
+	argsForCall := fake.evaluateJsonPathSortedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeEvaluator) EvaluateJsonPathSortedReturns(result1 interface{}, result2 error) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	defer fake.evaluateJsonPathSortedMutex.Unlock()
+	fake.EvaluateJsonPathSortedStub = nil
+	fake.evaluateJsonPathSortedReturns = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEvaluator) EvaluateJsonPathSortedReturnsOnCall(i int, result1 interface{}, result2 error) {
+	fake.evaluateJsonPathSortedMutex.Lock()
+	defer fake.evaluateJsonPathSortedMutex.Unlock()
+	fake.EvaluateJsonPathSortedStub = nil
+	if fake.evaluateJsonPathSortedReturnsOnCall == nil {
+		fake.evaluateJsonPathSortedReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 error
+		})
+	}
+	fake.evaluateJsonPathSortedReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeEvaluator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.evaluateJsonPathMutex.RLock()
 	defer fake.evaluateJsonPathMutex.RUnlock()
+	fake.evaluateJsonPathSortedMutex.RLock()
+	defer fake.evaluateJsonPathSortedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
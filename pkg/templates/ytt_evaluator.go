@@ -0,0 +1,98 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const yttExpressionTemplate = `#@ load("@ytt:data", "data")
+---
+value: #@ %s
+`
+
+// YttEvaluator evaluates output expressions with ytt. Each top level field of the
+// object is a data value, so the expression data.values.status.latestImage reads
+// the same value as the jsonpath .status.latestImage
+type YttEvaluator struct {
+	// Binary is the ytt executable, found the same way the stamper finds it when empty
+	Binary string
+}
+
+func (e YttEvaluator) EvaluateJsonPath(expression string, obj interface{}) (interface{}, error) {
+	if expression == "" {
+		return nil, fmt.Errorf("empty ytt expression not allowed")
+	}
+
+	// limit execution duration to protect against infinite loops or cpu wasting expressions
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	ytt := e.Binary
+	if ytt == "" {
+		ytt = yttBinary()
+	}
+
+	args := []string{"-f", "-"}
+
+	values := map[string]interface{}{}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal object: %w", err)
+	}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("ytt expressions can only be evaluated against an object: %w", err)
+	}
+	for k := range values {
+		raw, _ := json.Marshal(values[k])
+		args = append(args, "--data-value-yaml", fmt.Sprintf("%s=%s", k, raw))
+	}
+
+	stdout := bytes.NewBuffer([]byte{})
+	stderr := bytes.NewBuffer([]byte{})
+
+	cmd := exec.CommandContext(ctx, ytt, args...)
+	cmd.Stdin = bytes.NewReader([]byte(fmt.Sprintf(yttExpressionTemplate, expression)))
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := stderr.String()
+		if msg == "" {
+			return nil, fmt.Errorf("unable to evaluate ytt expression [%s]: %w", expression, err)
+		}
+		return nil, fmt.Errorf("unable to evaluate ytt expression [%s]: %s", expression, msg)
+	}
+
+	result := map[string]interface{}{}
+	if err := yaml.Unmarshal(stdout.Bytes(), &result); err != nil {
+		// ytt should never return invalid yaml
+		return nil, err
+	}
+
+	return result["value"], nil
+}
+
+// EvaluateJsonPathSorted is not supported, a ytt expression can sort and pick from a list itself
+func (e YttEvaluator) EvaluateJsonPathSorted(expression string, _ string, _ string, _ interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("ytt expression [%s] cannot be sorted, sort the values in the expression instead", expression)
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+var _ = Describe("YttEvaluator", func() {
+	var obj map[string]interface{}
+
+	BeforeEach(func() {
+		obj = map[string]interface{}{
+			"status": map[string]interface{}{"latestImage": "my-registry/my-image@sha256:abc"},
+		}
+	})
+
+	It("errors on an empty expression", func() {
+		_, err := templates.YttEvaluator{}.EvaluateJsonPath("", obj)
+		Expect(err).To(MatchError("empty ytt expression not allowed"))
+	})
+
+	It("errors when the object is not a map", func() {
+		_, err := templates.YttEvaluator{}.EvaluateJsonPath("data.values.status", []string{"a"})
+		Expect(err).To(MatchError(ContainSubstring("ytt expressions can only be evaluated against an object")))
+	})
+
+	It("names the expression when ytt cannot be run", func() {
+		_, err := templates.YttEvaluator{Binary: "/does/not/exist/ytt"}.EvaluateJsonPath("data.values.status.latestImage", obj)
+		Expect(err).To(MatchError(ContainSubstring("unable to evaluate ytt expression [data.values.status.latestImage]")))
+	})
+})
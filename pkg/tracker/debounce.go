@@ -0,0 +1,66 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// Debounce delays every request the handler enqueues by the window. The delaying queue keeps
+// the earliest time a request is due, so the events of an object arriving within the window
+// of its first event are coalesced into one reconcile at the end of the window. That
+// reconcile reads the object as the last of them left it, and an event arriving after it
+// enqueues the request again, so the final state is always reconciled. A zero window returns
+// the handler as is.
+func Debounce(h handler.EventHandler, window time.Duration) handler.EventHandler {
+	if window <= 0 {
+		return h
+	}
+	return debouncingHandler{handler: h, window: window}
+}
+
+type debouncingHandler struct {
+	handler handler.EventHandler
+	window  time.Duration
+}
+
+func (d debouncingHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	d.handler.Create(evt, debouncingQueue{RateLimitingInterface: q, window: d.window})
+}
+
+func (d debouncingHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	d.handler.Update(evt, debouncingQueue{RateLimitingInterface: q, window: d.window})
+}
+
+func (d debouncingHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	d.handler.Delete(evt, debouncingQueue{RateLimitingInterface: q, window: d.window})
+}
+
+func (d debouncingHandler) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	d.handler.Generic(evt, debouncingQueue{RateLimitingInterface: q, window: d.window})
+}
+
+type debouncingQueue struct {
+	workqueue.RateLimitingInterface
+	window time.Duration
+}
+
+func (q debouncingQueue) Add(item interface{}) {
+	q.RateLimitingInterface.AddAfter(item, q.window)
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
+)
+
+var _ = Describe("Debounce", func() {
+	const window = 200 * time.Millisecond
+
+	var (
+		queue     workqueue.RateLimitingInterface
+		debounced handler.EventHandler
+	)
+
+	stampedObject := func(replicas int64) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("apps/v1")
+		obj.SetKind("Deployment")
+		obj.SetNamespace("my-ns")
+		obj.SetName("my-deployment")
+		Expect(unstructured.SetNestedField(obj.Object, replicas, "status", "replicas")).To(Succeed())
+		return obj
+	}
+
+	BeforeEach(func() {
+		queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		debounced = tracker.Debounce(&handler.EnqueueRequestForObject{}, window)
+	})
+
+	AfterEach(func() {
+		queue.ShutDown()
+	})
+
+	It("coalesces rapid events of an object into a single reconcile at the end of the window", func() {
+		for replicas := int64(0); replicas < 10; replicas++ {
+			debounced.Update(event.UpdateEvent{ObjectOld: stampedObject(replicas), ObjectNew: stampedObject(replicas + 1)}, queue)
+		}
+		Expect(queue.Len()).To(Equal(0))
+
+		Eventually(queue.Len, 5*window).Should(Equal(1))
+		Consistently(queue.Len, 2*window).Should(Equal(1))
+
+		item, _ := queue.Get()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "my-ns", Name: "my-deployment"}}))
+		queue.Done(item)
+		Expect(queue.Len()).To(Equal(0))
+	})
+
+	It("reconciles again for an event arriving after the coalesced reconcile", func() {
+		debounced.Update(event.UpdateEvent{ObjectOld: stampedObject(1), ObjectNew: stampedObject(2)}, queue)
+		Eventually(queue.Len, 5*window).Should(Equal(1))
+		item, _ := queue.Get()
+		queue.Done(item)
+
+		debounced.Update(event.UpdateEvent{ObjectOld: stampedObject(2), ObjectNew: stampedObject(3)}, queue)
+		Expect(queue.Len()).To(Equal(0))
+		Eventually(queue.Len, 5*window).Should(Equal(1))
+	})
+
+	It("returns the handler as is for a zero window", func() {
+		hndl := &handler.EnqueueRequestForObject{}
+		Expect(tracker.Debounce(hndl, 0)).To(BeIdenticalTo(hndl))
+	})
+})
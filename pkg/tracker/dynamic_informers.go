@@ -0,0 +1,133 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//counterfeiter:generate . InformerStopper
+
+// InformerStopper is implemented by the InformerGetters whose informers can be stopped, which
+// an ObjectTracker needs to evict the kinds it watches. The manager's cache is not one of them.
+type InformerStopper interface {
+	StopInformer(obj client.Object)
+}
+
+// DefaultInformerResync is the resync period of the informers DynamicInformers starts when it
+// has none, the same as that of the manager's cache.
+const DefaultInformerResync = 10 * time.Hour
+
+// DynamicInformers starts an informer of its own for each kind, rather than sharing those of
+// the manager's cache, so that the informer of a kind can be stopped again. An informer runs
+// from the first time it is asked for until it is stopped, or until the context Start was
+// called with is cancelled; add DynamicInformers to the manager so that its informers stop
+// with it.
+type DynamicInformers struct {
+	Client dynamic.Interface
+	Mapper meta.RESTMapper
+	// Namespace, when set, is the only namespace the informers of namespaced kinds list and watch
+	Namespace string
+	// Resync defaults to DefaultInformerResync.
+	Resync time.Duration
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionKind]runningInformer
+	stopped   bool
+}
+
+type runningInformer struct {
+	informer toolscache.SharedIndexInformer
+	stop     chan struct{}
+}
+
+func (d *DynamicInformers) GetInformer(_ context.Context, obj client.Object) (cache.Informer, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if running, ok := d.informers[gvk]; ok {
+		return running.informer, nil
+	}
+	if d.stopped {
+		return nil, fmt.Errorf("informers for %q: stopped", gvk.String())
+	}
+
+	mapping, err := d.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("rest mapping for %q: %w", gvk.String(), err)
+	}
+
+	namespace := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = d.Namespace
+	}
+
+	resync := d.Resync
+	if resync == 0 {
+		resync = DefaultInformerResync
+	}
+
+	informer := dynamicinformer.NewFilteredDynamicInformer(d.Client, mapping.Resource, namespace, resync, toolscache.Indexers{}, nil).Informer()
+	stop := make(chan struct{})
+	go informer.Run(stop)
+
+	if d.informers == nil {
+		d.informers = make(map[schema.GroupVersionKind]runningInformer)
+	}
+	d.informers[gvk] = runningInformer{informer: informer, stop: stop}
+	return informer, nil
+}
+
+// StopInformer stops the informer of the object's kind, if it runs. The next GetInformer
+// for the kind starts a new one.
+func (d *DynamicInformers) StopInformer(obj client.Object) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if running, ok := d.informers[gvk]; ok {
+		close(running.stop)
+		delete(d.informers, gvk)
+	}
+}
+
+// Start blocks until the context is cancelled and then stops every running informer.
+func (d *DynamicInformers) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for gvk, running := range d.informers {
+		close(running.stop)
+		delete(d.informers, gvk)
+	}
+	d.stopped = true
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
+)
+
+var _ = Describe("DynamicInformers", func() {
+	var (
+		informers *tracker.DynamicInformers
+		obj       *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		gvk := schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"}
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+
+		informers = &tracker.DynamicInformers{
+			Client:    dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{{Group: "thing.io", Version: "v1", Resource: "mythings"}: "MyThingList"}),
+			Mapper:    mapper,
+			Namespace: "my-ns",
+		}
+
+		obj = &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+	})
+
+	AfterEach(func() {
+		informers.StopInformer(obj)
+	})
+
+	It("runs one informer per kind", func() {
+		informer, err := informers.GetInformer(context.Background(), obj)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(informer.HasSynced).Should(BeTrue())
+
+		again, err := informers.GetInformer(context.Background(), obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(informer))
+	})
+
+	It("starts a new informer for a kind whose informer was stopped", func() {
+		informer, err := informers.GetInformer(context.Background(), obj)
+		Expect(err).NotTo(HaveOccurred())
+
+		informers.StopInformer(obj)
+
+		restarted, err := informers.GetInformer(context.Background(), obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restarted).NotTo(BeIdenticalTo(informer))
+		Eventually(restarted.HasSynced).Should(BeTrue())
+	})
+
+	It("returns an error for a kind the mapper does not know", func() {
+		unknown := &unstructured.Unstructured{}
+		unknown.SetGroupVersionKind(schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "Unknown"})
+
+		_, err := informers.GetInformer(context.Background(), unknown)
+		Expect(err).To(MatchError(ContainSubstring(`rest mapping for "thing.io/v1, Kind=Unknown"`)))
+	})
+
+	Context("when the context it was started with is cancelled", func() {
+		var (
+			client *dynamicfake.FakeDynamicClient
+			added  chan string
+		)
+
+		BeforeEach(func() {
+			client = informers.Client.(*dynamicfake.FakeDynamicClient)
+			added = make(chan string, 10)
+		})
+
+		It("stops every running informer and starts no more", func() {
+			informer, err := informers.GetInformer(context.Background(), obj)
+			Expect(err).NotTo(HaveOccurred())
+			informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+					added <- obj.(*unstructured.Unstructured).GetName()
+				},
+			})
+			Eventually(informer.HasSynced).Should(BeTrue())
+
+			Expect(createThing(client, "before-stop")).To(Succeed())
+			Eventually(added).Should(Receive(Equal("before-stop")))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			Expect(informers.Start(ctx)).To(Succeed())
+
+			Expect(createThing(client, "after-stop")).To(Succeed())
+			Consistently(added, "200ms").ShouldNot(Receive())
+
+			_, err = informers.GetInformer(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("stopped")))
+		})
+	})
+
+	It("does nothing when stopping a kind without an informer", func() {
+		Expect(func() { informers.StopInformer(obj) }).NotTo(Panic())
+	})
+})
+
+func createThing(client *dynamicfake.FakeDynamicClient, name string) error {
+	thing := &unstructured.Unstructured{}
+	thing.SetGroupVersionKind(schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"})
+	thing.SetNamespace("my-ns")
+	thing.SetName(name)
+
+	_, err := client.Resource(schema.GroupVersionResource{Group: "thing.io", Version: "v1", Resource: "mythings"}).
+		Namespace("my-ns").Create(context.Background(), thing, metav1.CreateOptions{})
+	return err
+}
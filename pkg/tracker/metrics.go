@@ -0,0 +1,30 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ActiveInformers is the number of kinds each ObjectTracker watches, by the tracker's name.
+var ActiveInformers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cartographer_tracker_active_informers",
+	Help: "Number of kinds of stamped objects watched by the tracker of a controller",
+}, []string{"tracker"})
+
+func init() {
+	metrics.Registry.MustRegister(ActiveInformers)
+}
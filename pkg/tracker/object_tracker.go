@@ -0,0 +1,261 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+//counterfeiter:generate . InformerGetter
+type InformerGetter interface {
+	GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error)
+}
+
+//counterfeiter:generate sigs.k8s.io/controller-runtime/pkg/controller.Controller
+
+// ObjectTracker watches the kinds of the objects it is given, adding a watch on the shared
+// informer of a kind the first time one of its objects is seen.
+//
+// Watching an already watched kind with a tighter resync adds a handler that only receives
+// the resyncs, so the kind is resynced at the tightest period requested of it. client-go does
+// not resync a handler more often than the informer's own resync check period once the
+// informer is running.
+//
+// A kind whose watch could not be established is not tried again until its backoff has
+// elapsed, the backoff doubling with every consecutive failure up to the maximum. Until then
+// Watch returns a WatchPendingError for the kind.
+//
+// A non-zero Debounce delays the requests the handlers enqueue by that window, coalescing the
+// events of objects whose status changes rapidly into one reconcile, see Debounce.
+//
+// A non-zero MaxKinds bounds the kinds watched at once when the Informers are an
+// InformerStopper. Watching another kind at the bound stops the informer of the kind that was
+// least recently watched and forgets it, the next Watch of it establishing the watch anew.
+// Until then the owners of its objects are not reconciled on changes to them.
+type ObjectTracker struct {
+	Controller controller.Controller
+	Informers  InformerGetter
+	Backoff    WatchBackoff
+	Debounce   time.Duration
+	// Clock defaults to the real clock.
+	Clock    clock.PassiveClock
+	MaxKinds int
+	// Name labels the tracker's ActiveInformers gauge.
+	Name string
+
+	mu       sync.Mutex
+	resyncs  map[string]time.Duration
+	failures map[string]watchFailure
+	// recency orders the watched kinds from the most to the least recently watched
+	recency *list.List
+	kinds   map[string]*list.Element
+}
+
+// WatchBackoff bounds how often the watch of a kind is retried after it failed.
+// A zero Initial or Max falls back to DefaultWatchBackoff.
+type WatchBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+var DefaultWatchBackoff = WatchBackoff{
+	Initial: time.Second,
+	Max:     5 * time.Minute,
+}
+
+// delay is the backoff after the given number of consecutive failures
+func (b WatchBackoff) delay(failures int) time.Duration {
+	initial, max := b.Initial, b.Max
+	if initial <= 0 {
+		initial = DefaultWatchBackoff.Initial
+	}
+	if max <= 0 {
+		max = DefaultWatchBackoff.Max
+	}
+
+	delay := initial
+	for i := 1; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+type watchFailure struct {
+	count   int
+	retryAt time.Time
+}
+
+func (o *ObjectTracker) Watch(log logr.Logger, obj runtime.Object, handler handler.EventHandler, resync time.Duration, eventPredicates ...predicate.Predicate) error {
+	// Consider this a no-op if the controller isn't present.
+	if o.Controller == nil {
+		return nil
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	key := gvk.GroupKind().String()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	current, watched := o.resyncs[key]
+	if watched {
+		o.recency.MoveToFront(o.kinds[key])
+		if !tighterResync(resync, current) {
+			return nil
+		}
+	}
+
+	now := o.now()
+	if failure, failed := o.failures[key]; failed && now.Before(failure.retryAt) {
+		return WatchPendingError{GroupVersionKind: gvk, RetryAfter: failure.retryAt.Sub(now)}
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+
+	informer, err := o.Informers.GetInformer(context.Background(), u)
+	if err != nil {
+		return o.watchFailed(key, gvk, now, fmt.Errorf("failed to get informer for external object %q: %w", gvk.String(), err))
+	}
+
+	prct := append([]predicate.Predicate{predicates.ResourceNotPaused(log)}, eventPredicates...)
+	if watched {
+		log.Info("Tightening resync of watcher on external object", "GroupVersionKind", gvk.String(), "resync", resync.String())
+		prct = append(prct, resyncsOnly)
+	} else {
+		log.Info("Adding watcher on external object", "GroupVersionKind", gvk.String(), "resync", resync.String())
+	}
+
+	err = o.Controller.Watch(
+		&source.Informer{Informer: resyncInformer{Informer: informer, resync: resync}},
+		Debounce(handler, o.Debounce),
+		prct...,
+	)
+	if err != nil {
+		return o.watchFailed(key, gvk, now, fmt.Errorf("failed to add watcher on external object %q: %w", gvk.String(), err))
+	}
+
+	delete(o.failures, key)
+	if !watched {
+		o.evictLeastRecent(log)
+		o.track(key, gvk)
+	}
+	o.resyncs[key] = resync
+	ActiveInformers.WithLabelValues(o.Name).Set(float64(len(o.resyncs)))
+	return nil
+}
+
+func (o *ObjectTracker) track(key string, gvk schema.GroupVersionKind) {
+	if o.resyncs == nil {
+		o.resyncs = make(map[string]time.Duration)
+		o.kinds = make(map[string]*list.Element)
+		o.recency = list.New()
+	}
+	o.kinds[key] = o.recency.PushFront(gvk)
+}
+
+// evictLeastRecent stops watching the least recently watched kind when another is about to be
+// watched at MaxKinds.
+func (o *ObjectTracker) evictLeastRecent(log logr.Logger) {
+	stopper, ok := o.Informers.(InformerStopper)
+	if o.MaxKinds <= 0 || !ok || len(o.resyncs) < o.MaxKinds {
+		return
+	}
+
+	gvk := o.recency.Remove(o.recency.Back()).(schema.GroupVersionKind)
+	key := gvk.GroupKind().String()
+	delete(o.kinds, key)
+	delete(o.resyncs, key)
+
+	log.Info("Evicting watcher on least recently watched external object", "GroupVersionKind", gvk.String(), "maxKinds", o.MaxKinds)
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	stopper.StopInformer(u)
+}
+
+// watchFailed records another consecutive failure to watch the kind and returns the
+// WatchPendingError telling the caller when the watch will be tried again.
+func (o *ObjectTracker) watchFailed(key string, gvk schema.GroupVersionKind, now time.Time, err error) error {
+	if o.failures == nil {
+		o.failures = make(map[string]watchFailure)
+	}
+
+	failure := o.failures[key]
+	failure.count++
+	delay := o.Backoff.delay(failure.count)
+	failure.retryAt = now.Add(delay)
+	o.failures[key] = failure
+
+	return WatchPendingError{GroupVersionKind: gvk, RetryAfter: delay, Err: err}
+}
+
+func (o *ObjectTracker) now() time.Time {
+	if o.Clock == nil {
+		return time.Now()
+	}
+	return o.Clock.Now()
+}
+
+// tighterResync reports whether resync asks for more frequent resyncs than current,
+// a zero period standing for the informer's default.
+func tighterResync(resync, current time.Duration) bool {
+	return resync > 0 && (current == 0 || resync < current)
+}
+
+// resyncInformer registers every handler added to it with its own resync period
+type resyncInformer struct {
+	cache.Informer
+	resync time.Duration
+}
+
+func (i resyncInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	if i.resync == 0 {
+		i.Informer.AddEventHandler(handler)
+		return
+	}
+	i.Informer.AddEventHandlerWithResyncPeriod(handler, i.resync)
+}
+
+// resyncsOnly passes the updates an informer delivers on resync, in which the object is unchanged
+var resyncsOnly = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion()
+	},
+}
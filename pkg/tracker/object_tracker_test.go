@@ -0,0 +1,345 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
+	"github.com/vmware-tanzu/cartographer/pkg/tracker/trackerfakes"
+)
+
+// informer records the resync period of every handler added to it
+type informer struct {
+	resyncs []time.Duration
+}
+
+func (i *informer) AddEventHandler(_ toolscache.ResourceEventHandler) {
+	i.resyncs = append(i.resyncs, 0)
+}
+
+func (i *informer) AddEventHandlerWithResyncPeriod(_ toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.resyncs = append(i.resyncs, resyncPeriod)
+}
+
+func (i *informer) AddIndexers(_ toolscache.Indexers) error {
+	return nil
+}
+
+func (i *informer) HasSynced() bool {
+	return true
+}
+
+var _ = Describe("ObjectTracker", func() {
+	var (
+		ctrl          *trackerfakes.FakeController
+		informers     *trackerfakes.FakeInformerGetter
+		kindInformer  *informer
+		objectTracker *tracker.ObjectTracker
+		obj           *unstructured.Unstructured
+		hndl          handler.EventHandler
+	)
+
+	// startWatches starts the sources the tracker handed to the controller, as the
+	// controller does, registering their handlers with the informer
+	startWatches := func() {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+
+		for i := 0; i < ctrl.WatchCallCount(); i++ {
+			src, eventHandler, prct := ctrl.WatchArgsForCall(i)
+			Expect(src.Start(context.Background(), eventHandler, queue, prct...)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = &trackerfakes.FakeController{}
+		kindInformer = &informer{}
+		informers = &trackerfakes.FakeInformerGetter{}
+		informers.GetInformerReturns(kindInformer, nil)
+
+		objectTracker = &tracker.ObjectTracker{Controller: ctrl, Informers: informers}
+
+		obj = &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"})
+		hndl = &handler.EnqueueRequestForObject{}
+	})
+
+	It("watches the object's kind with the resync period", func() {
+		Expect(objectTracker.Watch(logr.Discard(), obj, hndl, time.Minute)).To(Succeed())
+
+		Expect(informers.GetInformerCallCount()).To(Equal(1))
+		_, informerObj := informers.GetInformerArgsForCall(0)
+		Expect(informerObj.GetObjectKind().GroupVersionKind()).To(Equal(obj.GroupVersionKind()))
+
+		Expect(ctrl.WatchCallCount()).To(Equal(1))
+		src, eventHandler, _ := ctrl.WatchArgsForCall(0)
+		Expect(src).To(BeAssignableToTypeOf(&source.Informer{}))
+		Expect(eventHandler).To(Equal(hndl))
+
+		startWatches()
+		Expect(kindInformer.resyncs).To(Equal([]time.Duration{time.Minute}))
+	})
+
+	It("debounces the handler when the tracker has a debounce window", func() {
+		objectTracker.Debounce = time.Second
+		Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+
+		Expect(ctrl.WatchCallCount()).To(Equal(1))
+		_, eventHandler, _ := ctrl.WatchArgsForCall(0)
+		Expect(eventHandler).To(Equal(tracker.Debounce(hndl, time.Second)))
+	})
+
+	It("filters the events with the given predicates", func() {
+		filter := predicate.NewPredicateFuncs(func(client.Object) bool { return false })
+		Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0, filter)).To(Succeed())
+
+		Expect(ctrl.WatchCallCount()).To(Equal(1))
+		_, _, predicates := ctrl.WatchArgsForCall(0)
+		Expect(predicates).To(HaveLen(2))
+		Expect(predicates[1].Generic(event.GenericEvent{Object: obj})).To(BeFalse())
+	})
+
+	It("uses the informer's resync period when none is given", func() {
+		Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+
+		startWatches()
+		Expect(kindInformer.resyncs).To(Equal([]time.Duration{0}))
+	})
+
+	Context("the kind is already watched", func() {
+		BeforeEach(func() {
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, time.Minute)).To(Succeed())
+		})
+
+		It("does not watch the kind again for the same or a looser resync", func() {
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, time.Minute)).To(Succeed())
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, time.Hour)).To(Succeed())
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+
+			Expect(ctrl.WatchCallCount()).To(Equal(1))
+		})
+
+		It("does not watch another version of the kind again", func() {
+			other := obj.DeepCopy()
+			other.SetAPIVersion("thing.io/v2")
+			Expect(objectTracker.Watch(logr.Discard(), other, hndl, 0)).To(Succeed())
+
+			Expect(ctrl.WatchCallCount()).To(Equal(1))
+		})
+
+		It("adds a resync only watch for a tighter resync", func() {
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 10*time.Second)).To(Succeed())
+
+			Expect(ctrl.WatchCallCount()).To(Equal(2))
+			_, _, firstPredicates := ctrl.WatchArgsForCall(0)
+			_, _, tighterPredicates := ctrl.WatchArgsForCall(1)
+			Expect(tighterPredicates).To(HaveLen(len(firstPredicates) + 1))
+
+			startWatches()
+			Expect(kindInformer.resyncs).To(Equal([]time.Duration{time.Minute, 10 * time.Second}))
+
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 30*time.Second)).To(Succeed())
+			Expect(ctrl.WatchCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("the watch fails", func() {
+		var fakeClock *clock.FakeClock
+
+		BeforeEach(func() {
+			fakeClock = clock.NewFakeClock(time.Now())
+			objectTracker.Clock = fakeClock
+			objectTracker.Backoff = tracker.WatchBackoff{Initial: time.Second, Max: 4 * time.Second}
+			ctrl.WatchReturnsOnCall(0, errors.New("no watch for you"))
+		})
+
+		It("returns a WatchPendingError with the backoff", func() {
+			err := objectTracker.Watch(logr.Discard(), obj, hndl, 0)
+			Expect(err).To(MatchError(ContainSubstring(`failed to add watcher on external object "thing.io/v1, Kind=MyThing": no watch for you`)))
+
+			pendingErr, ok := err.(tracker.WatchPendingError)
+			Expect(ok).To(BeTrue())
+			Expect(pendingErr.GroupVersionKind).To(Equal(obj.GroupVersionKind()))
+			Expect(pendingErr.RetryAfter).To(Equal(time.Second))
+		})
+
+		It("does not try the watch again until the backoff has elapsed", func() {
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).NotTo(Succeed())
+
+			fakeClock.Step(400 * time.Millisecond)
+			err := objectTracker.Watch(logr.Discard(), obj, hndl, 0)
+			Expect(err).To(Equal(tracker.WatchPendingError{GroupVersionKind: obj.GroupVersionKind(), RetryAfter: 600 * time.Millisecond}))
+			Expect(informers.GetInformerCallCount()).To(Equal(1))
+			Expect(ctrl.WatchCallCount()).To(Equal(1))
+
+			fakeClock.Step(600 * time.Millisecond)
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+			Expect(ctrl.WatchCallCount()).To(Equal(2))
+		})
+
+		Context("repeatedly", func() {
+			BeforeEach(func() {
+				ctrl.WatchReturns(errors.New("no watch for you"))
+			})
+
+			It("doubles the backoff with every failure up to the maximum", func() {
+				var backoffs []time.Duration
+				for i := 0; i < 5; i++ {
+					err := objectTracker.Watch(logr.Discard(), obj, hndl, 0)
+					pendingErr, ok := err.(tracker.WatchPendingError)
+					Expect(ok).To(BeTrue())
+					backoffs = append(backoffs, pendingErr.RetryAfter)
+					fakeClock.Step(pendingErr.RetryAfter)
+				}
+
+				Expect(backoffs).To(Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second}))
+				Expect(ctrl.WatchCallCount()).To(Equal(5))
+			})
+
+			It("starts the backoff over once the watch is established", func() {
+				Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).NotTo(Succeed())
+				fakeClock.Step(time.Second)
+				Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).NotTo(Succeed())
+				fakeClock.Step(2 * time.Second)
+
+				ctrl.WatchReturns(nil)
+				Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+
+				ctrl.WatchReturns(errors.New("no watch for you"))
+				otherObj := &unstructured.Unstructured{}
+				otherObj.SetGroupVersionKind(schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: "MyThing"})
+				Expect(objectTracker.Watch(logr.Discard(), otherObj, hndl, time.Second)).To(MatchError(ContainSubstring("retrying in 1s")))
+			})
+		})
+	})
+
+	Context("the informer cannot be found", func() {
+		BeforeEach(func() {
+			informers.GetInformerReturns(nil, errors.New("no kind"))
+		})
+
+		It("returns a WatchPendingError", func() {
+			err := objectTracker.Watch(logr.Discard(), obj, hndl, 0)
+			Expect(err).To(MatchError(ContainSubstring("failed to get informer for external object")))
+			Expect(err).To(BeAssignableToTypeOf(tracker.WatchPendingError{}))
+			Expect(ctrl.WatchCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("the tracker has a maximum number of kinds", func() {
+		var (
+			stopper        *trackerfakes.FakeInformerStopper
+			thingA, thingB *unstructured.Unstructured
+		)
+
+		stoppedKind := func(i int) schema.GroupVersionKind {
+			return stopper.StopInformerArgsForCall(i).GetObjectKind().GroupVersionKind()
+		}
+
+		kind := func(name string) *unstructured.Unstructured {
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(schema.GroupVersionKind{Group: "thing.io", Version: "v1", Kind: name})
+			return u
+		}
+
+		BeforeEach(func() {
+			stopper = &trackerfakes.FakeInformerStopper{}
+			objectTracker.Informers = struct {
+				*trackerfakes.FakeInformerGetter
+				*trackerfakes.FakeInformerStopper
+			}{informers, stopper}
+			objectTracker.MaxKinds = 2
+			objectTracker.Name = "test"
+
+			thingA, thingB = kind("ThingA"), kind("ThingB")
+			Expect(objectTracker.Watch(logr.Discard(), thingA, hndl, 0)).To(Succeed())
+			Expect(objectTracker.Watch(logr.Discard(), thingB, hndl, 0)).To(Succeed())
+		})
+
+		It("watches kinds up to the maximum without stopping any", func() {
+			Expect(ctrl.WatchCallCount()).To(Equal(2))
+			Expect(stopper.StopInformerCallCount()).To(Equal(0))
+			Expect(testutil.ToFloat64(tracker.ActiveInformers.WithLabelValues("test"))).To(Equal(2.0))
+		})
+
+		It("stops the informer of the least recently watched kind to watch another", func() {
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+
+			Expect(ctrl.WatchCallCount()).To(Equal(3))
+			Expect(stopper.StopInformerCallCount()).To(Equal(1))
+			Expect(stoppedKind(0)).To(Equal(thingA.GroupVersionKind()))
+			Expect(testutil.ToFloat64(tracker.ActiveInformers.WithLabelValues("test"))).To(Equal(2.0))
+		})
+
+		It("counts watching an already watched kind as using it", func() {
+			Expect(objectTracker.Watch(logr.Discard(), thingA, hndl, 0)).To(Succeed())
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+
+			Expect(stopper.StopInformerCallCount()).To(Equal(1))
+			Expect(stoppedKind(0)).To(Equal(thingB.GroupVersionKind()))
+		})
+
+		It("establishes the watch of an evicted kind again the next time it is watched", func() {
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+			Expect(objectTracker.Watch(logr.Discard(), thingA, hndl, time.Minute)).To(Succeed())
+
+			Expect(informers.GetInformerCallCount()).To(Equal(4))
+			_, informerObj := informers.GetInformerArgsForCall(3)
+			Expect(informerObj.GetObjectKind().GroupVersionKind()).To(Equal(thingA.GroupVersionKind()))
+
+			Expect(ctrl.WatchCallCount()).To(Equal(4))
+			_, _, predicates := ctrl.WatchArgsForCall(3)
+			Expect(predicates).To(HaveLen(1), "not a resync only watch")
+
+			Expect(stopper.StopInformerCallCount()).To(Equal(2))
+			Expect(stoppedKind(1)).To(Equal(thingB.GroupVersionKind()))
+		})
+
+		It("does not stop any informer when the informers cannot be stopped", func() {
+			objectTracker = &tracker.ObjectTracker{Controller: ctrl, Informers: informers, MaxKinds: 1}
+			Expect(objectTracker.Watch(logr.Discard(), thingA, hndl, 0)).To(Succeed())
+			Expect(objectTracker.Watch(logr.Discard(), thingB, hndl, 0)).To(Succeed())
+
+			Expect(ctrl.WatchCallCount()).To(Equal(4))
+			Expect(stopper.StopInformerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("there is no controller", func() {
+		It("does nothing", func() {
+			objectTracker.Controller = nil
+			Expect(objectTracker.Watch(logr.Discard(), obj, hndl, 0)).To(Succeed())
+			Expect(informers.GetInformerCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -17,12 +17,41 @@ package tracker
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 //counterfeiter:generate . DynamicTracker
 type DynamicTracker interface {
-	Watch(log logr.Logger, obj runtime.Object, handler handler.EventHandler) error
+	// Watch watches the kind of obj. A non-zero resync delivers every object of the kind to
+	// the handler again at that period; zero uses the resync period of the shared informer.
+	// Only the events passing every predicate reach the handler.
+	Watch(log logr.Logger, obj runtime.Object, handler handler.EventHandler, resync time.Duration, predicates ...predicate.Predicate) error
+}
+
+// WatchPendingError is returned by Watch while the watch of a kind cannot be established,
+// for instance because its CustomResourceDefinition is not installed yet. It is not worth
+// retrying the watch before RetryAfter has elapsed.
+type WatchPendingError struct {
+	GroupVersionKind schema.GroupVersionKind
+	RetryAfter       time.Duration
+	// Err is the error of the latest attempt, nil when the attempt was skipped for the backoff
+	Err error
+}
+
+func (e WatchPendingError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("watch on external object %q pending, retrying in %s", e.GroupVersionKind.String(), e.RetryAfter)
+	}
+	return fmt.Sprintf("watch on external object %q pending, retrying in %s: %s", e.GroupVersionKind.String(), e.RetryAfter, e.Err.Error())
+}
+
+func (e WatchPendingError) Unwrap() error {
+	return e.Err
 }
@@ -0,0 +1,341 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package trackerfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type FakeController struct {
+	GetLoggerStub        func() logr.Logger
+	getLoggerMutex       sync.RWMutex
+	getLoggerArgsForCall []struct {
+	}
+	getLoggerReturns struct {
+		result1 logr.Logger
+	}
+	getLoggerReturnsOnCall map[int]struct {
+		result1 logr.Logger
+	}
+	ReconcileStub        func(context.Context, reconcile.Request) (reconcile.Result, error)
+	reconcileMutex       sync.RWMutex
+	reconcileArgsForCall []struct {
+		arg1 context.Context
+		arg2 reconcile.Request
+	}
+	reconcileReturns struct {
+		result1 reconcile.Result
+		result2 error
+	}
+	reconcileReturnsOnCall map[int]struct {
+		result1 reconcile.Result
+		result2 error
+	}
+	StartStub        func(context.Context) error
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+		arg1 context.Context
+	}
+	startReturns struct {
+		result1 error
+	}
+	startReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WatchStub        func(source.Source, handler.EventHandler, ...predicate.Predicate) error
+	watchMutex       sync.RWMutex
+	watchArgsForCall []struct {
+		arg1 source.Source
+		arg2 handler.EventHandler
+		arg3 []predicate.Predicate
+	}
+	watchReturns struct {
+		result1 error
+	}
+	watchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeController) GetLogger() logr.Logger {
+	fake.getLoggerMutex.Lock()
+	ret, specificReturn := fake.getLoggerReturnsOnCall[len(fake.getLoggerArgsForCall)]
+	fake.getLoggerArgsForCall = append(fake.getLoggerArgsForCall, struct {
+	}{})
+	stub := fake.GetLoggerStub
+	fakeReturns := fake.getLoggerReturns
+	fake.recordInvocation("GetLogger", []interface{}{})
+	fake.getLoggerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeController) GetLoggerCallCount() int {
+	fake.getLoggerMutex.RLock()
+	defer fake.getLoggerMutex.RUnlock()
+	return len(fake.getLoggerArgsForCall)
+}
+
+func (fake *FakeController) GetLoggerCalls(stub func() logr.Logger) {
+	fake.getLoggerMutex.Lock()
+	defer fake.getLoggerMutex.Unlock()
+	fake.GetLoggerStub = stub
+}
+
+func (fake *FakeController) GetLoggerReturns(result1 logr.Logger) {
+	fake.getLoggerMutex.Lock()
+	defer fake.getLoggerMutex.Unlock()
+	fake.GetLoggerStub = nil
+	fake.getLoggerReturns = struct {
+		result1 logr.Logger
+	}{result1}
+}
+
+func (fake *FakeController) GetLoggerReturnsOnCall(i int, result1 logr.Logger) {
+	fake.getLoggerMutex.Lock()
+	defer fake.getLoggerMutex.Unlock()
+	fake.GetLoggerStub = nil
+	if fake.getLoggerReturnsOnCall == nil {
+		fake.getLoggerReturnsOnCall = make(map[int]struct {
+			result1 logr.Logger
+		})
+	}
+	fake.getLoggerReturnsOnCall[i] = struct {
+		result1 logr.Logger
+	}{result1}
+}
+
+func (fake *FakeController) Reconcile(arg1 context.Context, arg2 reconcile.Request) (reconcile.Result, error) {
+	fake.reconcileMutex.Lock()
+	ret, specificReturn := fake.reconcileReturnsOnCall[len(fake.reconcileArgsForCall)]
+	fake.reconcileArgsForCall = append(fake.reconcileArgsForCall, struct {
+		arg1 context.Context
+		arg2 reconcile.Request
+	}{arg1, arg2})
+	stub := fake.ReconcileStub
+	fakeReturns := fake.reconcileReturns
+	fake.recordInvocation("Reconcile", []interface{}{arg1, arg2})
+	fake.reconcileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeController) ReconcileCallCount() int {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return len(fake.reconcileArgsForCall)
+}
+
+func (fake *FakeController) ReconcileCalls(stub func(context.Context, reconcile.Request) (reconcile.Result, error)) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = stub
+}
+
+func (fake *FakeController) ReconcileArgsForCall(i int) (context.Context, reconcile.Request) {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	argsForCall := fake.reconcileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeController) ReconcileReturns(result1 reconcile.Result, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	fake.reconcileReturns = struct {
+		result1 reconcile.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeController) ReconcileReturnsOnCall(i int, result1 reconcile.Result, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	if fake.reconcileReturnsOnCall == nil {
+		fake.reconcileReturnsOnCall = make(map[int]struct {
+			result1 reconcile.Result
+			result2 error
+		})
+	}
+	fake.reconcileReturnsOnCall[i] = struct {
+		result1 reconcile.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeController) Start(arg1 context.Context) error {
+	fake.startMutex.Lock()
+	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StartStub
+	fakeReturns := fake.startReturns
+	fake.recordInvocation("Start", []interface{}{arg1})
+	fake.startMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeController) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *FakeController) StartCalls(stub func(context.Context) error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = stub
+}
+
+func (fake *FakeController) StartArgsForCall(i int) context.Context {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	argsForCall := fake.startArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeController) StartReturns(result1 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	fake.startReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeController) StartReturnsOnCall(i int, result1 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	if fake.startReturnsOnCall == nil {
+		fake.startReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeController) Watch(arg1 source.Source, arg2 handler.EventHandler, arg3 ...predicate.Predicate) error {
+	fake.watchMutex.Lock()
+	ret, specificReturn := fake.watchReturnsOnCall[len(fake.watchArgsForCall)]
+	fake.watchArgsForCall = append(fake.watchArgsForCall, struct {
+		arg1 source.Source
+		arg2 handler.EventHandler
+		arg3 []predicate.Predicate
+	}{arg1, arg2, arg3})
+	stub := fake.WatchStub
+	fakeReturns := fake.watchReturns
+	fake.recordInvocation("Watch", []interface{}{arg1, arg2, arg3})
+	fake.watchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeController) WatchCallCount() int {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	return len(fake.watchArgsForCall)
+}
+
+func (fake *FakeController) WatchCalls(stub func(source.Source, handler.EventHandler, ...predicate.Predicate) error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = stub
+}
+
+func (fake *FakeController) WatchArgsForCall(i int) (source.Source, handler.EventHandler, []predicate.Predicate) {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	argsForCall := fake.watchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeController) WatchReturns(result1 error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	fake.watchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeController) WatchReturnsOnCall(i int, result1 error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	if fake.watchReturnsOnCall == nil {
+		fake.watchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.watchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeController) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getLoggerMutex.RLock()
+	defer fake.getLoggerMutex.RUnlock()
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeController) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ controller.Controller = new(FakeController)
@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package trackerfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type FakeInformerGetter struct {
+	GetInformerStub        func(context.Context, client.Object) (cache.Informer, error)
+	getInformerMutex       sync.RWMutex
+	getInformerArgsForCall []struct {
+		arg1 context.Context
+		arg2 client.Object
+	}
+	getInformerReturns struct {
+		result1 cache.Informer
+		result2 error
+	}
+	getInformerReturnsOnCall map[int]struct {
+		result1 cache.Informer
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeInformerGetter) GetInformer(arg1 context.Context, arg2 client.Object) (cache.Informer, error) {
+	fake.getInformerMutex.Lock()
+	ret, specificReturn := fake.getInformerReturnsOnCall[len(fake.getInformerArgsForCall)]
+	fake.getInformerArgsForCall = append(fake.getInformerArgsForCall, struct {
+		arg1 context.Context
+		arg2 client.Object
+	}{arg1, arg2})
+	stub := fake.GetInformerStub
+	fakeReturns := fake.getInformerReturns
+	fake.recordInvocation("GetInformer", []interface{}{arg1, arg2})
+	fake.getInformerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInformerGetter) GetInformerCallCount() int {
+	fake.getInformerMutex.RLock()
+	defer fake.getInformerMutex.RUnlock()
+	return len(fake.getInformerArgsForCall)
+}
+
+func (fake *FakeInformerGetter) GetInformerCalls(stub func(context.Context, client.Object) (cache.Informer, error)) {
+	fake.getInformerMutex.Lock()
+	defer fake.getInformerMutex.Unlock()
+	fake.GetInformerStub = stub
+}
+
+func (fake *FakeInformerGetter) GetInformerArgsForCall(i int) (context.Context, client.Object) {
+	fake.getInformerMutex.RLock()
+	defer fake.getInformerMutex.RUnlock()
+	argsForCall := fake.getInformerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeInformerGetter) GetInformerReturns(result1 cache.Informer, result2 error) {
+	fake.getInformerMutex.Lock()
+	defer fake.getInformerMutex.Unlock()
+	fake.GetInformerStub = nil
+	fake.getInformerReturns = struct {
+		result1 cache.Informer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInformerGetter) GetInformerReturnsOnCall(i int, result1 cache.Informer, result2 error) {
+	fake.getInformerMutex.Lock()
+	defer fake.getInformerMutex.Unlock()
+	fake.GetInformerStub = nil
+	if fake.getInformerReturnsOnCall == nil {
+		fake.getInformerReturnsOnCall = make(map[int]struct {
+			result1 cache.Informer
+			result2 error
+		})
+	}
+	fake.getInformerReturnsOnCall[i] = struct {
+		result1 cache.Informer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInformerGetter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getInformerMutex.RLock()
+	defer fake.getInformerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeInformerGetter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tracker.InformerGetter = new(FakeInformerGetter)
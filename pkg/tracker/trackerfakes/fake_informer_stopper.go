@@ -0,0 +1,77 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package trackerfakes
+
+import (
+	"sync"
+
+	"github.com/vmware-tanzu/cartographer/pkg/tracker"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type FakeInformerStopper struct {
+	StopInformerStub        func(client.Object)
+	stopInformerMutex       sync.RWMutex
+	stopInformerArgsForCall []struct {
+		arg1 client.Object
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeInformerStopper) StopInformer(arg1 client.Object) {
+	fake.stopInformerMutex.Lock()
+	fake.stopInformerArgsForCall = append(fake.stopInformerArgsForCall, struct {
+		arg1 client.Object
+	}{arg1})
+	stub := fake.StopInformerStub
+	fake.recordInvocation("StopInformer", []interface{}{arg1})
+	fake.stopInformerMutex.Unlock()
+	if stub != nil {
+		fake.StopInformerStub(arg1)
+	}
+}
+
+func (fake *FakeInformerStopper) StopInformerCallCount() int {
+	fake.stopInformerMutex.RLock()
+	defer fake.stopInformerMutex.RUnlock()
+	return len(fake.stopInformerArgsForCall)
+}
+
+func (fake *FakeInformerStopper) StopInformerCalls(stub func(client.Object)) {
+	fake.stopInformerMutex.Lock()
+	defer fake.stopInformerMutex.Unlock()
+	fake.StopInformerStub = stub
+}
+
+func (fake *FakeInformerStopper) StopInformerArgsForCall(i int) client.Object {
+	fake.stopInformerMutex.RLock()
+	defer fake.stopInformerMutex.RUnlock()
+	argsForCall := fake.stopInformerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInformerStopper) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.stopInformerMutex.RLock()
+	defer fake.stopInformerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeInformerStopper) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tracker.InformerStopper = new(FakeInformerStopper)
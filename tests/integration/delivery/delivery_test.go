@@ -209,6 +209,56 @@ var _ = Describe("Deliveries", func() {
 		})
 	})
 
+	Describe("I can only consume outputs that the referenced resources produce", func() {
+		createDelivery := func(promoterSourceResource string) error {
+			deliveryYaml := utils.HereYaml(`
+				---
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterDelivery
+				metadata:
+				  name: my-delivery
+				spec:
+				  selector:
+					foo: bar
+				  resources:
+					- name: config-provider
+					  templateRef:
+						kind: ClusterSourceTemplate
+						name: my-source-template
+					- name: deployer
+					  templateRef:
+						kind: ClusterDeploymentTemplate
+						name: my-deployment-template
+					  deployment:
+						resource: config-provider
+					- name: promoter
+					  templateRef:
+						kind: ClusterTemplate
+						name: my-template
+					  sources:
+						- resource: ` + promoterSourceResource + `
+						  name: deployed
+			`)
+
+			delivery = &unstructured.Unstructured{}
+			err := yaml.Unmarshal([]byte(deliveryYaml), delivery)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = c.Create(ctx, delivery, &client.CreateOptions{})
+			cleanups = append(cleanups, delivery)
+			return err
+		}
+
+		It("accepts a source provided by a deployment template", func() {
+			Expect(createDelivery("deployer")).To(Succeed())
+		})
+
+		It("rejects a source provided by a template that produces no source", func() {
+			err := createDelivery("promoter")
+			Expect(err).To(MatchError(ContainSubstring("invalid sources for resource [promoter]: resource [promoter] providing [deployed] must reference a ClusterSourceTemplate or ClusterDeploymentTemplate")))
+		})
+	})
+
 	Describe("I can expect ClusterDelivery to not keep updating it's status", func() {
 
 		var (
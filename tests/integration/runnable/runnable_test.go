@@ -263,6 +263,47 @@ var _ = Describe("Stamping a resource on Runnable Creation", func() {
 			})
 		})
 
+		Context("and a Runnable omits the RunTemplateRef kind", func() {
+			BeforeEach(func() {
+				runnableYaml := HereYamlF(`---
+					apiVersion: carto.run/v1alpha1
+					kind: Runnable
+					metadata:
+					  namespace: %s
+					  name: my-runnable
+					spec:
+					  serviceAccountName: %s
+					  runTemplateRef: 
+					    name: my-run-template
+					  inputs:
+					    key: val
+					`,
+					testNS, serviceAccountName)
+
+				runnableDefinition = createNamespacedObject(ctx, runnableYaml, testNS)
+			})
+
+			AfterEach(func() {
+				err := c.Delete(ctx, runnableDefinition)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("defaults the kind to ClusterRunTemplate", func() {
+				runnable := &v1alpha1.Runnable{}
+				Expect(c.Get(ctx, client.ObjectKey{Namespace: testNS, Name: "my-runnable"}, runnable)).To(Succeed())
+				Expect(runnable.Spec.RunTemplateRef.Kind).To(Equal("ClusterRunTemplate"))
+			})
+
+			It("stamps the templated object", func() {
+				resourceList := &v1.ResourceQuotaList{}
+
+				Eventually(func() (int, error) {
+					err := c.List(ctx, resourceList, &client.ListOptions{Namespace: testNS})
+					return len(resourceList.Items), err
+				}).Should(Equal(1))
+			})
+		})
+
 		Context("a Runnable that does not match the RunTemplateRef", func() {
 			BeforeEach(func() {
 				runnableYaml := HereYamlF(`---
@@ -304,6 +345,77 @@ var _ = Describe("Stamping a resource on Runnable Creation", func() {
 		})
 	})
 
+	Describe("when a Runnable is created before the ClusterRunTemplate it references", func() {
+		BeforeEach(func() {
+			runnableYaml := HereYamlF(`---
+				apiVersion: carto.run/v1alpha1
+				kind: Runnable
+				metadata:
+				  namespace: %s
+				  name: my-runnable
+				spec:
+				  serviceAccountName: %s
+				  runTemplateRef:
+				    name: my-late-run-template
+				    kind: ClusterRunTemplate
+				  inputs:
+				    key: val
+				`,
+				testNS, serviceAccountName)
+
+			runnableDefinition = createNamespacedObject(ctx, runnableYaml, testNS)
+
+			Eventually(func() (string, error) {
+				runnable := &v1alpha1.Runnable{}
+				err := c.Get(ctx, client.ObjectKey{Name: "my-runnable", Namespace: testNS}, runnable)
+				if err != nil {
+					return "", err
+				}
+				for _, condition := range runnable.Status.Conditions {
+					if condition.Type == v1alpha1.RunTemplateReady {
+						return condition.Reason, nil
+					}
+				}
+				return "", nil
+			}).Should(Equal(v1alpha1.NotFoundRunTemplateReason))
+		})
+
+		AfterEach(func() {
+			Expect(c.Delete(ctx, runnableDefinition)).To(Succeed())
+			Expect(c.Delete(ctx, runTemplateDefinition)).To(Succeed())
+		})
+
+		It("stamps the templated object as soon as the ClusterRunTemplate is created", func() {
+			runTemplateYaml := HereYamlF(`
+				---
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterRunTemplate
+				metadata:
+				  name: my-late-run-template
+				spec:
+				  template:
+					apiVersion: v1
+					kind: ResourceQuota
+					metadata:
+					  generateName: my-stamped-resource-
+					  namespace: %s
+					spec:
+					  hard:
+						pods: "10"
+				`,
+				testNS,
+			)
+
+			runTemplateDefinition = createNamespacedObject(ctx, runTemplateYaml, "")
+
+			resourceList := &v1.ResourceQuotaList{}
+			Eventually(func() (int, error) {
+				err := c.List(ctx, resourceList, &client.ListOptions{Namespace: testNS})
+				return len(resourceList.Items), err
+			}).Should(Equal(1))
+		})
+	})
+
 	Describe("when a ClusterRunTemplate that produces a Resource leverages a Selector field", func() {
 		BeforeEach(func() {
 			runTemplateYaml := HereYamlF(`
@@ -0,0 +1,120 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supplychain_test
+
+import (
+	"context"
+
+	. "github.com/MakeNowJust/heredoc/dot"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("TemplateDefaulting", func() {
+	var (
+		ctx      context.Context
+		template *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		_ = c.Delete(ctx, template)
+	})
+
+	create := func(templateYaml string) {
+		template = &unstructured.Unstructured{}
+		Expect(yaml.Unmarshal([]byte(templateYaml), template)).To(Succeed())
+		Expect(c.Create(ctx, template)).To(Succeed())
+	}
+
+	Context("an image template without an imagePath", func() {
+		BeforeEach(func() {
+			create(D(`
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterImageTemplate
+				metadata:
+				  name: image-template-without-path
+				spec:
+				  template:
+				    apiVersion: v1
+				    kind: ConfigMap
+				    metadata:
+				      name: some-config-map
+			`))
+		})
+
+		It("is stored with the default imagePath", func() {
+			stored := &v1alpha1.ClusterImageTemplate{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "image-template-without-path"}, stored)).To(Succeed())
+			Expect(stored.Spec.ImagePath).To(Equal(".status.image"))
+		})
+	})
+
+	Context("an image template with an imagePath", func() {
+		BeforeEach(func() {
+			create(D(`
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterImageTemplate
+				metadata:
+				  name: image-template-with-path
+				spec:
+				  imagePath: .status.latestImage
+				  template:
+				    apiVersion: v1
+				    kind: ConfigMap
+				    metadata:
+				      name: some-config-map
+			`))
+		})
+
+		It("is stored with its own imagePath", func() {
+			stored := &v1alpha1.ClusterImageTemplate{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "image-template-with-path"}, stored)).To(Succeed())
+			Expect(stored.Spec.ImagePath).To(Equal(".status.latestImage"))
+		})
+	})
+
+	Context("a source template without url and revision paths", func() {
+		BeforeEach(func() {
+			create(D(`
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterSourceTemplate
+				metadata:
+				  name: source-template-without-paths
+				spec:
+				  template:
+				    apiVersion: v1
+				    kind: ConfigMap
+				    metadata:
+				      name: some-config-map
+			`))
+		})
+
+		It("is stored with the default paths", func() {
+			stored := &v1alpha1.ClusterSourceTemplate{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "source-template-without-paths"}, stored)).To(Succeed())
+			Expect(stored.Spec.URLPath).To(Equal(".status.artifact.url"))
+			Expect(stored.Spec.RevisionPath).To(Equal(".status.artifact.revision"))
+		})
+	})
+})